@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the effective configuration",
+	}
+
+	var redacted bool
+	print := &cobra.Command{
+		Use:   "print",
+		Short: "Print every setting with its value and source",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := c.loadConfig()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+			for _, v := range config.Resolved() {
+				value := v.Value
+				if redacted {
+					value = v.Redacted()
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", v.Key, value, v.Source)
+			}
+			return w.Flush()
+		},
+	}
+	print.Flags().BoolVar(&redacted, "redacted", false, "mask secrets and passwords in URLs")
+
+	cmd.AddCommand(print)
+	return cmd
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/database"
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+// logLevel is the minimum level of the default logger; LOG_LEVEL can change it at runtime
+var logLevel = new(slog.LevelVar)
+
+func main() {
+	slog.SetDefault(slog.New(telemetry.NewLogHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))))
+
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// cli holds state shared by all subcommands
+type cli struct {
+	config     *environment.Environment
+	configFile string
+	overrides  map[string]string
+}
+
+func newRootCmd() *cobra.Command {
+	c := &cli{}
+
+	root := &cobra.Command{
+		Use:           "api",
+		Short:         "Go API starter",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+	root.PersistentFlags().StringVar(&c.configFile, "config", "", "YAML or TOML config file (defaults to CONFIG_FILE)")
+	root.PersistentFlags().StringToStringVar(&c.overrides, "set", nil, "override a setting, e.g. --set PORT=9090")
+
+	root.AddCommand(
+		newServeCmd(c),
+		newMigrateCmd(c),
+		newSeedCmd(c),
+		newUserCmd(c),
+		newTokenCmd(c),
+		newOpenAPICmd(),
+		newConfigCmd(c),
+		newWorkerCmd(c),
+		newWebhooksCmd(),
+	)
+
+	return root
+}
+
+// loadConfig loads the environment once and shares it between commands
+func (c *cli) loadConfig() (*environment.Environment, error) {
+	if c.config != nil {
+		return c.config, nil
+	}
+
+	config, err := environment.Load(c.loadOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	logLevel.Set(config.Dynamic.LogLevel)
+	c.config = config
+	return config, nil
+}
+
+// loadOptions turns the root flags into environment options
+func (c *cli) loadOptions() []environment.Option {
+	opts := []environment.Option{environment.WithOverrides(c.overrides)}
+	if c.configFile != "" {
+		opts = append(opts, environment.WithConfigFile(c.configFile))
+	}
+	return opts
+}
+
+// databaseURL returns DATABASE_URL or an error when it is not configured
+func (c *cli) databaseURL() (string, error) {
+	config, err := c.loadConfig()
+	if err != nil {
+		return "", err
+	}
+	if config.DatabaseURL == "" {
+		return "", errors.New("DATABASE_URL environment variable is required")
+	}
+	return config.DatabaseURL, nil
+}
+
+// openPool connects to the configured database
+func (c *cli) openPool(ctx context.Context) (*pgxpool.Pool, error) {
+	databaseURL, err := c.databaseURL()
+	if err != nil {
+		return nil, err
+	}
+	return database.NewPool(ctx, databaseURL)
+}
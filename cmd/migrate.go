@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/database"
+)
+
+func newMigrateCmd(c *cli) *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database migrations",
+	}
+	cmd.PersistentFlags().StringVar(&dir, "dir", "", "read migrations from this directory instead of the embedded set (default $MIGRATIONS_DIR)")
+
+	// migrationsDir resolves the --dir flag, falling back to MIGRATIONS_DIR
+	migrationsDir := func() (string, error) {
+		if dir != "" {
+			return dir, nil
+		}
+		config, err := c.loadConfig()
+		if err != nil {
+			return "", err
+		}
+		return config.Assets.MigrationsDir, nil
+	}
+
+	// withMigrator opens a migrator for the duration of fn
+	withMigrator := func(fn func(m *database.Migrator) error) error {
+		databaseURL, err := c.databaseURL()
+		if err != nil {
+			return err
+		}
+		dir, err := migrationsDir()
+		if err != nil {
+			return err
+		}
+		m, err := database.NewMigrator(database.MigrationsFS(dir), databaseURL)
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+		return fn(m)
+	}
+
+	up := &cobra.Command{
+		Use:   "up [N]",
+		Short: "Apply all pending migrations, or the next N",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := parseSteps(args)
+			if err != nil {
+				return err
+			}
+			return withMigrator(func(m *database.Migrator) error {
+				if err := m.Up(steps); err != nil {
+					return err
+				}
+				return printStatus(cmd, m)
+			})
+		},
+	}
+
+	var all bool
+	down := &cobra.Command{
+		Use:   "down [N]",
+		Short: "Roll back the last N migrations, or all of them with --all",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := parseSteps(args)
+			if err != nil {
+				return err
+			}
+			if steps == 0 && !all {
+				return fmt.Errorf("pass a number of migrations to roll back or --all")
+			}
+			return withMigrator(func(m *database.Migrator) error {
+				if err := m.Down(steps); err != nil {
+					return err
+				}
+				return printStatus(cmd, m)
+			})
+		},
+	}
+	down.Flags().BoolVar(&all, "all", false, "roll back every migration")
+
+	gotoCmd := &cobra.Command{
+		Use:   "goto VERSION",
+		Short: "Migrate up or down to a specific version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := database.ParseVersion(args[0])
+			if err != nil {
+				return err
+			}
+			return withMigrator(func(m *database.Migrator) error {
+				if err := m.Goto(version); err != nil {
+					return err
+				}
+				return printStatus(cmd, m)
+			})
+		},
+	}
+
+	force := &cobra.Command{
+		Use:   "force VERSION",
+		Short: "Set the schema version without running migrations and clear the dirty flag",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid migration version %q: %w", args[0], err)
+			}
+			return withMigrator(func(m *database.Migrator) error {
+				if err := m.Force(version); err != nil {
+					return err
+				}
+				return printStatus(cmd, m)
+			})
+		},
+	}
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "Show the current schema version and pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(func(m *database.Migrator) error {
+				return printStatus(cmd, m)
+			})
+		},
+	}
+
+	create := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an empty up/down migration pair in the migrations directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := dir
+			if target == "" {
+				target = database.DefaultMigrationsDir
+			}
+			files, err := database.CreateMigration(target, args[0])
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				fmt.Fprintln(cmd.OutOrStdout(), "created", file)
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(up, down, gotoCmd, force, status, create)
+	return cmd
+}
+
+func parseSteps(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("invalid number of migrations %q", args[0])
+	}
+	return steps, nil
+}
+
+func printStatus(cmd *cobra.Command, m *database.Migrator) error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "version: %d\ndirty:   %t\nlatest:  %d\npending: %d\n",
+		status.Version, status.Dirty, status.Latest, status.Pending)
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/docs"
+)
+
+func newOpenAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "OpenAPI specification utilities",
+	}
+
+	var output string
+	dump := &cobra.Command{
+		Use:   "dump",
+		Short: "Write the embedded OpenAPI specification to stdout or a file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := fs.ReadFile(docs.FS, "openapi.json")
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				return os.WriteFile(output, spec, 0o644)
+			}
+			_, err = cmd.OutOrStdout().Write(spec)
+			return err
+		},
+	}
+	dump.Flags().StringVarP(&output, "output", "o", "", "write to file instead of stdout")
+
+	cmd.AddCommand(dump)
+	return cmd
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+)
+
+var seedLabubu = []string{"Hello from labubu", "Second labubu", "Third labubu"}
+
+func newSeedCmd(c *cli) *cobra.Command {
+	var (
+		email    string
+		password string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Insert development data",
+		Long:  "Insert a demo user that owns a few labubu entries. Nothing is written when the user already exists.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			pool, err := c.openPool(ctx)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			services, err := c.services(pool)
+			if err != nil {
+				return err
+			}
+
+			// The user and their labubu are created together or not at all
+			var (
+				user  *auth.User
+				items []*labubu.Labubu
+			)
+			err = uow.NewTxManager(pool, services).Do(ctx, func(ctx context.Context, svc uow.Services) error {
+				var (
+					first *labubu.Labubu
+					err   error
+				)
+				user, first, err = createUserWithLabubu(ctx, svc, auth.CreateUserRequest{
+					Email:    email,
+					Password: password,
+					// The demo account is usable straight away
+					Verified: true,
+				}, seedLabubu[0])
+				if err != nil {
+					return err
+				}
+				items = []*labubu.Labubu{first}
+
+				for _, text := range seedLabubu[1:] {
+					item, err := svc.Labubu.CreateLabubu(ctx, labubu.CreateLabubuRequest{Text: text, OwnerID: user.ID})
+					if err != nil {
+						return fmt.Errorf("creating labubu: %w", err)
+					}
+					items = append(items, item)
+				}
+				return nil
+			})
+			if errors.Is(err, auth.ErrEmailTaken) {
+				fmt.Fprintf(cmd.OutOrStdout(), "user %s already exists, skipping\n", email)
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("seeding: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created user %s (id %d) with %d labubu\n", user.Email, user.ID, len(items))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&email, "email", "demo@example.com", "demo user email")
+	cmd.Flags().StringVar(&password, "password", "demo-password", "demo user password")
+	return cmd
+}
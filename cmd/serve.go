@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,67 +11,184 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
 
+	"github.com/abdurrahimagca/go-api-starter/internal/database"
 	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/health"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/realtime"
 	"github.com/abdurrahimagca/go-api-starter/internal/server"
+	"github.com/abdurrahimagca/go-api-starter/platform/ratelimit"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
 )
 
-func main() {
-	log.Println("Starting server...")
-
-	if err := run(); err != nil {
-		log.Fatal(err)
+func newServeCmd(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP server",
+		Long:  "Start the HTTP server. MIGRATE_ON_STARTUP selects whether migrations are applied (auto), verified (check-only) or skipped (off).",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := c.loadConfig()
+			if err != nil {
+				return fmt.Errorf("error loading environment: %w", err)
+			}
+			return run(cmd.Context(), c, config)
+		},
 	}
 }
 
-func run() error {
-	// Load configuration
-	config, err := environment.Load()
-	if err != nil {
-		return fmt.Errorf("error loading environment: %w", err)
-	}
-
-	ctx := context.Background()
+func run(ctx context.Context, c *cli, config *environment.Environment) error {
+	slog.Info("Starting server...")
 
-	if config.DatabaseURL == "" {
-		return fmt.Errorf("DATABASE_URL environment variable is required")
+	migrationPolicy, err := database.ParseMigrationPolicy(config.Migrations.Policy)
+	if err != nil {
+		return fmt.Errorf("invalid MIGRATE_ON_STARTUP: %w", err)
 	}
 
-	log.Println("Running database migrations...")
-	if err := runMigrations(config.DatabaseURL); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+	// Initialize tracing before anything that creates spans
+	shutdownTracing, err := telemetry.Setup(ctx, telemetryConfig(config))
+	if err != nil {
+		return fmt.Errorf("error setting up tracing: %w", err)
 	}
+	defer flushTracing(shutdownTracing)
 
 	// Initialize database connection
-	log.Println("Connecting to database...")
-	pool, err := pgxpool.New(ctx, config.DatabaseURL)
+	slog.Info("Connecting to database...")
+	pool, err := c.openPool(ctx)
 	if err != nil {
-		return fmt.Errorf("error creating database pool: %w", err)
+		return err
 	}
 	defer pool.Close()
+	slog.Info("Database connection successful")
+
+	// Apply the startup migration policy; dirty or (with check-only) outdated schemas abort startup
+	schema, err := database.EnsureSchema(ctx, pool,
+		database.MigrationsFS(config.Assets.MigrationsDir),
+		config.DatabaseURL,
+		migrationPolicy,
+		config.Migrations.LockTimeout,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Register readiness checks
+	checker := health.NewChecker(config.Health.CheckTimeout)
+	checker.Register(health.Check{Name: "postgres", Run: health.PostgresCheck(pool)})
+	checker.Register(health.Check{Name: "migrations", Run: health.MigrationCheck(pool, schema.Latest)})
 
-	if err := pool.Ping(ctx); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
+	var rateLimits ratelimit.Store = ratelimit.NewMemoryStore()
+	if config.RedisURL != "" {
+		redisOptions, err := redis.ParseURL(config.RedisURL)
+		if err != nil {
+			return fmt.Errorf("error parsing REDIS_URL: %w", err)
+		}
+		redisClient := redis.NewClient(redisOptions)
+		defer redisClient.Close()
+
+		checker.Register(health.Check{Name: "redis", Run: health.RedisCheck(redisClient)})
+		if config.RateLimitBackend == "redis" {
+			rateLimits = ratelimit.NewRedisStore(redisClient, "ratelimit:")
+		}
+	}
+
+	// Reloadable settings are swapped on SIGHUP or POST /admin/config/reload
+	reloader := environment.NewReloader(config, c.loadOptions()...)
+	reloader.Subscribe(func(old, new *environment.Dynamic) {
+		logLevel.Set(new.LogLevel)
+	})
+
+	// The schedules are reported by every replica, whether or not it runs them
+	sched, err := newScheduler(pool, config)
+	if err != nil {
+		return err
 	}
-	log.Println("Database connection successful")
+
+	// Each replica listens for committed events to feed its own streaming clients
+	hub := realtime.NewHub(pool, outbox.NewPgxRepository(pool), realtime.Config{
+		BufferSize: config.Stream.BufferSize,
+	})
+	presence := realtime.NewPresence(hub, realtime.PresenceConfig{
+		AnnounceInterval: config.Collab.PresenceInterval,
+	})
 
 	// Initialize the unified server with all dependencies
-	handler, err := server.NewUnifiedServer(pool, config)
+	handler, err := server.NewUnifiedServer(pool, config, server.Options{
+		Checker:    checker,
+		Reloader:   reloader,
+		Scheduler:  sched,
+		Hub:        hub,
+		Presence:   presence,
+		RateLimits: rateLimits,
+		Storage:    newStorage(config),
+	})
 	if err != nil {
 		return fmt.Errorf("error creating unified server: %w", err)
 	}
 
+	// Run the job worker alongside the server unless it is deployed separately
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	defer stopWorker()
+	var workerWG sync.WaitGroup
+	// Every replica with SCHEDULER_ENABLED runs the scheduler; leader election picks the one that fires
+	if config.Scheduler.Enabled {
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			if err := sched.Run(workerCtx); err != nil {
+				slog.Error("Scheduler error", "error", err)
+			}
+		}()
+	}
+	if config.Outbox.RelayEnabled {
+		relay, closeSinks, err := c.newRelay(pool, config)
+		if err != nil {
+			return err
+		}
+		defer closeSinks()
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			if err := relay.Run(workerCtx); err != nil {
+				slog.Error("Outbox relay error", "error", err)
+			}
+		}()
+	}
+	if config.Jobs.InServer {
+		worker, err := c.newWorker(pool, config)
+		if err != nil {
+			return err
+		}
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			if err := worker.Run(workerCtx); err != nil {
+				slog.Error("Job worker error", "error", err)
+			}
+		}()
+	}
+
 	srv := &http.Server{
 		Addr:    ":" + config.Port,
 		Handler: handler,
 	}
 
-	log.Printf("🚀 Server starting on port %s", config.Port)
-	log.Printf("API Documentation available at: http://localhost:%s/docs", config.Port)
+	// Streams never go idle, so stopping the hub on shutdown is what ends them
+	hubCtx, stopHub := context.WithCancel(ctx)
+	defer stopHub()
+	srv.RegisterOnShutdown(stopHub)
+	go func() {
+		_ = hub.Run(hubCtx)
+	}()
+	go func() {
+		_ = presence.Run(hubCtx)
+	}()
+
+	slog.Info("🚀 Server starting", "port", config.Port)
+	slog.Info("API Documentation available", "url", "http://localhost:"+config.Port+"/docs")
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -79,38 +196,39 @@ func run() error {
 	go func() {
 		defer wg.Done()
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Printf("Server error: %v", err)
+			slog.Error("Server error", "error", err)
 		}
 	}()
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range signals {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		slog.Info("Received SIGHUP, reloading configuration")
+		// Reload logs the outcome; an invalid configuration keeps the current one
+		_, _ = reloader.Reload()
+	}
 
-	log.Println("Server shutting down...")
+	// Stop advertising readiness before draining so load balancers stop routing new traffic
+	checker.SetDraining()
+	slog.Info("Server shutting down...", "drain_delay", config.Health.ShutdownDrainDelay)
+	time.Sleep(config.Health.ShutdownDrainDelay)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Server forced to shutdown", "error", err)
 	}
 
 	wg.Wait()
-	log.Println("Server exited")
-	return nil
-}
-
-func runMigrations(databaseURL string) error {
-	m, err := migrate.New("file://migrations", databaseURL)
-	if err != nil {
-		return err
-	}
-	defer m.Close()
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return err
-	}
+	// Finish in-flight jobs after the last request has been served
+	stopWorker()
+	workerWG.Wait()
 
+	slog.Info("Server exited")
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/attachments"
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/collection"
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/reports"
+	"github.com/abdurrahimagca/go-api-starter/internal/server"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+	"github.com/abdurrahimagca/go-api-starter/internal/webhooks"
+	"github.com/abdurrahimagca/go-api-starter/platform/token"
+)
+
+func newUserCmd(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+	}
+
+	// withServices opens the database for the duration of fn
+	withServices := func(cmd *cobra.Command, fn func(svc uow.Services, tm uow.TxManager) error) error {
+		pool, err := c.openPool(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		svc, err := c.services(pool)
+		if err != nil {
+			return err
+		}
+		return fn(svc, uow.NewTxManager(pool, svc))
+	}
+
+	var (
+		createEmail, createPassword, firstLabubu string
+		createVerified                           bool
+	)
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user; a random password is generated when --password is omitted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, generated, err := passwordOrRandom(createPassword)
+			if err != nil {
+				return err
+			}
+			return withServices(cmd, func(_ uow.Services, tm uow.TxManager) error {
+				var user *auth.User
+				err := tm.Do(cmd.Context(), func(ctx context.Context, svc uow.Services) error {
+					var err error
+					user, _, err = createUserWithLabubu(ctx, svc, auth.CreateUserRequest{
+						Email:    createEmail,
+						Password: password,
+						Verified: createVerified,
+					}, firstLabubu)
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "created user %s (id %d)\n", user.Email, user.ID)
+				if generated {
+					fmt.Fprintf(cmd.OutOrStdout(), "password: %s\n", password)
+				}
+				return nil
+			})
+		},
+	}
+	create.Flags().StringVar(&createEmail, "email", "", "user email")
+	create.Flags().StringVar(&createPassword, "password", "", "user password")
+	create.Flags().BoolVar(&createVerified, "verified", false, "mark the email as verified instead of sending a verification email")
+	create.Flags().StringVar(&firstLabubu, "first-labubu", "", "also create a labubu owned by the new user, in the same transaction")
+	_ = create.MarkFlagRequired("email")
+
+	var disableEmail string
+	disable := &cobra.Command{
+		Use:   "disable",
+		Short: "Disable a user and revoke their sessions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withServices(cmd, func(svc uow.Services, _ uow.TxManager) error {
+				user, err := svc.Auth.DisableUser(cmd.Context(), disableEmail)
+				if err != nil {
+					return notFoundAsUserError(err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "disabled user %s (id %d)\n", user.Email, user.ID)
+				return nil
+			})
+		},
+	}
+	disable.Flags().StringVar(&disableEmail, "email", "", "user email")
+	_ = disable.MarkFlagRequired("email")
+
+	var resetEmail, resetPassword string
+	reset := &cobra.Command{
+		Use:   "reset-password",
+		Short: "Set a new password and revoke existing sessions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, generated, err := passwordOrRandom(resetPassword)
+			if err != nil {
+				return err
+			}
+			return withServices(cmd, func(svc uow.Services, _ uow.TxManager) error {
+				user, err := svc.Auth.ResetPassword(cmd.Context(), resetEmail, password)
+				if err != nil {
+					return notFoundAsUserError(err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "reset password for %s (id %d)\n", user.Email, user.ID)
+				if generated {
+					fmt.Fprintf(cmd.OutOrStdout(), "password: %s\n", password)
+				}
+				return nil
+			})
+		},
+	}
+	reset.Flags().StringVar(&resetEmail, "email", "", "user email")
+	reset.Flags().StringVar(&resetPassword, "password", "", "new password")
+	_ = reset.MarkFlagRequired("email")
+
+	var unlockEmail string
+	unlock := &cobra.Command{
+		Use:   "unlock",
+		Short: "Clear failed logins for an email, lifting any delay or lockout",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withServices(cmd, func(svc uow.Services, _ uow.TxManager) error {
+				err := svc.Auth.Unlock(cmd.Context(), unlockEmail)
+				if errors.Is(err, auth.ErrNotFound) {
+					return errors.New("no failed logins recorded for this email")
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "unlocked %s\n", auth.NormalizeEmail(unlockEmail))
+				return nil
+			})
+		},
+	}
+	unlock.Flags().StringVar(&unlockEmail, "email", "", "email to unlock")
+	_ = unlock.MarkFlagRequired("email")
+
+	cmd.AddCommand(create, disable, reset, unlock)
+	return cmd
+}
+
+// services builds the domain services from the shared configuration
+func (c *cli) services(pool *pgxpool.Pool) (uow.Services, error) {
+	config, err := c.loadConfig()
+	if err != nil {
+		return uow.Services{}, err
+	}
+	tokens := token.NewJWTToken(config.Token.Secret, config.Token.Issuer, config.Token.Audience)
+	queue := jobs.NewQueue(jobs.NewPgxRepository(pool))
+	labubuService := labubu.NewService(labubu.NewPgxRepository(pool), outbox.NewWriter(outbox.NewPgxRepository(pool)))
+	return uow.Services{
+		Auth:        auth.NewService(auth.NewPgxRepository(pool), tokens, queue, server.AuthConfig(config)),
+		Labubu:      labubuService,
+		Attachments: attachments.NewService(attachments.NewPgxRepository(pool), labubuService, newStorage(config), nil, queue, server.AttachmentsConfig(config)),
+		Jobs:        queue,
+		Reports:     reports.NewService(reports.NewPgxRepository(pool)),
+		Webhooks: webhooks.NewService(webhooks.NewPgxRepository(pool), queue, webhooks.NewHTTPClient(config.Webhooks.Timeout, config.Webhooks.AllowPrivate), webhooks.Config{
+			MaxAttempts:  config.Webhooks.MaxAttempts,
+			DisableAfter: config.Webhooks.DisableAfter,
+			AllowPrivate: config.Webhooks.AllowPrivate,
+		}),
+		Collections: collection.NewService(collection.NewPgxRepository(pool), labubuService),
+	}, nil
+}
+
+// createUserWithLabubu creates a user and, when text is set, their first labubu.
+// Call it inside a transaction so a failed labubu does not leave an orphan user.
+func createUserWithLabubu(ctx context.Context, svc uow.Services, req auth.CreateUserRequest, text string) (*auth.User, *labubu.Labubu, error) {
+	user, err := svc.Auth.CreateUser(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if text == "" {
+		return user, nil, nil
+	}
+
+	item, err := svc.Labubu.CreateLabubu(ctx, labubu.CreateLabubuRequest{Text: text, OwnerID: user.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, item, nil
+}
+
+func passwordOrRandom(password string) (string, bool, error) {
+	if password != "" {
+		return password, false, nil
+	}
+	generated, err := token.RandomString(12)
+	return generated, true, err
+}
+
+func notFoundAsUserError(err error) error {
+	if errors.Is(err, auth.ErrNotFound) {
+		return errors.New("user not found")
+	}
+	return err
+}
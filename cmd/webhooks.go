@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/webhooks"
+)
+
+func newWebhooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Webhook utilities for local development",
+	}
+
+	var (
+		addr      string
+		secret    string
+		tolerance time.Duration
+	)
+	listen := &cobra.Command{
+		Use:   "listen",
+		Short: "Run a receiver that verifies and prints incoming deliveries",
+		Long:  "Run a receiver that verifies signatures and prints incoming deliveries. Register it with POST /admin/webhooks using the same secret; the API only delivers to a local address with WEBHOOKS_ALLOW_PRIVATE=true.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			srv := &http.Server{
+				Addr: addr,
+				Handler: webhooks.Receiver(secret, tolerance, func(header http.Header, body []byte) {
+					fmt.Fprintf(out, "%s %s (delivery %s)\n%s\n", time.Now().Format(time.RFC3339),
+						header.Get(webhooks.HeaderEvent), header.Get(webhooks.HeaderID), body)
+				}),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				_ = srv.Close()
+			}()
+
+			fmt.Fprintf(out, "listening on %s\n", addr)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		},
+	}
+	listen.Flags().StringVar(&addr, "addr", ":9090", "address to listen on")
+	listen.Flags().StringVar(&secret, "secret", "", "subscription secret used to verify signatures")
+	listen.Flags().DurationVar(&tolerance, "tolerance", webhooks.DefaultTolerance, "maximum clock skew before a delivery counts as a replay")
+	_ = listen.MarkFlagRequired("secret")
+
+	cmd.AddCommand(listen)
+	return cmd
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/attachments"
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/mailer"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/scheduler"
+	"github.com/abdurrahimagca/go-api-starter/internal/tasks"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+	"github.com/abdurrahimagca/go-api-starter/internal/webhooks"
+	"github.com/abdurrahimagca/go-api-starter/platform/mail"
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+func newWorkerCmd(c *cli) *cobra.Command {
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "worker",
+		Short: "Run the background job worker without the HTTP server",
+		Long:  "Run the background job worker. Set JOBS_IN_SERVER=false on the HTTP servers when workers run as a separate deployment.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := c.loadConfig()
+			if err != nil {
+				return err
+			}
+			if concurrency > 0 {
+				config.Jobs.Workers = concurrency
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			shutdownTracing, err := telemetry.Setup(ctx, telemetryConfig(config))
+			if err != nil {
+				return fmt.Errorf("error setting up tracing: %w", err)
+			}
+			defer flushTracing(shutdownTracing)
+
+			pool, err := c.openPool(ctx)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			worker, err := c.newWorker(pool, config)
+			if err != nil {
+				return err
+			}
+
+			var sched *scheduler.Scheduler
+			if config.Scheduler.Enabled {
+				if sched, err = newScheduler(pool, config); err != nil {
+					return err
+				}
+			}
+			var relay *outbox.Relay
+			if config.Outbox.RelayEnabled {
+				var closeSinks func()
+				if relay, closeSinks, err = c.newRelay(pool, config); err != nil {
+					return err
+				}
+				defer closeSinks()
+			}
+
+			// The scheduler and relay stop with the worker, and finish before
+			// the sinks and the pool are closed
+			var wg sync.WaitGroup
+			defer func() {
+				stop()
+				wg.Wait()
+			}()
+			if sched != nil {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := sched.Run(ctx); err != nil {
+						slog.Error("Scheduler error", "error", err)
+					}
+				}()
+			}
+			if relay != nil {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := relay.Run(ctx); err != nil {
+						slog.Error("Outbox relay error", "error", err)
+					}
+				}()
+			}
+			return worker.Run(ctx)
+		},
+	}
+	cmd.Flags().IntVar(&concurrency, "concurrency", 0, "number of jobs run in parallel (defaults to JOBS_WORKERS)")
+
+	return cmd
+}
+
+// newWorker builds a job worker with every handler registered
+func (c *cli) newWorker(pool *pgxpool.Pool, config *environment.Environment) (*jobs.Worker, error) {
+	services, err := c.services(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := jobRegistry(pool, services, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs.NewWorker(jobs.NewPgxRepository(pool), registry, jobs.WorkerConfig{
+		Concurrency:     config.Jobs.Workers,
+		PollInterval:    config.Jobs.PollInterval,
+		StaleAfter:      config.Jobs.StaleAfter,
+		ShutdownTimeout: config.Jobs.ShutdownTimeout,
+	}), nil
+}
+
+// jobRegistry maps every job kind to its handler. Both `serve` and `worker`
+// use it, so any replica can pick up any job.
+func jobRegistry(pool *pgxpool.Pool, services uow.Services, config *environment.Environment) (*jobs.Registry, error) {
+	m, err := newMailer(config)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := mail.LoadTemplates(config.Mail.DefaultLocale)
+	if err != nil {
+		return nil, fmt.Errorf("MAIL_DEFAULT_LOCALE: %w", err)
+	}
+
+	registry := jobs.NewRegistry()
+	tasks.Register(registry, services, uow.NewTxManager(pool, services), outbox.NewPgxRepository(pool), jobs.NewPgxRepository(pool), tasksConfig(config))
+	webhooks.RegisterJobs(registry, services.Webhooks)
+	attachments.RegisterJobs(registry, services.Attachments)
+	mailer.RegisterJobs(registry, m, templates)
+	return registry, nil
+}
+
+// newMailer returns the MAIL_DRIVER implementation
+func newMailer(config *environment.Environment) (mail.Mailer, error) {
+	switch config.Mail.Driver {
+	case "resend":
+		return mail.NewResend(config.Resend.Url, config.Resend.Key, config.Mail.From, telemetry.NewHTTPClient()), nil
+	case "smtp":
+		return mail.NewSMTP(mail.SMTPConfig{
+			Host:     config.Mail.SMTP.Host,
+			Port:     config.Mail.SMTP.Port,
+			Username: config.Mail.SMTP.Username,
+			Password: config.Mail.SMTP.Password,
+			From:     config.Mail.From,
+			Security: config.Mail.SMTP.Security,
+		})
+	case "mailbox":
+		return mail.NewMailbox(config.Mail.MailboxDir), nil
+	default:
+		return mail.LogMailer{}, nil
+	}
+}
+
+// newStorage returns the STORAGE_BACKEND store
+func newStorage(config *environment.Environment) storage.Store {
+	if config.Storage.Backend == "r2" {
+		return storage.NewS3(storage.S3Config{
+			Endpoint:        config.R2.Endpoint(),
+			Region:          config.R2.Region,
+			Bucket:          config.R2.BucketName,
+			AccessKeyID:     config.R2.AccessKeyID,
+			SecretAccessKey: config.R2.SecretAccessKey,
+			HTTPClient:      telemetry.NewHTTPClient(),
+		})
+	}
+	return storage.NewLocal(storage.LocalConfig{
+		Dir:    config.Storage.LocalDir,
+		URL:    config.Storage.LocalURL,
+		Secret: []byte(config.Token.Secret),
+	})
+}
+
+// newScheduler builds the scheduler for the built-in recurring tasks
+func newScheduler(pool *pgxpool.Pool, config *environment.Environment) (*scheduler.Scheduler, error) {
+	return scheduler.New(pool,
+		scheduler.NewPgxRepository(pool),
+		jobs.NewQueue(jobs.NewPgxRepository(pool)),
+		tasks.Schedules(tasksConfig(config)),
+		scheduler.Config{},
+	)
+}
+
+func tasksConfig(config *environment.Environment) tasks.Config {
+	return tasks.Config{
+		SessionRetention:   config.Scheduler.SessionRetention,
+		LabubuPurgeAfter:   config.Scheduler.LabubuPurgeAfter,
+		SessionCleanupSpec: config.Scheduler.SessionCleanupSpec,
+		LabubuPurgeSpec:    config.Scheduler.LabubuPurgeSpec,
+		LabubuPublishSpec:  config.Scheduler.LabubuPublishSpec,
+		UsageReportSpec:    config.Scheduler.UsageReportSpec,
+		OutboxRetention:    config.Outbox.Retention,
+		OutboxCleanupSpec:  config.Outbox.CleanupSpec,
+		JobsRetention:      config.Jobs.Retention,
+		JobsCleanupSpec:    config.Jobs.CleanupSpec,
+	}
+}
+
+// newRelay builds the outbox relay with the configured sinks. The returned
+// function closes sink connections and must be called once the relay has stopped.
+func (c *cli) newRelay(pool *pgxpool.Pool, config *environment.Environment) (*outbox.Relay, func(), error) {
+	services, err := c.services(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sinks []outbox.Sink
+	var closers []io.Closer
+	closeSinks := func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}
+
+	for _, name := range config.Outbox.Sinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, outbox.LogSink{})
+		case "webhooks":
+			sinks = append(sinks, webhooks.NewSink(pool, services.Webhooks))
+		case "webhook":
+			sinks = append(sinks, outbox.NewWebhookSink(config.Outbox.WebhookURL, &http.Client{Timeout: 10 * time.Second}))
+		case "nats":
+			sink, err := outbox.NewNATSSink(config.Outbox.NATSURL, config.Outbox.NATSSubjectPrefix)
+			if err != nil {
+				closeSinks()
+				return nil, nil, err
+			}
+			sinks = append(sinks, sink)
+			closers = append(closers, sink)
+		}
+	}
+
+	relay := outbox.NewRelay(pool, outbox.NewPgxRepository(pool), sinks, outbox.RelayConfig{
+		PollInterval: config.Outbox.PollInterval,
+		BatchSize:    config.Outbox.BatchSize,
+	})
+	return relay, closeSinks, nil
+}
+
+// telemetryConfig maps the environment onto the tracing setup
+func telemetryConfig(config *environment.Environment) telemetry.Config {
+	return telemetry.Config{
+		ServiceName: config.Telemetry.ServiceName,
+		Exporter:    config.Telemetry.Exporter,
+		Endpoint:    config.Telemetry.Endpoint,
+		Insecure:    config.Telemetry.Insecure,
+		SampleRatio: config.Telemetry.SampleRatio,
+	}
+}
+
+// flushTracing exports buffered spans before exit
+func flushTracing(shutdown telemetry.ShutdownFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+}
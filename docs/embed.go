@@ -0,0 +1,10 @@
+// Package docs embeds the API documentation page and the bundled OpenAPI
+// specification so the binary can serve them from any working directory.
+package docs
+
+import "embed"
+
+// FS contains openapi.json and the _spotlight viewer assets
+//
+//go:embed openapi.json _spotlight
+var FS embed.FS
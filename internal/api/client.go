@@ -0,0 +1,11 @@
+package api
+
+import "github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+
+// NewTracedClient creates a Client whose requests carry the W3C traceparent
+// of the calling context. Options are applied after the traced transport, so
+// WithHTTPClient still overrides it.
+func NewTracedClient(server string, opts ...ClientOption) (*Client, error) {
+	opts = append([]ClientOption{WithHTTPClient(telemetry.NewHTTPClient())}, opts...)
+	return NewClient(server, opts...)
+}
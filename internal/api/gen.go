@@ -9,31 +9,249 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for GetLabubuParamsMatch.
+const (
+	All GetLabubuParamsMatch = "all"
+	Any GetLabubuParamsMatch = "any"
+)
+
+// Defines values for TransitionLabubuJSONBodyStatus.
+const (
+	Archived  TransitionLabubuJSONBodyStatus = "archived"
+	Draft     TransitionLabubuJSONBodyStatus = "draft"
+	Published TransitionLabubuJSONBodyStatus = "published"
+	Scheduled TransitionLabubuJSONBodyStatus = "scheduled"
+)
+
+// ListCollectionsParams defines parameters for ListCollections.
+type ListCollectionsParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// CreateCollectionJSONBody defines parameters for CreateCollection.
+type CreateCollectionJSONBody struct {
+	// Name 1 to 200 characters, trimmed
+	Name string `json:"name"`
+}
+
+// RenameCollectionJSONBody defines parameters for RenameCollection.
+type RenameCollectionJSONBody struct {
+	// Name 1 to 200 characters, trimmed
+	Name string `json:"name"`
+}
+
+// ListCollectionItemsParams defines parameters for ListCollectionItems.
+type ListCollectionItemsParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// AddCollectionItemJSONBody defines parameters for AddCollectionItem.
+type AddCollectionItemJSONBody struct {
+	// LabubuId One of your labubu
+	LabubuId int `json:"labubu_id"`
+}
+
+// MoveCollectionItemJSONBody defines parameters for MoveCollectionItem.
+type MoveCollectionItemJSONBody struct {
+	// Position Zero-based position; past the end moves the item last
+	Position int `json:"position"`
+}
+
+// ListCollectionSharesParams defines parameters for ListCollectionShares.
+type ListCollectionSharesParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ShareCollectionJSONBody defines parameters for ShareCollection.
+type ShareCollectionJSONBody struct {
+	Email openapi_types.Email `json:"email"`
+
+	// Role viewer or editor. Editors add their own labubu, remove and reorder items.
+	Role string `json:"role"`
+}
+
+// VerifyEmailParams defines parameters for VerifyEmail.
+type VerifyEmailParams struct {
+	Token string `form:"token" json:"token"`
+}
+
+// GetLabubuParams defines parameters for GetLabubu.
+type GetLabubuParams struct {
+	// Tag Only labubu with these tags, e.g. `?tag=a&tag=b`
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Match Whether labubu need any or all of the given tags
+	Match *GetLabubuParamsMatch `form:"match,omitempty" json:"match,omitempty"`
+
+	// Status Only labubu in these statuses (draft, scheduled, published, archived), e.g. `?status=draft&status=scheduled`
+	Status *[]string `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetLabubuParamsMatch defines parameters for GetLabubu.
+type GetLabubuParamsMatch string
+
 // CreateLabubuJSONBody defines parameters for CreateLabubu.
 type CreateLabubuJSONBody struct {
-	Text string `json:"text"`
+	// Tags Tag names; they are trimmed and lowercased, duplicates dropped. At most 20 of 1 to 50 characters.
+	Tags *[]string `json:"tags,omitempty"`
+	Text string    `json:"text"`
+}
+
+// UpdateLabubuJSONBody defines parameters for UpdateLabubu.
+type UpdateLabubuJSONBody struct {
+	// Tags Replaces the tags when given; leave it out to keep them
+	Tags *[]string `json:"tags,omitempty"`
+	Text string    `json:"text"`
+
+	// Version The version you last read; the update is rejected if it has changed since
+	Version int `json:"version"`
+}
+
+// UploadAttachmentJSONBody defines parameters for UploadAttachment.
+type UploadAttachmentJSONBody struct {
+	ContentType string `json:"content_type"`
+	Filename    string `json:"filename"`
+
+	// Size Size in bytes; the upload must match it
+	Size int64 `json:"size"`
+}
+
+// UploadAttachmentMultipartBody defines parameters for UploadAttachment.
+type UploadAttachmentMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// CreateShareLinkJSONBody defines parameters for CreateShareLink.
+type CreateShareLinkJSONBody struct {
+	// ExpiresAt When the link stops working; defaults to a week from now and may be at most 90 days away
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// MaxViews How many times the link can be opened; unlimited when left out
+	MaxViews *int `json:"max_views,omitempty"`
+
+	// Password At least 8 characters; visitors have to enter it to see the labubu
+	Password *string `json:"password,omitempty"`
+}
+
+// ListShareLinkAccessesParams defines parameters for ListShareLinkAccesses.
+type ListShareLinkAccessesParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// TransitionLabubuJSONBody defines parameters for TransitionLabubu.
+type TransitionLabubuJSONBody struct {
+	// PublishAt Required when scheduling; must be in the future
+	PublishAt *time.Time                     `json:"publish_at,omitempty"`
+	Status    TransitionLabubuJSONBodyStatus `json:"status"`
+}
+
+// TransitionLabubuJSONBodyStatus defines parameters for TransitionLabubu.
+type TransitionLabubuJSONBodyStatus string
+
 // LoginJSONBody defines parameters for Login.
-type LoginJSONBody = map[string]interface{}
+type LoginJSONBody struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// ForgotPasswordJSONBody defines parameters for ForgotPassword.
+type ForgotPasswordJSONBody struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// ResetPasswordJSONBody defines parameters for ResetPassword.
+type ResetPasswordJSONBody struct {
+	Password string `json:"password"`
+
+	// Token The token from the reset link
+	Token string `json:"token"`
+}
+
+// RenameTagJSONBody defines parameters for RenameTag.
+type RenameTagJSONBody struct {
+	Name string `json:"name"`
+}
+
+// MergeTagJSONBody defines parameters for MergeTag.
+type MergeTagJSONBody struct {
+	// Into The tag that takes over the labubu of this one
+	Into int `json:"into"`
+}
+
+// RefreshTokenJSONBody defines parameters for RefreshToken.
+type RefreshTokenJSONBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// CreateCollectionJSONRequestBody defines body for CreateCollection for application/json ContentType.
+type CreateCollectionJSONRequestBody CreateCollectionJSONBody
+
+// RenameCollectionJSONRequestBody defines body for RenameCollection for application/json ContentType.
+type RenameCollectionJSONRequestBody RenameCollectionJSONBody
+
+// AddCollectionItemJSONRequestBody defines body for AddCollectionItem for application/json ContentType.
+type AddCollectionItemJSONRequestBody AddCollectionItemJSONBody
+
+// MoveCollectionItemJSONRequestBody defines body for MoveCollectionItem for application/json ContentType.
+type MoveCollectionItemJSONRequestBody MoveCollectionItemJSONBody
+
+// ShareCollectionJSONRequestBody defines body for ShareCollection for application/json ContentType.
+type ShareCollectionJSONRequestBody ShareCollectionJSONBody
 
 // CreateLabubuJSONRequestBody defines body for CreateLabubu for application/json ContentType.
 type CreateLabubuJSONRequestBody CreateLabubuJSONBody
 
+// UpdateLabubuJSONRequestBody defines body for UpdateLabubu for application/json ContentType.
+type UpdateLabubuJSONRequestBody UpdateLabubuJSONBody
+
+// UploadAttachmentJSONRequestBody defines body for UploadAttachment for application/json ContentType.
+type UploadAttachmentJSONRequestBody UploadAttachmentJSONBody
+
+// UploadAttachmentMultipartRequestBody defines body for UploadAttachment for multipart/form-data ContentType.
+type UploadAttachmentMultipartRequestBody UploadAttachmentMultipartBody
+
+// CreateShareLinkJSONRequestBody defines body for CreateShareLink for application/json ContentType.
+type CreateShareLinkJSONRequestBody CreateShareLinkJSONBody
+
+// TransitionLabubuJSONRequestBody defines body for TransitionLabubu for application/json ContentType.
+type TransitionLabubuJSONRequestBody TransitionLabubuJSONBody
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
-type LoginJSONRequestBody = LoginJSONBody
+type LoginJSONRequestBody LoginJSONBody
+
+// ForgotPasswordJSONRequestBody defines body for ForgotPassword for application/json ContentType.
+type ForgotPasswordJSONRequestBody ForgotPasswordJSONBody
+
+// ResetPasswordJSONRequestBody defines body for ResetPassword for application/json ContentType.
+type ResetPasswordJSONRequestBody ResetPasswordJSONBody
+
+// RenameTagJSONRequestBody defines body for RenameTag for application/json ContentType.
+type RenameTagJSONRequestBody RenameTagJSONBody
+
+// MergeTagJSONRequestBody defines body for MergeTag for application/json ContentType.
+type MergeTagJSONRequestBody MergeTagJSONBody
+
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody RefreshTokenJSONBody
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
@@ -108,22 +326,152 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListCollections request
+	ListCollections(ctx context.Context, params *ListCollectionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateCollectionWithBody request with any body
+	CreateCollectionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateCollection(ctx context.Context, body CreateCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteCollection request
+	DeleteCollection(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCollection request
+	GetCollection(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RenameCollectionWithBody request with any body
+	RenameCollectionWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RenameCollection(ctx context.Context, id int, body RenameCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCollectionItems request
+	ListCollectionItems(ctx context.Context, id int, params *ListCollectionItemsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddCollectionItemWithBody request with any body
+	AddCollectionItemWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddCollectionItem(ctx context.Context, id int, body AddCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveCollectionItem request
+	RemoveCollectionItem(ctx context.Context, id int, labubuId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// MoveCollectionItemWithBody request with any body
+	MoveCollectionItemWithBody(ctx context.Context, id int, labubuId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	MoveCollectionItem(ctx context.Context, id int, labubuId int, body MoveCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCollectionShares request
+	ListCollectionShares(ctx context.Context, id int, params *ListCollectionSharesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ShareCollectionWithBody request with any body
+	ShareCollectionWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ShareCollection(ctx context.Context, id int, body ShareCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnshareCollection request
+	UnshareCollection(ctx context.Context, id int, userId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResendVerification request
+	ResendVerification(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// VerifyEmail request
+	VerifyEmail(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetLabubu request
-	GetLabubu(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetLabubu(ctx context.Context, params *GetLabubuParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// CreateLabubuWithBody request with any body
 	CreateLabubuWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	CreateLabubu(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteLabubu request
+	DeleteLabubu(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateLabubuWithBody request with any body
+	UpdateLabubuWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateLabubu(ctx context.Context, id int, body UpdateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAttachments request
+	ListAttachments(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadAttachmentWithBody request with any body
+	UploadAttachmentWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UploadAttachment(ctx context.Context, id int, body UploadAttachmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAttachment request
+	DeleteAttachment(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DownloadAttachment request
+	DownloadAttachment(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CompleteAttachment request
+	CompleteAttachment(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAttachmentVariants request
+	ListAttachmentVariants(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListLabubuTransitions request
+	ListLabubuTransitions(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListShareLinks request
+	ListShareLinks(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateShareLinkWithBody request with any body
+	CreateShareLinkWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateShareLink(ctx context.Context, id int, body CreateShareLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeShareLink request
+	RevokeShareLink(ctx context.Context, id int, linkId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListShareLinkAccesses request
+	ListShareLinkAccesses(ctx context.Context, id int, linkId int64, params *ListShareLinkAccessesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TransitionLabubuWithBody request with any body
+	TransitionLabubuWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TransitionLabubu(ctx context.Context, id int, body TransitionLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// LoginWithBody request with any body
 	LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ForgotPasswordWithBody request with any body
+	ForgotPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ForgotPassword(ctx context.Context, body ForgotPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResetPasswordWithBody request with any body
+	ResetPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ResetPassword(ctx context.Context, body ResetPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTags request
+	ListTags(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RenameTagWithBody request with any body
+	RenameTagWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RenameTag(ctx context.Context, id int, body RenameTagJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// MergeTagWithBody request with any body
+	MergeTagWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	MergeTag(ctx context.Context, id int, body MergeTagJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefreshTokenWithBody request with any body
+	RefreshTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RefreshToken(ctx context.Context, body RefreshTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetLabubu(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLabubuRequest(c.Server)
+func (c *Client) ListCollections(ctx context.Context, params *ListCollectionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCollectionsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -134,8 +482,8 @@ func (c *Client) GetLabubu(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateLabubuWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateLabubuRequestWithBody(c.Server, contentType, body)
+func (c *Client) CreateCollectionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCollectionRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -146,8 +494,8 @@ func (c *Client) CreateLabubuWithBody(ctx context.Context, contentType string, b
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateLabubu(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateLabubuRequest(c.Server, body)
+func (c *Client) CreateCollection(ctx context.Context, body CreateCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCollectionRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -158,8 +506,8 @@ func (c *Client) CreateLabubu(ctx context.Context, body CreateLabubuJSONRequestB
 	return c.Client.Do(req)
 }
 
-func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewLoginRequestWithBody(c.Server, contentType, body)
+func (c *Client) DeleteCollection(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCollectionRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +518,8 @@ func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.
 	return c.Client.Do(req)
 }
 
-func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewLoginRequest(c.Server, body)
+func (c *Client) GetCollection(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCollectionRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -182,725 +530,10093 @@ func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditor
 	return c.Client.Do(req)
 }
 
-// NewGetLabubuRequest generates requests for GetLabubu
-func NewGetLabubuRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) RenameCollectionWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameCollectionRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/labubu")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) RenameCollection(ctx context.Context, id int, body RenameCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameCollectionRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewCreateLabubuRequest calls the generic CreateLabubu builder with application/json body
-func NewCreateLabubuRequest(server string, body CreateLabubuJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) ListCollectionItems(ctx context.Context, id int, params *ListCollectionItemsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCollectionItemsRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateLabubuRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewCreateLabubuRequestWithBody generates requests for CreateLabubu with any type of body
-func NewCreateLabubuRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) AddCollectionItemWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddCollectionItemRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/labubu")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) AddCollectionItem(ctx context.Context, id int, body AddCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddCollectionItemRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewLoginRequest calls the generic Login builder with application/json body
-func NewLoginRequest(server string, body LoginJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) RemoveCollectionItem(ctx context.Context, id int, labubuId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveCollectionItemRequest(c.Server, id, labubuId)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewLoginRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewLoginRequestWithBody generates requests for Login with any type of body
-func NewLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) MoveCollectionItemWithBody(ctx context.Context, id int, labubuId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMoveCollectionItemRequestWithBody(c.Server, id, labubuId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/login")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) MoveCollectionItem(ctx context.Context, id int, labubuId int, body MoveCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMoveCollectionItemRequest(c.Server, id, labubuId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) ListCollectionShares(ctx context.Context, id int, params *ListCollectionSharesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCollectionSharesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
-}
-
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) ShareCollectionWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewShareCollectionRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+func (c *Client) ShareCollection(ctx context.Context, id int, body ShareCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewShareCollectionRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetLabubuWithResponse request
-	GetLabubuWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLabubuResponse, error)
-
-	// CreateLabubuWithBodyWithResponse request with any body
-	CreateLabubuWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error)
-
-	CreateLabubuWithResponse(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error)
-
-	// LoginWithBodyWithResponse request with any body
-	LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error)
-
-	LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+func (c *Client) UnshareCollection(ctx context.Context, id int, userId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnshareCollectionRequest(c.Server, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetLabubuResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]struct {
-		Id   int    `json:"id"`
-		Text string `json:"text"`
+func (c *Client) ResendVerification(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResendVerificationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetLabubuResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) VerifyEmail(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVerifyEmailRequest(c.Server, params)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetLabubuResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GetLabubu(ctx context.Context, params *GetLabubuParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLabubuRequest(c.Server, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type CreateLabubuResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		Id   int    `json:"id"`
-		Text string `json:"text"`
+func (c *Client) CreateLabubuWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateLabubuRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r CreateLabubuResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) CreateLabubu(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateLabubuRequest(c.Server, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateLabubuResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) DeleteLabubu(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteLabubuRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type LoginResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		AccessToken  string `json:"access_token"`
-		RefreshToken string `json:"refresh_token"`
+func (c *Client) UpdateLabubuWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateLabubuRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r LoginResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) UpdateLabubu(ctx context.Context, id int, body UpdateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateLabubuRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r LoginResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) ListAttachments(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAttachmentsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// GetLabubuWithResponse request returning *GetLabubuResponse
-func (c *ClientWithResponses) GetLabubuWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLabubuResponse, error) {
-	rsp, err := c.GetLabubu(ctx, reqEditors...)
+func (c *Client) UploadAttachmentWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadAttachmentRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetLabubuResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// CreateLabubuWithBodyWithResponse request with arbitrary body returning *CreateLabubuResponse
-func (c *ClientWithResponses) CreateLabubuWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error) {
-	rsp, err := c.CreateLabubuWithBody(ctx, contentType, body, reqEditors...)
+func (c *Client) UploadAttachment(ctx context.Context, id int, body UploadAttachmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadAttachmentRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateLabubuResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *ClientWithResponses) CreateLabubuWithResponse(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error) {
-	rsp, err := c.CreateLabubu(ctx, body, reqEditors...)
+func (c *Client) DeleteAttachment(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAttachmentRequest(c.Server, id, attachmentId)
 	if err != nil {
 		return nil, err
 	}
-	return ParseCreateLabubuResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// LoginWithBodyWithResponse request with arbitrary body returning *LoginResponse
-func (c *ClientWithResponses) LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
-	rsp, err := c.LoginWithBody(ctx, contentType, body, reqEditors...)
+func (c *Client) DownloadAttachment(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDownloadAttachmentRequest(c.Server, id, attachmentId)
 	if err != nil {
 		return nil, err
 	}
-	return ParseLoginResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *ClientWithResponses) LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
-	rsp, err := c.Login(ctx, body, reqEditors...)
+func (c *Client) CompleteAttachment(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCompleteAttachmentRequest(c.Server, id, attachmentId)
 	if err != nil {
 		return nil, err
 	}
-	return ParseLoginResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// ParseGetLabubuResponse parses an HTTP response from a GetLabubuWithResponse call
-func ParseGetLabubuResponse(rsp *http.Response) (*GetLabubuResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+func (c *Client) ListAttachmentVariants(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAttachmentVariantsRequest(c.Server, id, attachmentId)
 	if err != nil {
 		return nil, err
 	}
-
-	response := &GetLabubuResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []struct {
-			Id   int    `json:"id"`
-			Text string `json:"text"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
+func (c *Client) ListLabubuTransitions(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListLabubuTransitionsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-
-	return response, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// ParseCreateLabubuResponse parses an HTTP response from a CreateLabubuWithResponse call
-func ParseCreateLabubuResponse(rsp *http.Response) (*CreateLabubuResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+func (c *Client) ListShareLinks(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListShareLinksRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	response := &CreateLabubuResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			Id   int    `json:"id"`
-			Text string `json:"text"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
+func (c *Client) CreateShareLinkWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateShareLinkRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-
-	return response, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// ParseLoginResponse parses an HTTP response from a LoginWithResponse call
-func ParseLoginResponse(rsp *http.Response) (*LoginResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+func (c *Client) CreateShareLink(ctx context.Context, id int, body CreateShareLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateShareLinkRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeShareLink(ctx context.Context, id int, linkId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeShareLinkRequest(c.Server, id, linkId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListShareLinkAccesses(ctx context.Context, id int, linkId int64, params *ListShareLinkAccessesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListShareLinkAccessesRequest(c.Server, id, linkId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransitionLabubuWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransitionLabubuRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TransitionLabubu(ctx context.Context, id int, body TransitionLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTransitionLabubuRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ForgotPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewForgotPasswordRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ForgotPassword(ctx context.Context, body ForgotPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewForgotPasswordRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResetPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResetPasswordRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResetPassword(ctx context.Context, body ResetPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResetPasswordRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTags(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTagsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RenameTagWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameTagRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RenameTag(ctx context.Context, id int, body RenameTagJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameTagRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MergeTagWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMergeTagRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MergeTag(ctx context.Context, id int, body MergeTagJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMergeTagRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RefreshTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RefreshToken(ctx context.Context, body RefreshTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListCollectionsRequest generates requests for ListCollections
+func NewListCollectionsRequest(server string, params *ListCollectionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateCollectionRequest calls the generic CreateCollection builder with application/json body
+func NewCreateCollectionRequest(server string, body CreateCollectionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateCollectionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateCollectionRequestWithBody generates requests for CreateCollection with any type of body
+func NewCreateCollectionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteCollectionRequest generates requests for DeleteCollection
+func NewDeleteCollectionRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCollectionRequest generates requests for GetCollection
+func NewGetCollectionRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRenameCollectionRequest calls the generic RenameCollection builder with application/json body
+func NewRenameCollectionRequest(server string, id int, body RenameCollectionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRenameCollectionRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRenameCollectionRequestWithBody generates requests for RenameCollection with any type of body
+func NewRenameCollectionRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListCollectionItemsRequest generates requests for ListCollectionItems
+func NewListCollectionItemsRequest(server string, id int, params *ListCollectionItemsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/items", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddCollectionItemRequest calls the generic AddCollectionItem builder with application/json body
+func NewAddCollectionItemRequest(server string, id int, body AddCollectionItemJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddCollectionItemRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAddCollectionItemRequestWithBody generates requests for AddCollectionItem with any type of body
+func NewAddCollectionItemRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/items", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveCollectionItemRequest generates requests for RemoveCollectionItem
+func NewRemoveCollectionItemRequest(server string, id int, labubuId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "labubuId", runtime.ParamLocationPath, labubuId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/items/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewMoveCollectionItemRequest calls the generic MoveCollectionItem builder with application/json body
+func NewMoveCollectionItemRequest(server string, id int, labubuId int, body MoveCollectionItemJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewMoveCollectionItemRequestWithBody(server, id, labubuId, "application/json", bodyReader)
+}
+
+// NewMoveCollectionItemRequestWithBody generates requests for MoveCollectionItem with any type of body
+func NewMoveCollectionItemRequestWithBody(server string, id int, labubuId int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "labubuId", runtime.ParamLocationPath, labubuId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/items/%s/position", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListCollectionSharesRequest generates requests for ListCollectionShares
+func NewListCollectionSharesRequest(server string, id int, params *ListCollectionSharesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/shares", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewShareCollectionRequest calls the generic ShareCollection builder with application/json body
+func NewShareCollectionRequest(server string, id int, body ShareCollectionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewShareCollectionRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewShareCollectionRequestWithBody generates requests for ShareCollection with any type of body
+func NewShareCollectionRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/shares", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnshareCollectionRequest generates requests for UnshareCollection
+func NewUnshareCollectionRequest(server string, id int, userId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/collections/%s/shares/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResendVerificationRequest generates requests for ResendVerification
+func NewResendVerificationRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/email/verification")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewVerifyEmailRequest generates requests for VerifyEmail
+func NewVerifyEmailRequest(server string, params *VerifyEmailParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/email/verify")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "token", runtime.ParamLocationQuery, params.Token); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLabubuRequest generates requests for GetLabubu
+func NewGetLabubuRequest(server string, params *GetLabubuParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Match != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "match", runtime.ParamLocationQuery, *params.Match); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateLabubuRequest calls the generic CreateLabubu builder with application/json body
+func NewCreateLabubuRequest(server string, body CreateLabubuJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateLabubuRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateLabubuRequestWithBody generates requests for CreateLabubu with any type of body
+func NewCreateLabubuRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteLabubuRequest generates requests for DeleteLabubu
+func NewDeleteLabubuRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateLabubuRequest calls the generic UpdateLabubu builder with application/json body
+func NewUpdateLabubuRequest(server string, id int, body UpdateLabubuJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateLabubuRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateLabubuRequestWithBody generates requests for UpdateLabubu with any type of body
+func NewUpdateLabubuRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListAttachmentsRequest generates requests for ListAttachments
+func NewListAttachmentsRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/attachments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUploadAttachmentRequest calls the generic UploadAttachment builder with application/json body
+func NewUploadAttachmentRequest(server string, id int, body UploadAttachmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUploadAttachmentRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUploadAttachmentRequestWithBody generates requests for UploadAttachment with any type of body
+func NewUploadAttachmentRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/attachments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAttachmentRequest generates requests for DeleteAttachment
+func NewDeleteAttachmentRequest(server string, id int, attachmentId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/attachments/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDownloadAttachmentRequest generates requests for DownloadAttachment
+func NewDownloadAttachmentRequest(server string, id int, attachmentId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/attachments/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCompleteAttachmentRequest generates requests for CompleteAttachment
+func NewCompleteAttachmentRequest(server string, id int, attachmentId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/attachments/%s/complete", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListAttachmentVariantsRequest generates requests for ListAttachmentVariants
+func NewListAttachmentVariantsRequest(server string, id int, attachmentId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/attachments/%s/variants", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListLabubuTransitionsRequest generates requests for ListLabubuTransitions
+func NewListLabubuTransitionsRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListShareLinksRequest generates requests for ListShareLinks
+func NewListShareLinksRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/share", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateShareLinkRequest calls the generic CreateShareLink builder with application/json body
+func NewCreateShareLinkRequest(server string, id int, body CreateShareLinkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateShareLinkRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCreateShareLinkRequestWithBody generates requests for CreateShareLink with any type of body
+func NewCreateShareLinkRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/share", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeShareLinkRequest generates requests for RevokeShareLink
+func NewRevokeShareLinkRequest(server string, id int, linkId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "linkId", runtime.ParamLocationPath, linkId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/share/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListShareLinkAccessesRequest generates requests for ListShareLinkAccesses
+func NewListShareLinkAccessesRequest(server string, id int, linkId int64, params *ListShareLinkAccessesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "linkId", runtime.ParamLocationPath, linkId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/share/%s/accesses", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTransitionLabubuRequest calls the generic TransitionLabubu builder with application/json body
+func NewTransitionLabubuRequest(server string, id int, body TransitionLabubuJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTransitionLabubuRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewTransitionLabubuRequestWithBody generates requests for TransitionLabubu with any type of body
+func NewTransitionLabubuRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/labubu/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLoginRequest calls the generic Login builder with application/json body
+func NewLoginRequest(server string, body LoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLoginRequestWithBody generates requests for Login with any type of body
+func NewLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewForgotPasswordRequest calls the generic ForgotPassword builder with application/json body
+func NewForgotPasswordRequest(server string, body ForgotPasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewForgotPasswordRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewForgotPasswordRequestWithBody generates requests for ForgotPassword with any type of body
+func NewForgotPasswordRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/password/forgot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewResetPasswordRequest calls the generic ResetPassword builder with application/json body
+func NewResetPasswordRequest(server string, body ResetPasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewResetPasswordRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewResetPasswordRequestWithBody generates requests for ResetPassword with any type of body
+func NewResetPasswordRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/password/reset")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTagsRequest generates requests for ListTags
+func NewListTagsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tags")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRenameTagRequest calls the generic RenameTag builder with application/json body
+func NewRenameTagRequest(server string, id int, body RenameTagJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRenameTagRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRenameTagRequestWithBody generates requests for RenameTag with any type of body
+func NewRenameTagRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tags/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewMergeTagRequest calls the generic MergeTag builder with application/json body
+func NewMergeTagRequest(server string, id int, body MergeTagJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewMergeTagRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewMergeTagRequestWithBody generates requests for MergeTag with any type of body
+func NewMergeTagRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tags/%s/merge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRefreshTokenRequest calls the generic RefreshToken builder with application/json body
+func NewRefreshTokenRequest(server string, body RefreshTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRefreshTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRefreshTokenRequestWithBody generates requests for RefreshToken with any type of body
+func NewRefreshTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/token/refresh")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListCollectionsWithResponse request
+	ListCollectionsWithResponse(ctx context.Context, params *ListCollectionsParams, reqEditors ...RequestEditorFn) (*ListCollectionsResponse, error)
+
+	// CreateCollectionWithBodyWithResponse request with any body
+	CreateCollectionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCollectionResponse, error)
+
+	CreateCollectionWithResponse(ctx context.Context, body CreateCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCollectionResponse, error)
+
+	// DeleteCollectionWithResponse request
+	DeleteCollectionWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteCollectionResponse, error)
+
+	// GetCollectionWithResponse request
+	GetCollectionWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetCollectionResponse, error)
+
+	// RenameCollectionWithBodyWithResponse request with any body
+	RenameCollectionWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameCollectionResponse, error)
+
+	RenameCollectionWithResponse(ctx context.Context, id int, body RenameCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameCollectionResponse, error)
+
+	// ListCollectionItemsWithResponse request
+	ListCollectionItemsWithResponse(ctx context.Context, id int, params *ListCollectionItemsParams, reqEditors ...RequestEditorFn) (*ListCollectionItemsResponse, error)
+
+	// AddCollectionItemWithBodyWithResponse request with any body
+	AddCollectionItemWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddCollectionItemResponse, error)
+
+	AddCollectionItemWithResponse(ctx context.Context, id int, body AddCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*AddCollectionItemResponse, error)
+
+	// RemoveCollectionItemWithResponse request
+	RemoveCollectionItemWithResponse(ctx context.Context, id int, labubuId int, reqEditors ...RequestEditorFn) (*RemoveCollectionItemResponse, error)
+
+	// MoveCollectionItemWithBodyWithResponse request with any body
+	MoveCollectionItemWithBodyWithResponse(ctx context.Context, id int, labubuId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MoveCollectionItemResponse, error)
+
+	MoveCollectionItemWithResponse(ctx context.Context, id int, labubuId int, body MoveCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*MoveCollectionItemResponse, error)
+
+	// ListCollectionSharesWithResponse request
+	ListCollectionSharesWithResponse(ctx context.Context, id int, params *ListCollectionSharesParams, reqEditors ...RequestEditorFn) (*ListCollectionSharesResponse, error)
+
+	// ShareCollectionWithBodyWithResponse request with any body
+	ShareCollectionWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShareCollectionResponse, error)
+
+	ShareCollectionWithResponse(ctx context.Context, id int, body ShareCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*ShareCollectionResponse, error)
+
+	// UnshareCollectionWithResponse request
+	UnshareCollectionWithResponse(ctx context.Context, id int, userId int, reqEditors ...RequestEditorFn) (*UnshareCollectionResponse, error)
+
+	// ResendVerificationWithResponse request
+	ResendVerificationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResendVerificationResponse, error)
+
+	// VerifyEmailWithResponse request
+	VerifyEmailWithResponse(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error)
+
+	// GetLabubuWithResponse request
+	GetLabubuWithResponse(ctx context.Context, params *GetLabubuParams, reqEditors ...RequestEditorFn) (*GetLabubuResponse, error)
+
+	// CreateLabubuWithBodyWithResponse request with any body
+	CreateLabubuWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error)
+
+	CreateLabubuWithResponse(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error)
+
+	// DeleteLabubuWithResponse request
+	DeleteLabubuWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteLabubuResponse, error)
+
+	// UpdateLabubuWithBodyWithResponse request with any body
+	UpdateLabubuWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateLabubuResponse, error)
+
+	UpdateLabubuWithResponse(ctx context.Context, id int, body UpdateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateLabubuResponse, error)
+
+	// ListAttachmentsWithResponse request
+	ListAttachmentsWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*ListAttachmentsResponse, error)
+
+	// UploadAttachmentWithBodyWithResponse request with any body
+	UploadAttachmentWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadAttachmentResponse, error)
+
+	UploadAttachmentWithResponse(ctx context.Context, id int, body UploadAttachmentJSONRequestBody, reqEditors ...RequestEditorFn) (*UploadAttachmentResponse, error)
+
+	// DeleteAttachmentWithResponse request
+	DeleteAttachmentWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*DeleteAttachmentResponse, error)
+
+	// DownloadAttachmentWithResponse request
+	DownloadAttachmentWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*DownloadAttachmentResponse, error)
+
+	// CompleteAttachmentWithResponse request
+	CompleteAttachmentWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*CompleteAttachmentResponse, error)
+
+	// ListAttachmentVariantsWithResponse request
+	ListAttachmentVariantsWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*ListAttachmentVariantsResponse, error)
+
+	// ListLabubuTransitionsWithResponse request
+	ListLabubuTransitionsWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*ListLabubuTransitionsResponse, error)
+
+	// ListShareLinksWithResponse request
+	ListShareLinksWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*ListShareLinksResponse, error)
+
+	// CreateShareLinkWithBodyWithResponse request with any body
+	CreateShareLinkWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateShareLinkResponse, error)
+
+	CreateShareLinkWithResponse(ctx context.Context, id int, body CreateShareLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateShareLinkResponse, error)
+
+	// RevokeShareLinkWithResponse request
+	RevokeShareLinkWithResponse(ctx context.Context, id int, linkId int64, reqEditors ...RequestEditorFn) (*RevokeShareLinkResponse, error)
+
+	// ListShareLinkAccessesWithResponse request
+	ListShareLinkAccessesWithResponse(ctx context.Context, id int, linkId int64, params *ListShareLinkAccessesParams, reqEditors ...RequestEditorFn) (*ListShareLinkAccessesResponse, error)
+
+	// TransitionLabubuWithBodyWithResponse request with any body
+	TransitionLabubuWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransitionLabubuResponse, error)
+
+	TransitionLabubuWithResponse(ctx context.Context, id int, body TransitionLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*TransitionLabubuResponse, error)
+
+	// LoginWithBodyWithResponse request with any body
+	LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	// ForgotPasswordWithBodyWithResponse request with any body
+	ForgotPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ForgotPasswordResponse, error)
+
+	ForgotPasswordWithResponse(ctx context.Context, body ForgotPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*ForgotPasswordResponse, error)
+
+	// ResetPasswordWithBodyWithResponse request with any body
+	ResetPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResetPasswordResponse, error)
+
+	ResetPasswordWithResponse(ctx context.Context, body ResetPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*ResetPasswordResponse, error)
+
+	// ListTagsWithResponse request
+	ListTagsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTagsResponse, error)
+
+	// RenameTagWithBodyWithResponse request with any body
+	RenameTagWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameTagResponse, error)
+
+	RenameTagWithResponse(ctx context.Context, id int, body RenameTagJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameTagResponse, error)
+
+	// MergeTagWithBodyWithResponse request with any body
+	MergeTagWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MergeTagResponse, error)
+
+	MergeTagWithResponse(ctx context.Context, id int, body MergeTagJSONRequestBody, reqEditors ...RequestEditorFn) (*MergeTagResponse, error)
+
+	// RefreshTokenWithBodyWithResponse request with any body
+	RefreshTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RefreshTokenResponse, error)
+
+	RefreshTokenWithResponse(ctx context.Context, body RefreshTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*RefreshTokenResponse, error)
+}
+
+type ListCollectionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Items []struct {
+			CreatedAt time.Time `json:"created_at"`
+			Id        int       `json:"id"`
+			Name      string    `json:"name"`
+			OwnerId   int       `json:"owner_id"`
+
+			// Role Your role in the collection, owner, editor or viewer
+			Role string `json:"role"`
+
+			// UpdatedAt When the collection or its items last changed
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"items"`
+
+		// NextOffset The offset of the next page; missing on the last one
+		NextOffset *int `json:"next_offset,omitempty"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCollectionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCollectionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		CreatedAt time.Time `json:"created_at"`
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		OwnerId   int       `json:"owner_id"`
+
+		// Role Your role in the collection, owner, editor or viewer
+		Role string `json:"role"`
+
+		// UpdatedAt When the collection or its items last changed
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		CreatedAt time.Time `json:"created_at"`
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		OwnerId   int       `json:"owner_id"`
+
+		// Role Your role in the collection, owner, editor or viewer
+		Role string `json:"role"`
+
+		// UpdatedAt When the collection or its items last changed
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RenameCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		CreatedAt time.Time `json:"created_at"`
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		OwnerId   int       `json:"owner_id"`
+
+		// Role Your role in the collection, owner, editor or viewer
+		Role string `json:"role"`
+
+		// UpdatedAt When the collection or its items last changed
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RenameCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RenameCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCollectionItemsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Items []struct {
+			AddedAt time.Time `json:"added_at"`
+
+			// AddedBy Who added the item; missing once their account is deleted
+			AddedBy *int `json:"added_by,omitempty"`
+			Labubu  struct {
+				Id int `json:"id"`
+
+				// PublishAt When a scheduled labubu gets published
+				PublishAt *time.Time `json:"publish_at,omitempty"`
+
+				// PublishedAt When the labubu was last published
+				PublishedAt *time.Time `json:"published_at,omitempty"`
+
+				// Status draft, scheduled, published or archived
+				Status string `json:"status"`
+
+				// Tags Normalized tag names, sorted
+				Tags    []string `json:"tags"`
+				Text    string   `json:"text"`
+				Version int      `json:"version"`
+			} `json:"labubu"`
+			LabubuId int `json:"labubu_id"`
+
+			// Position Zero-based position in the collection
+			Position int `json:"position"`
+		} `json:"items"`
+
+		// NextOffset The offset of the next page; missing on the last one
+		NextOffset *int `json:"next_offset,omitempty"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCollectionItemsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCollectionItemsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddCollectionItemResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		AddedAt time.Time `json:"added_at"`
+
+		// AddedBy Who added the item; missing once their account is deleted
+		AddedBy *int `json:"added_by,omitempty"`
+		Labubu  struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		} `json:"labubu"`
+		LabubuId int `json:"labubu_id"`
+
+		// Position Zero-based position in the collection
+		Position int `json:"position"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r AddCollectionItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddCollectionItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveCollectionItemResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveCollectionItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveCollectionItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MoveCollectionItemResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		AddedAt time.Time `json:"added_at"`
+
+		// AddedBy Who added the item; missing once their account is deleted
+		AddedBy *int `json:"added_by,omitempty"`
+		Labubu  struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		} `json:"labubu"`
+		LabubuId int `json:"labubu_id"`
+
+		// Position Zero-based position in the collection
+		Position int `json:"position"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r MoveCollectionItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MoveCollectionItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCollectionSharesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Items []struct {
+			CreatedAt time.Time `json:"created_at"`
+			Email     string    `json:"email"`
+			Role      string    `json:"role"`
+			UserId    int       `json:"user_id"`
+		} `json:"items"`
+
+		// NextOffset The offset of the next page; missing on the last one
+		NextOffset *int `json:"next_offset,omitempty"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCollectionSharesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCollectionSharesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ShareCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ShareCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ShareCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnshareCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnshareCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnshareCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResendVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ResendVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResendVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type VerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Email           openapi_types.Email `json:"email"`
+		EmailVerifiedAt time.Time           `json:"email_verified_at"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r VerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r VerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLabubuResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		Id int `json:"id"`
+
+		// PublishAt When a scheduled labubu gets published
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+
+		// PublishedAt When the labubu was last published
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+
+		// Status draft, scheduled, published or archived
+		Status string `json:"status"`
+
+		// Tags Normalized tag names, sorted
+		Tags    []string `json:"tags"`
+		Text    string   `json:"text"`
+		Version int      `json:"version"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLabubuResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLabubuResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateLabubuResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Id int `json:"id"`
+
+		// PublishAt When a scheduled labubu gets published
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+
+		// PublishedAt When the labubu was last published
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+
+		// Status draft, scheduled, published or archived
+		Status string `json:"status"`
+
+		// Tags Normalized tag names, sorted
+		Tags    []string `json:"tags"`
+		Text    string   `json:"text"`
+		Version int      `json:"version"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateLabubuResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateLabubuResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteLabubuResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteLabubuResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteLabubuResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateLabubuResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Id int `json:"id"`
+
+		// PublishAt When a scheduled labubu gets published
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+
+		// PublishedAt When the labubu was last published
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+
+		// Status draft, scheduled, published or archived
+		Status string `json:"status"`
+
+		// Tags Normalized tag names, sorted
+		Tags    []string `json:"tags"`
+		Text    string   `json:"text"`
+		Version int      `json:"version"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateLabubuResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateLabubuResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAttachmentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		ContentType string    `json:"content_type"`
+		CreatedAt   time.Time `json:"created_at"`
+		Filename    string    `json:"filename"`
+
+		// Height Height in pixels as displayed, once the image is processed
+		Height *int  `json:"height,omitempty"`
+		Id     int64 `json:"id"`
+
+		// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+		ImageStatus *string `json:"image_status,omitempty"`
+
+		// Sha256 Hex SHA-256 of the content, once uploaded
+		Sha256 *string `json:"sha256,omitempty"`
+		Size   int64   `json:"size"`
+
+		// Status pending until a direct upload is completed, then ready or rejected
+		Status string `json:"status"`
+		Upload *struct {
+			ExpiresAt time.Time `json:"expires_at"`
+
+			// Headers Headers the upload has to send
+			Headers map[string]string `json:"headers"`
+			Method  string            `json:"method"`
+			Url     string            `json:"url"`
+		} `json:"upload,omitempty"`
+
+		// Width Width in pixels as displayed, once the image is processed
+		Width *int `json:"width,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAttachmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAttachmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadAttachmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		ContentType string    `json:"content_type"`
+		CreatedAt   time.Time `json:"created_at"`
+		Filename    string    `json:"filename"`
+
+		// Height Height in pixels as displayed, once the image is processed
+		Height *int  `json:"height,omitempty"`
+		Id     int64 `json:"id"`
+
+		// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+		ImageStatus *string `json:"image_status,omitempty"`
+
+		// Sha256 Hex SHA-256 of the content, once uploaded
+		Sha256 *string `json:"sha256,omitempty"`
+		Size   int64   `json:"size"`
+
+		// Status pending until a direct upload is completed, then ready or rejected
+		Status string `json:"status"`
+		Upload *struct {
+			ExpiresAt time.Time `json:"expires_at"`
+
+			// Headers Headers the upload has to send
+			Headers map[string]string `json:"headers"`
+			Method  string            `json:"method"`
+			Url     string            `json:"url"`
+		} `json:"upload,omitempty"`
+
+		// Width Width in pixels as displayed, once the image is processed
+		Width *int `json:"width,omitempty"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadAttachmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadAttachmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAttachmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAttachmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAttachmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DownloadAttachmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DownloadAttachmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DownloadAttachmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CompleteAttachmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		ContentType string    `json:"content_type"`
+		CreatedAt   time.Time `json:"created_at"`
+		Filename    string    `json:"filename"`
+
+		// Height Height in pixels as displayed, once the image is processed
+		Height *int  `json:"height,omitempty"`
+		Id     int64 `json:"id"`
+
+		// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+		ImageStatus *string `json:"image_status,omitempty"`
+
+		// Sha256 Hex SHA-256 of the content, once uploaded
+		Sha256 *string `json:"sha256,omitempty"`
+		Size   int64   `json:"size"`
+
+		// Status pending until a direct upload is completed, then ready or rejected
+		Status string `json:"status"`
+		Upload *struct {
+			ExpiresAt time.Time `json:"expires_at"`
+
+			// Headers Headers the upload has to send
+			Headers map[string]string `json:"headers"`
+			Method  string            `json:"method"`
+			Url     string            `json:"url"`
+		} `json:"upload,omitempty"`
+
+		// Width Width in pixels as displayed, once the image is processed
+		Width *int `json:"width,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r CompleteAttachmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CompleteAttachmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAttachmentVariantsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Height *int `json:"height,omitempty"`
+
+		// ImageError Why the image could not be processed
+		ImageError *string `json:"image_error,omitempty"`
+
+		// ImageStatus pending, processed, unsupported or failed; missing for files that are not images
+		ImageStatus *string `json:"image_status,omitempty"`
+
+		// Variants Thumbnails of a processed image, smallest first
+		Variants []struct {
+			ContentType string    `json:"content_type"`
+			ExpiresAt   time.Time `json:"expires_at"`
+			Height      int       `json:"height"`
+			Name        string    `json:"name"`
+			Size        int64     `json:"size"`
+
+			// Url Short-lived URL of the thumbnail
+			Url   string `json:"url"`
+			Width int    `json:"width"`
+		} `json:"variants"`
+		Width *int `json:"width,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAttachmentVariantsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAttachmentVariantsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListLabubuTransitionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// ActorId Who changed the status; absent when it was published on schedule
+		ActorId   *int      `json:"actor_id,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+
+		// From Absent for the status the labubu was created in
+		From *string `json:"from,omitempty"`
+
+		// PublishAt The time the labubu was scheduled for
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+		To        string     `json:"to"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListLabubuTransitionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListLabubuTransitionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListShareLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		CreatedAt   time.Time  `json:"created_at"`
+		ExpiresAt   time.Time  `json:"expires_at"`
+		HasPassword bool       `json:"has_password"`
+		Id          int64      `json:"id"`
+		MaxViews    *int       `json:"max_views,omitempty"`
+		RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+		// Token The token in the URL; only returned when the link is created and not stored
+		Token *string `json:"token,omitempty"`
+
+		// Url Public URL of the link; only returned when the link is created
+		Url *string `json:"url,omitempty"`
+
+		// Views How many times the link has been opened
+		Views int `json:"views"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListShareLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListShareLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateShareLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		CreatedAt   time.Time  `json:"created_at"`
+		ExpiresAt   time.Time  `json:"expires_at"`
+		HasPassword bool       `json:"has_password"`
+		Id          int64      `json:"id"`
+		MaxViews    *int       `json:"max_views,omitempty"`
+		RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+		// Token The token in the URL; only returned when the link is created and not stored
+		Token *string `json:"token,omitempty"`
+
+		// Url Public URL of the link; only returned when the link is created
+		Url *string `json:"url,omitempty"`
+
+		// Views How many times the link has been opened
+		Views int `json:"views"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateShareLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateShareLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeShareLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeShareLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeShareLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListShareLinkAccessesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		AccessedAt time.Time `json:"accessed_at"`
+		Id         int64     `json:"id"`
+		Ip         string    `json:"ip"`
+
+		// Outcome viewed, password_required, wrong_password, expired, revoked, exhausted (no views left) or unavailable (the labubu was deleted)
+		Outcome   string `json:"outcome"`
+		UserAgent string `json:"user_agent"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListShareLinkAccessesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListShareLinkAccessesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TransitionLabubuResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Id int `json:"id"`
+
+		// PublishAt When a scheduled labubu gets published
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+
+		// PublishedAt When the labubu was last published
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+
+		// Status draft, scheduled, published or archived
+		Status string `json:"status"`
+
+		// Tags Normalized tag names, sorted
+		Tags    []string `json:"tags"`
+		Text    string   `json:"text"`
+		Version int      `json:"version"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+	JSON409 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r TransitionLabubuResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TransitionLabubuResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ForgotPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ForgotPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ForgotPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResetPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ResetPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResetPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// Count How many of your labubu carry the tag
+		Count int    `json:"count"`
+		Id    int    `json:"id"`
+		Name  string `json:"name"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTagsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTagsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RenameTagResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Count How many of your labubu carry the tag
+		Count int    `json:"count"`
+		Id    int    `json:"id"`
+		Name  string `json:"name"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RenameTagResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RenameTagResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MergeTagResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Count How many of your labubu carry the tag
+		Count int    `json:"count"`
+		Id    int    `json:"id"`
+		Name  string `json:"name"`
+	}
+	JSON400 *struct {
+		Error string `json:"error"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r MergeTagResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MergeTagResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RefreshTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RefreshTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefreshTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListCollectionsWithResponse request returning *ListCollectionsResponse
+func (c *ClientWithResponses) ListCollectionsWithResponse(ctx context.Context, params *ListCollectionsParams, reqEditors ...RequestEditorFn) (*ListCollectionsResponse, error) {
+	rsp, err := c.ListCollections(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCollectionsResponse(rsp)
+}
+
+// CreateCollectionWithBodyWithResponse request with arbitrary body returning *CreateCollectionResponse
+func (c *ClientWithResponses) CreateCollectionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCollectionResponse, error) {
+	rsp, err := c.CreateCollectionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCollectionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateCollectionWithResponse(ctx context.Context, body CreateCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCollectionResponse, error) {
+	rsp, err := c.CreateCollection(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCollectionResponse(rsp)
+}
+
+// DeleteCollectionWithResponse request returning *DeleteCollectionResponse
+func (c *ClientWithResponses) DeleteCollectionWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteCollectionResponse, error) {
+	rsp, err := c.DeleteCollection(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCollectionResponse(rsp)
+}
+
+// GetCollectionWithResponse request returning *GetCollectionResponse
+func (c *ClientWithResponses) GetCollectionWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetCollectionResponse, error) {
+	rsp, err := c.GetCollection(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCollectionResponse(rsp)
+}
+
+// RenameCollectionWithBodyWithResponse request with arbitrary body returning *RenameCollectionResponse
+func (c *ClientWithResponses) RenameCollectionWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameCollectionResponse, error) {
+	rsp, err := c.RenameCollectionWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameCollectionResponse(rsp)
+}
+
+func (c *ClientWithResponses) RenameCollectionWithResponse(ctx context.Context, id int, body RenameCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameCollectionResponse, error) {
+	rsp, err := c.RenameCollection(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameCollectionResponse(rsp)
+}
+
+// ListCollectionItemsWithResponse request returning *ListCollectionItemsResponse
+func (c *ClientWithResponses) ListCollectionItemsWithResponse(ctx context.Context, id int, params *ListCollectionItemsParams, reqEditors ...RequestEditorFn) (*ListCollectionItemsResponse, error) {
+	rsp, err := c.ListCollectionItems(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCollectionItemsResponse(rsp)
+}
+
+// AddCollectionItemWithBodyWithResponse request with arbitrary body returning *AddCollectionItemResponse
+func (c *ClientWithResponses) AddCollectionItemWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddCollectionItemResponse, error) {
+	rsp, err := c.AddCollectionItemWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddCollectionItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddCollectionItemWithResponse(ctx context.Context, id int, body AddCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*AddCollectionItemResponse, error) {
+	rsp, err := c.AddCollectionItem(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddCollectionItemResponse(rsp)
+}
+
+// RemoveCollectionItemWithResponse request returning *RemoveCollectionItemResponse
+func (c *ClientWithResponses) RemoveCollectionItemWithResponse(ctx context.Context, id int, labubuId int, reqEditors ...RequestEditorFn) (*RemoveCollectionItemResponse, error) {
+	rsp, err := c.RemoveCollectionItem(ctx, id, labubuId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveCollectionItemResponse(rsp)
+}
+
+// MoveCollectionItemWithBodyWithResponse request with arbitrary body returning *MoveCollectionItemResponse
+func (c *ClientWithResponses) MoveCollectionItemWithBodyWithResponse(ctx context.Context, id int, labubuId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MoveCollectionItemResponse, error) {
+	rsp, err := c.MoveCollectionItemWithBody(ctx, id, labubuId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMoveCollectionItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) MoveCollectionItemWithResponse(ctx context.Context, id int, labubuId int, body MoveCollectionItemJSONRequestBody, reqEditors ...RequestEditorFn) (*MoveCollectionItemResponse, error) {
+	rsp, err := c.MoveCollectionItem(ctx, id, labubuId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMoveCollectionItemResponse(rsp)
+}
+
+// ListCollectionSharesWithResponse request returning *ListCollectionSharesResponse
+func (c *ClientWithResponses) ListCollectionSharesWithResponse(ctx context.Context, id int, params *ListCollectionSharesParams, reqEditors ...RequestEditorFn) (*ListCollectionSharesResponse, error) {
+	rsp, err := c.ListCollectionShares(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCollectionSharesResponse(rsp)
+}
+
+// ShareCollectionWithBodyWithResponse request with arbitrary body returning *ShareCollectionResponse
+func (c *ClientWithResponses) ShareCollectionWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShareCollectionResponse, error) {
+	rsp, err := c.ShareCollectionWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShareCollectionResponse(rsp)
+}
+
+func (c *ClientWithResponses) ShareCollectionWithResponse(ctx context.Context, id int, body ShareCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*ShareCollectionResponse, error) {
+	rsp, err := c.ShareCollection(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShareCollectionResponse(rsp)
+}
+
+// UnshareCollectionWithResponse request returning *UnshareCollectionResponse
+func (c *ClientWithResponses) UnshareCollectionWithResponse(ctx context.Context, id int, userId int, reqEditors ...RequestEditorFn) (*UnshareCollectionResponse, error) {
+	rsp, err := c.UnshareCollection(ctx, id, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnshareCollectionResponse(rsp)
+}
+
+// ResendVerificationWithResponse request returning *ResendVerificationResponse
+func (c *ClientWithResponses) ResendVerificationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResendVerificationResponse, error) {
+	rsp, err := c.ResendVerification(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResendVerificationResponse(rsp)
+}
+
+// VerifyEmailWithResponse request returning *VerifyEmailResponse
+func (c *ClientWithResponses) VerifyEmailWithResponse(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error) {
+	rsp, err := c.VerifyEmail(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyEmailResponse(rsp)
+}
+
+// GetLabubuWithResponse request returning *GetLabubuResponse
+func (c *ClientWithResponses) GetLabubuWithResponse(ctx context.Context, params *GetLabubuParams, reqEditors ...RequestEditorFn) (*GetLabubuResponse, error) {
+	rsp, err := c.GetLabubu(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLabubuResponse(rsp)
+}
+
+// CreateLabubuWithBodyWithResponse request with arbitrary body returning *CreateLabubuResponse
+func (c *ClientWithResponses) CreateLabubuWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error) {
+	rsp, err := c.CreateLabubuWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateLabubuResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateLabubuWithResponse(ctx context.Context, body CreateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateLabubuResponse, error) {
+	rsp, err := c.CreateLabubu(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateLabubuResponse(rsp)
+}
+
+// DeleteLabubuWithResponse request returning *DeleteLabubuResponse
+func (c *ClientWithResponses) DeleteLabubuWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteLabubuResponse, error) {
+	rsp, err := c.DeleteLabubu(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteLabubuResponse(rsp)
+}
+
+// UpdateLabubuWithBodyWithResponse request with arbitrary body returning *UpdateLabubuResponse
+func (c *ClientWithResponses) UpdateLabubuWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateLabubuResponse, error) {
+	rsp, err := c.UpdateLabubuWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateLabubuResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateLabubuWithResponse(ctx context.Context, id int, body UpdateLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateLabubuResponse, error) {
+	rsp, err := c.UpdateLabubu(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateLabubuResponse(rsp)
+}
+
+// ListAttachmentsWithResponse request returning *ListAttachmentsResponse
+func (c *ClientWithResponses) ListAttachmentsWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*ListAttachmentsResponse, error) {
+	rsp, err := c.ListAttachments(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAttachmentsResponse(rsp)
+}
+
+// UploadAttachmentWithBodyWithResponse request with arbitrary body returning *UploadAttachmentResponse
+func (c *ClientWithResponses) UploadAttachmentWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadAttachmentResponse, error) {
+	rsp, err := c.UploadAttachmentWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadAttachmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) UploadAttachmentWithResponse(ctx context.Context, id int, body UploadAttachmentJSONRequestBody, reqEditors ...RequestEditorFn) (*UploadAttachmentResponse, error) {
+	rsp, err := c.UploadAttachment(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadAttachmentResponse(rsp)
+}
+
+// DeleteAttachmentWithResponse request returning *DeleteAttachmentResponse
+func (c *ClientWithResponses) DeleteAttachmentWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*DeleteAttachmentResponse, error) {
+	rsp, err := c.DeleteAttachment(ctx, id, attachmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAttachmentResponse(rsp)
+}
+
+// DownloadAttachmentWithResponse request returning *DownloadAttachmentResponse
+func (c *ClientWithResponses) DownloadAttachmentWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*DownloadAttachmentResponse, error) {
+	rsp, err := c.DownloadAttachment(ctx, id, attachmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDownloadAttachmentResponse(rsp)
+}
+
+// CompleteAttachmentWithResponse request returning *CompleteAttachmentResponse
+func (c *ClientWithResponses) CompleteAttachmentWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*CompleteAttachmentResponse, error) {
+	rsp, err := c.CompleteAttachment(ctx, id, attachmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCompleteAttachmentResponse(rsp)
+}
+
+// ListAttachmentVariantsWithResponse request returning *ListAttachmentVariantsResponse
+func (c *ClientWithResponses) ListAttachmentVariantsWithResponse(ctx context.Context, id int, attachmentId int64, reqEditors ...RequestEditorFn) (*ListAttachmentVariantsResponse, error) {
+	rsp, err := c.ListAttachmentVariants(ctx, id, attachmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAttachmentVariantsResponse(rsp)
+}
+
+// ListLabubuTransitionsWithResponse request returning *ListLabubuTransitionsResponse
+func (c *ClientWithResponses) ListLabubuTransitionsWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*ListLabubuTransitionsResponse, error) {
+	rsp, err := c.ListLabubuTransitions(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListLabubuTransitionsResponse(rsp)
+}
+
+// ListShareLinksWithResponse request returning *ListShareLinksResponse
+func (c *ClientWithResponses) ListShareLinksWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*ListShareLinksResponse, error) {
+	rsp, err := c.ListShareLinks(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListShareLinksResponse(rsp)
+}
+
+// CreateShareLinkWithBodyWithResponse request with arbitrary body returning *CreateShareLinkResponse
+func (c *ClientWithResponses) CreateShareLinkWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateShareLinkResponse, error) {
+	rsp, err := c.CreateShareLinkWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateShareLinkResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateShareLinkWithResponse(ctx context.Context, id int, body CreateShareLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateShareLinkResponse, error) {
+	rsp, err := c.CreateShareLink(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateShareLinkResponse(rsp)
+}
+
+// RevokeShareLinkWithResponse request returning *RevokeShareLinkResponse
+func (c *ClientWithResponses) RevokeShareLinkWithResponse(ctx context.Context, id int, linkId int64, reqEditors ...RequestEditorFn) (*RevokeShareLinkResponse, error) {
+	rsp, err := c.RevokeShareLink(ctx, id, linkId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeShareLinkResponse(rsp)
+}
+
+// ListShareLinkAccessesWithResponse request returning *ListShareLinkAccessesResponse
+func (c *ClientWithResponses) ListShareLinkAccessesWithResponse(ctx context.Context, id int, linkId int64, params *ListShareLinkAccessesParams, reqEditors ...RequestEditorFn) (*ListShareLinkAccessesResponse, error) {
+	rsp, err := c.ListShareLinkAccesses(ctx, id, linkId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListShareLinkAccessesResponse(rsp)
+}
+
+// TransitionLabubuWithBodyWithResponse request with arbitrary body returning *TransitionLabubuResponse
+func (c *ClientWithResponses) TransitionLabubuWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TransitionLabubuResponse, error) {
+	rsp, err := c.TransitionLabubuWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransitionLabubuResponse(rsp)
+}
+
+func (c *ClientWithResponses) TransitionLabubuWithResponse(ctx context.Context, id int, body TransitionLabubuJSONRequestBody, reqEditors ...RequestEditorFn) (*TransitionLabubuResponse, error) {
+	rsp, err := c.TransitionLabubu(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTransitionLabubuResponse(rsp)
+}
+
+// LoginWithBodyWithResponse request with arbitrary body returning *LoginResponse
+func (c *ClientWithResponses) LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.LoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.Login(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+// ForgotPasswordWithBodyWithResponse request with arbitrary body returning *ForgotPasswordResponse
+func (c *ClientWithResponses) ForgotPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ForgotPasswordResponse, error) {
+	rsp, err := c.ForgotPasswordWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseForgotPasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) ForgotPasswordWithResponse(ctx context.Context, body ForgotPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*ForgotPasswordResponse, error) {
+	rsp, err := c.ForgotPassword(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseForgotPasswordResponse(rsp)
+}
+
+// ResetPasswordWithBodyWithResponse request with arbitrary body returning *ResetPasswordResponse
+func (c *ClientWithResponses) ResetPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResetPasswordResponse, error) {
+	rsp, err := c.ResetPasswordWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResetPasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) ResetPasswordWithResponse(ctx context.Context, body ResetPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*ResetPasswordResponse, error) {
+	rsp, err := c.ResetPassword(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResetPasswordResponse(rsp)
+}
+
+// ListTagsWithResponse request returning *ListTagsResponse
+func (c *ClientWithResponses) ListTagsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTagsResponse, error) {
+	rsp, err := c.ListTags(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTagsResponse(rsp)
+}
+
+// RenameTagWithBodyWithResponse request with arbitrary body returning *RenameTagResponse
+func (c *ClientWithResponses) RenameTagWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameTagResponse, error) {
+	rsp, err := c.RenameTagWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameTagResponse(rsp)
+}
+
+func (c *ClientWithResponses) RenameTagWithResponse(ctx context.Context, id int, body RenameTagJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameTagResponse, error) {
+	rsp, err := c.RenameTag(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameTagResponse(rsp)
+}
+
+// MergeTagWithBodyWithResponse request with arbitrary body returning *MergeTagResponse
+func (c *ClientWithResponses) MergeTagWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MergeTagResponse, error) {
+	rsp, err := c.MergeTagWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMergeTagResponse(rsp)
+}
+
+func (c *ClientWithResponses) MergeTagWithResponse(ctx context.Context, id int, body MergeTagJSONRequestBody, reqEditors ...RequestEditorFn) (*MergeTagResponse, error) {
+	rsp, err := c.MergeTag(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMergeTagResponse(rsp)
+}
+
+// RefreshTokenWithBodyWithResponse request with arbitrary body returning *RefreshTokenResponse
+func (c *ClientWithResponses) RefreshTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RefreshTokenResponse, error) {
+	rsp, err := c.RefreshTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) RefreshTokenWithResponse(ctx context.Context, body RefreshTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*RefreshTokenResponse, error) {
+	rsp, err := c.RefreshToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshTokenResponse(rsp)
+}
+
+// ParseListCollectionsResponse parses an HTTP response from a ListCollectionsWithResponse call
+func ParseListCollectionsResponse(rsp *http.Response) (*ListCollectionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCollectionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Items []struct {
+				CreatedAt time.Time `json:"created_at"`
+				Id        int       `json:"id"`
+				Name      string    `json:"name"`
+				OwnerId   int       `json:"owner_id"`
+
+				// Role Your role in the collection, owner, editor or viewer
+				Role string `json:"role"`
+
+				// UpdatedAt When the collection or its items last changed
+				UpdatedAt time.Time `json:"updated_at"`
+			} `json:"items"`
+
+			// NextOffset The offset of the next page; missing on the last one
+			NextOffset *int `json:"next_offset,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateCollectionResponse parses an HTTP response from a CreateCollectionWithResponse call
+func ParseCreateCollectionResponse(rsp *http.Response) (*CreateCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			CreatedAt time.Time `json:"created_at"`
+			Id        int       `json:"id"`
+			Name      string    `json:"name"`
+			OwnerId   int       `json:"owner_id"`
+
+			// Role Your role in the collection, owner, editor or viewer
+			Role string `json:"role"`
+
+			// UpdatedAt When the collection or its items last changed
+			UpdatedAt time.Time `json:"updated_at"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCollectionResponse parses an HTTP response from a DeleteCollectionWithResponse call
+func ParseDeleteCollectionResponse(rsp *http.Response) (*DeleteCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetCollectionResponse parses an HTTP response from a GetCollectionWithResponse call
+func ParseGetCollectionResponse(rsp *http.Response) (*GetCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			CreatedAt time.Time `json:"created_at"`
+			Id        int       `json:"id"`
+			Name      string    `json:"name"`
+			OwnerId   int       `json:"owner_id"`
+
+			// Role Your role in the collection, owner, editor or viewer
+			Role string `json:"role"`
+
+			// UpdatedAt When the collection or its items last changed
+			UpdatedAt time.Time `json:"updated_at"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRenameCollectionResponse parses an HTTP response from a RenameCollectionWithResponse call
+func ParseRenameCollectionResponse(rsp *http.Response) (*RenameCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenameCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			CreatedAt time.Time `json:"created_at"`
+			Id        int       `json:"id"`
+			Name      string    `json:"name"`
+			OwnerId   int       `json:"owner_id"`
+
+			// Role Your role in the collection, owner, editor or viewer
+			Role string `json:"role"`
+
+			// UpdatedAt When the collection or its items last changed
+			UpdatedAt time.Time `json:"updated_at"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCollectionItemsResponse parses an HTTP response from a ListCollectionItemsWithResponse call
+func ParseListCollectionItemsResponse(rsp *http.Response) (*ListCollectionItemsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCollectionItemsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Items []struct {
+				AddedAt time.Time `json:"added_at"`
+
+				// AddedBy Who added the item; missing once their account is deleted
+				AddedBy *int `json:"added_by,omitempty"`
+				Labubu  struct {
+					Id int `json:"id"`
+
+					// PublishAt When a scheduled labubu gets published
+					PublishAt *time.Time `json:"publish_at,omitempty"`
+
+					// PublishedAt When the labubu was last published
+					PublishedAt *time.Time `json:"published_at,omitempty"`
+
+					// Status draft, scheduled, published or archived
+					Status string `json:"status"`
+
+					// Tags Normalized tag names, sorted
+					Tags    []string `json:"tags"`
+					Text    string   `json:"text"`
+					Version int      `json:"version"`
+				} `json:"labubu"`
+				LabubuId int `json:"labubu_id"`
+
+				// Position Zero-based position in the collection
+				Position int `json:"position"`
+			} `json:"items"`
+
+			// NextOffset The offset of the next page; missing on the last one
+			NextOffset *int `json:"next_offset,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddCollectionItemResponse parses an HTTP response from a AddCollectionItemWithResponse call
+func ParseAddCollectionItemResponse(rsp *http.Response) (*AddCollectionItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddCollectionItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			AddedAt time.Time `json:"added_at"`
+
+			// AddedBy Who added the item; missing once their account is deleted
+			AddedBy *int `json:"added_by,omitempty"`
+			Labubu  struct {
+				Id int `json:"id"`
+
+				// PublishAt When a scheduled labubu gets published
+				PublishAt *time.Time `json:"publish_at,omitempty"`
+
+				// PublishedAt When the labubu was last published
+				PublishedAt *time.Time `json:"published_at,omitempty"`
+
+				// Status draft, scheduled, published or archived
+				Status string `json:"status"`
+
+				// Tags Normalized tag names, sorted
+				Tags    []string `json:"tags"`
+				Text    string   `json:"text"`
+				Version int      `json:"version"`
+			} `json:"labubu"`
+			LabubuId int `json:"labubu_id"`
+
+			// Position Zero-based position in the collection
+			Position int `json:"position"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveCollectionItemResponse parses an HTTP response from a RemoveCollectionItemWithResponse call
+func ParseRemoveCollectionItemResponse(rsp *http.Response) (*RemoveCollectionItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveCollectionItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseMoveCollectionItemResponse parses an HTTP response from a MoveCollectionItemWithResponse call
+func ParseMoveCollectionItemResponse(rsp *http.Response) (*MoveCollectionItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MoveCollectionItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			AddedAt time.Time `json:"added_at"`
+
+			// AddedBy Who added the item; missing once their account is deleted
+			AddedBy *int `json:"added_by,omitempty"`
+			Labubu  struct {
+				Id int `json:"id"`
+
+				// PublishAt When a scheduled labubu gets published
+				PublishAt *time.Time `json:"publish_at,omitempty"`
+
+				// PublishedAt When the labubu was last published
+				PublishedAt *time.Time `json:"published_at,omitempty"`
+
+				// Status draft, scheduled, published or archived
+				Status string `json:"status"`
+
+				// Tags Normalized tag names, sorted
+				Tags    []string `json:"tags"`
+				Text    string   `json:"text"`
+				Version int      `json:"version"`
+			} `json:"labubu"`
+			LabubuId int `json:"labubu_id"`
+
+			// Position Zero-based position in the collection
+			Position int `json:"position"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCollectionSharesResponse parses an HTTP response from a ListCollectionSharesWithResponse call
+func ParseListCollectionSharesResponse(rsp *http.Response) (*ListCollectionSharesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCollectionSharesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Items []struct {
+				CreatedAt time.Time `json:"created_at"`
+				Email     string    `json:"email"`
+				Role      string    `json:"role"`
+				UserId    int       `json:"user_id"`
+			} `json:"items"`
+
+			// NextOffset The offset of the next page; missing on the last one
+			NextOffset *int `json:"next_offset,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseShareCollectionResponse parses an HTTP response from a ShareCollectionWithResponse call
+func ParseShareCollectionResponse(rsp *http.Response) (*ShareCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ShareCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest struct {
+			Email string `json:"email"`
+			Role  string `json:"role"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnshareCollectionResponse parses an HTTP response from a UnshareCollectionWithResponse call
+func ParseUnshareCollectionResponse(rsp *http.Response) (*UnshareCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnshareCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseResendVerificationResponse parses an HTTP response from a ResendVerificationWithResponse call
+func ParseResendVerificationResponse(rsp *http.Response) (*ResendVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResendVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseVerifyEmailResponse parses an HTTP response from a VerifyEmailWithResponse call
+func ParseVerifyEmailResponse(rsp *http.Response) (*VerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Email           openapi_types.Email `json:"email"`
+			EmailVerifiedAt time.Time           `json:"email_verified_at"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLabubuResponse parses an HTTP response from a GetLabubuWithResponse call
+func ParseGetLabubuResponse(rsp *http.Response) (*GetLabubuResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLabubuResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateLabubuResponse parses an HTTP response from a CreateLabubuWithResponse call
+func ParseCreateLabubuResponse(rsp *http.Response) (*CreateLabubuResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateLabubuResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteLabubuResponse parses an HTTP response from a DeleteLabubuWithResponse call
+func ParseDeleteLabubuResponse(rsp *http.Response) (*DeleteLabubuResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteLabubuResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseUpdateLabubuResponse parses an HTTP response from a UpdateLabubuWithResponse call
+func ParseUpdateLabubuResponse(rsp *http.Response) (*UpdateLabubuResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateLabubuResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAttachmentsResponse parses an HTTP response from a ListAttachmentsWithResponse call
+func ParseListAttachmentsResponse(rsp *http.Response) (*ListAttachmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAttachmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			ContentType string    `json:"content_type"`
+			CreatedAt   time.Time `json:"created_at"`
+			Filename    string    `json:"filename"`
+
+			// Height Height in pixels as displayed, once the image is processed
+			Height *int  `json:"height,omitempty"`
+			Id     int64 `json:"id"`
+
+			// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+			ImageStatus *string `json:"image_status,omitempty"`
+
+			// Sha256 Hex SHA-256 of the content, once uploaded
+			Sha256 *string `json:"sha256,omitempty"`
+			Size   int64   `json:"size"`
+
+			// Status pending until a direct upload is completed, then ready or rejected
+			Status string `json:"status"`
+			Upload *struct {
+				ExpiresAt time.Time `json:"expires_at"`
+
+				// Headers Headers the upload has to send
+				Headers map[string]string `json:"headers"`
+				Method  string            `json:"method"`
+				Url     string            `json:"url"`
+			} `json:"upload,omitempty"`
+
+			// Width Width in pixels as displayed, once the image is processed
+			Width *int `json:"width,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadAttachmentResponse parses an HTTP response from a UploadAttachmentWithResponse call
+func ParseUploadAttachmentResponse(rsp *http.Response) (*UploadAttachmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadAttachmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			ContentType string    `json:"content_type"`
+			CreatedAt   time.Time `json:"created_at"`
+			Filename    string    `json:"filename"`
+
+			// Height Height in pixels as displayed, once the image is processed
+			Height *int  `json:"height,omitempty"`
+			Id     int64 `json:"id"`
+
+			// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+			ImageStatus *string `json:"image_status,omitempty"`
+
+			// Sha256 Hex SHA-256 of the content, once uploaded
+			Sha256 *string `json:"sha256,omitempty"`
+			Size   int64   `json:"size"`
+
+			// Status pending until a direct upload is completed, then ready or rejected
+			Status string `json:"status"`
+			Upload *struct {
+				ExpiresAt time.Time `json:"expires_at"`
+
+				// Headers Headers the upload has to send
+				Headers map[string]string `json:"headers"`
+				Method  string            `json:"method"`
+				Url     string            `json:"url"`
+			} `json:"upload,omitempty"`
+
+			// Width Width in pixels as displayed, once the image is processed
+			Width *int `json:"width,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAttachmentResponse parses an HTTP response from a DeleteAttachmentWithResponse call
+func ParseDeleteAttachmentResponse(rsp *http.Response) (*DeleteAttachmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAttachmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDownloadAttachmentResponse parses an HTTP response from a DownloadAttachmentWithResponse call
+func ParseDownloadAttachmentResponse(rsp *http.Response) (*DownloadAttachmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DownloadAttachmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseCompleteAttachmentResponse parses an HTTP response from a CompleteAttachmentWithResponse call
+func ParseCompleteAttachmentResponse(rsp *http.Response) (*CompleteAttachmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CompleteAttachmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			ContentType string    `json:"content_type"`
+			CreatedAt   time.Time `json:"created_at"`
+			Filename    string    `json:"filename"`
+
+			// Height Height in pixels as displayed, once the image is processed
+			Height *int  `json:"height,omitempty"`
+			Id     int64 `json:"id"`
+
+			// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+			ImageStatus *string `json:"image_status,omitempty"`
+
+			// Sha256 Hex SHA-256 of the content, once uploaded
+			Sha256 *string `json:"sha256,omitempty"`
+			Size   int64   `json:"size"`
+
+			// Status pending until a direct upload is completed, then ready or rejected
+			Status string `json:"status"`
+			Upload *struct {
+				ExpiresAt time.Time `json:"expires_at"`
+
+				// Headers Headers the upload has to send
+				Headers map[string]string `json:"headers"`
+				Method  string            `json:"method"`
+				Url     string            `json:"url"`
+			} `json:"upload,omitempty"`
+
+			// Width Width in pixels as displayed, once the image is processed
+			Width *int `json:"width,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAttachmentVariantsResponse parses an HTTP response from a ListAttachmentVariantsWithResponse call
+func ParseListAttachmentVariantsResponse(rsp *http.Response) (*ListAttachmentVariantsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAttachmentVariantsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Height *int `json:"height,omitempty"`
+
+			// ImageError Why the image could not be processed
+			ImageError *string `json:"image_error,omitempty"`
+
+			// ImageStatus pending, processed, unsupported or failed; missing for files that are not images
+			ImageStatus *string `json:"image_status,omitempty"`
+
+			// Variants Thumbnails of a processed image, smallest first
+			Variants []struct {
+				ContentType string    `json:"content_type"`
+				ExpiresAt   time.Time `json:"expires_at"`
+				Height      int       `json:"height"`
+				Name        string    `json:"name"`
+				Size        int64     `json:"size"`
+
+				// Url Short-lived URL of the thumbnail
+				Url   string `json:"url"`
+				Width int    `json:"width"`
+			} `json:"variants"`
+			Width *int `json:"width,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListLabubuTransitionsResponse parses an HTTP response from a ListLabubuTransitionsWithResponse call
+func ParseListLabubuTransitionsResponse(rsp *http.Response) (*ListLabubuTransitionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListLabubuTransitionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// ActorId Who changed the status; absent when it was published on schedule
+			ActorId   *int      `json:"actor_id,omitempty"`
+			CreatedAt time.Time `json:"created_at"`
+
+			// From Absent for the status the labubu was created in
+			From *string `json:"from,omitempty"`
+
+			// PublishAt The time the labubu was scheduled for
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+			To        string     `json:"to"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListShareLinksResponse parses an HTTP response from a ListShareLinksWithResponse call
+func ParseListShareLinksResponse(rsp *http.Response) (*ListShareLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListShareLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			CreatedAt   time.Time  `json:"created_at"`
+			ExpiresAt   time.Time  `json:"expires_at"`
+			HasPassword bool       `json:"has_password"`
+			Id          int64      `json:"id"`
+			MaxViews    *int       `json:"max_views,omitempty"`
+			RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+			// Token The token in the URL; only returned when the link is created and not stored
+			Token *string `json:"token,omitempty"`
+
+			// Url Public URL of the link; only returned when the link is created
+			Url *string `json:"url,omitempty"`
+
+			// Views How many times the link has been opened
+			Views int `json:"views"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateShareLinkResponse parses an HTTP response from a CreateShareLinkWithResponse call
+func ParseCreateShareLinkResponse(rsp *http.Response) (*CreateShareLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateShareLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			CreatedAt   time.Time  `json:"created_at"`
+			ExpiresAt   time.Time  `json:"expires_at"`
+			HasPassword bool       `json:"has_password"`
+			Id          int64      `json:"id"`
+			MaxViews    *int       `json:"max_views,omitempty"`
+			RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+			// Token The token in the URL; only returned when the link is created and not stored
+			Token *string `json:"token,omitempty"`
+
+			// Url Public URL of the link; only returned when the link is created
+			Url *string `json:"url,omitempty"`
+
+			// Views How many times the link has been opened
+			Views int `json:"views"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeShareLinkResponse parses an HTTP response from a RevokeShareLinkWithResponse call
+func ParseRevokeShareLinkResponse(rsp *http.Response) (*RevokeShareLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeShareLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListShareLinkAccessesResponse parses an HTTP response from a ListShareLinkAccessesWithResponse call
+func ParseListShareLinkAccessesResponse(rsp *http.Response) (*ListShareLinkAccessesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListShareLinkAccessesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			AccessedAt time.Time `json:"accessed_at"`
+			Id         int64     `json:"id"`
+			Ip         string    `json:"ip"`
+
+			// Outcome viewed, password_required, wrong_password, expired, revoked, exhausted (no views left) or unavailable (the labubu was deleted)
+			Outcome   string `json:"outcome"`
+			UserAgent string `json:"user_agent"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTransitionLabubuResponse parses an HTTP response from a TransitionLabubuWithResponse call
+func ParseTransitionLabubuResponse(rsp *http.Response) (*TransitionLabubuResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransitionLabubuResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginResponse parses an HTTP response from a LoginWithResponse call
+func ParseLoginResponse(rsp *http.Response) (*LoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
 	response := &LoginResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			AccessToken  string `json:"access_token"`
-			RefreshToken string `json:"refresh_token"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			AccessToken  string `json:"access_token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseForgotPasswordResponse parses an HTTP response from a ForgotPasswordWithResponse call
+func ParseForgotPasswordResponse(rsp *http.Response) (*ForgotPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ForgotPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseResetPasswordResponse parses an HTTP response from a ResetPasswordWithResponse call
+func ParseResetPasswordResponse(rsp *http.Response) (*ResetPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResetPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTagsResponse parses an HTTP response from a ListTagsWithResponse call
+func ParseListTagsResponse(rsp *http.Response) (*ListTagsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTagsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// Count How many of your labubu carry the tag
+			Count int    `json:"count"`
+			Id    int    `json:"id"`
+			Name  string `json:"name"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRenameTagResponse parses an HTTP response from a RenameTagWithResponse call
+func ParseRenameTagResponse(rsp *http.Response) (*RenameTagResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenameTagResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Count How many of your labubu carry the tag
+			Count int    `json:"count"`
+			Id    int    `json:"id"`
+			Name  string `json:"name"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMergeTagResponse parses an HTTP response from a MergeTagWithResponse call
+func ParseMergeTagResponse(rsp *http.Response) (*MergeTagResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MergeTagResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Count How many of your labubu carry the tag
+			Count int    `json:"count"`
+			Id    int    `json:"id"`
+			Name  string `json:"name"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRefreshTokenResponse parses an HTTP response from a RefreshTokenWithResponse call
+func ParseRefreshTokenResponse(rsp *http.Response) (*RefreshTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefreshTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			AccessToken  string `json:"access_token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List collections
+	// (GET /collections)
+	ListCollections(w http.ResponseWriter, r *http.Request, params ListCollectionsParams)
+	// Create a collection
+	// (POST /collections)
+	CreateCollection(w http.ResponseWriter, r *http.Request)
+	// Delete a collection
+	// (DELETE /collections/{id})
+	DeleteCollection(w http.ResponseWriter, r *http.Request, id int)
+	// Get a collection
+	// (GET /collections/{id})
+	GetCollection(w http.ResponseWriter, r *http.Request, id int)
+	// Rename a collection
+	// (PUT /collections/{id})
+	RenameCollection(w http.ResponseWriter, r *http.Request, id int)
+	// List the items of a collection
+	// (GET /collections/{id}/items)
+	ListCollectionItems(w http.ResponseWriter, r *http.Request, id int, params ListCollectionItemsParams)
+	// Add a labubu to a collection
+	// (POST /collections/{id}/items)
+	AddCollectionItem(w http.ResponseWriter, r *http.Request, id int)
+	// Remove a labubu from a collection
+	// (DELETE /collections/{id}/items/{labubuId})
+	RemoveCollectionItem(w http.ResponseWriter, r *http.Request, id int, labubuId int)
+	// Move an item
+	// (PUT /collections/{id}/items/{labubuId}/position)
+	MoveCollectionItem(w http.ResponseWriter, r *http.Request, id int, labubuId int)
+	// List who a collection is shared with
+	// (GET /collections/{id}/shares)
+	ListCollectionShares(w http.ResponseWriter, r *http.Request, id int, params ListCollectionSharesParams)
+	// Share a collection
+	// (PUT /collections/{id}/shares)
+	ShareCollection(w http.ResponseWriter, r *http.Request, id int)
+	// Stop sharing a collection
+	// (DELETE /collections/{id}/shares/{userId})
+	UnshareCollection(w http.ResponseWriter, r *http.Request, id int, userId int)
+	// Send a new verification email
+	// (POST /email/verification)
+	ResendVerification(w http.ResponseWriter, r *http.Request)
+	// Confirm an email address
+	// (GET /email/verify)
+	VerifyEmail(w http.ResponseWriter, r *http.Request, params VerifyEmailParams)
+	// Get all labubu
+	// (GET /labubu)
+	GetLabubu(w http.ResponseWriter, r *http.Request, params GetLabubuParams)
+	// Create labubu
+	// (POST /labubu)
+	CreateLabubu(w http.ResponseWriter, r *http.Request)
+	// Delete labubu
+	// (DELETE /labubu/{id})
+	DeleteLabubu(w http.ResponseWriter, r *http.Request, id int)
+	// Update labubu
+	// (PUT /labubu/{id})
+	UpdateLabubu(w http.ResponseWriter, r *http.Request, id int)
+	// List attachments
+	// (GET /labubu/{id}/attachments)
+	ListAttachments(w http.ResponseWriter, r *http.Request, id int)
+	// Attach a file
+	// (POST /labubu/{id}/attachments)
+	UploadAttachment(w http.ResponseWriter, r *http.Request, id int)
+	// Delete an attachment
+	// (DELETE /labubu/{id}/attachments/{attachmentId})
+	DeleteAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64)
+	// Download an attachment
+	// (GET /labubu/{id}/attachments/{attachmentId})
+	DownloadAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64)
+	// Complete a direct upload
+	// (POST /labubu/{id}/attachments/{attachmentId}/complete)
+	CompleteAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64)
+	// List image thumbnails
+	// (GET /labubu/{id}/attachments/{attachmentId}/variants)
+	ListAttachmentVariants(w http.ResponseWriter, r *http.Request, id int, attachmentId int64)
+	// List labubu status history
+	// (GET /labubu/{id}/history)
+	ListLabubuTransitions(w http.ResponseWriter, r *http.Request, id int)
+	// List share links
+	// (GET /labubu/{id}/share)
+	ListShareLinks(w http.ResponseWriter, r *http.Request, id int)
+	// Create a share link
+	// (POST /labubu/{id}/share)
+	CreateShareLink(w http.ResponseWriter, r *http.Request, id int)
+	// Revoke a share link
+	// (DELETE /labubu/{id}/share/{linkId})
+	RevokeShareLink(w http.ResponseWriter, r *http.Request, id int, linkId int64)
+	// List who opened a share link
+	// (GET /labubu/{id}/share/{linkId}/accesses)
+	ListShareLinkAccesses(w http.ResponseWriter, r *http.Request, id int, linkId int64, params ListShareLinkAccessesParams)
+	// Change labubu status
+	// (POST /labubu/{id}/status)
+	TransitionLabubu(w http.ResponseWriter, r *http.Request, id int)
+	// Login endpoint
+	// (POST /login)
+	Login(w http.ResponseWriter, r *http.Request)
+	// Request a password reset
+	// (POST /password/forgot)
+	ForgotPassword(w http.ResponseWriter, r *http.Request)
+	// Reset the password
+	// (POST /password/reset)
+	ResetPassword(w http.ResponseWriter, r *http.Request)
+	// List tags
+	// (GET /tags)
+	ListTags(w http.ResponseWriter, r *http.Request)
+	// Rename a tag
+	// (PUT /tags/{id})
+	RenameTag(w http.ResponseWriter, r *http.Request, id int)
+	// Merge tags
+	// (POST /tags/{id}/merge)
+	MergeTag(w http.ResponseWriter, r *http.Request, id int)
+	// Refresh tokens
+	// (POST /token/refresh)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List collections
+// (GET /collections)
+func (_ Unimplemented) ListCollections(w http.ResponseWriter, r *http.Request, params ListCollectionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a collection
+// (POST /collections)
+func (_ Unimplemented) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a collection
+// (DELETE /collections/{id})
+func (_ Unimplemented) DeleteCollection(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a collection
+// (GET /collections/{id})
+func (_ Unimplemented) GetCollection(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a collection
+// (PUT /collections/{id})
+func (_ Unimplemented) RenameCollection(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the items of a collection
+// (GET /collections/{id}/items)
+func (_ Unimplemented) ListCollectionItems(w http.ResponseWriter, r *http.Request, id int, params ListCollectionItemsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a labubu to a collection
+// (POST /collections/{id}/items)
+func (_ Unimplemented) AddCollectionItem(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a labubu from a collection
+// (DELETE /collections/{id}/items/{labubuId})
+func (_ Unimplemented) RemoveCollectionItem(w http.ResponseWriter, r *http.Request, id int, labubuId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Move an item
+// (PUT /collections/{id}/items/{labubuId}/position)
+func (_ Unimplemented) MoveCollectionItem(w http.ResponseWriter, r *http.Request, id int, labubuId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List who a collection is shared with
+// (GET /collections/{id}/shares)
+func (_ Unimplemented) ListCollectionShares(w http.ResponseWriter, r *http.Request, id int, params ListCollectionSharesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Share a collection
+// (PUT /collections/{id}/shares)
+func (_ Unimplemented) ShareCollection(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stop sharing a collection
+// (DELETE /collections/{id}/shares/{userId})
+func (_ Unimplemented) UnshareCollection(w http.ResponseWriter, r *http.Request, id int, userId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Send a new verification email
+// (POST /email/verification)
+func (_ Unimplemented) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm an email address
+// (GET /email/verify)
+func (_ Unimplemented) VerifyEmail(w http.ResponseWriter, r *http.Request, params VerifyEmailParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all labubu
+// (GET /labubu)
+func (_ Unimplemented) GetLabubu(w http.ResponseWriter, r *http.Request, params GetLabubuParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create labubu
+// (POST /labubu)
+func (_ Unimplemented) CreateLabubu(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete labubu
+// (DELETE /labubu/{id})
+func (_ Unimplemented) DeleteLabubu(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update labubu
+// (PUT /labubu/{id})
+func (_ Unimplemented) UpdateLabubu(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List attachments
+// (GET /labubu/{id}/attachments)
+func (_ Unimplemented) ListAttachments(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Attach a file
+// (POST /labubu/{id}/attachments)
+func (_ Unimplemented) UploadAttachment(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete an attachment
+// (DELETE /labubu/{id}/attachments/{attachmentId})
+func (_ Unimplemented) DeleteAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download an attachment
+// (GET /labubu/{id}/attachments/{attachmentId})
+func (_ Unimplemented) DownloadAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete a direct upload
+// (POST /labubu/{id}/attachments/{attachmentId}/complete)
+func (_ Unimplemented) CompleteAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List image thumbnails
+// (GET /labubu/{id}/attachments/{attachmentId}/variants)
+func (_ Unimplemented) ListAttachmentVariants(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List labubu status history
+// (GET /labubu/{id}/history)
+func (_ Unimplemented) ListLabubuTransitions(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List share links
+// (GET /labubu/{id}/share)
+func (_ Unimplemented) ListShareLinks(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a share link
+// (POST /labubu/{id}/share)
+func (_ Unimplemented) CreateShareLink(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a share link
+// (DELETE /labubu/{id}/share/{linkId})
+func (_ Unimplemented) RevokeShareLink(w http.ResponseWriter, r *http.Request, id int, linkId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List who opened a share link
+// (GET /labubu/{id}/share/{linkId}/accesses)
+func (_ Unimplemented) ListShareLinkAccesses(w http.ResponseWriter, r *http.Request, id int, linkId int64, params ListShareLinkAccessesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change labubu status
+// (POST /labubu/{id}/status)
+func (_ Unimplemented) TransitionLabubu(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Login endpoint
+// (POST /login)
+func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Request a password reset
+// (POST /password/forgot)
+func (_ Unimplemented) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset the password
+// (POST /password/reset)
+func (_ Unimplemented) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tags
+// (GET /tags)
+func (_ Unimplemented) ListTags(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a tag
+// (PUT /tags/{id})
+func (_ Unimplemented) RenameTag(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Merge tags
+// (POST /tags/{id}/merge)
+func (_ Unimplemented) MergeTag(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refresh tokens
+// (POST /token/refresh)
+func (_ Unimplemented) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListCollections operation middleware
+func (siw *ServerInterfaceWrapper) ListCollections(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCollectionsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCollections(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCollection operation middleware
+func (siw *ServerInterfaceWrapper) CreateCollection(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCollection(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCollection operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCollection(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCollection operation middleware
+func (siw *ServerInterfaceWrapper) GetCollection(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCollection(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameCollection operation middleware
+func (siw *ServerInterfaceWrapper) RenameCollection(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameCollection(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCollectionItems operation middleware
+func (siw *ServerInterfaceWrapper) ListCollectionItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCollectionItemsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCollectionItems(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddCollectionItem operation middleware
+func (siw *ServerInterfaceWrapper) AddCollectionItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddCollectionItem(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveCollectionItem operation middleware
+func (siw *ServerInterfaceWrapper) RemoveCollectionItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "labubuId" -------------
+	var labubuId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "labubuId", chi.URLParam(r, "labubuId"), &labubuId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labubuId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveCollectionItem(w, r, id, labubuId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MoveCollectionItem operation middleware
+func (siw *ServerInterfaceWrapper) MoveCollectionItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "labubuId" -------------
+	var labubuId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "labubuId", chi.URLParam(r, "labubuId"), &labubuId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labubuId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MoveCollectionItem(w, r, id, labubuId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCollectionShares operation middleware
+func (siw *ServerInterfaceWrapper) ListCollectionShares(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCollectionSharesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCollectionShares(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ShareCollection operation middleware
+func (siw *ServerInterfaceWrapper) ShareCollection(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShareCollection(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnshareCollection operation middleware
+func (siw *ServerInterfaceWrapper) UnshareCollection(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnshareCollection(w, r, id, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResendVerification operation middleware
+func (siw *ServerInterfaceWrapper) ResendVerification(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResendVerification(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyEmail operation middleware
+func (siw *ServerInterfaceWrapper) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params VerifyEmailParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyEmail(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLabubu operation middleware
+func (siw *ServerInterfaceWrapper) GetLabubu(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLabubuParams
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "match", r.URL.Query(), &params.Match)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "match", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLabubu(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateLabubu operation middleware
+func (siw *ServerInterfaceWrapper) CreateLabubu(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateLabubu(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteLabubu operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLabubu(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteLabubu(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateLabubu operation middleware
+func (siw *ServerInterfaceWrapper) UpdateLabubu(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateLabubu(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAttachments operation middleware
+func (siw *ServerInterfaceWrapper) ListAttachments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAttachments(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadAttachment operation middleware
+func (siw *ServerInterfaceWrapper) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadAttachment(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAttachment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", chi.URLParam(r, "attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAttachment(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DownloadAttachment operation middleware
+func (siw *ServerInterfaceWrapper) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", chi.URLParam(r, "attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DownloadAttachment(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CompleteAttachment operation middleware
+func (siw *ServerInterfaceWrapper) CompleteAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", chi.URLParam(r, "attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CompleteAttachment(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAttachmentVariants operation middleware
+func (siw *ServerInterfaceWrapper) ListAttachmentVariants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", chi.URLParam(r, "attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAttachmentVariants(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListLabubuTransitions operation middleware
+func (siw *ServerInterfaceWrapper) ListLabubuTransitions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLabubuTransitions(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListShareLinks operation middleware
+func (siw *ServerInterfaceWrapper) ListShareLinks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListShareLinks(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateShareLink operation middleware
+func (siw *ServerInterfaceWrapper) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateShareLink(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeShareLink operation middleware
+func (siw *ServerInterfaceWrapper) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "linkId" -------------
+	var linkId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "linkId", chi.URLParam(r, "linkId"), &linkId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "linkId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeShareLink(w, r, id, linkId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListShareLinkAccesses operation middleware
+func (siw *ServerInterfaceWrapper) ListShareLinkAccesses(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "linkId" -------------
+	var linkId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "linkId", chi.URLParam(r, "linkId"), &linkId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "linkId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListShareLinkAccessesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListShareLinkAccesses(w, r, id, linkId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TransitionLabubu operation middleware
+func (siw *ServerInterfaceWrapper) TransitionLabubu(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransitionLabubu(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ForgotPassword operation middleware
+func (siw *ServerInterfaceWrapper) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ForgotPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetPassword operation middleware
+func (siw *ServerInterfaceWrapper) ResetPassword(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTags operation middleware
+func (siw *ServerInterfaceWrapper) ListTags(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTags(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameTag operation middleware
+func (siw *ServerInterfaceWrapper) RenameTag(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameTag(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergeTag operation middleware
+func (siw *ServerInterfaceWrapper) MergeTag(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeTag(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/collections", wrapper.ListCollections)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/collections", wrapper.CreateCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/collections/{id}", wrapper.DeleteCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/collections/{id}", wrapper.GetCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/collections/{id}", wrapper.RenameCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/collections/{id}/items", wrapper.ListCollectionItems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/collections/{id}/items", wrapper.AddCollectionItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/collections/{id}/items/{labubuId}", wrapper.RemoveCollectionItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/collections/{id}/items/{labubuId}/position", wrapper.MoveCollectionItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/collections/{id}/shares", wrapper.ListCollectionShares)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/collections/{id}/shares", wrapper.ShareCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/collections/{id}/shares/{userId}", wrapper.UnshareCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/email/verification", wrapper.ResendVerification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/email/verify", wrapper.VerifyEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu", wrapper.GetLabubu)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/labubu", wrapper.CreateLabubu)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/labubu/{id}", wrapper.DeleteLabubu)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/labubu/{id}", wrapper.UpdateLabubu)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu/{id}/attachments", wrapper.ListAttachments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/labubu/{id}/attachments", wrapper.UploadAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/labubu/{id}/attachments/{attachmentId}", wrapper.DeleteAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu/{id}/attachments/{attachmentId}", wrapper.DownloadAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/labubu/{id}/attachments/{attachmentId}/complete", wrapper.CompleteAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu/{id}/attachments/{attachmentId}/variants", wrapper.ListAttachmentVariants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu/{id}/history", wrapper.ListLabubuTransitions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu/{id}/share", wrapper.ListShareLinks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/labubu/{id}/share", wrapper.CreateShareLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/labubu/{id}/share/{linkId}", wrapper.RevokeShareLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/labubu/{id}/share/{linkId}/accesses", wrapper.ListShareLinkAccesses)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/labubu/{id}/status", wrapper.TransitionLabubu)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/password/forgot", wrapper.ForgotPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/password/reset", wrapper.ResetPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tags", wrapper.ListTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tags/{id}", wrapper.RenameTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tags/{id}/merge", wrapper.MergeTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/token/refresh", wrapper.RefreshToken)
+	})
+
+	return r
+}
+
+type ListCollectionsRequestObject struct {
+	Params ListCollectionsParams
+}
+
+type ListCollectionsResponseObject interface {
+	VisitListCollectionsResponse(w http.ResponseWriter) error
+}
+
+type ListCollections200JSONResponse struct {
+	Items []struct {
+		CreatedAt time.Time `json:"created_at"`
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		OwnerId   int       `json:"owner_id"`
+
+		// Role Your role in the collection, owner, editor or viewer
+		Role string `json:"role"`
+
+		// UpdatedAt When the collection or its items last changed
+		UpdatedAt time.Time `json:"updated_at"`
+	} `json:"items"`
+
+	// NextOffset The offset of the next page; missing on the last one
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+func (response ListCollections200JSONResponse) VisitListCollectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCollections400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response ListCollections400JSONResponse) VisitListCollectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCollectionRequestObject struct {
+	Body *CreateCollectionJSONRequestBody
+}
+
+type CreateCollectionResponseObject interface {
+	VisitCreateCollectionResponse(w http.ResponseWriter) error
+}
+
+type CreateCollection201JSONResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	OwnerId   int       `json:"owner_id"`
+
+	// Role Your role in the collection, owner, editor or viewer
+	Role string `json:"role"`
+
+	// UpdatedAt When the collection or its items last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (response CreateCollection201JSONResponse) VisitCreateCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCollection400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response CreateCollection400JSONResponse) VisitCreateCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCollectionRequestObject struct {
+	Id int `json:"id"`
+}
+
+type DeleteCollectionResponseObject interface {
+	VisitDeleteCollectionResponse(w http.ResponseWriter) error
+}
+
+type DeleteCollection204Response struct {
+}
+
+func (response DeleteCollection204Response) VisitDeleteCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteCollection403Response struct {
+}
+
+func (response DeleteCollection403Response) VisitDeleteCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteCollection404Response struct {
+}
+
+func (response DeleteCollection404Response) VisitDeleteCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetCollectionRequestObject struct {
+	Id int `json:"id"`
+}
+
+type GetCollectionResponseObject interface {
+	VisitGetCollectionResponse(w http.ResponseWriter) error
+}
+
+type GetCollection200JSONResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	OwnerId   int       `json:"owner_id"`
+
+	// Role Your role in the collection, owner, editor or viewer
+	Role string `json:"role"`
+
+	// UpdatedAt When the collection or its items last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (response GetCollection200JSONResponse) VisitGetCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCollection404Response struct {
+}
+
+func (response GetCollection404Response) VisitGetCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type RenameCollectionRequestObject struct {
+	Id   int `json:"id"`
+	Body *RenameCollectionJSONRequestBody
+}
+
+type RenameCollectionResponseObject interface {
+	VisitRenameCollectionResponse(w http.ResponseWriter) error
+}
+
+type RenameCollection200JSONResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	OwnerId   int       `json:"owner_id"`
+
+	// Role Your role in the collection, owner, editor or viewer
+	Role string `json:"role"`
+
+	// UpdatedAt When the collection or its items last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (response RenameCollection200JSONResponse) VisitRenameCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameCollection400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response RenameCollection400JSONResponse) VisitRenameCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameCollection403Response struct {
+}
+
+func (response RenameCollection403Response) VisitRenameCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RenameCollection404Response struct {
+}
+
+func (response RenameCollection404Response) VisitRenameCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListCollectionItemsRequestObject struct {
+	Id     int `json:"id"`
+	Params ListCollectionItemsParams
+}
+
+type ListCollectionItemsResponseObject interface {
+	VisitListCollectionItemsResponse(w http.ResponseWriter) error
+}
+
+type ListCollectionItems200JSONResponse struct {
+	Items []struct {
+		AddedAt time.Time `json:"added_at"`
+
+		// AddedBy Who added the item; missing once their account is deleted
+		AddedBy *int `json:"added_by,omitempty"`
+		Labubu  struct {
+			Id int `json:"id"`
+
+			// PublishAt When a scheduled labubu gets published
+			PublishAt *time.Time `json:"publish_at,omitempty"`
+
+			// PublishedAt When the labubu was last published
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+
+			// Status draft, scheduled, published or archived
+			Status string `json:"status"`
+
+			// Tags Normalized tag names, sorted
+			Tags    []string `json:"tags"`
+			Text    string   `json:"text"`
+			Version int      `json:"version"`
+		} `json:"labubu"`
+		LabubuId int `json:"labubu_id"`
+
+		// Position Zero-based position in the collection
+		Position int `json:"position"`
+	} `json:"items"`
+
+	// NextOffset The offset of the next page; missing on the last one
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+func (response ListCollectionItems200JSONResponse) VisitListCollectionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCollectionItems400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response ListCollectionItems400JSONResponse) VisitListCollectionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCollectionItems404Response struct {
+}
+
+func (response ListCollectionItems404Response) VisitListCollectionItemsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type AddCollectionItemRequestObject struct {
+	Id   int `json:"id"`
+	Body *AddCollectionItemJSONRequestBody
+}
+
+type AddCollectionItemResponseObject interface {
+	VisitAddCollectionItemResponse(w http.ResponseWriter) error
+}
+
+type AddCollectionItem201JSONResponse struct {
+	AddedAt time.Time `json:"added_at"`
+
+	// AddedBy Who added the item; missing once their account is deleted
+	AddedBy *int `json:"added_by,omitempty"`
+	Labubu  struct {
+		Id int `json:"id"`
+
+		// PublishAt When a scheduled labubu gets published
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+
+		// PublishedAt When the labubu was last published
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+
+		// Status draft, scheduled, published or archived
+		Status string `json:"status"`
+
+		// Tags Normalized tag names, sorted
+		Tags    []string `json:"tags"`
+		Text    string   `json:"text"`
+		Version int      `json:"version"`
+	} `json:"labubu"`
+	LabubuId int `json:"labubu_id"`
+
+	// Position Zero-based position in the collection
+	Position int `json:"position"`
+}
+
+func (response AddCollectionItem201JSONResponse) VisitAddCollectionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddCollectionItem403Response struct {
+}
+
+func (response AddCollectionItem403Response) VisitAddCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type AddCollectionItem404Response struct {
+}
+
+func (response AddCollectionItem404Response) VisitAddCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type AddCollectionItem409Response struct {
+}
+
+func (response AddCollectionItem409Response) VisitAddCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type RemoveCollectionItemRequestObject struct {
+	Id       int `json:"id"`
+	LabubuId int `json:"labubuId"`
+}
+
+type RemoveCollectionItemResponseObject interface {
+	VisitRemoveCollectionItemResponse(w http.ResponseWriter) error
+}
+
+type RemoveCollectionItem204Response struct {
+}
+
+func (response RemoveCollectionItem204Response) VisitRemoveCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveCollectionItem403Response struct {
+}
+
+func (response RemoveCollectionItem403Response) VisitRemoveCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemoveCollectionItem404Response struct {
+}
+
+func (response RemoveCollectionItem404Response) VisitRemoveCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type MoveCollectionItemRequestObject struct {
+	Id       int `json:"id"`
+	LabubuId int `json:"labubuId"`
+	Body     *MoveCollectionItemJSONRequestBody
+}
+
+type MoveCollectionItemResponseObject interface {
+	VisitMoveCollectionItemResponse(w http.ResponseWriter) error
+}
+
+type MoveCollectionItem200JSONResponse struct {
+	AddedAt time.Time `json:"added_at"`
+
+	// AddedBy Who added the item; missing once their account is deleted
+	AddedBy *int `json:"added_by,omitempty"`
+	Labubu  struct {
+		Id int `json:"id"`
+
+		// PublishAt When a scheduled labubu gets published
+		PublishAt *time.Time `json:"publish_at,omitempty"`
+
+		// PublishedAt When the labubu was last published
+		PublishedAt *time.Time `json:"published_at,omitempty"`
+
+		// Status draft, scheduled, published or archived
+		Status string `json:"status"`
+
+		// Tags Normalized tag names, sorted
+		Tags    []string `json:"tags"`
+		Text    string   `json:"text"`
+		Version int      `json:"version"`
+	} `json:"labubu"`
+	LabubuId int `json:"labubu_id"`
+
+	// Position Zero-based position in the collection
+	Position int `json:"position"`
+}
+
+func (response MoveCollectionItem200JSONResponse) VisitMoveCollectionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MoveCollectionItem403Response struct {
+}
+
+func (response MoveCollectionItem403Response) VisitMoveCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type MoveCollectionItem404Response struct {
+}
+
+func (response MoveCollectionItem404Response) VisitMoveCollectionItemResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListCollectionSharesRequestObject struct {
+	Id     int `json:"id"`
+	Params ListCollectionSharesParams
+}
+
+type ListCollectionSharesResponseObject interface {
+	VisitListCollectionSharesResponse(w http.ResponseWriter) error
+}
+
+type ListCollectionShares200JSONResponse struct {
+	Items []struct {
+		CreatedAt time.Time `json:"created_at"`
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		UserId    int       `json:"user_id"`
+	} `json:"items"`
+
+	// NextOffset The offset of the next page; missing on the last one
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+func (response ListCollectionShares200JSONResponse) VisitListCollectionSharesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCollectionShares400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response ListCollectionShares400JSONResponse) VisitListCollectionSharesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCollectionShares403Response struct {
+}
+
+func (response ListCollectionShares403Response) VisitListCollectionSharesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type ListCollectionShares404Response struct {
+}
+
+func (response ListCollectionShares404Response) VisitListCollectionSharesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ShareCollectionRequestObject struct {
+	Id   int `json:"id"`
+	Body *ShareCollectionJSONRequestBody
+}
+
+type ShareCollectionResponseObject interface {
+	VisitShareCollectionResponse(w http.ResponseWriter) error
+}
+
+type ShareCollection202JSONResponse struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+func (response ShareCollection202JSONResponse) VisitShareCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShareCollection400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response ShareCollection400JSONResponse) VisitShareCollectionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShareCollection403Response struct {
+}
+
+func (response ShareCollection403Response) VisitShareCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type ShareCollection404Response struct {
+}
+
+func (response ShareCollection404Response) VisitShareCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UnshareCollectionRequestObject struct {
+	Id     int `json:"id"`
+	UserId int `json:"userId"`
+}
+
+type UnshareCollectionResponseObject interface {
+	VisitUnshareCollectionResponse(w http.ResponseWriter) error
+}
+
+type UnshareCollection204Response struct {
+}
+
+func (response UnshareCollection204Response) VisitUnshareCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnshareCollection403Response struct {
+}
+
+func (response UnshareCollection403Response) VisitUnshareCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type UnshareCollection404Response struct {
+}
+
+func (response UnshareCollection404Response) VisitUnshareCollectionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ResendVerificationRequestObject struct {
+}
+
+type ResendVerificationResponseObject interface {
+	VisitResendVerificationResponse(w http.ResponseWriter) error
+}
+
+type ResendVerification202Response struct {
+}
+
+func (response ResendVerification202Response) VisitResendVerificationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type ResendVerification401Response struct {
+}
+
+func (response ResendVerification401Response) VisitResendVerificationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type ResendVerification409Response struct {
+}
+
+func (response ResendVerification409Response) VisitResendVerificationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type VerifyEmailRequestObject struct {
+	Params VerifyEmailParams
+}
+
+type VerifyEmailResponseObject interface {
+	VisitVerifyEmailResponse(w http.ResponseWriter) error
+}
+
+type VerifyEmail200JSONResponse struct {
+	Email           openapi_types.Email `json:"email"`
+	EmailVerifiedAt time.Time           `json:"email_verified_at"`
+}
+
+func (response VerifyEmail200JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmail400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response VerifyEmail400JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLabubuRequestObject struct {
+	Params GetLabubuParams
+}
+
+type GetLabubuResponseObject interface {
+	VisitGetLabubuResponse(w http.ResponseWriter) error
+}
+
+type GetLabubu200JSONResponse []struct {
+	Id int `json:"id"`
+
+	// PublishAt When a scheduled labubu gets published
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+
+	// PublishedAt When the labubu was last published
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Status draft, scheduled, published or archived
+	Status string `json:"status"`
+
+	// Tags Normalized tag names, sorted
+	Tags    []string `json:"tags"`
+	Text    string   `json:"text"`
+	Version int      `json:"version"`
+}
+
+func (response GetLabubu200JSONResponse) VisitGetLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLabubu400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response GetLabubu400JSONResponse) VisitGetLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLabubuRequestObject struct {
+	Body *CreateLabubuJSONRequestBody
+}
+
+type CreateLabubuResponseObject interface {
+	VisitCreateLabubuResponse(w http.ResponseWriter) error
+}
+
+type CreateLabubu200JSONResponse struct {
+	Id int `json:"id"`
+
+	// PublishAt When a scheduled labubu gets published
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+
+	// PublishedAt When the labubu was last published
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Status draft, scheduled, published or archived
+	Status string `json:"status"`
+
+	// Tags Normalized tag names, sorted
+	Tags    []string `json:"tags"`
+	Text    string   `json:"text"`
+	Version int      `json:"version"`
+}
+
+func (response CreateLabubu200JSONResponse) VisitCreateLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLabubu400Response struct {
+}
+
+func (response CreateLabubu400Response) VisitCreateLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteLabubuRequestObject struct {
+	Id int `json:"id"`
+}
+
+type DeleteLabubuResponseObject interface {
+	VisitDeleteLabubuResponse(w http.ResponseWriter) error
+}
+
+type DeleteLabubu204Response struct {
+}
+
+func (response DeleteLabubu204Response) VisitDeleteLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteLabubu404Response struct {
+}
+
+func (response DeleteLabubu404Response) VisitDeleteLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UpdateLabubuRequestObject struct {
+	Id   int `json:"id"`
+	Body *UpdateLabubuJSONRequestBody
+}
+
+type UpdateLabubuResponseObject interface {
+	VisitUpdateLabubuResponse(w http.ResponseWriter) error
+}
+
+type UpdateLabubu200JSONResponse struct {
+	Id int `json:"id"`
+
+	// PublishAt When a scheduled labubu gets published
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+
+	// PublishedAt When the labubu was last published
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Status draft, scheduled, published or archived
+	Status string `json:"status"`
+
+	// Tags Normalized tag names, sorted
+	Tags    []string `json:"tags"`
+	Text    string   `json:"text"`
+	Version int      `json:"version"`
+}
+
+func (response UpdateLabubu200JSONResponse) VisitUpdateLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateLabubu400Response struct {
+}
+
+func (response UpdateLabubu400Response) VisitUpdateLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type UpdateLabubu404Response struct {
+}
+
+func (response UpdateLabubu404Response) VisitUpdateLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UpdateLabubu409Response struct {
+}
+
+func (response UpdateLabubu409Response) VisitUpdateLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type ListAttachmentsRequestObject struct {
+	Id int `json:"id"`
+}
+
+type ListAttachmentsResponseObject interface {
+	VisitListAttachmentsResponse(w http.ResponseWriter) error
+}
+
+type ListAttachments200JSONResponse []struct {
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Filename    string    `json:"filename"`
+
+	// Height Height in pixels as displayed, once the image is processed
+	Height *int  `json:"height,omitempty"`
+	Id     int64 `json:"id"`
+
+	// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+	ImageStatus *string `json:"image_status,omitempty"`
+
+	// Sha256 Hex SHA-256 of the content, once uploaded
+	Sha256 *string `json:"sha256,omitempty"`
+	Size   int64   `json:"size"`
+
+	// Status pending until a direct upload is completed, then ready or rejected
+	Status string `json:"status"`
+	Upload *struct {
+		ExpiresAt time.Time `json:"expires_at"`
+
+		// Headers Headers the upload has to send
+		Headers map[string]string `json:"headers"`
+		Method  string            `json:"method"`
+		Url     string            `json:"url"`
+	} `json:"upload,omitempty"`
+
+	// Width Width in pixels as displayed, once the image is processed
+	Width *int `json:"width,omitempty"`
+}
+
+func (response ListAttachments200JSONResponse) VisitListAttachmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAttachments404Response struct {
+}
+
+func (response ListAttachments404Response) VisitListAttachmentsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UploadAttachmentRequestObject struct {
+	Id            int `json:"id"`
+	JSONBody      *UploadAttachmentJSONRequestBody
+	MultipartBody *multipart.Reader
+}
+
+type UploadAttachmentResponseObject interface {
+	VisitUploadAttachmentResponse(w http.ResponseWriter) error
+}
+
+type UploadAttachment201JSONResponse struct {
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Filename    string    `json:"filename"`
+
+	// Height Height in pixels as displayed, once the image is processed
+	Height *int  `json:"height,omitempty"`
+	Id     int64 `json:"id"`
+
+	// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+	ImageStatus *string `json:"image_status,omitempty"`
+
+	// Sha256 Hex SHA-256 of the content, once uploaded
+	Sha256 *string `json:"sha256,omitempty"`
+	Size   int64   `json:"size"`
+
+	// Status pending until a direct upload is completed, then ready or rejected
+	Status string `json:"status"`
+	Upload *struct {
+		ExpiresAt time.Time `json:"expires_at"`
+
+		// Headers Headers the upload has to send
+		Headers map[string]string `json:"headers"`
+		Method  string            `json:"method"`
+		Url     string            `json:"url"`
+	} `json:"upload,omitempty"`
+
+	// Width Width in pixels as displayed, once the image is processed
+	Width *int `json:"width,omitempty"`
+}
+
+func (response UploadAttachment201JSONResponse) VisitUploadAttachmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadAttachment400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response UploadAttachment400JSONResponse) VisitUploadAttachmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadAttachment404Response struct {
+}
+
+func (response UploadAttachment404Response) VisitUploadAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type UploadAttachment413Response struct {
+}
+
+func (response UploadAttachment413Response) VisitUploadAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(413)
+	return nil
+}
+
+type UploadAttachment415Response struct {
+}
+
+func (response UploadAttachment415Response) VisitUploadAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(415)
+	return nil
+}
+
+type UploadAttachment422Response struct {
+}
+
+func (response UploadAttachment422Response) VisitUploadAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(422)
+	return nil
+}
+
+type DeleteAttachmentRequestObject struct {
+	Id           int   `json:"id"`
+	AttachmentId int64 `json:"attachmentId"`
+}
+
+type DeleteAttachmentResponseObject interface {
+	VisitDeleteAttachmentResponse(w http.ResponseWriter) error
+}
+
+type DeleteAttachment204Response struct {
+}
+
+func (response DeleteAttachment204Response) VisitDeleteAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAttachment404Response struct {
+}
+
+func (response DeleteAttachment404Response) VisitDeleteAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DownloadAttachmentRequestObject struct {
+	Id           int   `json:"id"`
+	AttachmentId int64 `json:"attachmentId"`
+}
+
+type DownloadAttachmentResponseObject interface {
+	VisitDownloadAttachmentResponse(w http.ResponseWriter) error
+}
+
+type DownloadAttachment302ResponseHeaders struct {
+	Location string
+}
+
+type DownloadAttachment302Response struct {
+	Headers DownloadAttachment302ResponseHeaders
+}
+
+func (response DownloadAttachment302Response) VisitDownloadAttachmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type DownloadAttachment404Response struct {
+}
+
+func (response DownloadAttachment404Response) VisitDownloadAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DownloadAttachment409Response struct {
+}
+
+func (response DownloadAttachment409Response) VisitDownloadAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type CompleteAttachmentRequestObject struct {
+	Id           int   `json:"id"`
+	AttachmentId int64 `json:"attachmentId"`
+}
+
+type CompleteAttachmentResponseObject interface {
+	VisitCompleteAttachmentResponse(w http.ResponseWriter) error
+}
+
+type CompleteAttachment200JSONResponse struct {
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Filename    string    `json:"filename"`
+
+	// Height Height in pixels as displayed, once the image is processed
+	Height *int  `json:"height,omitempty"`
+	Id     int64 `json:"id"`
+
+	// ImageStatus Set for images: pending until processed in the background, then processed, unsupported or failed
+	ImageStatus *string `json:"image_status,omitempty"`
+
+	// Sha256 Hex SHA-256 of the content, once uploaded
+	Sha256 *string `json:"sha256,omitempty"`
+	Size   int64   `json:"size"`
+
+	// Status pending until a direct upload is completed, then ready or rejected
+	Status string `json:"status"`
+	Upload *struct {
+		ExpiresAt time.Time `json:"expires_at"`
+
+		// Headers Headers the upload has to send
+		Headers map[string]string `json:"headers"`
+		Method  string            `json:"method"`
+		Url     string            `json:"url"`
+	} `json:"upload,omitempty"`
+
+	// Width Width in pixels as displayed, once the image is processed
+	Width *int `json:"width,omitempty"`
+}
+
+func (response CompleteAttachment200JSONResponse) VisitCompleteAttachmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteAttachment404Response struct {
+}
+
+func (response CompleteAttachment404Response) VisitCompleteAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CompleteAttachment409Response struct {
+}
+
+func (response CompleteAttachment409Response) VisitCompleteAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type CompleteAttachment415Response struct {
+}
+
+func (response CompleteAttachment415Response) VisitCompleteAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(415)
+	return nil
+}
+
+type CompleteAttachment422Response struct {
+}
+
+func (response CompleteAttachment422Response) VisitCompleteAttachmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(422)
+	return nil
+}
+
+type ListAttachmentVariantsRequestObject struct {
+	Id           int   `json:"id"`
+	AttachmentId int64 `json:"attachmentId"`
+}
+
+type ListAttachmentVariantsResponseObject interface {
+	VisitListAttachmentVariantsResponse(w http.ResponseWriter) error
+}
+
+type ListAttachmentVariants200JSONResponse struct {
+	Height *int `json:"height,omitempty"`
+
+	// ImageError Why the image could not be processed
+	ImageError *string `json:"image_error,omitempty"`
+
+	// ImageStatus pending, processed, unsupported or failed; missing for files that are not images
+	ImageStatus *string `json:"image_status,omitempty"`
+
+	// Variants Thumbnails of a processed image, smallest first
+	Variants []struct {
+		ContentType string    `json:"content_type"`
+		ExpiresAt   time.Time `json:"expires_at"`
+		Height      int       `json:"height"`
+		Name        string    `json:"name"`
+		Size        int64     `json:"size"`
+
+		// Url Short-lived URL of the thumbnail
+		Url   string `json:"url"`
+		Width int    `json:"width"`
+	} `json:"variants"`
+	Width *int `json:"width,omitempty"`
+}
+
+func (response ListAttachmentVariants200JSONResponse) VisitListAttachmentVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAttachmentVariants404Response struct {
+}
+
+func (response ListAttachmentVariants404Response) VisitListAttachmentVariantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListLabubuTransitionsRequestObject struct {
+	Id int `json:"id"`
+}
+
+type ListLabubuTransitionsResponseObject interface {
+	VisitListLabubuTransitionsResponse(w http.ResponseWriter) error
+}
+
+type ListLabubuTransitions200JSONResponse []struct {
+	// ActorId Who changed the status; absent when it was published on schedule
+	ActorId   *int      `json:"actor_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// From Absent for the status the labubu was created in
+	From *string `json:"from,omitempty"`
+
+	// PublishAt The time the labubu was scheduled for
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	To        string     `json:"to"`
+}
+
+func (response ListLabubuTransitions200JSONResponse) VisitListLabubuTransitionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLabubuTransitions404Response struct {
+}
+
+func (response ListLabubuTransitions404Response) VisitListLabubuTransitionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListShareLinksRequestObject struct {
+	Id int `json:"id"`
+}
+
+type ListShareLinksResponseObject interface {
+	VisitListShareLinksResponse(w http.ResponseWriter) error
+}
+
+type ListShareLinks200JSONResponse []struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	HasPassword bool       `json:"has_password"`
+	Id          int64      `json:"id"`
+	MaxViews    *int       `json:"max_views,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+	// Token The token in the URL; only returned when the link is created and not stored
+	Token *string `json:"token,omitempty"`
+
+	// Url Public URL of the link; only returned when the link is created
+	Url *string `json:"url,omitempty"`
+
+	// Views How many times the link has been opened
+	Views int `json:"views"`
+}
+
+func (response ListShareLinks200JSONResponse) VisitListShareLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListShareLinks404Response struct {
+}
+
+func (response ListShareLinks404Response) VisitListShareLinksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type CreateShareLinkRequestObject struct {
+	Id   int `json:"id"`
+	Body *CreateShareLinkJSONRequestBody
+}
+
+type CreateShareLinkResponseObject interface {
+	VisitCreateShareLinkResponse(w http.ResponseWriter) error
+}
+
+type CreateShareLink201JSONResponse struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	HasPassword bool       `json:"has_password"`
+	Id          int64      `json:"id"`
+	MaxViews    *int       `json:"max_views,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+
+	// Token The token in the URL; only returned when the link is created and not stored
+	Token *string `json:"token,omitempty"`
+
+	// Url Public URL of the link; only returned when the link is created
+	Url *string `json:"url,omitempty"`
+
+	// Views How many times the link has been opened
+	Views int `json:"views"`
+}
+
+func (response CreateShareLink201JSONResponse) VisitCreateShareLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateShareLink400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response CreateShareLink400JSONResponse) VisitCreateShareLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateShareLink404Response struct {
+}
+
+func (response CreateShareLink404Response) VisitCreateShareLinkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type RevokeShareLinkRequestObject struct {
+	Id     int   `json:"id"`
+	LinkId int64 `json:"linkId"`
+}
+
+type RevokeShareLinkResponseObject interface {
+	VisitRevokeShareLinkResponse(w http.ResponseWriter) error
+}
+
+type RevokeShareLink204Response struct {
+}
+
+func (response RevokeShareLink204Response) VisitRevokeShareLinkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeShareLink404Response struct {
+}
+
+func (response RevokeShareLink404Response) VisitRevokeShareLinkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type ListShareLinkAccessesRequestObject struct {
+	Id     int   `json:"id"`
+	LinkId int64 `json:"linkId"`
+	Params ListShareLinkAccessesParams
+}
+
+type ListShareLinkAccessesResponseObject interface {
+	VisitListShareLinkAccessesResponse(w http.ResponseWriter) error
+}
+
+type ListShareLinkAccesses200JSONResponse []struct {
+	AccessedAt time.Time `json:"accessed_at"`
+	Id         int64     `json:"id"`
+	Ip         string    `json:"ip"`
+
+	// Outcome viewed, password_required, wrong_password, expired, revoked, exhausted (no views left) or unavailable (the labubu was deleted)
+	Outcome   string `json:"outcome"`
+	UserAgent string `json:"user_agent"`
+}
+
+func (response ListShareLinkAccesses200JSONResponse) VisitListShareLinkAccessesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListShareLinkAccesses400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response ListShareLinkAccesses400JSONResponse) VisitListShareLinkAccessesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListShareLinkAccesses404Response struct {
+}
+
+func (response ListShareLinkAccesses404Response) VisitListShareLinkAccessesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type TransitionLabubuRequestObject struct {
+	Id   int `json:"id"`
+	Body *TransitionLabubuJSONRequestBody
+}
+
+type TransitionLabubuResponseObject interface {
+	VisitTransitionLabubuResponse(w http.ResponseWriter) error
+}
+
+type TransitionLabubu200JSONResponse struct {
+	Id int `json:"id"`
+
+	// PublishAt When a scheduled labubu gets published
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+
+	// PublishedAt When the labubu was last published
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Status draft, scheduled, published or archived
+	Status string `json:"status"`
+
+	// Tags Normalized tag names, sorted
+	Tags    []string `json:"tags"`
+	Text    string   `json:"text"`
+	Version int      `json:"version"`
+}
+
+func (response TransitionLabubu200JSONResponse) VisitTransitionLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransitionLabubu400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response TransitionLabubu400JSONResponse) VisitTransitionLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TransitionLabubu404Response struct {
+}
+
+func (response TransitionLabubu404Response) VisitTransitionLabubuResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type TransitionLabubu409JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response TransitionLabubu409JSONResponse) VisitTransitionLabubuResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(w http.ResponseWriter) error
+}
+
+type Login200JSONResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login401Response struct {
+}
+
+func (response Login401Response) VisitLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type Login429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type Login429Response struct {
+	Headers Login429ResponseHeaders
+}
+
+func (response Login429Response) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+	return nil
+}
+
+type ForgotPasswordRequestObject struct {
+	Body *ForgotPasswordJSONRequestBody
+}
+
+type ForgotPasswordResponseObject interface {
+	VisitForgotPasswordResponse(w http.ResponseWriter) error
+}
+
+type ForgotPassword202Response struct {
+}
+
+func (response ForgotPassword202Response) VisitForgotPasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type ResetPasswordRequestObject struct {
+	Body *ResetPasswordJSONRequestBody
+}
+
+type ResetPasswordResponseObject interface {
+	VisitResetPasswordResponse(w http.ResponseWriter) error
+}
+
+type ResetPassword204Response struct {
+}
+
+func (response ResetPassword204Response) VisitResetPasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ResetPassword400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response ResetPassword400JSONResponse) VisitResetPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTagsRequestObject struct {
+}
+
+type ListTagsResponseObject interface {
+	VisitListTagsResponse(w http.ResponseWriter) error
+}
+
+type ListTags200JSONResponse []struct {
+	// Count How many of your labubu carry the tag
+	Count int    `json:"count"`
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+}
+
+func (response ListTags200JSONResponse) VisitListTagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTagRequestObject struct {
+	Id   int `json:"id"`
+	Body *RenameTagJSONRequestBody
+}
+
+type RenameTagResponseObject interface {
+	VisitRenameTagResponse(w http.ResponseWriter) error
+}
+
+type RenameTag200JSONResponse struct {
+	// Count How many of your labubu carry the tag
+	Count int    `json:"count"`
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+}
+
+func (response RenameTag200JSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTag400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response RenameTag400JSONResponse) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenameTag404Response struct {
+}
+
+func (response RenameTag404Response) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type RenameTag409Response struct {
+}
+
+func (response RenameTag409Response) VisitRenameTagResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type MergeTagRequestObject struct {
+	Id   int `json:"id"`
+	Body *MergeTagJSONRequestBody
+}
+
+type MergeTagResponseObject interface {
+	VisitMergeTagResponse(w http.ResponseWriter) error
+}
+
+type MergeTag200JSONResponse struct {
+	// Count How many of your labubu carry the tag
+	Count int    `json:"count"`
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+}
+
+func (response MergeTag200JSONResponse) VisitMergeTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeTag400JSONResponse struct {
+	Error string `json:"error"`
+}
+
+func (response MergeTag400JSONResponse) VisitMergeTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeTag404Response struct {
+}
+
+func (response MergeTag404Response) VisitMergeTagResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type RefreshTokenRequestObject struct {
+	Body *RefreshTokenJSONRequestBody
+}
+
+type RefreshTokenResponseObject interface {
+	VisitRefreshTokenResponse(w http.ResponseWriter) error
+}
+
+type RefreshToken200JSONResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (response RefreshToken200JSONResponse) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshToken401Response struct {
+}
+
+func (response RefreshToken401Response) VisitRefreshTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List collections
+	// (GET /collections)
+	ListCollections(ctx context.Context, request ListCollectionsRequestObject) (ListCollectionsResponseObject, error)
+	// Create a collection
+	// (POST /collections)
+	CreateCollection(ctx context.Context, request CreateCollectionRequestObject) (CreateCollectionResponseObject, error)
+	// Delete a collection
+	// (DELETE /collections/{id})
+	DeleteCollection(ctx context.Context, request DeleteCollectionRequestObject) (DeleteCollectionResponseObject, error)
+	// Get a collection
+	// (GET /collections/{id})
+	GetCollection(ctx context.Context, request GetCollectionRequestObject) (GetCollectionResponseObject, error)
+	// Rename a collection
+	// (PUT /collections/{id})
+	RenameCollection(ctx context.Context, request RenameCollectionRequestObject) (RenameCollectionResponseObject, error)
+	// List the items of a collection
+	// (GET /collections/{id}/items)
+	ListCollectionItems(ctx context.Context, request ListCollectionItemsRequestObject) (ListCollectionItemsResponseObject, error)
+	// Add a labubu to a collection
+	// (POST /collections/{id}/items)
+	AddCollectionItem(ctx context.Context, request AddCollectionItemRequestObject) (AddCollectionItemResponseObject, error)
+	// Remove a labubu from a collection
+	// (DELETE /collections/{id}/items/{labubuId})
+	RemoveCollectionItem(ctx context.Context, request RemoveCollectionItemRequestObject) (RemoveCollectionItemResponseObject, error)
+	// Move an item
+	// (PUT /collections/{id}/items/{labubuId}/position)
+	MoveCollectionItem(ctx context.Context, request MoveCollectionItemRequestObject) (MoveCollectionItemResponseObject, error)
+	// List who a collection is shared with
+	// (GET /collections/{id}/shares)
+	ListCollectionShares(ctx context.Context, request ListCollectionSharesRequestObject) (ListCollectionSharesResponseObject, error)
+	// Share a collection
+	// (PUT /collections/{id}/shares)
+	ShareCollection(ctx context.Context, request ShareCollectionRequestObject) (ShareCollectionResponseObject, error)
+	// Stop sharing a collection
+	// (DELETE /collections/{id}/shares/{userId})
+	UnshareCollection(ctx context.Context, request UnshareCollectionRequestObject) (UnshareCollectionResponseObject, error)
+	// Send a new verification email
+	// (POST /email/verification)
+	ResendVerification(ctx context.Context, request ResendVerificationRequestObject) (ResendVerificationResponseObject, error)
+	// Confirm an email address
+	// (GET /email/verify)
+	VerifyEmail(ctx context.Context, request VerifyEmailRequestObject) (VerifyEmailResponseObject, error)
+	// Get all labubu
+	// (GET /labubu)
+	GetLabubu(ctx context.Context, request GetLabubuRequestObject) (GetLabubuResponseObject, error)
+	// Create labubu
+	// (POST /labubu)
+	CreateLabubu(ctx context.Context, request CreateLabubuRequestObject) (CreateLabubuResponseObject, error)
+	// Delete labubu
+	// (DELETE /labubu/{id})
+	DeleteLabubu(ctx context.Context, request DeleteLabubuRequestObject) (DeleteLabubuResponseObject, error)
+	// Update labubu
+	// (PUT /labubu/{id})
+	UpdateLabubu(ctx context.Context, request UpdateLabubuRequestObject) (UpdateLabubuResponseObject, error)
+	// List attachments
+	// (GET /labubu/{id}/attachments)
+	ListAttachments(ctx context.Context, request ListAttachmentsRequestObject) (ListAttachmentsResponseObject, error)
+	// Attach a file
+	// (POST /labubu/{id}/attachments)
+	UploadAttachment(ctx context.Context, request UploadAttachmentRequestObject) (UploadAttachmentResponseObject, error)
+	// Delete an attachment
+	// (DELETE /labubu/{id}/attachments/{attachmentId})
+	DeleteAttachment(ctx context.Context, request DeleteAttachmentRequestObject) (DeleteAttachmentResponseObject, error)
+	// Download an attachment
+	// (GET /labubu/{id}/attachments/{attachmentId})
+	DownloadAttachment(ctx context.Context, request DownloadAttachmentRequestObject) (DownloadAttachmentResponseObject, error)
+	// Complete a direct upload
+	// (POST /labubu/{id}/attachments/{attachmentId}/complete)
+	CompleteAttachment(ctx context.Context, request CompleteAttachmentRequestObject) (CompleteAttachmentResponseObject, error)
+	// List image thumbnails
+	// (GET /labubu/{id}/attachments/{attachmentId}/variants)
+	ListAttachmentVariants(ctx context.Context, request ListAttachmentVariantsRequestObject) (ListAttachmentVariantsResponseObject, error)
+	// List labubu status history
+	// (GET /labubu/{id}/history)
+	ListLabubuTransitions(ctx context.Context, request ListLabubuTransitionsRequestObject) (ListLabubuTransitionsResponseObject, error)
+	// List share links
+	// (GET /labubu/{id}/share)
+	ListShareLinks(ctx context.Context, request ListShareLinksRequestObject) (ListShareLinksResponseObject, error)
+	// Create a share link
+	// (POST /labubu/{id}/share)
+	CreateShareLink(ctx context.Context, request CreateShareLinkRequestObject) (CreateShareLinkResponseObject, error)
+	// Revoke a share link
+	// (DELETE /labubu/{id}/share/{linkId})
+	RevokeShareLink(ctx context.Context, request RevokeShareLinkRequestObject) (RevokeShareLinkResponseObject, error)
+	// List who opened a share link
+	// (GET /labubu/{id}/share/{linkId}/accesses)
+	ListShareLinkAccesses(ctx context.Context, request ListShareLinkAccessesRequestObject) (ListShareLinkAccessesResponseObject, error)
+	// Change labubu status
+	// (POST /labubu/{id}/status)
+	TransitionLabubu(ctx context.Context, request TransitionLabubuRequestObject) (TransitionLabubuResponseObject, error)
+	// Login endpoint
+	// (POST /login)
+	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
+	// Request a password reset
+	// (POST /password/forgot)
+	ForgotPassword(ctx context.Context, request ForgotPasswordRequestObject) (ForgotPasswordResponseObject, error)
+	// Reset the password
+	// (POST /password/reset)
+	ResetPassword(ctx context.Context, request ResetPasswordRequestObject) (ResetPasswordResponseObject, error)
+	// List tags
+	// (GET /tags)
+	ListTags(ctx context.Context, request ListTagsRequestObject) (ListTagsResponseObject, error)
+	// Rename a tag
+	// (PUT /tags/{id})
+	RenameTag(ctx context.Context, request RenameTagRequestObject) (RenameTagResponseObject, error)
+	// Merge tags
+	// (POST /tags/{id}/merge)
+	MergeTag(ctx context.Context, request MergeTagRequestObject) (MergeTagResponseObject, error)
+	// Refresh tokens
+	// (POST /token/refresh)
+	RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListCollections operation middleware
+func (sh *strictHandler) ListCollections(w http.ResponseWriter, r *http.Request, params ListCollectionsParams) {
+	var request ListCollectionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCollections(ctx, request.(ListCollectionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCollections")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCollectionsResponseObject); ok {
+		if err := validResponse.VisitListCollectionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCollection operation middleware
+func (sh *strictHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	var request CreateCollectionRequestObject
+
+	var body CreateCollectionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCollection(ctx, request.(CreateCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCollectionResponseObject); ok {
+		if err := validResponse.VisitCreateCollectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteCollection operation middleware
+func (sh *strictHandler) DeleteCollection(w http.ResponseWriter, r *http.Request, id int) {
+	var request DeleteCollectionRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCollection(ctx, request.(DeleteCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteCollectionResponseObject); ok {
+		if err := validResponse.VisitDeleteCollectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCollection operation middleware
+func (sh *strictHandler) GetCollection(w http.ResponseWriter, r *http.Request, id int) {
+	var request GetCollectionRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCollection(ctx, request.(GetCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCollectionResponseObject); ok {
+		if err := validResponse.VisitGetCollectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RenameCollection operation middleware
+func (sh *strictHandler) RenameCollection(w http.ResponseWriter, r *http.Request, id int) {
+	var request RenameCollectionRequestObject
+
+	request.Id = id
+
+	var body RenameCollectionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RenameCollection(ctx, request.(RenameCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RenameCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RenameCollectionResponseObject); ok {
+		if err := validResponse.VisitRenameCollectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCollectionItems operation middleware
+func (sh *strictHandler) ListCollectionItems(w http.ResponseWriter, r *http.Request, id int, params ListCollectionItemsParams) {
+	var request ListCollectionItemsRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCollectionItems(ctx, request.(ListCollectionItemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCollectionItems")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCollectionItemsResponseObject); ok {
+		if err := validResponse.VisitListCollectionItemsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddCollectionItem operation middleware
+func (sh *strictHandler) AddCollectionItem(w http.ResponseWriter, r *http.Request, id int) {
+	var request AddCollectionItemRequestObject
+
+	request.Id = id
+
+	var body AddCollectionItemJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddCollectionItem(ctx, request.(AddCollectionItemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddCollectionItem")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddCollectionItemResponseObject); ok {
+		if err := validResponse.VisitAddCollectionItemResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveCollectionItem operation middleware
+func (sh *strictHandler) RemoveCollectionItem(w http.ResponseWriter, r *http.Request, id int, labubuId int) {
+	var request RemoveCollectionItemRequestObject
+
+	request.Id = id
+	request.LabubuId = labubuId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveCollectionItem(ctx, request.(RemoveCollectionItemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveCollectionItem")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveCollectionItemResponseObject); ok {
+		if err := validResponse.VisitRemoveCollectionItemResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MoveCollectionItem operation middleware
+func (sh *strictHandler) MoveCollectionItem(w http.ResponseWriter, r *http.Request, id int, labubuId int) {
+	var request MoveCollectionItemRequestObject
+
+	request.Id = id
+	request.LabubuId = labubuId
+
+	var body MoveCollectionItemJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MoveCollectionItem(ctx, request.(MoveCollectionItemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MoveCollectionItem")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MoveCollectionItemResponseObject); ok {
+		if err := validResponse.VisitMoveCollectionItemResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCollectionShares operation middleware
+func (sh *strictHandler) ListCollectionShares(w http.ResponseWriter, r *http.Request, id int, params ListCollectionSharesParams) {
+	var request ListCollectionSharesRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCollectionShares(ctx, request.(ListCollectionSharesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCollectionShares")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCollectionSharesResponseObject); ok {
+		if err := validResponse.VisitListCollectionSharesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ShareCollection operation middleware
+func (sh *strictHandler) ShareCollection(w http.ResponseWriter, r *http.Request, id int) {
+	var request ShareCollectionRequestObject
+
+	request.Id = id
+
+	var body ShareCollectionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ShareCollection(ctx, request.(ShareCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ShareCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ShareCollectionResponseObject); ok {
+		if err := validResponse.VisitShareCollectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UnshareCollection operation middleware
+func (sh *strictHandler) UnshareCollection(w http.ResponseWriter, r *http.Request, id int, userId int) {
+	var request UnshareCollectionRequestObject
+
+	request.Id = id
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnshareCollection(ctx, request.(UnshareCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnshareCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnshareCollectionResponseObject); ok {
+		if err := validResponse.VisitUnshareCollectionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResendVerification operation middleware
+func (sh *strictHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var request ResendVerificationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResendVerification(ctx, request.(ResendVerificationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResendVerification")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResendVerificationResponseObject); ok {
+		if err := validResponse.VisitResendVerificationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// VerifyEmail operation middleware
+func (sh *strictHandler) VerifyEmail(w http.ResponseWriter, r *http.Request, params VerifyEmailParams) {
+	var request VerifyEmailRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifyEmail(ctx, request.(VerifyEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifyEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VerifyEmailResponseObject); ok {
+		if err := validResponse.VisitVerifyEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLabubu operation middleware
+func (sh *strictHandler) GetLabubu(w http.ResponseWriter, r *http.Request, params GetLabubuParams) {
+	var request GetLabubuRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLabubu(ctx, request.(GetLabubuRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLabubu")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLabubuResponseObject); ok {
+		if err := validResponse.VisitGetLabubuResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateLabubu operation middleware
+func (sh *strictHandler) CreateLabubu(w http.ResponseWriter, r *http.Request) {
+	var request CreateLabubuRequestObject
+
+	var body CreateLabubuJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateLabubu(ctx, request.(CreateLabubuRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateLabubu")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateLabubuResponseObject); ok {
+		if err := validResponse.VisitCreateLabubuResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteLabubu operation middleware
+func (sh *strictHandler) DeleteLabubu(w http.ResponseWriter, r *http.Request, id int) {
+	var request DeleteLabubuRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteLabubu(ctx, request.(DeleteLabubuRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteLabubu")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteLabubuResponseObject); ok {
+		if err := validResponse.VisitDeleteLabubuResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateLabubu operation middleware
+func (sh *strictHandler) UpdateLabubu(w http.ResponseWriter, r *http.Request, id int) {
+	var request UpdateLabubuRequestObject
+
+	request.Id = id
+
+	var body UpdateLabubuJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateLabubu(ctx, request.(UpdateLabubuRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateLabubu")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateLabubuResponseObject); ok {
+		if err := validResponse.VisitUpdateLabubuResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAttachments operation middleware
+func (sh *strictHandler) ListAttachments(w http.ResponseWriter, r *http.Request, id int) {
+	var request ListAttachmentsRequestObject
+
+	request.Id = id
 
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAttachments(ctx, request.(ListAttachmentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAttachments")
 	}
 
-	return response, nil
-}
+	response, err := handler(r.Context(), w, r, request)
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get all labubu
-	// (GET /labubu)
-	GetLabubu(w http.ResponseWriter, r *http.Request)
-	// Create labubu
-	// (POST /labubu)
-	CreateLabubu(w http.ResponseWriter, r *http.Request)
-	// Login endpoint
-	// (POST /login)
-	Login(w http.ResponseWriter, r *http.Request)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAttachmentsResponseObject); ok {
+		if err := validResponse.VisitListAttachmentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// UploadAttachment operation middleware
+func (sh *strictHandler) UploadAttachment(w http.ResponseWriter, r *http.Request, id int) {
+	var request UploadAttachmentRequestObject
 
-type Unimplemented struct{}
+	request.Id = id
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
 
-// Get all labubu
-// (GET /labubu)
-func (_ Unimplemented) GetLabubu(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+		var body UploadAttachmentJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if reader, err := r.MultipartReader(); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+			return
+		} else {
+			request.MultipartBody = reader
+		}
+	}
 
-// Create labubu
-// (POST /labubu)
-func (_ Unimplemented) CreateLabubu(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UploadAttachment(ctx, request.(UploadAttachmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UploadAttachment")
+	}
 
-// Login endpoint
-// (POST /login)
-func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UploadAttachmentResponseObject); ok {
+		if err := validResponse.VisitUploadAttachmentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// DeleteAttachment operation middleware
+func (sh *strictHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	var request DeleteAttachmentRequestObject
 
-// GetLabubu operation middleware
-func (siw *ServerInterfaceWrapper) GetLabubu(w http.ResponseWriter, r *http.Request) {
+	request.Id = id
+	request.AttachmentId = attachmentId
 
-	ctx := r.Context()
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAttachment(ctx, request.(DeleteAttachmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAttachment")
+	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	response, err := handler(r.Context(), w, r, request)
 
-	r = r.WithContext(ctx)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAttachmentResponseObject); ok {
+		if err := validResponse.VisitDeleteAttachmentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLabubu(w, r)
-	}))
+// DownloadAttachment operation middleware
+func (sh *strictHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	var request DownloadAttachmentRequestObject
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	request.Id = id
+	request.AttachmentId = attachmentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DownloadAttachment(ctx, request.(DownloadAttachmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DownloadAttachment")
 	}
 
-	handler.ServeHTTP(w, r)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DownloadAttachmentResponseObject); ok {
+		if err := validResponse.VisitDownloadAttachmentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// CreateLabubu operation middleware
-func (siw *ServerInterfaceWrapper) CreateLabubu(w http.ResponseWriter, r *http.Request) {
+// CompleteAttachment operation middleware
+func (sh *strictHandler) CompleteAttachment(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	var request CompleteAttachmentRequestObject
 
-	ctx := r.Context()
+	request.Id = id
+	request.AttachmentId = attachmentId
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CompleteAttachment(ctx, request.(CompleteAttachmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CompleteAttachment")
+	}
 
-	r = r.WithContext(ctx)
+	response, err := handler(r.Context(), w, r, request)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateLabubu(w, r)
-	}))
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CompleteAttachmentResponseObject); ok {
+		if err := validResponse.VisitCompleteAttachmentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+// ListAttachmentVariants operation middleware
+func (sh *strictHandler) ListAttachmentVariants(w http.ResponseWriter, r *http.Request, id int, attachmentId int64) {
+	var request ListAttachmentVariantsRequestObject
+
+	request.Id = id
+	request.AttachmentId = attachmentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAttachmentVariants(ctx, request.(ListAttachmentVariantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAttachmentVariants")
 	}
 
-	handler.ServeHTTP(w, r)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAttachmentVariantsResponseObject); ok {
+		if err := validResponse.VisitListAttachmentVariantsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// Login operation middleware
-func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+// ListLabubuTransitions operation middleware
+func (sh *strictHandler) ListLabubuTransitions(w http.ResponseWriter, r *http.Request, id int) {
+	var request ListLabubuTransitionsRequestObject
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Login(w, r)
-	}))
+	request.Id = id
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListLabubuTransitions(ctx, request.(ListLabubuTransitionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListLabubuTransitions")
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListLabubuTransitionsResponseObject); ok {
+		if err := validResponse.VisitListLabubuTransitionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+// ListShareLinks operation middleware
+func (sh *strictHandler) ListShareLinks(w http.ResponseWriter, r *http.Request, id int) {
+	var request ListShareLinksRequestObject
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	request.Id = id
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListShareLinks(ctx, request.(ListShareLinksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListShareLinks")
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListShareLinksResponseObject); ok {
+		if err := validResponse.VisitListShareLinksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequiredParamError struct {
-	ParamName string
-}
+// CreateShareLink operation middleware
+func (sh *strictHandler) CreateShareLink(w http.ResponseWriter, r *http.Request, id int) {
+	var request CreateShareLinkRequestObject
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	request.Id = id
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	var body CreateShareLinkJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateShareLink(ctx, request.(CreateShareLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateShareLink")
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateShareLinkResponseObject); ok {
+		if err := validResponse.VisitCreateShareLinkResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+// RevokeShareLink operation middleware
+func (sh *strictHandler) RevokeShareLink(w http.ResponseWriter, r *http.Request, id int, linkId int64) {
+	var request RevokeShareLinkRequestObject
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	request.Id = id
+	request.LinkId = linkId
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeShareLink(ctx, request.(RevokeShareLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeShareLink")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeShareLinkResponseObject); ok {
+		if err := validResponse.VisitRevokeShareLinkResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+// ListShareLinkAccesses operation middleware
+func (sh *strictHandler) ListShareLinkAccesses(w http.ResponseWriter, r *http.Request, id int, linkId int64, params ListShareLinkAccessesParams) {
+	var request ListShareLinkAccessesRequestObject
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	request.Id = id
+	request.LinkId = linkId
+	request.Params = params
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListShareLinkAccesses(ctx, request.(ListShareLinkAccessesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListShareLinkAccesses")
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListShareLinkAccessesResponseObject); ok {
+		if err := validResponse.VisitListShareLinkAccessesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// TransitionLabubu operation middleware
+func (sh *strictHandler) TransitionLabubu(w http.ResponseWriter, r *http.Request, id int) {
+	var request TransitionLabubuRequestObject
 
-	if r == nil {
-		r = chi.NewRouter()
+	request.Id = id
+
+	var body TransitionLabubuJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.TransitionLabubu(ctx, request.(TransitionLabubuRequestObject))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TransitionLabubu")
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/labubu", wrapper.GetLabubu)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/labubu", wrapper.CreateLabubu)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/login", wrapper.Login)
-	})
-
-	return r
-}
-
-type GetLabubuRequestObject struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetLabubuResponseObject interface {
-	VisitGetLabubuResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(TransitionLabubuResponseObject); ok {
+		if err := validResponse.VisitTransitionLabubuResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetLabubu200JSONResponse []struct {
-	Id   int    `json:"id"`
-	Text string `json:"text"`
-}
+// Login operation middleware
+func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequestObject
 
-func (response GetLabubu200JSONResponse) VisitGetLabubuResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var body LoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Login")
+	}
 
-type CreateLabubuRequestObject struct {
-	Body *CreateLabubuJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateLabubuResponseObject interface {
-	VisitCreateLabubuResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LoginResponseObject); ok {
+		if err := validResponse.VisitLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateLabubu200JSONResponse struct {
-	Id   int    `json:"id"`
-	Text string `json:"text"`
-}
+// ForgotPassword operation middleware
+func (sh *strictHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request ForgotPasswordRequestObject
 
-func (response CreateLabubu200JSONResponse) VisitCreateLabubuResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var body ForgotPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ForgotPassword(ctx, request.(ForgotPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ForgotPassword")
+	}
 
-type CreateLabubu400Response struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func (response CreateLabubu400Response) VisitCreateLabubuResponse(w http.ResponseWriter) error {
-	w.WriteHeader(400)
-	return nil
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ForgotPasswordResponseObject); ok {
+		if err := validResponse.VisitForgotPasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type LoginRequestObject struct {
-	Body *LoginJSONRequestBody
-}
+// ResetPassword operation middleware
+func (sh *strictHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request ResetPasswordRequestObject
 
-type LoginResponseObject interface {
-	VisitLoginResponse(w http.ResponseWriter) error
-}
+	var body ResetPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type Login200JSONResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetPassword(ctx, request.(ResetPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetPassword")
+	}
 
-func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetPasswordResponseObject); ok {
+		if err := validResponse.VisitResetPasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get all labubu
-	// (GET /labubu)
-	GetLabubu(ctx context.Context, request GetLabubuRequestObject) (GetLabubuResponseObject, error)
-	// Create labubu
-	// (POST /labubu)
-	CreateLabubu(ctx context.Context, request CreateLabubuRequestObject) (CreateLabubuResponseObject, error)
-	// Login endpoint
-	// (POST /login)
-	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
-}
+// ListTags operation middleware
+func (sh *strictHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	var request ListTagsRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTags(ctx, request.(ListTagsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTags")
+	}
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTagsResponseObject); ok {
+		if err := validResponse.VisitListTagsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+// RenameTag operation middleware
+func (sh *strictHandler) RenameTag(w http.ResponseWriter, r *http.Request, id int) {
+	var request RenameTagRequestObject
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+	request.Id = id
 
-// GetLabubu operation middleware
-func (sh *strictHandler) GetLabubu(w http.ResponseWriter, r *http.Request) {
-	var request GetLabubuRequestObject
+	var body RenameTagJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetLabubu(ctx, request.(GetLabubuRequestObject))
+		return sh.ssi.RenameTag(ctx, request.(RenameTagRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetLabubu")
+		handler = middleware(handler, "RenameTag")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetLabubuResponseObject); ok {
-		if err := validResponse.VisitGetLabubuResponse(w); err != nil {
+	} else if validResponse, ok := response.(RenameTagResponseObject); ok {
+		if err := validResponse.VisitRenameTagResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -908,11 +10624,13 @@ func (sh *strictHandler) GetLabubu(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateLabubu operation middleware
-func (sh *strictHandler) CreateLabubu(w http.ResponseWriter, r *http.Request) {
-	var request CreateLabubuRequestObject
+// MergeTag operation middleware
+func (sh *strictHandler) MergeTag(w http.ResponseWriter, r *http.Request, id int) {
+	var request MergeTagRequestObject
 
-	var body CreateLabubuJSONRequestBody
+	request.Id = id
+
+	var body MergeTagJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -920,18 +10638,18 @@ func (sh *strictHandler) CreateLabubu(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateLabubu(ctx, request.(CreateLabubuRequestObject))
+		return sh.ssi.MergeTag(ctx, request.(MergeTagRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateLabubu")
+		handler = middleware(handler, "MergeTag")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateLabubuResponseObject); ok {
-		if err := validResponse.VisitCreateLabubuResponse(w); err != nil {
+	} else if validResponse, ok := response.(MergeTagResponseObject); ok {
+		if err := validResponse.VisitMergeTagResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -939,11 +10657,11 @@ func (sh *strictHandler) CreateLabubu(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Login operation middleware
-func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
-	var request LoginRequestObject
+// RefreshToken operation middleware
+func (sh *strictHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var request RefreshTokenRequestObject
 
-	var body LoginJSONRequestBody
+	var body RefreshTokenJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -951,18 +10669,18 @@ func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+		return sh.ssi.RefreshToken(ctx, request.(RefreshTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Login")
+		handler = middleware(handler, "RefreshToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(LoginResponseObject); ok {
-		if err := validResponse.VisitLoginResponse(w); err != nil {
+	} else if validResponse, ok := response.(RefreshTokenResponseObject); ok {
+		if err := validResponse.VisitRefreshTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -0,0 +1,113 @@
+// Package attachments keeps files attached to labubu entries in object storage
+package attachments
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+)
+
+// Status is the lifecycle state of an attachment
+type Status string
+
+const (
+	// StatusPending marks a direct upload the client has not completed yet
+	StatusPending Status = "pending"
+	StatusReady   Status = "ready"
+	// StatusRejected marks an upload refused by the checks or the scanner; its content is deleted
+	StatusRejected Status = "rejected"
+)
+
+// ImageStatus tracks the processing of an image attachment; it is empty for other files
+type ImageStatus string
+
+const (
+	ImagePending   ImageStatus = "pending"
+	ImageProcessed ImageStatus = "processed"
+	// ImageUnsupported marks an image type that cannot be decoded, e.g. image/tiff
+	ImageUnsupported ImageStatus = "unsupported"
+	// ImageFailed marks an image that could not be processed, e.g. because it is corrupt
+	ImageFailed ImageStatus = "failed"
+)
+
+// Attachment is a file attached to a labubu
+type Attachment struct {
+	ID       int64 `json:"id"`
+	LabubuID int   `json:"labubu_id"`
+	// UploaderID is 0 once the uploader's account is deleted
+	UploaderID  int    `json:"uploader_id"`
+	Key         string `json:"-"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256,omitempty"`
+	Status      Status `json:"status"`
+	// Width and Height are known once an image is processed
+	Width       int         `json:"width,omitempty"`
+	Height      int         `json:"height,omitempty"`
+	ImageStatus ImageStatus `json:"image_status,omitempty"`
+	ImageError  string      `json:"image_error,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Variant is a resized copy of an image attachment, kept next to the original
+type Variant struct {
+	ID           int64 `json:"id"`
+	AttachmentID int64 `json:"attachment_id"`
+	// Name is the configured size, e.g. small
+	Name        string    `json:"name"`
+	Key         string    `json:"-"`
+	ContentType string    `json:"content_type"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// VariantURL is a variant with a presigned URL to download it
+type VariantURL struct {
+	*Variant
+	URL       string
+	ExpiresAt time.Time
+}
+
+// UploadRequest sends a file through the API. The content type is detected
+// from Body; the client's claim is not trusted.
+type UploadRequest struct {
+	LabubuID   int
+	UploaderID int
+	Filename   string
+	Body       io.Reader
+}
+
+// CreateUploadRequest reserves an attachment the client uploads to storage
+// directly. ContentType and Size are enforced by the presigned request.
+type CreateUploadRequest struct {
+	LabubuID    int
+	UploaderID  int
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
+// Upload is a pending attachment and the request that uploads its content
+type Upload struct {
+	Attachment *Attachment
+	Request    *storage.PresignedRequest
+}
+
+// Common errors
+var (
+	ErrNotFound        = errors.New("attachment not found")
+	ErrInvalidFilename = errors.New("invalid filename")
+	ErrTooLarge        = errors.New("attachment is too large")
+	ErrTypeNotAllowed  = errors.New("content type is not allowed")
+	// ErrNotUploaded is returned when a direct upload is completed before the content arrived
+	ErrNotUploaded = errors.New("attachment content has not been uploaded")
+	ErrNotReady    = errors.New("attachment is not ready")
+	// ErrRejected is wrapped by scanners to refuse a file
+	ErrRejected = errors.New("attachment was rejected")
+)
@@ -0,0 +1,145 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/imaging"
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+// variantKey is where the thumbnail called name of the object at key is kept
+func variantKey(key, name string) string {
+	return key + "_" + name
+}
+
+func (s *service) ProcessImage(ctx context.Context, id int64, final bool) (err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.ProcessImage")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	a, err := s.repo.GetAttachmentByID(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		// Deleted before it was processed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if a.Status != StatusReady || a.ImageStatus != ImagePending {
+		return nil
+	}
+
+	err = s.processImage(ctx, a)
+	if err != nil && final {
+		slog.WarnContext(ctx, "Failed to process image attachment", "attachment_id", a.ID, "error", err)
+		return s.repo.FailImage(ctx, a.ID, ImageFailed, "processing failed")
+	}
+	return err
+}
+
+func (s *service) processImage(ctx context.Context, a *Attachment) error {
+	body, _, err := s.store.Get(ctx, a.Key)
+	if err != nil {
+		return err
+	}
+	// Attachments are never larger than MaxSize, so neither is what is read here
+	data, err := io.ReadAll(io.LimitReader(body, s.config.MaxSize))
+	_ = body.Close()
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", a.Key, err)
+	}
+
+	img, info, err := imaging.Decode(data, s.config.MaxPixels)
+	switch {
+	case errors.Is(err, imaging.ErrUnsupported):
+		return s.repo.FailImage(ctx, a.ID, ImageUnsupported, "the image format is not supported")
+	case errors.Is(err, imaging.ErrTooLarge):
+		return s.repo.FailImage(ctx, a.ID, ImageFailed, "the image has too many pixels")
+	case err != nil:
+		// The content is at fault, so another attempt fails the same way
+		return s.repo.FailImage(ctx, a.ID, ImageFailed, "the image could not be decoded")
+	}
+
+	stripped, err := imaging.StripMetadata(info.Format, data)
+	if err != nil {
+		return s.repo.FailImage(ctx, a.ID, ImageFailed, "the image could not be decoded")
+	}
+	if !bytes.Equal(stripped, data) {
+		obj, err := s.store.Put(ctx, a.Key, bytes.NewReader(stripped), storage.PutOptions{ContentType: a.ContentType})
+		if err != nil {
+			return err
+		}
+		a.Size, a.SHA256 = obj.Size, obj.SHA256
+	}
+
+	variants := make([]*Variant, 0, len(s.config.Sizes))
+	for _, size := range s.config.Sizes {
+		thumbnail := imaging.Thumbnail(img, info.Orientation, size.Max)
+		var buf bytes.Buffer
+		contentType, err := imaging.Encode(&buf, thumbnail)
+		if err != nil {
+			return fmt.Errorf("encoding %s thumbnail: %w", size.Name, err)
+		}
+		key := variantKey(a.Key, size.Name)
+		obj, err := s.store.Put(ctx, key, &buf, storage.PutOptions{ContentType: contentType})
+		if err != nil {
+			return err
+		}
+		variants = append(variants, &Variant{
+			Name:        size.Name,
+			Key:         key,
+			ContentType: contentType,
+			Width:       thumbnail.Bounds().Dx(),
+			Height:      thumbnail.Bounds().Dy(),
+			Size:        obj.Size,
+		})
+	}
+
+	a.Width, a.Height = info.Width, info.Height
+	err = s.repo.RecordImage(ctx, a, variants)
+	if errors.Is(err, ErrNotFound) {
+		// Deleted while it was processed; what was written since goes too
+		keys := []string{a.Key}
+		for _, v := range variants {
+			keys = append(keys, v.Key)
+		}
+		return s.DeleteObjects(ctx, keys)
+	}
+	return err
+}
+
+func (s *service) ListVariants(ctx context.Context, labubuID int, id int64, userID int) (_ *Attachment, _ []VariantURL, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.ListVariants")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, userID); err != nil {
+		return nil, nil, err
+	}
+	a, err := s.repo.GetAttachment(ctx, id, labubuID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.ImageStatus != ImageProcessed {
+		// Variants of an attempt that failed halfway are not shown
+		return a, []VariantURL{}, nil
+	}
+
+	variants, err := s.repo.ListVariants(ctx, a.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	urls := make([]VariantURL, 0, len(variants))
+	for _, v := range variants {
+		download, err := s.store.PresignGet(ctx, v.Key, storage.PresignOptions{TTL: s.config.URLTTL})
+		if err != nil {
+			return nil, nil, err
+		}
+		urls = append(urls, VariantURL{Variant: v, URL: download.URL, ExpiresAt: download.ExpiresAt})
+	}
+	return a, urls, nil
+}
@@ -0,0 +1,258 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for attachment data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	CreateAttachment(ctx context.Context, a *Attachment) (*Attachment, error)
+	GetAttachment(ctx context.Context, id int64, labubuID int) (*Attachment, error)
+	GetAttachmentByID(ctx context.Context, id int64) (*Attachment, error)
+	ListAttachments(ctx context.Context, labubuID int) ([]*Attachment, error)
+	// CompleteAttachment marks a pending attachment ready; ErrNotFound when it is not pending
+	CompleteAttachment(ctx context.Context, a *Attachment) (*Attachment, error)
+	RejectAttachment(ctx context.Context, id int64) error
+	DeleteAttachment(ctx context.Context, id int64, labubuID int) (*Attachment, error)
+	DeleteAttachments(ctx context.Context, ids []int64) (int64, error)
+	ListPurgeableAttachments(ctx context.Context, deletedBefore, pendingBefore time.Time) ([]*Attachment, error)
+	// RecordImage stores what processing found out about an image and its variants
+	RecordImage(ctx context.Context, a *Attachment, variants []*Variant) error
+	FailImage(ctx context.Context, id int64, status ImageStatus, reason string) error
+	ListVariants(ctx context.Context, attachmentID int64) ([]*Variant, error)
+	ListVariantKeys(ctx context.Context, attachmentIDs []int64) ([]string, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+func (r *pgxRepository) CreateAttachment(ctx context.Context, a *Attachment) (*Attachment, error) {
+	result, err := r.q.CreateAttachment(ctx, sqlc.CreateAttachmentParams{
+		LabubuID:    int32(a.LabubuID),
+		UploaderID:  pgtype.Int4{Int32: int32(a.UploaderID), Valid: a.UploaderID != 0},
+		ObjectKey:   a.Key,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      pgtype.Text{String: a.SHA256, Valid: a.SHA256 != ""},
+		Status:      string(a.Status),
+		ImageStatus: pgtype.Text{String: string(a.ImageStatus), Valid: a.ImageStatus != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateAttachment failed: %w", err)
+	}
+	return toAttachment(result), nil
+}
+
+func (r *pgxRepository) GetAttachment(ctx context.Context, id int64, labubuID int) (*Attachment, error) {
+	result, err := r.q.GetAttachment(ctx, sqlc.GetAttachmentParams{ID: id, LabubuID: int32(labubuID)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetAttachment failed: %w", err)
+	}
+	return toAttachment(result), nil
+}
+
+func (r *pgxRepository) GetAttachmentByID(ctx context.Context, id int64) (*Attachment, error) {
+	result, err := r.q.GetAttachmentByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetAttachmentByID failed: %w", err)
+	}
+	return toAttachment(result), nil
+}
+
+func (r *pgxRepository) ListAttachments(ctx context.Context, labubuID int) ([]*Attachment, error) {
+	results, err := r.q.ListAttachments(ctx, int32(labubuID))
+	if err != nil {
+		return nil, fmt.Errorf("ListAttachments failed: %w", err)
+	}
+	return toAttachments(results), nil
+}
+
+func (r *pgxRepository) CompleteAttachment(ctx context.Context, a *Attachment) (*Attachment, error) {
+	result, err := r.q.CompleteAttachment(ctx, sqlc.CompleteAttachmentParams{
+		ID:          a.ID,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      pgtype.Text{String: a.SHA256, Valid: true},
+		ImageStatus: pgtype.Text{String: string(a.ImageStatus), Valid: a.ImageStatus != ""},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CompleteAttachment failed: %w", err)
+	}
+	return toAttachment(result), nil
+}
+
+func (r *pgxRepository) RejectAttachment(ctx context.Context, id int64) error {
+	if err := r.q.RejectAttachment(ctx, id); err != nil {
+		return fmt.Errorf("RejectAttachment failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) DeleteAttachment(ctx context.Context, id int64, labubuID int) (*Attachment, error) {
+	result, err := r.q.DeleteAttachment(ctx, sqlc.DeleteAttachmentParams{ID: id, LabubuID: int32(labubuID)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("DeleteAttachment failed: %w", err)
+	}
+	return toAttachment(result), nil
+}
+
+func (r *pgxRepository) DeleteAttachments(ctx context.Context, ids []int64) (int64, error) {
+	n, err := r.q.DeleteAttachmentsByID(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteAttachmentsByID failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) ListPurgeableAttachments(ctx context.Context, deletedBefore, pendingBefore time.Time) ([]*Attachment, error) {
+	results, err := r.q.ListPurgeableAttachments(ctx, sqlc.ListPurgeableAttachmentsParams{
+		DeletedAt: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: pendingBefore, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListPurgeableAttachments failed: %w", err)
+	}
+	return toAttachments(results), nil
+}
+
+func (r *pgxRepository) RecordImage(ctx context.Context, a *Attachment, variants []*Variant) error {
+	// Variants go first, so a failure leaves the image pending and processing is repeated
+	for _, v := range variants {
+		_, err := r.q.UpsertAttachmentVariant(ctx, sqlc.UpsertAttachmentVariantParams{
+			AttachmentID: a.ID,
+			Name:         v.Name,
+			ObjectKey:    v.Key,
+			ContentType:  v.ContentType,
+			Width:        int32(v.Width),
+			Height:       int32(v.Height),
+			Size:         v.Size,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("UpsertAttachmentVariant failed: %w", err)
+		}
+	}
+
+	_, err := r.q.RecordAttachmentImage(ctx, sqlc.RecordAttachmentImageParams{
+		ID:     a.ID,
+		Width:  pgtype.Int4{Int32: int32(a.Width), Valid: true},
+		Height: pgtype.Int4{Int32: int32(a.Height), Valid: true},
+		Size:   a.Size,
+		Sha256: pgtype.Text{String: a.SHA256, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("RecordAttachmentImage failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) FailImage(ctx context.Context, id int64, status ImageStatus, reason string) error {
+	err := r.q.FailAttachmentImage(ctx, sqlc.FailAttachmentImageParams{
+		ID:          id,
+		ImageStatus: pgtype.Text{String: string(status), Valid: true},
+		ImageError:  pgtype.Text{String: reason, Valid: reason != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("FailAttachmentImage failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListVariants(ctx context.Context, attachmentID int64) ([]*Variant, error) {
+	results, err := r.q.ListAttachmentVariants(ctx, attachmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ListAttachmentVariants failed: %w", err)
+	}
+	variants := make([]*Variant, 0, len(results))
+	for _, v := range results {
+		variants = append(variants, &Variant{
+			ID:           v.ID,
+			AttachmentID: v.AttachmentID,
+			Name:         v.Name,
+			Key:          v.ObjectKey,
+			ContentType:  v.ContentType,
+			Width:        int(v.Width),
+			Height:       int(v.Height),
+			Size:         v.Size,
+			CreatedAt:    v.CreatedAt.Time,
+		})
+	}
+	return variants, nil
+}
+
+func (r *pgxRepository) ListVariantKeys(ctx context.Context, attachmentIDs []int64) ([]string, error) {
+	keys, err := r.q.ListVariantKeys(ctx, attachmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ListVariantKeys failed: %w", err)
+	}
+	return keys, nil
+}
+
+func toAttachments(results []sqlc.Attachment) []*Attachment {
+	items := make([]*Attachment, 0, len(results))
+	for _, result := range results {
+		items = append(items, toAttachment(result))
+	}
+	return items
+}
+
+func toAttachment(a sqlc.Attachment) *Attachment {
+	return &Attachment{
+		ID:          a.ID,
+		LabubuID:    int(a.LabubuID),
+		UploaderID:  int(a.UploaderID.Int32),
+		Key:         a.ObjectKey,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.Sha256.String,
+		Status:      Status(a.Status),
+		Width:       int(a.Width.Int32),
+		Height:      int(a.Height.Int32),
+		ImageStatus: ImageStatus(a.ImageStatus.String),
+		ImageError:  a.ImageError.String,
+		CreatedAt:   a.CreatedAt.Time,
+		UpdatedAt:   a.UpdatedAt.Time,
+	}
+}
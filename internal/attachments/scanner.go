@@ -0,0 +1,29 @@
+package attachments
+
+import (
+	"context"
+	"io"
+)
+
+// Scanner inspects the content of an upload before it becomes ready, e.g.
+// with a virus scanner. open returns the stored content and may be called
+// more than once.
+type Scanner interface {
+	// Scan returns an error wrapping ErrRejected to refuse the file; any other
+	// error fails the upload without deciding about the file
+	Scan(ctx context.Context, a *Attachment, open func(context.Context) (io.ReadCloser, error)) error
+}
+
+// ScanFunc adapts a function to Scanner
+type ScanFunc func(ctx context.Context, a *Attachment, open func(context.Context) (io.ReadCloser, error)) error
+
+func (f ScanFunc) Scan(ctx context.Context, a *Attachment, open func(context.Context) (io.ReadCloser, error)) error {
+	return f(ctx, a, open)
+}
+
+// NopScanner accepts every file without reading it
+type NopScanner struct{}
+
+func (NopScanner) Scan(context.Context, *Attachment, func(context.Context) (io.ReadCloser, error)) error {
+	return nil
+}
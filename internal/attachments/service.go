@@ -0,0 +1,537 @@
+package attachments
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/attachments")
+
+// maxFilenameLength is the longest filename kept, in bytes
+const maxFilenameLength = 255
+
+// abandonAfter is how long after its URL expired a direct upload that was
+// never completed is purged
+const abandonAfter = 24 * time.Hour
+
+// Config limits what can be attached
+type Config struct {
+	// MaxSize is the largest file accepted, in bytes
+	MaxSize int64
+	// AllowedTypes are media types such as "application/pdf", or "image/*"
+	// for every subtype
+	AllowedTypes []string
+	// URLTTL is how long presigned upload and download URLs stay valid
+	URLTTL time.Duration
+	// Sizes are the thumbnails written for every image
+	Sizes []ThumbnailSize
+	// MaxPixels is the largest image decoded; larger ones are marked failed
+	MaxPixels int
+}
+
+// ThumbnailSize names a thumbnail that fits in a Max by Max square
+type ThumbnailSize struct {
+	Name string
+	Max  int
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxSize <= 0 {
+		c.MaxSize = 25 << 20
+	}
+	if c.URLTTL <= 0 {
+		c.URLTTL = 15 * time.Minute
+	}
+	if c.MaxPixels <= 0 {
+		c.MaxPixels = 25_000_000
+	}
+	return c
+}
+
+// allows reports whether contentType is on the allowlist, ignoring parameters
+func (c Config) allows(contentType string) bool {
+	mediaType := mediaType(contentType)
+	for _, allowed := range c.AllowedTypes {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+		if strings.EqualFold(allowed, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteObjectsArgs is the job that removes the content of deleted attachments
+type DeleteObjectsArgs struct {
+	Keys []string `json:"keys"`
+}
+
+func (DeleteObjectsArgs) Kind() string { return "attachments.delete_objects" }
+
+// ProcessImageArgs is the job that strips the metadata of an image and writes its thumbnails
+type ProcessImageArgs struct {
+	AttachmentID int64 `json:"attachment_id"`
+}
+
+func (ProcessImageArgs) Kind() string { return "attachments.process_image" }
+
+// RegisterJobs adds the object cleanup and image processing handlers to registry
+func RegisterJobs(registry *jobs.Registry, svc Service) {
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args DeleteObjectsArgs) error {
+		return svc.DeleteObjects(ctx, args.Keys)
+	})
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args ProcessImageArgs) error {
+		return svc.ProcessImage(ctx, args.AttachmentID, job.Attempts >= job.MaxAttempts)
+	})
+}
+
+// Service defines the contract for attachment business logic. Only the owner
+// of a labubu sees its attachments; other callers get labubu.ErrNotFound.
+// Upload, CompleteUpload and ProcessImage write to storage and must not run
+// in a transaction that may be retried; Save is meant to run in one.
+type Service interface {
+	WithTx(tx pgx.Tx) Service
+	// Upload stores and scans the content of a new attachment; Save records it
+	Upload(ctx context.Context, req UploadRequest) (*Attachment, error)
+	// CreateUpload records a pending attachment and presigns the request that uploads it
+	CreateUpload(ctx context.Context, req CreateUploadRequest) (*Upload, error)
+	// CompleteUpload checks the uploaded content of a pending attachment; Save marks it ready
+	CompleteUpload(ctx context.Context, labubuID int, id int64, userID int) (*Attachment, error)
+	// Save records an attachment returned by Upload or CompleteUpload and
+	// enqueues the processing of images
+	Save(ctx context.Context, a *Attachment) (*Attachment, error)
+	// Discard deletes the content of an upload that was not saved
+	Discard(ctx context.Context, a *Attachment)
+	ListAttachments(ctx context.Context, labubuID, userID int) ([]*Attachment, error)
+	DownloadURL(ctx context.Context, labubuID int, id int64, userID int) (*storage.PresignedRequest, error)
+	// ListVariants returns an image attachment and URLs of its thumbnails once it is processed
+	ListVariants(ctx context.Context, labubuID int, id int64, userID int) (*Attachment, []VariantURL, error)
+	// DeleteAttachment removes the record and enqueues the removal of its content
+	DeleteAttachment(ctx context.Context, labubuID int, id int64, userID int) error
+	DeleteObjects(ctx context.Context, keys []string) error
+	// ProcessImage strips the metadata of an image attachment, reads its size
+	// and writes its thumbnails. Images that cannot be processed are marked;
+	// other errors are only recorded on the final attempt.
+	ProcessImage(ctx context.Context, id int64, final bool) error
+	// PurgeDeleted removes the attachments of labubu deleted before deletedBefore
+	// and abandoned direct uploads, content first so an interrupted run is repeated
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type service struct {
+	repo    Repository
+	labubu  labubu.Service
+	store   storage.Store
+	scanner Scanner
+	queue   jobs.Queue
+	config  Config
+}
+
+// NewService creates a new attachment service that keeps content in store.
+// A nil scanner accepts every file.
+func NewService(repo Repository, labubuService labubu.Service, store storage.Store, scanner Scanner, queue jobs.Queue, config Config) Service {
+	if scanner == nil {
+		scanner = NopScanner{}
+	}
+	return &service{
+		repo:    repo,
+		labubu:  labubuService,
+		store:   store,
+		scanner: scanner,
+		queue:   queue,
+		config:  config.withDefaults(),
+	}
+}
+
+func (s *service) WithTx(tx pgx.Tx) Service {
+	return &service{
+		repo:    s.repo.WithTx(tx),
+		labubu:  s.labubu.WithTx(tx),
+		store:   s.store,
+		scanner: s.scanner,
+		queue:   s.queue.WithTx(tx),
+		config:  s.config,
+	}
+}
+
+// authorize returns labubu.ErrNotFound unless userID owns the labubu
+func (s *service) authorize(ctx context.Context, labubuID, userID int) error {
+	l, err := s.labubu.GetLabubuByID(ctx, labubuID)
+	if err != nil {
+		return err
+	}
+	if l.OwnerID != userID {
+		return labubu.ErrNotFound
+	}
+	return nil
+}
+
+func (s *service) Upload(ctx context.Context, req UploadRequest) (_ *Attachment, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.Upload")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	filename, err := cleanFilename(req.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, req.LabubuID, req.UploaderID); err != nil {
+		return nil, err
+	}
+
+	contentType, body, err := storage.Sniff(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !s.config.allows(contentType) {
+		return nil, ErrTypeNotAllowed
+	}
+
+	a := &Attachment{
+		LabubuID:    req.LabubuID,
+		UploaderID:  req.UploaderID,
+		Filename:    filename,
+		ContentType: contentType,
+		Status:      StatusReady,
+	}
+	if a.Key, err = newKey(req.LabubuID); err != nil {
+		return nil, err
+	}
+
+	// One byte over the limit tells a body that is too large from one that fits exactly
+	obj, err := s.store.Put(ctx, a.Key, io.LimitReader(body, s.config.MaxSize+1), storage.PutOptions{ContentType: contentType})
+	if err != nil {
+		return nil, err
+	}
+	if obj.Size > s.config.MaxSize {
+		s.discard(ctx, a.Key)
+		return nil, ErrTooLarge
+	}
+	a.Size, a.SHA256 = obj.Size, obj.SHA256
+
+	if err := s.scan(ctx, a); err != nil {
+		s.discard(ctx, a.Key)
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *service) CreateUpload(ctx context.Context, req CreateUploadRequest) (_ *Upload, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.CreateUpload")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	filename, err := cleanFilename(req.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if req.Size <= 0 || req.Size > s.config.MaxSize {
+		return nil, ErrTooLarge
+	}
+	if _, _, err := mime.ParseMediaType(req.ContentType); err != nil || !s.config.allows(req.ContentType) {
+		return nil, ErrTypeNotAllowed
+	}
+	if err := s.authorize(ctx, req.LabubuID, req.UploaderID); err != nil {
+		return nil, err
+	}
+
+	key, err := newKey(req.LabubuID)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateAttachment(ctx, &Attachment{
+		LabubuID:    req.LabubuID,
+		UploaderID:  req.UploaderID,
+		Key:         key,
+		Filename:    filename,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		Status:      StatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.store.PresignPut(ctx, key, storage.PresignOptions{
+		TTL:         s.config.URLTTL,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Upload{Attachment: created, Request: upload}, nil
+}
+
+func (s *service) CompleteUpload(ctx context.Context, labubuID int, id int64, userID int) (_ *Attachment, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.CompleteUpload")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, userID); err != nil {
+		return nil, err
+	}
+	a, err := s.repo.GetAttachment(ctx, id, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	switch a.Status {
+	case StatusReady:
+		// Completing twice is harmless
+		return a, nil
+	case StatusRejected:
+		return nil, ErrRejected
+	}
+
+	obj, err := s.store.Stat(ctx, a.Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrNotUploaded
+	}
+	if err != nil {
+		return nil, err
+	}
+	if obj.Size != a.Size {
+		return nil, s.reject(ctx, a, fmt.Errorf("%w: size differs from the declared size", ErrRejected))
+	}
+
+	// The content, not the declared type, decides; both have to agree
+	contentType, sum, err := s.inspect(ctx, a.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !s.config.allows(contentType) || mediaType(contentType) != mediaType(a.ContentType) {
+		return nil, s.reject(ctx, a, ErrTypeNotAllowed)
+	}
+	a.SHA256 = sum
+
+	if err := s.scan(ctx, a); err != nil {
+		if errors.Is(err, ErrRejected) {
+			return nil, s.reject(ctx, a, err)
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *service) Save(ctx context.Context, a *Attachment) (_ *Attachment, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.Save")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if strings.HasPrefix(mediaType(a.ContentType), "image/") {
+		a.ImageStatus = ImagePending
+	}
+
+	var saved *Attachment
+	if a.ID == 0 {
+		saved, err = s.repo.CreateAttachment(ctx, a)
+	} else {
+		saved, err = s.repo.CompleteAttachment(ctx, a)
+		if errors.Is(err, ErrNotFound) {
+			// Completed before, which enqueued the processing then
+			return s.repo.GetAttachment(ctx, a.ID, a.LabubuID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if saved.ImageStatus == ImagePending {
+		_, err = s.queue.Enqueue(ctx, ProcessImageArgs{AttachmentID: saved.ID},
+			jobs.WithUniqueKey("attachment-image:"+strconv.FormatInt(saved.ID, 10)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return saved, nil
+}
+
+func (s *service) Discard(ctx context.Context, a *Attachment) {
+	s.discard(ctx, a.Key)
+}
+
+// inspect reads the stored content once to detect its type and digest
+func (s *service) inspect(ctx context.Context, key string) (contentType, sum string, err error) {
+	body, _, err := s.store.Get(ctx, key)
+	if err != nil {
+		return "", "", err
+	}
+	defer body.Close()
+
+	contentType, content, err := storage.Sniff(body)
+	if err != nil {
+		return "", "", err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", "", fmt.Errorf("reading %s: %w", key, err)
+	}
+	return contentType, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// scan runs the scanner; a refusal always wraps ErrRejected
+func (s *service) scan(ctx context.Context, a *Attachment) error {
+	err := s.scanner.Scan(ctx, a, func(ctx context.Context) (io.ReadCloser, error) {
+		body, _, err := s.store.Get(ctx, a.Key)
+		return body, err
+	})
+	if err != nil && !errors.Is(err, ErrRejected) {
+		return fmt.Errorf("scanning attachment: %w", err)
+	}
+	return err
+}
+
+// reject records that a pending upload was refused for reason and deletes its content
+func (s *service) reject(ctx context.Context, a *Attachment, reason error) error {
+	slog.InfoContext(ctx, "Rejected attachment", "attachment_id", a.ID, "reason", reason)
+	if err := s.repo.RejectAttachment(ctx, a.ID); err != nil {
+		return err
+	}
+	s.discard(ctx, a.Key)
+	return reason
+}
+
+// discard deletes content that will not be attached; an object left behind is
+// only wasted space, so failures are logged
+func (s *service) discard(ctx context.Context, key string) {
+	if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+		slog.WarnContext(ctx, "Failed to delete discarded attachment content", "key", key, "error", err)
+	}
+}
+
+func (s *service) ListAttachments(ctx context.Context, labubuID, userID int) (_ []*Attachment, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.ListAttachments")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAttachments(ctx, labubuID)
+}
+
+func (s *service) DownloadURL(ctx context.Context, labubuID int, id int64, userID int) (_ *storage.PresignedRequest, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.DownloadURL")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, userID); err != nil {
+		return nil, err
+	}
+	a, err := s.repo.GetAttachment(ctx, id, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != StatusReady {
+		return nil, ErrNotReady
+	}
+	return s.store.PresignGet(ctx, a.Key, storage.PresignOptions{
+		TTL:      s.config.URLTTL,
+		Filename: a.Filename,
+	})
+}
+
+func (s *service) DeleteAttachment(ctx context.Context, labubuID int, id int64, userID int) (err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.DeleteAttachment")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, userID); err != nil {
+		return err
+	}
+	// The variants go with the record, so their keys are read first
+	keys, err := s.repo.ListVariantKeys(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteAttachment(ctx, id, labubuID)
+	if err != nil {
+		return err
+	}
+	_, err = s.queue.Enqueue(ctx, DeleteObjectsArgs{Keys: append(keys, deleted.Key)})
+	return err
+}
+
+func (s *service) DeleteObjects(ctx context.Context, keys []string) (err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.DeleteObjects")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "attachments.Service.PurgeDeleted")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	purgeable, err := s.repo.ListPurgeableAttachments(ctx, deletedBefore, time.Now().Add(-s.config.URLTTL-abandonAfter))
+	if err != nil {
+		return 0, err
+	}
+	if len(purgeable) == 0 {
+		return 0, nil
+	}
+	ids := make([]int64, 0, len(purgeable))
+	keys := make([]string, 0, len(purgeable))
+	for _, a := range purgeable {
+		ids = append(ids, a.ID)
+		keys = append(keys, a.Key)
+	}
+	variantKeys, err := s.repo.ListVariantKeys(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.DeleteObjects(ctx, append(variantKeys, keys...)); err != nil {
+		return 0, err
+	}
+	return s.repo.DeleteAttachments(ctx, ids)
+}
+
+// newKey returns an unguessable object key under the labubu's prefix. The
+// filename stays out of the key, so any name is safe to keep.
+func newKey(labubuID int) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "attachments/" + strconv.Itoa(labubuID) + "/" + hex.EncodeToString(b), nil
+}
+
+// cleanFilename keeps the last path element of a client's filename
+func cleanFilename(name string) (string, error) {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" || name == ".." || len(name) > maxFilenameLength || !utf8.ValidString(name) {
+		return "", ErrInvalidFilename
+	}
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return "", ErrInvalidFilename
+	}
+	return name, nil
+}
+
+// mediaType drops the parameters of a content type, e.g. "; charset=utf-8"
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
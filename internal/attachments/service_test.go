@@ -0,0 +1,425 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+)
+
+const (
+	ownerID  = 1
+	labubuID = 7
+)
+
+// png is the smallest content sniffed as image/png
+var png = []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 32))
+
+// memoryRepository keeps attachments in memory the way the queries treat them
+type memoryRepository struct {
+	Repository
+
+	attachments map[int64]*Attachment
+	purgeable   []*Attachment
+	purged      []int64
+}
+
+func (r *memoryRepository) CreateAttachment(_ context.Context, a *Attachment) (*Attachment, error) {
+	if r.attachments == nil {
+		r.attachments = make(map[int64]*Attachment)
+	}
+	created := *a
+	created.ID = int64(len(r.attachments) + 1)
+	r.attachments[created.ID] = &created
+	copied := created
+	return &copied, nil
+}
+
+func (r *memoryRepository) GetAttachment(_ context.Context, id int64, labubuID int) (*Attachment, error) {
+	a, ok := r.attachments[id]
+	if !ok || a.LabubuID != labubuID {
+		return nil, ErrNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (r *memoryRepository) CompleteAttachment(_ context.Context, a *Attachment) (*Attachment, error) {
+	stored, ok := r.attachments[a.ID]
+	if !ok || stored.Status != StatusPending {
+		return nil, ErrNotFound
+	}
+	stored.ContentType, stored.Size, stored.SHA256, stored.ImageStatus = a.ContentType, a.Size, a.SHA256, a.ImageStatus
+	stored.Status = StatusReady
+	copied := *stored
+	return &copied, nil
+}
+
+func (r *memoryRepository) RejectAttachment(_ context.Context, id int64) error {
+	r.attachments[id].Status = StatusRejected
+	return nil
+}
+
+func (r *memoryRepository) DeleteAttachment(_ context.Context, id int64, labubuID int) (*Attachment, error) {
+	a, ok := r.attachments[id]
+	if !ok || a.LabubuID != labubuID {
+		return nil, ErrNotFound
+	}
+	delete(r.attachments, id)
+	return a, nil
+}
+
+func (r *memoryRepository) ListPurgeableAttachments(context.Context, time.Time, time.Time) ([]*Attachment, error) {
+	return r.purgeable, nil
+}
+
+func (r *memoryRepository) ListVariantKeys(context.Context, []int64) ([]string, error) {
+	return nil, nil
+}
+
+func (r *memoryRepository) DeleteAttachments(_ context.Context, ids []int64) (int64, error) {
+	r.purged = append(r.purged, ids...)
+	return int64(len(ids)), nil
+}
+
+// labubuService knows a single labubu owned by ownerID
+type labubuService struct {
+	labubu.Service
+}
+
+func (labubuService) GetLabubuByID(_ context.Context, id int) (*labubu.Labubu, error) {
+	if id != labubuID {
+		return nil, labubu.ErrNotFound
+	}
+	return &labubu.Labubu{ID: labubuID, OwnerID: ownerID}, nil
+}
+
+// recordingQueue records enqueued jobs instead of running them
+type recordingQueue struct {
+	jobs.Queue
+	enqueued []jobs.Args
+}
+
+func (q *recordingQueue) Enqueue(_ context.Context, args jobs.Args, _ ...jobs.EnqueueOption) (*jobs.Job, error) {
+	q.enqueued = append(q.enqueued, args)
+	return &jobs.Job{}, nil
+}
+
+type fixture struct {
+	svc   Service
+	repo  *memoryRepository
+	store *storage.Local
+	queue *recordingQueue
+}
+
+func newFixture(t *testing.T, scanner Scanner) *fixture {
+	t.Helper()
+	f := &fixture{
+		repo:  &memoryRepository{},
+		store: storage.NewLocal(storage.LocalConfig{Dir: t.TempDir(), URL: "http://localhost/storage", Secret: []byte("test-secret")}),
+		queue: &recordingQueue{},
+	}
+	f.svc = NewService(f.repo, labubuService{}, f.store, scanner, f.queue, Config{
+		MaxSize:      64,
+		AllowedTypes: []string{"image/*", "application/pdf"},
+	})
+	return f
+}
+
+// objects returns the keys left in storage
+func (f *fixture) objects(t *testing.T) []string {
+	t.Helper()
+	objects, err := f.store.List(context.Background(), "attachments/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+func TestUpload(t *testing.T) {
+	refuse := ScanFunc(func(context.Context, *Attachment, func(context.Context) (io.ReadCloser, error)) error {
+		return fmt.Errorf("%w: infected", ErrRejected)
+	})
+
+	tests := []struct {
+		name     string
+		uploader int
+		filename string
+		body     []byte
+		scanner  Scanner
+		wantErr  error
+	}{
+		{name: "image", uploader: ownerID, filename: "dir/photo.png", body: png},
+		{name: "not the owner", uploader: 2, filename: "photo.png", body: png, wantErr: labubu.ErrNotFound},
+		{name: "type not allowed", uploader: ownerID, filename: "notes.png", body: []byte("plain text"), wantErr: ErrTypeNotAllowed},
+		{name: "too large", uploader: ownerID, filename: "photo.png", body: append(bytes.Clone(png), make([]byte, 64)...), wantErr: ErrTooLarge},
+		{name: "refused by the scanner", uploader: ownerID, filename: "photo.png", body: png, scanner: refuse, wantErr: ErrRejected},
+		{name: "invalid filename", uploader: ownerID, filename: "..", body: png, wantErr: ErrInvalidFilename},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.scanner)
+			a, err := f.svc.Upload(context.Background(), UploadRequest{
+				LabubuID:   labubuID,
+				UploaderID: tt.uploader,
+				Filename:   tt.filename,
+				Body:       bytes.NewReader(tt.body),
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Upload() = %v, want %v", err, tt.wantErr)
+				}
+				if keys := f.objects(t); len(keys) != 0 {
+					t.Errorf("content left in storage after a failed upload: %v", keys)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sum := sha256.Sum256(tt.body)
+			if a.Status != StatusReady || a.ContentType != "image/png" || a.Filename != "photo.png" ||
+				a.Size != int64(len(tt.body)) || a.SHA256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("Upload() = %+v, want a ready image with its size and digest", a)
+			}
+			if _, err := f.store.Stat(context.Background(), a.Key); err != nil {
+				t.Errorf("content not stored: %v", err)
+			}
+			if len(f.repo.attachments) != 0 {
+				t.Fatal("Upload() recorded the attachment before Save")
+			}
+
+			saved, err := f.svc.Save(context.Background(), a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.ID == 0 || saved.ImageStatus != ImagePending {
+				t.Errorf("Save() = %+v, want a recorded image pending processing", saved)
+			}
+			if len(f.queue.enqueued) != 1 || f.queue.enqueued[0] != (ProcessImageArgs{AttachmentID: saved.ID}) {
+				t.Errorf("enqueued %+v, want the processing of the image", f.queue.enqueued)
+			}
+		})
+	}
+}
+
+func TestDirectUpload(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		size        int64
+		// uploaded is what the client sends to the presigned URL; nil sends nothing
+		uploaded   []byte
+		wantErr    error
+		wantStatus Status
+	}{
+		{name: "completed", contentType: "image/png", size: int64(len(png)), uploaded: png, wantStatus: StatusReady},
+		{name: "not uploaded yet", contentType: "image/png", size: int64(len(png)), wantErr: ErrNotUploaded, wantStatus: StatusPending},
+		{name: "size differs", contentType: "image/png", size: 10, uploaded: png, wantErr: ErrRejected, wantStatus: StatusRejected},
+		{name: "content is not the declared type", contentType: "application/pdf", size: int64(len(png)), uploaded: png, wantErr: ErrTypeNotAllowed, wantStatus: StatusRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, nil)
+			ctx := context.Background()
+			upload, err := f.svc.CreateUpload(ctx, CreateUploadRequest{
+				LabubuID:    labubuID,
+				UploaderID:  ownerID,
+				Filename:    "photo.png",
+				ContentType: tt.contentType,
+				Size:        tt.size,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if upload.Attachment.Status != StatusPending || upload.Request.Method != "PUT" {
+				t.Fatalf("CreateUpload() = %+v, want a pending attachment and a presigned PUT", upload)
+			}
+
+			key := f.repo.attachments[upload.Attachment.ID].Key
+			if tt.uploaded != nil {
+				if _, err := f.store.Put(ctx, key, bytes.NewReader(tt.uploaded), storage.PutOptions{ContentType: tt.contentType}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			a, err := f.svc.CompleteUpload(ctx, labubuID, upload.Attachment.ID, ownerID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CompleteUpload() = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if a, err = f.svc.Save(ctx, a); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := f.repo.attachments[upload.Attachment.ID].Status; got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+			if tt.wantStatus == StatusRejected && len(f.objects(t)) != 0 {
+				t.Error("rejected content left in storage")
+			}
+			if tt.wantErr == nil && a.SHA256 == "" {
+				t.Errorf("CompleteUpload() = %+v, want the digest of the content", a)
+			}
+		})
+	}
+}
+
+func TestCreateUploadLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		size        int64
+		uploader    int
+		wantErr     error
+	}{
+		{"too large", "image/png", 65, ownerID, ErrTooLarge},
+		{"empty", "image/png", 0, ownerID, ErrTooLarge},
+		{"type not allowed", "text/html", 10, ownerID, ErrTypeNotAllowed},
+		{"invalid type", "image/png; x", 10, ownerID, ErrTypeNotAllowed},
+		{"not the owner", "image/png", 10, 2, labubu.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, nil)
+			_, err := f.svc.CreateUpload(context.Background(), CreateUploadRequest{
+				LabubuID:    labubuID,
+				UploaderID:  tt.uploader,
+				Filename:    "photo.png",
+				ContentType: tt.contentType,
+				Size:        tt.size,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateUpload() = %v, want %v", err, tt.wantErr)
+			}
+			if len(f.repo.attachments) != 0 {
+				t.Error("attachment recorded for a refused upload")
+			}
+		})
+	}
+}
+
+// save uploads png and records it
+func (f *fixture) save(t *testing.T) *Attachment {
+	t.Helper()
+	ctx := context.Background()
+	a, err := f.svc.Upload(ctx, UploadRequest{LabubuID: labubuID, UploaderID: ownerID, Filename: "photo.png", Body: bytes.NewReader(png)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, err = f.svc.Save(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	f.queue.enqueued = nil
+	return a
+}
+
+func TestDeleteAndPurge(t *testing.T) {
+	f := newFixture(t, nil)
+	ctx := context.Background()
+	a := f.save(t)
+
+	if err := f.svc.DeleteAttachment(ctx, labubuID, a.ID, 2); !errors.Is(err, labubu.ErrNotFound) {
+		t.Fatalf("DeleteAttachment() by another user = %v, want labubu.ErrNotFound", err)
+	}
+	if err := f.svc.DeleteAttachment(ctx, labubuID, a.ID, ownerID); err != nil {
+		t.Fatal(err)
+	}
+	// The content goes in a job, after the record is gone
+	if len(f.queue.enqueued) != 1 {
+		t.Fatalf("enqueued %v, want one cleanup job", f.queue.enqueued)
+	}
+	args, ok := f.queue.enqueued[0].(DeleteObjectsArgs)
+	if !ok || len(args.Keys) != 1 || args.Keys[0] != a.Key {
+		t.Fatalf("enqueued %+v, want the key of the deleted attachment", f.queue.enqueued[0])
+	}
+	if err := f.svc.DeleteObjects(ctx, args.Keys); err != nil {
+		t.Fatal(err)
+	}
+	if keys := f.objects(t); len(keys) != 0 {
+		t.Errorf("content left in storage after the cleanup job: %v", keys)
+	}
+
+	// Purging removes content first, then the records
+	b := f.save(t)
+	f.repo.purgeable = []*Attachment{b}
+	n, err := f.svc.PurgeDeleted(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || len(f.repo.purged) != 1 || f.repo.purged[0] != b.ID {
+		t.Errorf("PurgeDeleted() = %d purging %v, want attachment %d", n, f.repo.purged, b.ID)
+	}
+	if keys := f.objects(t); len(keys) != 0 {
+		t.Errorf("content left in storage after the purge: %v", keys)
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"plain", "photo.png", "photo.png", false},
+		{"unix path", "../../etc/passwd", "passwd", false},
+		{"windows path", `C:\Users\me\photo.png`, "photo.png", false},
+		{"surrounding space", "  photo.png ", "photo.png", false},
+		{"unicode", "fotoğraf.png", "fotoğraf.png", false},
+		{"empty", "", "", true},
+		{"parent", "..", "", true},
+		{"control character", "photo\x00.png", "", true},
+		{"invalid utf-8", "photo\xff.png", "", true},
+		{"too long", strings.Repeat("a", maxFilenameLength+1), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cleanFilename(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFilename) {
+					t.Errorf("cleanFilename(%q) = %q, %v, want ErrInvalidFilename", tt.in, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("cleanFilename(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigAllows(t *testing.T) {
+	c := Config{AllowedTypes: []string{"image/*", "application/pdf"}}
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"image/png", true},
+		{"image/jpeg", true},
+		{"application/pdf", true},
+		{"Application/PDF", true},
+		{"text/plain; charset=utf-8", false},
+		{"application/pdfx", false},
+	}
+	for _, tt := range tests {
+		if got := c.allows(tt.contentType); got != tt.want {
+			t.Errorf("allows(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,73 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// User represents an account that can log in
+type User struct {
+	ID           int        `json:"id"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+	// EmailVerifiedAt is set once the user confirmed their address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Disabled reports whether the account has been disabled
+func (u *User) Disabled() bool {
+	return u.DisabledAt != nil
+}
+
+// EmailVerified reports whether the user confirmed their email address
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// TokenPurpose is what a mailed single-use token may be used for
+type TokenPurpose string
+
+const (
+	PurposePasswordReset     TokenPurpose = "password_reset"
+	PurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// Session represents an issued refresh token
+type Session struct {
+	ID        int64      `json:"id"`
+	UserID    int        `json:"user_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LoginAttempt tracks the failed logins for an email, whether or not an
+// account exists for it
+type LoginAttempt struct {
+	Email         string     `json:"email"`
+	Failures      int        `json:"failures"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	LastFailedAt  time.Time  `json:"last_failed_at"`
+}
+
+// ThrottledError is returned by Login while an email has to wait before the
+// next attempt. It is returned the same way for unknown emails.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("too many failed logins, retry after %s", e.RetryAfter)
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrLoginThrottled
+}
 
 // LoginResponse represents the response from login
 type LoginResponse struct {
@@ -8,9 +75,30 @@ type LoginResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// LoginRequest represents the credentials sent to login
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// CreateUserRequest represents the request to create a user. Unless
+// Verified is set, a verification email is sent to the address.
+type CreateUserRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Verified bool   `json:"verified"`
+}
+
 // Common errors
 var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrExpiredToken = errors.New("token expired")
-	ErrNotFound     = errors.New("not found")
-)
\ No newline at end of file
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrExpiredToken       = errors.New("token expired")
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserDisabled       = errors.New("user disabled")
+	ErrEmailTaken         = errors.New("email already registered")
+	ErrInvalidEmail       = errors.New("invalid email")
+	ErrWeakPassword       = errors.New("password must be at least 8 characters")
+	ErrLoginThrottled     = errors.New("too many failed logins")
+	ErrAlreadyVerified    = errors.New("email already verified")
+)
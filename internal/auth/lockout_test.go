@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+)
+
+// memoryRepository keeps users and login attempts in memory, reserving
+// attempts the way the ReserveLoginAttempt query does
+type memoryRepository struct {
+	Repository
+
+	mu       sync.Mutex
+	users    map[string]*User
+	attempts map[string]*LoginAttempt
+}
+
+func (r *memoryRepository) GetUserByEmail(_ context.Context, email string) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[email]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return user, nil
+}
+
+func (r *memoryRepository) GetLoginAttempt(_ context.Context, email string) (*LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.attempts[email]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (r *memoryRepository) ReserveLoginAttempt(_ context.Context, email string, holdUntil, resetBefore time.Time) (*LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	a, ok := r.attempts[email]
+	switch {
+	case !ok:
+		a = &LoginAttempt{Email: email}
+		r.attempts[email] = a
+	case a.NextAttemptAt != nil && a.NextAttemptAt.After(now), a.LockedUntil != nil && a.LockedUntil.After(now):
+		return nil, ErrLoginThrottled
+	case a.LastFailedAt.Before(resetBefore) && (a.LockedUntil == nil || a.LockedUntil.Before(resetBefore)):
+		a.Failures = 0
+	}
+	a.Failures++
+	a.NextAttemptAt, a.LockedUntil, a.LastFailedAt = &holdUntil, nil, now
+	copied := *a
+	return &copied, nil
+}
+
+func (r *memoryRepository) BlockLoginAttempts(_ context.Context, email string, nextAttemptAt, lockedUntil *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a := r.attempts[email]
+	a.NextAttemptAt, a.LockedUntil = nextAttemptAt, lockedUntil
+	return nil
+}
+
+// recordingQueue records enqueued jobs instead of running them
+type recordingQueue struct {
+	jobs.Queue
+	enqueued []jobs.Args
+}
+
+func (q *recordingQueue) Enqueue(_ context.Context, args jobs.Args, _ ...jobs.EnqueueOption) (*jobs.Job, error) {
+	q.enqueued = append(q.enqueued, args)
+	return &jobs.Job{}, nil
+}
+
+func newLoginService(t *testing.T, lockout LockoutConfig) (Service, *memoryRepository, *recordingQueue) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &memoryRepository{
+		users:    map[string]*User{"ada@example.com": {ID: 1, Email: "ada@example.com", PasswordHash: string(hash)}},
+		attempts: make(map[string]*LoginAttempt),
+	}
+	queue := &recordingQueue{}
+	return NewService(repo, nil, queue, Config{Lockout: lockout}), repo, queue
+}
+
+func TestLockoutBlock(t *testing.T) {
+	config := LockoutConfig{
+		DelayAfter: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   10 * time.Second,
+		LockAfter:  10,
+		Duration:   15 * time.Minute,
+	}
+
+	tests := []struct {
+		name       string
+		config     LockoutConfig
+		failures   int
+		wantWait   time.Duration
+		wantLocked bool
+	}{
+		{"no failures", config, 0, 0, false},
+		{"below the delay threshold", config, 2, 0, false},
+		{"first delay", config, 3, time.Second, false},
+		{"doubles", config, 4, 2 * time.Second, false},
+		{"doubles again", config, 5, 4 * time.Second, false},
+		{"capped at the max delay", config, 7, 10 * time.Second, false},
+		{"just before the lock", config, 9, 10 * time.Second, false},
+		{"locked", config, 10, 15 * time.Minute, true},
+		{"stays locked", config, 25, 15 * time.Minute, true},
+		{"delays disabled", LockoutConfig{LockAfter: 5, Duration: time.Hour}, 4, 0, false},
+		{"lockout disabled", LockoutConfig{DelayAfter: 1, BaseDelay: time.Second, MaxDelay: time.Minute}, 100, time.Minute, false},
+		{"nothing configured", LockoutConfig{}, 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, locked := tt.config.block(tt.failures)
+			if wait != tt.wantWait || locked != tt.wantLocked {
+				t.Errorf("block(%d) = %v, %v; want %v, %v", tt.failures, wait, locked, tt.wantWait, tt.wantLocked)
+			}
+		})
+	}
+}
+
+func TestLoginParallelGuesses(t *testing.T) {
+	svc, repo, _ := newLoginService(t, LockoutConfig{DelayAfter: 1, BaseDelay: time.Minute, MaxDelay: time.Hour})
+
+	const guesses = 8
+	errs := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for range guesses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Login(context.Background(), LoginRequest{Email: "ada@example.com", Password: "guess"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Only the attempt that reserved the email gets its password compared
+	var compared, throttled int
+	for err := range errs {
+		switch {
+		case errors.Is(err, ErrInvalidCredentials):
+			compared++
+		case errors.Is(err, ErrLoginThrottled):
+			throttled++
+		default:
+			t.Errorf("Login() = %v, want invalid credentials or throttled", err)
+		}
+	}
+	if compared != 1 || throttled != guesses-1 {
+		t.Errorf("compared %d and throttled %d guesses, want 1 and %d", compared, throttled, guesses-1)
+	}
+	if a := repo.attempts["ada@example.com"]; a.Failures != 1 || a.NextAttemptAt == nil || time.Until(*a.NextAttemptAt) < 59*time.Second {
+		t.Errorf("attempt = %+v, want one failure delayed by the base delay", a)
+	}
+}
+
+func TestLoginReleasesHold(t *testing.T) {
+	svc, repo, _ := newLoginService(t, LockoutConfig{DelayAfter: 3, BaseDelay: time.Minute, MaxDelay: time.Hour, ResetAfter: time.Hour})
+
+	for i := 1; i <= 2; i++ {
+		_, err := svc.Login(context.Background(), LoginRequest{Email: "ada@example.com", Password: "guess"})
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("failure %d: Login() = %v, want ErrInvalidCredentials", i, err)
+		}
+		if a := repo.attempts["ada@example.com"]; a.Failures != i || a.NextAttemptAt != nil {
+			t.Errorf("failure %d: attempt = %+v, want the hold released", i, a)
+		}
+	}
+}
+
+func TestLoginLockoutEscalates(t *testing.T) {
+	svc, repo, queue := newLoginService(t, LockoutConfig{LockAfter: 3, Duration: time.Minute, ResetAfter: time.Hour})
+
+	// The lockout ended a while ago, but the failures before it still count
+	lockedUntil := time.Now().Add(-time.Second)
+	repo.attempts["ada@example.com"] = &LoginAttempt{
+		Email:        "ada@example.com",
+		Failures:     3,
+		LockedUntil:  &lockedUntil,
+		LastFailedAt: time.Now().Add(-2 * time.Hour),
+	}
+
+	_, err := svc.Login(context.Background(), LoginRequest{Email: "ada@example.com", Password: "guess"})
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("Login() = %v, want ErrInvalidCredentials", err)
+	}
+	a := repo.attempts["ada@example.com"]
+	if a.Failures != 4 || a.LockedUntil == nil || time.Until(*a.LockedUntil) < 59*time.Second {
+		t.Errorf("attempt = %+v, want locked again with the failures kept", a)
+	}
+	if len(queue.enqueued) != 1 {
+		t.Errorf("enqueued %d emails, want the lockout email", len(queue.enqueued))
+	}
+
+	_, err = svc.Login(context.Background(), LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	var throttled *ThrottledError
+	if !errors.As(err, &throttled) || throttled.RetryAfter < 59*time.Second {
+		t.Errorf("Login() = %v, want throttled until the lockout ends", err)
+	}
+}
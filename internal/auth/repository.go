@@ -1,15 +1,44 @@
 package auth
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Repository defines the contract for auth data operations
 type Repository interface {
 	WithTx(tx pgx.Tx) Repository
-	// Add methods as needed for auth operations
+	CreateUser(ctx context.Context, email, passwordHash string, verified bool) (*User, error)
+	GetUserByID(ctx context.Context, id int) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	DisableUser(ctx context.Context, id int) (*User, error)
+	UpdateUserPassword(ctx context.Context, id int, passwordHash string) (*User, error)
+	MarkEmailVerified(ctx context.Context, id int) (*User, error)
+	CreateSession(ctx context.Context, userID int, refreshTokenHash string, expiresAt time.Time) (*Session, error)
+	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*Session, error)
+	RevokeSession(ctx context.Context, id int64) error
+	RevokeUserSessions(ctx context.Context, userID int) error
+	DeleteStaleSessions(ctx context.Context, before time.Time) (int64, error)
+	CreateUserToken(ctx context.Context, userID int, purpose TokenPurpose, tokenHash string, expiresAt time.Time) error
+	ConsumeUserToken(ctx context.Context, tokenHash string, purpose TokenPurpose) (userID int, err error)
+	InvalidateUserTokens(ctx context.Context, userID int, purpose TokenPurpose) error
+	DeleteStaleUserTokens(ctx context.Context, before time.Time) (int64, error)
+	GetLoginAttempt(ctx context.Context, email string) (*LoginAttempt, error)
+	// ReserveLoginAttempt counts an attempt as failed and holds the email until
+	// holdUntil; ErrLoginThrottled while the email is delayed or locked
+	ReserveLoginAttempt(ctx context.Context, email string, holdUntil, resetBefore time.Time) (*LoginAttempt, error)
+	BlockLoginAttempts(ctx context.Context, email string, nextAttemptAt, lockedUntil *time.Time) error
+	DeleteLoginAttempts(ctx context.Context, email string) (int64, error)
+	ListLockedLogins(ctx context.Context) ([]*LoginAttempt, error)
+	DeleteStaleLoginAttempts(ctx context.Context, before time.Time) (int64, error)
 }
 
 type pgxRepository struct {
@@ -27,4 +56,269 @@ func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
 	return &pgxRepository{
 		q: r.q.WithTx(tx),
 	}
-}
\ No newline at end of file
+}
+
+func (r *pgxRepository) CreateUser(ctx context.Context, email, passwordHash string, verified bool) (*User, error) {
+	var verifiedAt *time.Time
+	if verified {
+		now := time.Now()
+		verifiedAt = &now
+	}
+	result, err := r.q.CreateUser(ctx, sqlc.CreateUserParams{
+		Email:           email,
+		PasswordHash:    passwordHash,
+		EmailVerifiedAt: timestamptz(verifiedAt),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrEmailTaken
+		}
+		return nil, fmt.Errorf("CreateUser failed: %w", err)
+	}
+	return toUser(result), nil
+}
+
+func (r *pgxRepository) GetUserByID(ctx context.Context, id int) (*User, error) {
+	result, err := r.q.GetUserByID(ctx, int32(id))
+	if err != nil {
+		return nil, wrapNotFound("GetUserByID", err)
+	}
+	return toUser(result), nil
+}
+
+func (r *pgxRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	result, err := r.q.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, wrapNotFound("GetUserByEmail", err)
+	}
+	return toUser(result), nil
+}
+
+func (r *pgxRepository) DisableUser(ctx context.Context, id int) (*User, error) {
+	result, err := r.q.DisableUser(ctx, int32(id))
+	if err != nil {
+		return nil, wrapNotFound("DisableUser", err)
+	}
+	return toUser(result), nil
+}
+
+func (r *pgxRepository) UpdateUserPassword(ctx context.Context, id int, passwordHash string) (*User, error) {
+	result, err := r.q.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		ID:           int32(id),
+		PasswordHash: passwordHash,
+	})
+	if err != nil {
+		return nil, wrapNotFound("UpdateUserPassword", err)
+	}
+	return toUser(result), nil
+}
+
+func (r *pgxRepository) MarkEmailVerified(ctx context.Context, id int) (*User, error) {
+	result, err := r.q.MarkEmailVerified(ctx, int32(id))
+	if err != nil {
+		return nil, wrapNotFound("MarkEmailVerified", err)
+	}
+	return toUser(result), nil
+}
+
+func (r *pgxRepository) CreateSession(ctx context.Context, userID int, refreshTokenHash string, expiresAt time.Time) (*Session, error) {
+	result, err := r.q.CreateSession(ctx, sqlc.CreateSessionParams{
+		UserID:           int32(userID),
+		RefreshTokenHash: refreshTokenHash,
+		ExpiresAt:        pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateSession failed: %w", err)
+	}
+	return toSession(result), nil
+}
+
+func (r *pgxRepository) GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*Session, error) {
+	result, err := r.q.GetSessionByRefreshTokenHash(ctx, refreshTokenHash)
+	if err != nil {
+		return nil, wrapNotFound("GetSessionByRefreshTokenHash", err)
+	}
+	return toSession(result), nil
+}
+
+func (r *pgxRepository) RevokeSession(ctx context.Context, id int64) error {
+	if err := r.q.RevokeSession(ctx, id); err != nil {
+		return fmt.Errorf("RevokeSession failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) RevokeUserSessions(ctx context.Context, userID int) error {
+	if err := r.q.RevokeUserSessions(ctx, int32(userID)); err != nil {
+		return fmt.Errorf("RevokeUserSessions failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) DeleteStaleSessions(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.DeleteStaleSessions(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("DeleteStaleSessions failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) CreateUserToken(ctx context.Context, userID int, purpose TokenPurpose, tokenHash string, expiresAt time.Time) error {
+	_, err := r.q.CreateUserToken(ctx, sqlc.CreateUserTokenParams{
+		UserID:    int32(userID),
+		Purpose:   string(purpose),
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("CreateUserToken failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ConsumeUserToken(ctx context.Context, tokenHash string, purpose TokenPurpose) (int, error) {
+	result, err := r.q.ConsumeUserToken(ctx, sqlc.ConsumeUserTokenParams{
+		TokenHash: tokenHash,
+		Purpose:   string(purpose),
+	})
+	if err != nil {
+		return 0, wrapNotFound("ConsumeUserToken", err)
+	}
+	return int(result.UserID), nil
+}
+
+func (r *pgxRepository) InvalidateUserTokens(ctx context.Context, userID int, purpose TokenPurpose) error {
+	err := r.q.InvalidateUserTokens(ctx, sqlc.InvalidateUserTokensParams{
+		UserID:  int32(userID),
+		Purpose: string(purpose),
+	})
+	if err != nil {
+		return fmt.Errorf("InvalidateUserTokens failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) DeleteStaleUserTokens(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.DeleteStaleUserTokens(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("DeleteStaleUserTokens failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) GetLoginAttempt(ctx context.Context, email string) (*LoginAttempt, error) {
+	result, err := r.q.GetLoginAttempt(ctx, email)
+	if err != nil {
+		return nil, wrapNotFound("GetLoginAttempt", err)
+	}
+	return toLoginAttempt(result), nil
+}
+
+func (r *pgxRepository) ReserveLoginAttempt(ctx context.Context, email string, holdUntil, resetBefore time.Time) (*LoginAttempt, error) {
+	result, err := r.q.ReserveLoginAttempt(ctx, sqlc.ReserveLoginAttemptParams{
+		Email:       email,
+		HoldUntil:   pgtype.Timestamptz{Time: holdUntil, Valid: true},
+		ResetBefore: pgtype.Timestamptz{Time: resetBefore, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLoginThrottled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ReserveLoginAttempt failed: %w", err)
+	}
+	return toLoginAttempt(result), nil
+}
+
+func (r *pgxRepository) BlockLoginAttempts(ctx context.Context, email string, nextAttemptAt, lockedUntil *time.Time) error {
+	err := r.q.BlockLoginAttempts(ctx, sqlc.BlockLoginAttemptsParams{
+		Email:         email,
+		NextAttemptAt: timestamptz(nextAttemptAt),
+		LockedUntil:   timestamptz(lockedUntil),
+	})
+	if err != nil {
+		return fmt.Errorf("BlockLoginAttempts failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) DeleteLoginAttempts(ctx context.Context, email string) (int64, error) {
+	n, err := r.q.DeleteLoginAttempts(ctx, email)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteLoginAttempts failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) ListLockedLogins(ctx context.Context) ([]*LoginAttempt, error) {
+	results, err := r.q.ListLockedLogins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListLockedLogins failed: %w", err)
+	}
+	attempts := make([]*LoginAttempt, len(results))
+	for i, result := range results {
+		attempts[i] = toLoginAttempt(result)
+	}
+	return attempts, nil
+}
+
+func (r *pgxRepository) DeleteStaleLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.DeleteStaleLoginAttempts(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("DeleteStaleLoginAttempts failed: %w", err)
+	}
+	return n, nil
+}
+
+func wrapNotFound(op string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return fmt.Errorf("%s failed: %w", op, err)
+}
+
+func toUser(u sqlc.User) *User {
+	return &User{
+		ID:              int(u.ID),
+		Email:           u.Email,
+		PasswordHash:    u.PasswordHash,
+		DisabledAt:      timePtr(u.DisabledAt),
+		EmailVerifiedAt: timePtr(u.EmailVerifiedAt),
+		CreatedAt:       u.CreatedAt.Time,
+		UpdatedAt:       u.UpdatedAt.Time,
+	}
+}
+
+func toSession(s sqlc.Session) *Session {
+	return &Session{
+		ID:        s.ID,
+		UserID:    int(s.UserID),
+		ExpiresAt: s.ExpiresAt.Time,
+		RevokedAt: timePtr(s.RevokedAt),
+		CreatedAt: s.CreatedAt.Time,
+	}
+}
+
+func timePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func toLoginAttempt(a sqlc.LoginAttempt) *LoginAttempt {
+	return &LoginAttempt{
+		Email:         a.Email,
+		Failures:      int(a.Failures),
+		NextAttemptAt: timePtr(a.NextAttemptAt),
+		LockedUntil:   timePtr(a.LockedUntil),
+		LastFailedAt:  a.LastFailedAt.Time,
+	}
+}
+
+func timestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
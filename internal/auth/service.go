@@ -2,28 +2,108 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/mailer"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
 	"github.com/abdurrahimagca/go-api-starter/platform/token"
 	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
 )
 
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/auth")
+
+// dummyPasswordHash is compared against when the account does not exist so
+// that login takes the same time either way
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// loginHold is how long a login attempt keeps others for the same email
+// waiting while its password is compared. It only matters if the attempt
+// dies before recording its outcome.
+const loginHold = 10 * time.Second
+
+// Config holds the token lifetimes, login throttling and account email links used by the service
+type Config struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	Lockout         LockoutConfig
+	// PasswordResetURL is the page that accepts a reset token as ?token=
+	PasswordResetURL string
+	PasswordResetTTL time.Duration
+	// VerifyEmailURL is GET /email/verify as clients reach it
+	VerifyEmailURL  string
+	VerificationTTL time.Duration
+}
+
+// LockoutConfig slows down and then locks out repeated failed logins per email
+type LockoutConfig struct {
+	// After DelayAfter failures each further failure doubles the wait, from BaseDelay up to MaxDelay
+	DelayAfter int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	// LockAfter failures lock the email for Duration
+	LockAfter int
+	Duration  time.Duration
+	// ResetAfter is how long without a failure before counting starts over
+	ResetAfter time.Duration
+}
+
+// block returns how long the next attempt must wait after the given number
+// of failures, and whether that wait is a lockout
+func (c LockoutConfig) block(failures int) (wait time.Duration, locked bool) {
+	switch {
+	case c.LockAfter > 0 && failures >= c.LockAfter:
+		return c.Duration, true
+	case c.DelayAfter > 0 && failures >= c.DelayAfter:
+		wait = c.BaseDelay
+		for i := c.DelayAfter; i < failures && wait < c.MaxDelay; i++ {
+			wait *= 2
+		}
+		return min(wait, c.MaxDelay), false
+	}
+	return 0, false
+}
+
 // Service defines the contract for auth business logic
 type Service interface {
 	WithTx(tx pgx.Tx) Service
-	Login(ctx context.Context) (*LoginResponse, error)
+	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*LoginResponse, error)
 	VerifyToken(ctx context.Context, tokenStr string) (*token.Claims, error)
+	CreateUser(ctx context.Context, req CreateUserRequest) (*User, error)
+	DisableUser(ctx context.Context, email string) (*User, error)
+	ResetPassword(ctx context.Context, email, password string) (*User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	CompletePasswordReset(ctx context.Context, resetToken, password string) (*User, error)
+	SendVerification(ctx context.Context, userID int) error
+	VerifyEmail(ctx context.Context, verificationToken string) (*User, error)
+	PurgeUserTokens(ctx context.Context, before time.Time) (int64, error)
+	PurgeSessions(ctx context.Context, before time.Time) (int64, error)
+	ListLockouts(ctx context.Context) ([]*LoginAttempt, error)
+	Unlock(ctx context.Context, email string) error
+	PurgeLoginAttempts(ctx context.Context, before time.Time) (int64, error)
 }
 
 type service struct {
 	repo   Repository
 	tokens token.IToken
+	queue  jobs.Queue
+	config Config
 }
 
-// NewService creates a new auth service
-func NewService(repo Repository, tokens token.IToken) Service {
+// NewService creates a new auth service. queue sends the lockout emails.
+func NewService(repo Repository, tokens token.IToken, queue jobs.Queue, config Config) Service {
 	return &service{
 		repo:   repo,
 		tokens: tokens,
+		queue:  queue,
+		config: config,
 	}
 }
 
@@ -31,19 +111,400 @@ func (s *service) WithTx(tx pgx.Tx) Service {
 	return &service{
 		repo:   s.repo.WithTx(tx),
 		tokens: s.tokens,
+		queue:  s.queue.WithTx(tx),
+		config: s.config,
+	}
+}
+
+func (s *service) Login(ctx context.Context, req LoginRequest) (_ *LoginResponse, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.Login")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	email := NormalizeEmail(req.Email)
+	// The attempt is counted and the email held before the password is
+	// compared, so parallel guesses are refused rather than all compared
+	now := time.Now()
+	attempt, err := s.repo.ReserveLoginAttempt(ctx, email, now.Add(loginHold), now.Add(-s.config.Lockout.ResetAfter))
+	if errors.Is(err, ErrLoginThrottled) {
+		return nil, s.throttled(ctx, email)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if errors.Is(err, ErrNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+		return nil, s.loginFailed(ctx, attempt, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, s.loginFailed(ctx, attempt, user)
+	}
+	if user.Disabled() {
+		// Counted like a wrong password, but a disabled account is never emailed
+		return nil, s.loginFailed(ctx, attempt, nil)
+	}
+
+	if _, err := s.repo.DeleteLoginAttempts(ctx, email); err != nil {
+		return nil, err
+	}
+	return s.issueTokens(ctx, user)
+}
+
+// throttled reports how long a refused attempt has to wait
+func (s *service) throttled(ctx context.Context, email string) error {
+	attempt, err := s.repo.GetLoginAttempt(ctx, email)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	// The wait may have ended since the attempt was refused
+	retryAfter := time.Second
+	if attempt != nil {
+		until := attempt.NextAttemptAt
+		if attempt.LockedUntil != nil && (until == nil || attempt.LockedUntil.After(*until)) {
+			until = attempt.LockedUntil
+		}
+		if until != nil {
+			retryAfter = max(time.Until(*until), retryAfter)
+		}
 	}
+	return &ThrottledError{RetryAfter: retryAfter}
 }
 
-func (s *service) Login(ctx context.Context) (*LoginResponse, error) {
-	accessToken, err := s.tokens.Generate()
+// loginFailed applies the delay or lockout earned by the reserved attempt and
+// releases its hold. user is nil for unknown emails, which are throttled the
+// same way but never emailed.
+func (s *service) loginFailed(ctx context.Context, attempt *LoginAttempt, user *User) error {
+	var nextAttemptAt, lockedUntil *time.Time
+	wait, locked := s.config.Lockout.block(attempt.Failures)
+	if wait > 0 {
+		until := time.Now().Add(wait)
+		nextAttemptAt = &until
+		if locked {
+			lockedUntil = &until
+		}
+	}
+	if err := s.repo.BlockLoginAttempts(ctx, attempt.Email, nextAttemptAt, lockedUntil); err != nil {
+		return err
+	}
+
+	if locked && user != nil {
+		err := mailer.Send(ctx, s.queue, user.Email, "account_locked", map[string]any{
+			"Email":       user.Email,
+			"LockedUntil": *lockedUntil,
+		}, jobs.WithUniqueKey("lockout-email:"+attempt.Email))
+		if err != nil {
+			return err
+		}
+	}
+	return ErrInvalidCredentials
+}
+
+func (s *service) Refresh(ctx context.Context, refreshToken string) (_ *LoginResponse, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.Refresh")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	session, err := s.repo.GetSessionByRefreshTokenHash(ctx, token.HashOpaque(refreshToken))
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
 	if err != nil {
 		return nil, err
 	}
+	if session.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+	if !time.Now().Before(session.ExpiresAt) {
+		return nil, ErrExpiredToken
+	}
 
-	refreshToken, err := s.tokens.Generate()
+	user, err := s.repo.GetUserByID(ctx, session.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if user.Disabled() {
+		return nil, ErrInvalidToken
+	}
+
+	// Rotate: each refresh token can only be used once
+	if err := s.repo.RevokeSession(ctx, session.ID); err != nil {
+		return nil, err
+	}
+	return s.issueTokens(ctx, user)
+}
+
+func (s *service) VerifyToken(ctx context.Context, tokenStr string) (_ *token.Claims, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.VerifyToken")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.tokens.Verify(ctx, tokenStr)
+}
+
+func (s *service) CreateUser(ctx context.Context, req CreateUserRequest) (_ *User, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.CreateUser")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	email := NormalizeEmail(req.Email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, ErrInvalidEmail
+	}
+
+	hash, err := HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.repo.CreateUser(ctx, email, hash, req.Verified)
+	if err != nil {
+		return nil, err
+	}
+	if !user.EmailVerified() {
+		if err := s.sendVerification(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+func (s *service) DisableUser(ctx context.Context, email string) (_ *User, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.DisableUser")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	user, err := s.repo.GetUserByEmail(ctx, NormalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err = s.repo.DisableUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RevokeUserSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *service) ResetPassword(ctx context.Context, email, password string) (_ *User, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.ResetPassword")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	user, err := s.repo.GetUserByEmail(ctx, NormalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user, err = s.repo.UpdateUserPassword(ctx, user.ID, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RevokeUserSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RequestPasswordReset mails a reset link when the email belongs to an active
+// account. It succeeds either way so callers cannot probe for accounts.
+func (s *service) RequestPasswordReset(ctx context.Context, email string) (err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.RequestPasswordReset")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	user, err := s.repo.GetUserByEmail(ctx, NormalizeEmail(email))
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Disabled() {
+		return nil
+	}
+
+	// Only the newest link works
+	if err := s.repo.InvalidateUserTokens(ctx, user.ID, PurposePasswordReset); err != nil {
+		return err
+	}
+	resetToken, expiresAt, err := s.createToken(ctx, user.ID, PurposePasswordReset, s.config.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+	return mailer.Send(ctx, s.queue, user.Email, "password_reset", map[string]any{
+		"Email":     user.Email,
+		"Link":      withToken(s.config.PasswordResetURL, resetToken),
+		"ExpiresAt": expiresAt,
+	})
+}
+
+// CompletePasswordReset sets a new password with a mailed token and revokes
+// every session. Run it in a transaction so a failure leaves the token usable.
+func (s *service) CompletePasswordReset(ctx context.Context, resetToken, password string) (_ *User, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.CompletePasswordReset")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	// Check the password first so a weak one does not use up the token
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.repo.ConsumeUserToken(ctx, token.HashOpaque(resetToken), PurposePasswordReset)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.UpdateUserPassword(ctx, userID, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RevokeUserSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	// Proving access to the mailbox also lifts a lockout
+	if _, err := s.repo.DeleteLoginAttempts(ctx, user.Email); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *service) SendVerification(ctx context.Context, userID int) (err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.SendVerification")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return ErrAlreadyVerified
+	}
+	if err := s.repo.InvalidateUserTokens(ctx, user.ID, PurposeEmailVerification); err != nil {
+		return err
+	}
+	return s.sendVerification(ctx, user)
+}
+
+func (s *service) VerifyEmail(ctx context.Context, verificationToken string) (_ *User, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.VerifyEmail")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	userID, err := s.repo.ConsumeUserToken(ctx, token.HashOpaque(verificationToken), PurposeEmailVerification)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.MarkEmailVerified(ctx, userID)
+}
+
+func (s *service) PurgeUserTokens(ctx context.Context, before time.Time) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.PurgeUserTokens")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.DeleteStaleUserTokens(ctx, before)
+}
+
+// sendVerification mails user a new verification link
+func (s *service) sendVerification(ctx context.Context, user *User) error {
+	verificationToken, expiresAt, err := s.createToken(ctx, user.ID, PurposeEmailVerification, s.config.VerificationTTL)
+	if err != nil {
+		return err
+	}
+	return mailer.Send(ctx, s.queue, user.Email, "email_verification", map[string]any{
+		"Email":     user.Email,
+		"Link":      withToken(s.config.VerifyEmailURL, verificationToken),
+		"ExpiresAt": expiresAt,
+	})
+}
+
+// createToken stores the hash of a new single-use token and returns the token
+func (s *service) createToken(ctx context.Context, userID int, purpose TokenPurpose, ttl time.Duration) (string, time.Time, error) {
+	raw, err := token.RandomString(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	if err := s.repo.CreateUserToken(ctx, userID, purpose, token.HashOpaque(raw), expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+	return raw, expiresAt, nil
+}
+
+// withToken adds ?token= to link, keeping any query it already has
+func withToken(link, value string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	q := u.Query()
+	q.Set("token", value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (s *service) PurgeSessions(ctx context.Context, before time.Time) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.PurgeSessions")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.DeleteStaleSessions(ctx, before)
+}
+
+func (s *service) ListLockouts(ctx context.Context) (_ []*LoginAttempt, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.ListLockouts")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.ListLockedLogins(ctx)
+}
+
+func (s *service) Unlock(ctx context.Context, email string) (err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.Unlock")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	n, err := s.repo.DeleteLoginAttempts(ctx, NormalizeEmail(email))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *service) PurgeLoginAttempts(ctx context.Context, before time.Time) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Service.PurgeLoginAttempts")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.DeleteStaleLoginAttempts(ctx, before)
+}
+
+func (s *service) issueTokens(ctx context.Context, user *User) (*LoginResponse, error) {
+	accessToken, err := s.tokens.Generate(strconv.Itoa(user.ID), s.config.AccessTokenTTL, map[string]interface{}{
+		"email":          user.Email,
+		"email_verified": user.EmailVerified(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := token.RandomString(32)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(s.config.RefreshTokenTTL)
+	if _, err := s.repo.CreateSession(ctx, user.ID, token.HashOpaque(refreshToken), expiresAt); err != nil {
+		return nil, err
+	}
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
@@ -51,6 +512,19 @@ func (s *service) Login(ctx context.Context) (*LoginResponse, error) {
 	}, nil
 }
 
-func (s *service) VerifyToken(ctx context.Context, tokenStr string) (*token.Claims, error) {
-	return s.tokens.Verify(ctx, tokenStr)
+// NormalizeEmail lower-cases and trims an email so lookups are case-insensitive
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// HashPassword validates and hashes a password with bcrypt
+func HashPassword(password string) (string, error) {
+	if len(password) < 8 {
+		return "", ErrWeakPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
 }
@@ -0,0 +1,123 @@
+// Package collection groups labubu into named, ordered collections that
+// their owner shares with other users
+package collection
+
+import (
+	"errors"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+)
+
+// Role is what a user may do with a collection
+type Role string
+
+const (
+	RoleOwner Role = "owner"
+	// RoleEditor adds, removes and reorders items
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// canEdit reports whether r may change the items of a collection
+func (r Role) canEdit() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+// Collection is a named, ordered group of labubu
+type Collection struct {
+	ID      int    `json:"id"`
+	OwnerID int    `json:"owner_id"`
+	Name    string `json:"name"`
+	// Role is the caller's role in the collection
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Item is a labubu in a collection
+type Item struct {
+	CollectionID int `json:"collection_id"`
+	LabubuID     int `json:"labubu_id"`
+	Position     int `json:"position"`
+	// AddedBy is 0 once the account that added the item is deleted
+	AddedBy int       `json:"added_by"`
+	AddedAt time.Time `json:"added_at"`
+	// Labubu is set when items are listed
+	Labubu *labubu.Labubu `json:"labubu,omitempty"`
+}
+
+// Share gives a user other than the owner access to a collection
+type Share struct {
+	CollectionID int       `json:"collection_id"`
+	UserID       int       `json:"user_id"`
+	Email        string    `json:"email"`
+	Role         Role      `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Page sizes
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Page selects a slice of a list
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// clamp keeps Limit between 1 and MaxPageSize and Offset positive
+func (p Page) clamp() Page {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageSize
+	}
+	p.Limit = min(p.Limit, MaxPageSize)
+	p.Offset = max(p.Offset, 0)
+	return p
+}
+
+// CreateCollectionRequest creates a collection owned by OwnerID
+type CreateCollectionRequest struct {
+	OwnerID int
+	Name    string
+}
+
+// MoveItemRequest moves an item to Position, shifting the items in between
+type MoveItemRequest struct {
+	CollectionID int
+	LabubuID     int
+	UserID       int
+	Position     int
+}
+
+// ShareRequest shares a collection with the user registered with Email, or
+// invites Email if nobody is registered with it yet
+type ShareRequest struct {
+	CollectionID int
+	OwnerID      int
+	Email        string
+	Role         Role
+}
+
+// Invite is what Share reports back, the same whether the email already has
+// an account or is invited until someone verifies it
+type Invite struct {
+	CollectionID int    `json:"collection_id"`
+	Email        string `json:"email"`
+	Role         Role   `json:"role"`
+}
+
+// Common errors
+var (
+	ErrNotFound = errors.New("collection not found")
+	// ErrForbidden is returned when the caller's role does not allow the change
+	ErrForbidden     = errors.New("not allowed by your role in the collection")
+	ErrInvalidName   = errors.New("name must be 1 to 200 characters")
+	ErrItemNotFound  = errors.New("labubu is not in the collection")
+	ErrAlreadyAdded  = errors.New("labubu is already in the collection")
+	ErrInvalidRole   = errors.New("role must be viewer or editor")
+	ErrUserNotFound  = errors.New("no user with this email")
+	ErrShareWithSelf = errors.New("the owner cannot be added to a share")
+)
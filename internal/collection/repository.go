@@ -0,0 +1,336 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for collection data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	CreateCollection(ctx context.Context, ownerID int, name string) (*Collection, error)
+	// GetCollection returns ErrNotFound unless the collection is owned by or shared with userID
+	GetCollection(ctx context.Context, id, userID int) (*Collection, error)
+	// LockCollection holds the collection until the transaction ends
+	LockCollection(ctx context.Context, id int) error
+	ListCollections(ctx context.Context, userID int, page Page) ([]*Collection, error)
+	RenameCollection(ctx context.Context, id int, name string) (*Collection, error)
+	DeleteCollection(ctx context.Context, id int) error
+	// ListItems skips labubu that are unpublished unless viewerID owns them
+	ListItems(ctx context.Context, collectionID, viewerID int, page Page) ([]*Item, error)
+	GetItem(ctx context.Context, collectionID, labubuID int) (*Item, error)
+	AddItem(ctx context.Context, collectionID, labubuID, addedBy int) (*Item, error)
+	RemoveItem(ctx context.Context, collectionID, labubuID int) error
+	MoveItem(ctx context.Context, item *Item, position int) (*Item, error)
+	GetUserIDByEmail(ctx context.Context, email string) (int, error)
+	UpsertShare(ctx context.Context, collectionID, userID int, role Role) (*Share, error)
+	UpsertInvite(ctx context.Context, collectionID int, email string, role Role) error
+	// AcceptInvites turns the invites for email into shares for userID
+	AcceptInvites(ctx context.Context, userID int, email string) (int64, error)
+	ListShares(ctx context.Context, collectionID int, page Page) ([]*Share, error)
+	DeleteShare(ctx context.Context, collectionID, userID int) error
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+func (r *pgxRepository) CreateCollection(ctx context.Context, ownerID int, name string) (*Collection, error) {
+	result, err := r.q.CreateCollection(ctx, sqlc.CreateCollectionParams{OwnerID: int32(ownerID), Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("CreateCollection failed: %w", err)
+	}
+	return toCollection(result, RoleOwner), nil
+}
+
+func (r *pgxRepository) GetCollection(ctx context.Context, id, userID int) (*Collection, error) {
+	result, err := r.q.GetCollectionForUser(ctx, sqlc.GetCollectionForUserParams{ID: int32(id), UserID: int32(userID)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetCollectionForUser failed: %w", err)
+	}
+	return toCollection(sqlc.Collection{
+		ID:        result.ID,
+		OwnerID:   result.OwnerID,
+		Name:      result.Name,
+		CreatedAt: result.CreatedAt,
+		UpdatedAt: result.UpdatedAt,
+	}, Role(result.Role)), nil
+}
+
+func (r *pgxRepository) LockCollection(ctx context.Context, id int) error {
+	if err := r.q.LockCollection(ctx, int32(id)); err != nil {
+		return fmt.Errorf("LockCollection failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListCollections(ctx context.Context, userID int, page Page) ([]*Collection, error) {
+	results, err := r.q.ListCollectionsForUser(ctx, sqlc.ListCollectionsForUserParams{
+		UserID:    int32(userID),
+		RowLimit:  int32(page.Limit),
+		RowOffset: int32(page.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListCollectionsForUser failed: %w", err)
+	}
+
+	items := make([]*Collection, 0, len(results))
+	for _, result := range results {
+		items = append(items, toCollection(sqlc.Collection{
+			ID:        result.ID,
+			OwnerID:   result.OwnerID,
+			Name:      result.Name,
+			CreatedAt: result.CreatedAt,
+			UpdatedAt: result.UpdatedAt,
+		}, Role(result.Role)))
+	}
+	return items, nil
+}
+
+func (r *pgxRepository) RenameCollection(ctx context.Context, id int, name string) (*Collection, error) {
+	result, err := r.q.RenameCollection(ctx, sqlc.RenameCollectionParams{ID: int32(id), Name: name})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RenameCollection failed: %w", err)
+	}
+	return toCollection(result, RoleOwner), nil
+}
+
+func (r *pgxRepository) DeleteCollection(ctx context.Context, id int) error {
+	if err := r.q.DeleteCollection(ctx, int32(id)); err != nil {
+		return fmt.Errorf("DeleteCollection failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListItems(ctx context.Context, collectionID, viewerID int, page Page) ([]*Item, error) {
+	results, err := r.q.ListCollectionItems(ctx, sqlc.ListCollectionItemsParams{
+		CollectionID: int32(collectionID),
+		ViewerID:     int32(viewerID),
+		RowLimit:     int32(page.Limit),
+		RowOffset:    int32(page.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListCollectionItems failed: %w", err)
+	}
+
+	items := make([]*Item, 0, len(results))
+	for _, result := range results {
+		items = append(items, toItem(result))
+	}
+	return items, nil
+}
+
+func (r *pgxRepository) GetItem(ctx context.Context, collectionID, labubuID int) (*Item, error) {
+	result, err := r.q.GetCollectionItem(ctx, sqlc.GetCollectionItemParams{
+		CollectionID: int32(collectionID),
+		LabubuID:     int32(labubuID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetCollectionItem failed: %w", err)
+	}
+	return toItem(result), nil
+}
+
+// AddItem appends the labubu; ErrAlreadyAdded when it is in the collection
+func (r *pgxRepository) AddItem(ctx context.Context, collectionID, labubuID, addedBy int) (*Item, error) {
+	result, err := r.q.AddCollectionItem(ctx, sqlc.AddCollectionItemParams{
+		CollectionID: int32(collectionID),
+		LabubuID:     int32(labubuID),
+		AddedBy:      pgtype.Int4{Int32: int32(addedBy), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAlreadyAdded
+	}
+	if err != nil {
+		return nil, fmt.Errorf("AddCollectionItem failed: %w", err)
+	}
+	if err := r.q.TouchCollection(ctx, int32(collectionID)); err != nil {
+		return nil, fmt.Errorf("TouchCollection failed: %w", err)
+	}
+	return toItem(result), nil
+}
+
+// RemoveItem removes the labubu and moves the items after it up
+func (r *pgxRepository) RemoveItem(ctx context.Context, collectionID, labubuID int) error {
+	removed, err := r.q.RemoveCollectionItem(ctx, sqlc.RemoveCollectionItemParams{
+		CollectionID: int32(collectionID),
+		LabubuID:     int32(labubuID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("RemoveCollectionItem failed: %w", err)
+	}
+	if err := r.q.CloseCollectionGap(ctx, sqlc.CloseCollectionGapParams{
+		CollectionID: removed.CollectionID,
+		Position:     removed.Position,
+	}); err != nil {
+		return fmt.Errorf("CloseCollectionGap failed: %w", err)
+	}
+	if err := r.q.TouchCollection(ctx, int32(collectionID)); err != nil {
+		return fmt.Errorf("TouchCollection failed: %w", err)
+	}
+	return nil
+}
+
+// MoveItem moves item to position, or to the end when position is past it
+func (r *pgxRepository) MoveItem(ctx context.Context, item *Item, position int) (*Item, error) {
+	last, err := r.q.MaxCollectionPosition(ctx, int32(item.CollectionID))
+	if err != nil {
+		return nil, fmt.Errorf("MaxCollectionPosition failed: %w", err)
+	}
+	position = min(position, int(last))
+	if position == item.Position {
+		return item, nil
+	}
+
+	if err := r.q.MoveCollectionItem(ctx, sqlc.MoveCollectionItemParams{
+		CollectionID: int32(item.CollectionID),
+		LabubuID:     int32(item.LabubuID),
+		OldPosition:  int32(item.Position),
+		NewPosition:  int32(position),
+	}); err != nil {
+		return nil, fmt.Errorf("MoveCollectionItem failed: %w", err)
+	}
+	if err := r.q.TouchCollection(ctx, int32(item.CollectionID)); err != nil {
+		return nil, fmt.Errorf("TouchCollection failed: %w", err)
+	}
+	moved := *item
+	moved.Position = position
+	return &moved, nil
+}
+
+func (r *pgxRepository) GetUserIDByEmail(ctx context.Context, email string) (int, error) {
+	result, err := r.q.GetUserByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrUserNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("GetUserByEmail failed: %w", err)
+	}
+	return int(result.ID), nil
+}
+
+func (r *pgxRepository) UpsertShare(ctx context.Context, collectionID, userID int, role Role) (*Share, error) {
+	result, err := r.q.UpsertCollectionShare(ctx, sqlc.UpsertCollectionShareParams{
+		CollectionID: int32(collectionID),
+		UserID:       int32(userID),
+		Role:         string(role),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("UpsertCollectionShare failed: %w", err)
+	}
+	return &Share{
+		CollectionID: int(result.CollectionID),
+		UserID:       int(result.UserID),
+		Role:         Role(result.Role),
+		CreatedAt:    result.CreatedAt.Time,
+	}, nil
+}
+
+func (r *pgxRepository) UpsertInvite(ctx context.Context, collectionID int, email string, role Role) error {
+	err := r.q.UpsertCollectionInvite(ctx, sqlc.UpsertCollectionInviteParams{
+		CollectionID: int32(collectionID),
+		Email:        email,
+		Role:         string(role),
+	})
+	if err != nil {
+		return fmt.Errorf("UpsertCollectionInvite failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) AcceptInvites(ctx context.Context, userID int, email string) (int64, error) {
+	n, err := r.q.AcceptCollectionInvites(ctx, sqlc.AcceptCollectionInvitesParams{UserID: int32(userID), Email: email})
+	if err != nil {
+		return 0, fmt.Errorf("AcceptCollectionInvites failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) ListShares(ctx context.Context, collectionID int, page Page) ([]*Share, error) {
+	results, err := r.q.ListCollectionShares(ctx, sqlc.ListCollectionSharesParams{
+		CollectionID: int32(collectionID),
+		RowLimit:     int32(page.Limit),
+		RowOffset:    int32(page.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListCollectionShares failed: %w", err)
+	}
+
+	shares := make([]*Share, 0, len(results))
+	for _, result := range results {
+		shares = append(shares, &Share{
+			CollectionID: int(result.CollectionID),
+			UserID:       int(result.UserID),
+			Email:        result.Email,
+			Role:         Role(result.Role),
+			CreatedAt:    result.CreatedAt.Time,
+		})
+	}
+	return shares, nil
+}
+
+func (r *pgxRepository) DeleteShare(ctx context.Context, collectionID, userID int) error {
+	n, err := r.q.DeleteCollectionShare(ctx, sqlc.DeleteCollectionShareParams{
+		CollectionID: int32(collectionID),
+		UserID:       int32(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteCollectionShare failed: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func toCollection(c sqlc.Collection, role Role) *Collection {
+	return &Collection{
+		ID:        int(c.ID),
+		OwnerID:   int(c.OwnerID),
+		Name:      c.Name,
+		Role:      role,
+		CreatedAt: c.CreatedAt.Time,
+		UpdatedAt: c.UpdatedAt.Time,
+	}
+}
+
+func toItem(i sqlc.CollectionItem) *Item {
+	return &Item{
+		CollectionID: int(i.CollectionID),
+		LabubuID:     int(i.LabubuID),
+		Position:     int(i.Position),
+		AddedBy:      int(i.AddedBy.Int32),
+		AddedAt:      i.AddedAt.Time,
+	}
+}
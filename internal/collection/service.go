@@ -0,0 +1,332 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/collection")
+
+// maxNameLength is the longest collection name, in bytes
+const maxNameLength = 200
+
+// Service defines the contract for collection business logic. Users who
+// neither own a collection nor have it shared with them get ErrNotFound;
+// those whose role does not allow a change get ErrForbidden. Changes to the
+// items lock the collection and must run on a service bound to a transaction.
+// Lists return the next page, or nil after the last one.
+type Service interface {
+	WithTx(tx pgx.Tx) Service
+	CreateCollection(ctx context.Context, req CreateCollectionRequest) (*Collection, error)
+	GetCollection(ctx context.Context, id, userID int) (*Collection, error)
+	// ListCollections returns the collections userID owns or has been shared
+	ListCollections(ctx context.Context, userID int, page Page) ([]*Collection, *Page, error)
+	RenameCollection(ctx context.Context, id, userID int, name string) (*Collection, error)
+	DeleteCollection(ctx context.Context, id, userID int) error
+	// ListItems returns the items in order with their labubu; others'
+	// labubu are listed only once published
+	ListItems(ctx context.Context, id, userID int, page Page) ([]*Item, *Page, error)
+	// AddItem appends a labubu owned by userID
+	AddItem(ctx context.Context, id, labubuID, userID int) (*Item, error)
+	RemoveItem(ctx context.Context, id, labubuID, userID int) error
+	MoveItem(ctx context.Context, req MoveItemRequest) (*Item, error)
+	ListShares(ctx context.Context, id, userID int, page Page) ([]*Share, *Page, error)
+	// Share adds a user to the collection or changes their role. An email
+	// without an account is invited instead, and the result does not tell
+	// the two apart.
+	Share(ctx context.Context, req ShareRequest) (*Invite, error)
+	// AcceptInvites shares the collections email was invited to with userID,
+	// once userID has verified that email
+	AcceptInvites(ctx context.Context, userID int, email string) (int64, error)
+	// Unshare removes sharedUserID; the owner removes anyone, others only themselves
+	Unshare(ctx context.Context, id, userID, sharedUserID int) error
+}
+
+type service struct {
+	repo   Repository
+	labubu labubu.Service
+}
+
+// NewService creates a new collection service
+func NewService(repo Repository, labubuService labubu.Service) Service {
+	return &service{
+		repo:   repo,
+		labubu: labubuService,
+	}
+}
+
+func (s *service) WithTx(tx pgx.Tx) Service {
+	return &service{
+		repo:   s.repo.WithTx(tx),
+		labubu: s.labubu.WithTx(tx),
+	}
+}
+
+// authorize returns the collection when userID's role in it is one of allowed
+func (s *service) authorize(ctx context.Context, id, userID int, allowed ...Role) (*Collection, error) {
+	c, err := s.repo.GetCollection(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range allowed {
+		if c.Role == role {
+			return c, nil
+		}
+	}
+	return nil, ErrForbidden
+}
+
+// editable locks the collection for a change to its items by userID
+func (s *service) editable(ctx context.Context, id, userID int) (*Collection, error) {
+	c, err := s.repo.GetCollection(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.Role.canEdit() {
+		return nil, ErrForbidden
+	}
+	if err := s.repo.LockCollection(ctx, id); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *service) CreateCollection(ctx context.Context, req CreateCollectionRequest) (_ *Collection, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.CreateCollection")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	name, err := cleanName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CreateCollection(ctx, req.OwnerID, name)
+}
+
+func (s *service) GetCollection(ctx context.Context, id, userID int) (_ *Collection, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.GetCollection")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.GetCollection(ctx, id, userID)
+}
+
+func (s *service) ListCollections(ctx context.Context, userID int, page Page) (_ []*Collection, _ *Page, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.ListCollections")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	page = page.clamp()
+	collections, err := s.repo.ListCollections(ctx, userID, page.extra())
+	if err != nil {
+		return nil, nil, err
+	}
+	collections, next := paginate(collections, page)
+	return collections, next, nil
+}
+
+func (s *service) RenameCollection(ctx context.Context, id, userID int, name string) (_ *Collection, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.RenameCollection")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if name, err = cleanName(name); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, id, userID, RoleOwner); err != nil {
+		return nil, err
+	}
+	return s.repo.RenameCollection(ctx, id, name)
+}
+
+func (s *service) DeleteCollection(ctx context.Context, id, userID int) (err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.DeleteCollection")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.authorize(ctx, id, userID, RoleOwner); err != nil {
+		return err
+	}
+	return s.repo.DeleteCollection(ctx, id)
+}
+
+func (s *service) ListItems(ctx context.Context, id, userID int, page Page) (_ []*Item, _ *Page, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.ListItems")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.repo.GetCollection(ctx, id, userID); err != nil {
+		return nil, nil, err
+	}
+	page = page.clamp()
+	items, err := s.repo.ListItems(ctx, id, userID, page.extra())
+	if err != nil {
+		return nil, nil, err
+	}
+	items, next := paginate(items, page)
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.LabubuID
+	}
+	entries, err := s.labubu.GetLabubuByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[int]*labubu.Labubu, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+	listed := items[:0]
+	for _, item := range items {
+		// Entries deleted since the page was read are left out
+		if item.Labubu = byID[item.LabubuID]; item.Labubu != nil {
+			listed = append(listed, item)
+		}
+	}
+	return listed, next, nil
+}
+
+func (s *service) AddItem(ctx context.Context, id, labubuID, userID int) (_ *Item, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.AddItem")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.editable(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	entry, err := s.labubu.GetLabubuByID(ctx, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.OwnerID != userID {
+		// Only your own labubu can be shared through a collection
+		return nil, labubu.ErrNotFound
+	}
+	item, err := s.repo.AddItem(ctx, id, labubuID, userID)
+	if err != nil {
+		return nil, err
+	}
+	item.Labubu = entry
+	return item, nil
+}
+
+func (s *service) RemoveItem(ctx context.Context, id, labubuID, userID int) (err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.RemoveItem")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.editable(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.repo.RemoveItem(ctx, id, labubuID)
+}
+
+func (s *service) MoveItem(ctx context.Context, req MoveItemRequest) (_ *Item, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.MoveItem")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if req.Position < 0 {
+		req.Position = 0
+	}
+	if _, err := s.editable(ctx, req.CollectionID, req.UserID); err != nil {
+		return nil, err
+	}
+	item, err := s.repo.GetItem(ctx, req.CollectionID, req.LabubuID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.MoveItem(ctx, item, req.Position)
+}
+
+func (s *service) ListShares(ctx context.Context, id, userID int, page Page) (_ []*Share, _ *Page, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.ListShares")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.authorize(ctx, id, userID, RoleOwner); err != nil {
+		return nil, nil, err
+	}
+	page = page.clamp()
+	shares, err := s.repo.ListShares(ctx, id, page.extra())
+	if err != nil {
+		return nil, nil, err
+	}
+	shares, next := paginate(shares, page)
+	return shares, next, nil
+}
+
+func (s *service) Share(ctx context.Context, req ShareRequest) (_ *Invite, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.Share")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if req.Role != RoleViewer && req.Role != RoleEditor {
+		return nil, ErrInvalidRole
+	}
+	c, err := s.authorize(ctx, req.CollectionID, req.OwnerID, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	email := auth.NormalizeEmail(req.Email)
+	invite := &Invite{CollectionID: c.ID, Email: email, Role: req.Role}
+
+	userID, err := s.repo.GetUserIDByEmail(ctx, email)
+	if errors.Is(err, ErrUserNotFound) {
+		if err := s.repo.UpsertInvite(ctx, c.ID, email, req.Role); err != nil {
+			return nil, err
+		}
+		return invite, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userID == c.OwnerID {
+		return nil, ErrShareWithSelf
+	}
+	if _, err := s.repo.UpsertShare(ctx, c.ID, userID, req.Role); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (s *service) AcceptInvites(ctx context.Context, userID int, email string) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.AcceptInvites")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.AcceptInvites(ctx, userID, auth.NormalizeEmail(email))
+}
+
+func (s *service) Unshare(ctx context.Context, id, userID, sharedUserID int) (err error) {
+	ctx, span := tracer.Start(ctx, "collection.Service.Unshare")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	c, err := s.repo.GetCollection(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if c.Role != RoleOwner && sharedUserID != userID {
+		return ErrForbidden
+	}
+	return s.repo.DeleteShare(ctx, id, sharedUserID)
+}
+
+// extra asks for one row more than the page, which tells whether another follows
+func (p Page) extra() Page {
+	p.Limit++
+	return p
+}
+
+// paginate trims the row asked for by extra and returns the next page, if any
+func paginate[T any](items []T, page Page) ([]T, *Page) {
+	if len(items) <= page.Limit {
+		return items, nil
+	}
+	return items[:page.Limit], &Page{Limit: page.Limit, Offset: page.Offset + page.Limit}
+}
+
+// cleanName trims a collection name and checks its length
+func cleanName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxNameLength {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
@@ -0,0 +1,184 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+)
+
+const ownerID = 1
+
+// memoryRepository keeps one collection with its shares and invites in memory
+type memoryRepository struct {
+	Repository
+
+	users   map[string]int
+	shares  map[int]Role
+	invites map[string]Role
+}
+
+func (r *memoryRepository) GetCollection(_ context.Context, id, userID int) (*Collection, error) {
+	if id != 1 || userID != ownerID {
+		return nil, ErrNotFound
+	}
+	return &Collection{ID: 1, OwnerID: ownerID, Role: RoleOwner}, nil
+}
+
+func (r *memoryRepository) GetUserIDByEmail(_ context.Context, email string) (int, error) {
+	id, ok := r.users[email]
+	if !ok {
+		return 0, ErrUserNotFound
+	}
+	return id, nil
+}
+
+func (r *memoryRepository) UpsertShare(_ context.Context, collectionID, userID int, role Role) (*Share, error) {
+	r.shares[userID] = role
+	return &Share{CollectionID: collectionID, UserID: userID, Role: role}, nil
+}
+
+func (r *memoryRepository) UpsertInvite(_ context.Context, _ int, email string, role Role) error {
+	r.invites[email] = role
+	return nil
+}
+
+func (r *memoryRepository) AcceptInvites(_ context.Context, userID int, email string) (int64, error) {
+	role, ok := r.invites[email]
+	if !ok {
+		return 0, nil
+	}
+	delete(r.invites, email)
+	r.shares[userID] = role
+	return 1, nil
+}
+
+func TestPaginate(t *testing.T) {
+	// rows returns what the repository yields for page.extra()
+	rows := func(n int) []int {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = i + 1
+		}
+		return items
+	}
+
+	tests := []struct {
+		name     string
+		page     Page
+		rows     []int
+		want     []int
+		wantNext *Page
+	}{
+		{"empty", Page{Limit: 3}, nil, nil, nil},
+		{"short page", Page{Limit: 3}, rows(2), rows(2), nil},
+		{"exactly full", Page{Limit: 3}, rows(3), rows(3), nil},
+		{"more follow", Page{Limit: 3}, rows(4), rows(3), &Page{Limit: 3, Offset: 3}},
+		{"later page", Page{Limit: 3, Offset: 6}, rows(4), rows(3), &Page{Limit: 3, Offset: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next := paginate(tt.rows, tt.page)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+			switch {
+			case tt.wantNext == nil && next != nil:
+				t.Errorf("next = %+v, want none", *next)
+			case tt.wantNext != nil && (next == nil || *next != *tt.wantNext):
+				t.Errorf("next = %v, want %+v", next, *tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestPageClamp(t *testing.T) {
+	tests := []struct {
+		name string
+		page Page
+		want Page
+	}{
+		{"zero value", Page{}, Page{Limit: DefaultPageSize}},
+		{"negative", Page{Limit: -1, Offset: -5}, Page{Limit: DefaultPageSize}},
+		{"within bounds", Page{Limit: 50, Offset: 10}, Page{Limit: 50, Offset: 10}},
+		{"too large", Page{Limit: MaxPageSize + 1}, Page{Limit: MaxPageSize}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.clamp(); got != tt.want {
+				t.Errorf("clamp() = %+v, want %+v", got, tt.want)
+			}
+			if got := tt.page.clamp().extra().Limit; got != tt.want.Limit+1 {
+				t.Errorf("extra().Limit = %d, want %d", got, tt.want.Limit+1)
+			}
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         ShareRequest
+		wantErr     error
+		wantShared  bool
+		wantInvited bool
+	}{
+		{name: "registered", req: ShareRequest{Email: "Friend@Example.com", Role: RoleViewer}, wantShared: true},
+		{name: "not registered", req: ShareRequest{Email: "stranger@example.com", Role: RoleEditor}, wantInvited: true},
+		{name: "own email", req: ShareRequest{Email: "owner@example.com", Role: RoleViewer}, wantErr: ErrShareWithSelf},
+		{name: "invalid role", req: ShareRequest{Email: "friend@example.com", Role: RoleOwner}, wantErr: ErrInvalidRole},
+		{name: "not the owner", req: ShareRequest{OwnerID: 2, Email: "friend@example.com", Role: RoleViewer}, wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepository{
+				users:   map[string]int{"owner@example.com": ownerID, "friend@example.com": 2},
+				shares:  make(map[int]Role),
+				invites: make(map[string]Role),
+			}
+			svc := NewService(repo, nil)
+			tt.req.CollectionID = 1
+			if tt.req.OwnerID == 0 {
+				tt.req.OwnerID = ownerID
+			}
+
+			got, err := svc.Share(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Share() = %v, want %v", err, tt.wantErr)
+			}
+			if (len(repo.shares) == 1) != tt.wantShared || (len(repo.invites) == 1) != tt.wantInvited {
+				t.Errorf("shares = %v, invites = %v", repo.shares, repo.invites)
+			}
+			if err != nil {
+				return
+			}
+			// The caller cannot tell a share from an invite
+			want := Invite{CollectionID: 1, Email: auth.NormalizeEmail(tt.req.Email), Role: tt.req.Role}
+			if *got != want {
+				t.Errorf("Share() = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestAcceptInvites(t *testing.T) {
+	repo := &memoryRepository{
+		users:   map[string]int{"owner@example.com": ownerID},
+		shares:  make(map[int]Role),
+		invites: make(map[string]Role),
+	}
+	svc := NewService(repo, nil)
+	if _, err := svc.Share(context.Background(), ShareRequest{CollectionID: 1, OwnerID: ownerID, Email: "new@example.com", Role: RoleEditor}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := svc.AcceptInvites(context.Background(), 3, "New@Example.com")
+	if err != nil || n != 1 {
+		t.Fatalf("AcceptInvites() = %d, %v, want one accepted invite", n, err)
+	}
+	if repo.shares[3] != RoleEditor || len(repo.invites) != 0 {
+		t.Errorf("shares = %v, invites = %v, want the invite turned into a share", repo.shares, repo.invites)
+	}
+}
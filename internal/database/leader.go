@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RunAsLeader competes for the advisory lock derived from name and calls lead
+// while this process holds it. lead's context is cancelled when ctx is done
+// or the connection holding the lock dies; the election is retried every
+// interval until ctx is done.
+func RunAsLeader(ctx context.Context, pool *pgxpool.Pool, name string, interval time.Duration, lead func(ctx context.Context)) {
+	key := LockKey(name)
+	for {
+		lock, err := TryAdvisoryLock(ctx, pool, key)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Leader election failed", "lock", name, "error", err)
+		}
+		if lock != nil {
+			holdLock(ctx, lock, name, interval, lead)
+			lock.Release()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func holdLock(ctx context.Context, lock *AdvisoryLock, name string, interval time.Duration, lead func(ctx context.Context)) {
+	leadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	slog.Info("Became leader", "lock", name)
+	defer slog.Info("Stepped down as leader", "lock", name)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-leadCtx.Done():
+				return
+			case <-ticker.C:
+				if !lock.Alive(leadCtx) && leadCtx.Err() == nil {
+					slog.Warn("Lost leader lock connection", "lock", name)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	lead(leadCtx)
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrLockTimeout is returned when an advisory lock could not be acquired in time
+var ErrLockTimeout = errors.New("timed out waiting for advisory lock")
+
+// lockPollInterval is how often a busy advisory lock is retried
+const lockPollInterval = 250 * time.Millisecond
+
+// LockKey derives a stable advisory lock key from a name
+func LockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// AdvisoryLock is a session-level Postgres advisory lock held on a dedicated connection
+type AdvisoryLock struct {
+	conn *pgxpool.Conn
+	key  int64
+}
+
+// TryAdvisoryLock attempts to take the lock once without waiting.
+// It returns nil and no error when another session holds the lock.
+func TryAdvisoryLock(ctx context.Context, pool *pgxpool.Pool, key int64) (*AdvisoryLock, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection for advisory lock: %w", err)
+	}
+
+	var ok bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&ok); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("taking advisory lock: %w", err)
+	}
+	if !ok {
+		conn.Release()
+		return nil, nil
+	}
+	return &AdvisoryLock{conn: conn, key: key}, nil
+}
+
+// AcquireAdvisoryLock polls for the lock until it is taken or timeout elapses
+func AcquireAdvisoryLock(ctx context.Context, pool *pgxpool.Pool, key int64, timeout time.Duration) (*AdvisoryLock, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		lock, err := TryAdvisoryLock(ctx, pool, key)
+		if lock != nil || (err != nil && ctx.Err() == nil) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w after %s", ErrLockTimeout, timeout)
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// Alive reports whether the connection holding the lock is still usable.
+// Losing the connection releases the lock on the server.
+func (l *AdvisoryLock) Alive(ctx context.Context) bool {
+	return l.conn.Ping(ctx) == nil
+}
+
+// Release unlocks and returns the connection to the pool
+func (l *AdvisoryLock) Release() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		// Closing the connection drops every session lock it holds
+		slog.Warn("Failed to release advisory lock, closing connection", "error", err)
+		_ = l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+}
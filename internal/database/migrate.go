@@ -0,0 +1,206 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/abdurrahimagca/go-api-starter/migrations"
+)
+
+// DefaultMigrationsDir is where migration files live relative to the repo root
+const DefaultMigrationsDir = "migrations"
+
+// MigrationsFS returns the embedded migrations, or dir on disk when dir is set
+func MigrationsFS(dir string) fs.FS {
+	if dir != "" {
+		return os.DirFS(dir)
+	}
+	return migrations.FS
+}
+
+// MigrationStatus describes the schema state compared to the available migrations
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending int
+}
+
+// Migrator wraps golang-migrate for the CLI and startup code
+type Migrator struct {
+	m    *migrate.Migrate
+	fsys fs.FS
+}
+
+// NewMigrator opens the migration source and target database
+func NewMigrator(fsys fs.FS, databaseURL string) (*Migrator, error) {
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error opening migration source: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error opening migrations: %w", err)
+	}
+	return &Migrator{m: m, fsys: fsys}, nil
+}
+
+// Close releases the source and database handles
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// Up applies all pending migrations, or at most steps when steps > 0
+func (mg *Migrator) Up(steps int) error {
+	var err error
+	if steps > 0 {
+		err = mg.m.Steps(steps)
+	} else {
+		err = mg.m.Up()
+	}
+	return ignoreNoChange(err)
+}
+
+// Down rolls back steps migrations, or all of them when steps <= 0
+func (mg *Migrator) Down(steps int) error {
+	var err error
+	if steps > 0 {
+		err = mg.m.Steps(-steps)
+	} else {
+		err = mg.m.Down()
+	}
+	return ignoreNoChange(err)
+}
+
+// Goto migrates up or down to the given version
+func (mg *Migrator) Goto(version uint) error {
+	return ignoreNoChange(mg.m.Migrate(version))
+}
+
+// Force sets the version without running migrations and clears the dirty flag
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// Status reports the current version and how many migrations are pending
+func (mg *Migrator) Status() (*MigrationStatus, error) {
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("error reading schema version: %w", err)
+	}
+
+	versions, err := SourceVersions(mg.fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MigrationStatus{Version: version, Dirty: dirty}
+	for _, v := range versions {
+		if v > version {
+			status.Pending++
+		}
+		status.Latest = v
+	}
+	return status, nil
+}
+
+// SourceVersions lists every migration version available in fsys, in order
+func SourceVersions(fsys fs.FS) ([]uint, error) {
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error opening migration source: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []uint{version}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return versions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, next)
+		version = next
+	}
+}
+
+// LatestVersion returns the highest migration version in fsys
+func LatestVersion(fsys fs.FS) (uint, error) {
+	versions, err := SourceVersions(fsys)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	return versions[len(versions)-1], nil
+}
+
+// CreateMigration writes an empty up/down pair with the next sequence number
+// and returns the created file paths
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), "_")
+	if name == "" {
+		return nil, errors.New("migration name is required")
+	}
+
+	latest, err := LatestVersion(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("%03d_%s", latest+1, name)
+	files := []string{
+		filepath.Join(dir, prefix+".up.sql"),
+		filepath.Join(dir, prefix+".down.sql"),
+	}
+	for _, file := range files {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// ParseVersion parses a migration version argument
+func ParseVersion(arg string) (uint, error) {
+	v, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid migration version %q: %w", arg, err)
+	}
+	return uint(v), nil
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
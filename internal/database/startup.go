@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MigrationPolicy controls what the server does with migrations at startup
+type MigrationPolicy string
+
+const (
+	// MigrateAuto applies pending migrations under an advisory lock
+	MigrateAuto MigrationPolicy = "auto"
+	// MigrateCheckOnly refuses to start when the schema is behind or dirty
+	MigrateCheckOnly MigrationPolicy = "check-only"
+	// MigrateOff skips migrations entirely; readiness still reports the version
+	MigrateOff MigrationPolicy = "off"
+)
+
+// ParseMigrationPolicy validates a policy name
+func ParseMigrationPolicy(s string) (MigrationPolicy, error) {
+	switch p := MigrationPolicy(s); p {
+	case MigrateAuto, MigrateCheckOnly, MigrateOff:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown migration policy %q (want auto, check-only or off)", s)
+	}
+}
+
+// Errors returned by EnsureSchema
+var (
+	ErrSchemaDirty  = errors.New("database schema is dirty")
+	ErrSchemaBehind = errors.New("database schema is behind")
+)
+
+// migrationLockKey serializes startup migrations across replicas
+var migrationLockKey = LockKey("go-api-starter:migrations")
+
+// EnsureSchema applies policy to the database and returns the resulting status
+func EnsureSchema(ctx context.Context, pool *pgxpool.Pool, fsys fs.FS, databaseURL string, policy MigrationPolicy, lockTimeout time.Duration) (*MigrationStatus, error) {
+	m, err := NewMigrator(fsys, databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+	m.m.LockTimeout = lockTimeout
+
+	if policy == MigrateOff {
+		status, err := m.Status()
+		if err != nil {
+			return nil, err
+		}
+		logStatus("Skipping migrations", status)
+		return status, nil
+	}
+
+	if policy == MigrateAuto {
+		lock, err := AcquireAdvisoryLock(ctx, pool, migrationLockKey, lockTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("another instance is migrating: %w", err)
+		}
+		defer lock.Release()
+	}
+
+	// Read the status after taking the lock so we see the work of whoever held it before us
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return status, fmt.Errorf("%w at version %d: a previous migration failed part way; "+
+			"repair the schema by hand, then run `migrate force %d` (or the previous version) before starting",
+			ErrSchemaDirty, status.Version, status.Version)
+	}
+
+	if status.Pending == 0 {
+		logStatus("Database schema is up to date", status)
+		return status, nil
+	}
+
+	if policy == MigrateCheckOnly {
+		return status, fmt.Errorf("%w: at version %d, latest is %d with %d pending; run `migrate up`",
+			ErrSchemaBehind, status.Version, status.Latest, status.Pending)
+	}
+
+	slog.Info("Applying database migrations", "from", status.Version, "to", status.Latest, "pending", status.Pending)
+	if err := m.Up(0); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	status, err = m.Status()
+	if err != nil {
+		return nil, err
+	}
+	logStatus("Database migrations applied", status)
+	return status, nil
+}
+
+func logStatus(msg string, status *MigrationStatus) {
+	slog.Info(msg, "version", status.Version, "latest", status.Latest, "pending", status.Pending, "dirty", status.Dirty)
+}
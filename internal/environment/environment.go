@@ -1,11 +1,17 @@
 package environment
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/robfig/cron/v3"
 )
 
 type ResendEnvironment struct {
@@ -13,6 +19,51 @@ type ResendEnvironment struct {
 	Key string
 }
 
+// MailEnvironment selects how transactional email is sent
+type MailEnvironment struct {
+	// Driver is log, resend, smtp or mailbox
+	Driver string
+	// From is the sender, e.g. "Labubu <no-reply@example.com>"
+	From string
+	// DefaultLocale is used when the reader's locale has no templates
+	DefaultLocale string
+	// MailboxDir is where the mailbox driver keeps messages for /dev/mailbox
+	MailboxDir string
+	SMTP       SMTPEnvironment
+}
+
+type SMTPEnvironment struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	// Security is starttls, tls or none
+	Security string
+}
+
+// AccountEnvironment configures the password reset and email verification emails
+type AccountEnvironment struct {
+	// PasswordResetURL is the page that takes the reset token as ?token=
+	PasswordResetURL string
+	PasswordResetTTL time.Duration
+	// VerifyEmailURL is GET /email/verify as users reach it
+	VerifyEmailURL  string
+	VerificationTTL time.Duration
+	// RequireVerifiedEmail keeps unverified accounts out of protected routes
+	RequireVerifiedEmail bool
+}
+
+// LockoutEnvironment configures the throttling of failed logins per email
+type LockoutEnvironment struct {
+	DelayAfter int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	LockAfter  int
+	Duration   time.Duration
+	// ResetAfter is how long without a failure before counting starts over
+	ResetAfter time.Duration
+}
+
 type TokenEnvironment struct {
 	Secret                 string
 	AccessTokenExpireTime  int
@@ -21,6 +72,16 @@ type TokenEnvironment struct {
 	Audience               string
 }
 
+// AccessTokenTTL returns AccessTokenExpireTime as a duration
+func (t TokenEnvironment) AccessTokenTTL() time.Duration {
+	return time.Duration(t.AccessTokenExpireTime) * time.Second
+}
+
+// RefreshTokenTTL returns RefreshTokenExpireTime as a duration
+func (t TokenEnvironment) RefreshTokenTTL() time.Duration {
+	return time.Duration(t.RefreshTokenExpireTime) * time.Second
+}
+
 type R2Environment struct {
 	BucketName      string
 	URL             string
@@ -28,78 +89,740 @@ type R2Environment struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	AccountID       string
+	// Region signs requests; R2 expects auto, MinIO its configured region
+	Region string
+}
+
+// Endpoint is R2_URL, or the account's R2 endpoint when it is not set
+func (r R2Environment) Endpoint() string {
+	if r.URL != "" || r.AccountID == "" {
+		return r.URL
+	}
+	return "https://" + r.AccountID + ".r2.cloudflarestorage.com"
+}
+
+// StorageEnvironment selects where uploaded files are kept
+type StorageEnvironment struct {
+	// Backend is local or r2, which also covers S3 and MinIO
+	Backend string
+	// LocalDir holds the files of the local backend
+	LocalDir string
+	// LocalURL is where the server answers the local backend's presigned URLs
+	LocalURL string
+}
+
+// AttachmentsEnvironment limits the files attached to labubu
+type AttachmentsEnvironment struct {
+	// MaxSize is the largest file accepted, in bytes
+	MaxSize int64
+	// AllowedTypes are media types such as application/pdf, or image/* for every subtype
+	AllowedTypes []string
+	// URLTTL is how long presigned upload and download URLs stay valid
+	URLTTL time.Duration
+	// ThumbnailSizes are the variants written for every image, smallest first
+	ThumbnailSizes []ThumbnailSize
+	// ImageMaxPixels is the largest image decoded; larger ones are not processed
+	ImageMaxPixels int
+}
+
+// ThumbnailSize fits a variant in a Max by Max square
+type ThumbnailSize struct {
+	Name string
+	Max  int
+}
+
+// ShareLinksEnvironment configures the public links to single labubu
+type ShareLinksEnvironment struct {
+	// URL is the public page that opens a link, with the token appended as a path segment
+	URL string
+	// TTL is how long a link stays valid when it is created without an expiry
+	TTL time.Duration
+	// MaxTTL is the latest expiry a link may be given
+	MaxTTL time.Duration
+}
+
+type TelemetryEnvironment struct {
+	ServiceName string
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+}
+
+type HealthEnvironment struct {
+	CheckTimeout       time.Duration
+	ShutdownDrainDelay time.Duration
+}
+
+// MigrationEnvironment controls how the server handles migrations at startup
+type MigrationEnvironment struct {
+	Policy      string
+	LockTimeout time.Duration
+}
+
+// AssetsEnvironment overrides the embedded assets with directories on disk,
+// which is useful while editing migrations or docs during development
+type AssetsEnvironment struct {
+	MigrationsDir string
+	DocsDir       string
+}
+
+// JobsEnvironment configures the background job worker
+type JobsEnvironment struct {
+	// InServer runs the worker inside `serve`; disable it when running `worker` separately
+	InServer        bool
+	Workers         int
+	PollInterval    time.Duration
+	StaleAfter      time.Duration
+	ShutdownTimeout time.Duration
+	// Retention is how long succeeded and dead jobs are kept
+	Retention   time.Duration
+	CleanupSpec string
+}
+
+// SchedulerEnvironment configures recurring tasks. Specs are standard cron
+// expressions evaluated in UTC.
+type SchedulerEnvironment struct {
+	Enabled            bool
+	SessionCleanupSpec string
+	LabubuPurgeSpec    string
+	LabubuPublishSpec  string
+	UsageReportSpec    string
+	SessionRetention   time.Duration
+	LabubuPurgeAfter   time.Duration
+}
+
+// OutboxEnvironment configures the relay that publishes domain events
+type OutboxEnvironment struct {
+	// RelayEnabled runs the relay in `serve` and `worker`; leader election keeps one active
+	RelayEnabled      bool
+	Sinks             []string
+	WebhookURL        string
+	NATSURL           string
+	NATSSubjectPrefix string
+	PollInterval      time.Duration
+	BatchSize         int
+	Retention         time.Duration
+	CleanupSpec       string
+}
+
+// WebhooksEnvironment configures outgoing webhook deliveries
+type WebhooksEnvironment struct {
+	Timeout     time.Duration
+	MaxAttempts int
+	// DisableAfter is the number of consecutive failed attempts that disables a subscription
+	DisableAfter int
+	// AllowPrivate lets subscriptions point at loopback and private addresses, for development
+	AllowPrivate bool
+}
+
+// StreamEnvironment configures the Server-Sent Events endpoints
+type StreamEnvironment struct {
+	HeartbeatInterval time.Duration
+	// BufferSize is how many events may queue for a client before it is disconnected
+	BufferSize   int
+	ReplayLimit  int
+	WriteTimeout time.Duration
+}
+
+// CollabEnvironment configures the WebSocket collaboration endpoint
+type CollabEnvironment struct {
+	PingInterval time.Duration
+	// MessagesPerSecond and Burst limit the messages each connection may send
+	MessagesPerSecond int
+	Burst             int
+	MaxSubscriptions  int
+	// PresenceInterval is how often each replica re-announces who is connected to it
+	PresenceInterval time.Duration
+}
+
+// RateLimitEnvironment is the default request budget per client
+type RateLimitEnvironment struct {
+	Enabled  bool
+	Requests int
+	Window   time.Duration
+	// Operations overrides the budget per operation ID, e.g. a stricter one for login
+	Operations map[string]RateLimitBudget
+}
+
+// RateLimitBudget allows Requests per Window
+type RateLimitBudget struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Dynamic holds the settings that can change at runtime via Reloader.
+// Values are replaced as a whole and must not be mutated.
+type Dynamic struct {
+	LogLevel           slog.Level
+	CORSAllowedOrigins []string
+	RateLimit          RateLimitEnvironment
+	Features           map[string]bool
+}
+
+// Enabled reports whether a feature flag is on
+func (d *Dynamic) Enabled(feature string) bool {
+	return d.Features[feature]
 }
 
 type Environment struct {
+	Env         string
 	APIKey      string
 	Resend      ResendEnvironment
+	Mail        MailEnvironment
 	DatabaseURL string
-	Token       TokenEnvironment
-	R2          R2Environment
-	Port        string
+	RedisURL    string
+	// RateLimitBackend is where request counts are kept: memory or redis
+	RateLimitBackend string
+	Token            TokenEnvironment
+	Lockout          LockoutEnvironment
+	Account          AccountEnvironment
+	R2               R2Environment
+	Storage          StorageEnvironment
+	Attachments      AttachmentsEnvironment
+	ShareLinks       ShareLinksEnvironment
+	Telemetry        TelemetryEnvironment
+	Health           HealthEnvironment
+	Assets           AssetsEnvironment
+	Migrations       MigrationEnvironment
+	Jobs             JobsEnvironment
+	Scheduler        SchedulerEnvironment
+	Outbox           OutboxEnvironment
+	Webhooks         WebhooksEnvironment
+	Stream           StreamEnvironment
+	Collab           CollabEnvironment
+	Port             string
+	Dynamic          Dynamic
+
+	resolved map[string]value
 }
 
-func Load() (*Environment, error) {
-	env := os.Getenv("ENV")
-	if env == "" {
-		env = "development"
-	}
+// IsProduction reports whether ENV is production
+func (e *Environment) IsProduction() bool {
+	return e.Env == "production"
+}
 
-	envFileName := fmt.Sprintf(".env.%s", env)
-	if err := godotenv.Load(envFileName); err != nil {
-		// Fallback to .env if specific env file not found
-		if err := godotenv.Load(); err != nil {
-			return nil, fmt.Errorf("error loading environment files: %w", err)
-		}
-	}
+// options configure Load
+type options struct {
+	configFile string
+	overrides  map[string]string
+}
 
-	accessTokenExpireTime := 3600 // default 1 hour
-	if val := os.Getenv("ACCESS_TOKEN_EXPIRE_TIME"); val != "" {
-		var err error
-		accessTokenExpireTime, err = strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("error converting ACCESS_TOKEN_EXPIRE_TIME to int: %w", err)
-		}
+// Option configures Load
+type Option func(*options)
+
+// WithConfigFile reads a YAML or TOML config file; it takes precedence over CONFIG_FILE
+func WithConfigFile(path string) Option {
+	return func(o *options) { o.configFile = path }
+}
+
+// WithOverrides applies values on top of every other source, e.g. from CLI flags
+func WithOverrides(overrides map[string]string) Option {
+	return func(o *options) { o.overrides = overrides }
+}
+
+// Load resolves the configuration from, in increasing precedence: defaults,
+// an optional config file, .env and .env.<ENV>, the process environment and
+// overrides. Any KEY can instead be given as KEY_FILE pointing at a file that
+// holds the value. Every invalid value is reported in the returned error.
+func Load(opts ...Option) (*Environment, error) {
+	o := options{configFile: os.Getenv("CONFIG_FILE")}
+	for _, opt := range opts {
+		opt(&o)
 	}
 
-	refreshTokenExpireTime := 604800 // default 7 days
-	if val := os.Getenv("REFRESH_TOKEN_EXPIRE_TIME"); val != "" {
-		var err error
-		refreshTokenExpireTime, err = strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("error converting REFRESH_TOKEN_EXPIRE_TIME to int: %w", err)
-		}
+	l := newLayers()
+	if o.configFile != "" {
+		l.loadConfigFile(o.configFile)
 	}
+	l.loadEnvFiles(envName(l, o.overrides))
+	l.loadProcessEnv()
+	l.applyMap(o.overrides, SourceFlag, true)
 
-	return &Environment{
-		APIKey: os.Getenv("API_KEY"),
+	p := &parser{values: l.values}
+	config := &Environment{
+		Env:              p.oneOf("ENV", "development", "test", "staging", "production"),
+		APIKey:           p.string("API_KEY"),
+		DatabaseURL:      p.string("DATABASE_URL"),
+		RedisURL:         p.string("REDIS_URL"),
+		RateLimitBackend: p.oneOf("RATE_LIMIT_BACKEND", "memory", "redis"),
 		Resend: ResendEnvironment{
-			Url: os.Getenv("RESEND_URL"),
-			Key: os.Getenv("RESEND_KEY"),
+			Url: p.string("RESEND_URL"),
+			Key: p.string("RESEND_KEY"),
+		},
+		Mail: MailEnvironment{
+			Driver:        p.oneOf("MAIL_DRIVER", "log", "resend", "smtp", "mailbox"),
+			From:          p.string("MAIL_FROM"),
+			DefaultLocale: p.string("MAIL_DEFAULT_LOCALE"),
+			MailboxDir:    p.string("MAILBOX_DIR"),
+			SMTP: SMTPEnvironment{
+				Host:     p.string("SMTP_HOST"),
+				Port:     p.port("SMTP_PORT"),
+				Username: p.string("SMTP_USERNAME"),
+				Password: p.string("SMTP_PASSWORD"),
+				Security: p.oneOf("SMTP_SECURITY", "starttls", "tls", "none"),
+			},
+		},
+		Account: AccountEnvironment{
+			PasswordResetURL:     p.absoluteURL("PASSWORD_RESET_URL"),
+			PasswordResetTTL:     p.positiveDuration("PASSWORD_RESET_TTL"),
+			VerifyEmailURL:       p.absoluteURL("EMAIL_VERIFY_URL"),
+			VerificationTTL:      p.positiveDuration("EMAIL_VERIFICATION_TTL"),
+			RequireVerifiedEmail: p.bool("REQUIRE_VERIFIED_EMAIL"),
+		},
+		Lockout: LockoutEnvironment{
+			DelayAfter: p.positiveInt("LOGIN_DELAY_AFTER"),
+			BaseDelay:  p.positiveDuration("LOGIN_DELAY_BASE"),
+			MaxDelay:   p.positiveDuration("LOGIN_DELAY_MAX"),
+			LockAfter:  p.positiveInt("LOGIN_LOCK_AFTER"),
+			Duration:   p.positiveDuration("LOGIN_LOCK_DURATION"),
+			ResetAfter: p.positiveDuration("LOGIN_FAILURE_RESET"),
 		},
-		DatabaseURL: os.Getenv("DATABASE_URL"),
 		Token: TokenEnvironment{
-			Secret:                 getEnvOrDefault("JWT_SECRET", "dev-secret-key-change-in-production"),
-			AccessTokenExpireTime:  accessTokenExpireTime,
-			RefreshTokenExpireTime: refreshTokenExpireTime,
-			Issuer:                 getEnvOrDefault("ISSUER", "go-api-starter"),
-			Audience:               getEnvOrDefault("AUDIENCE", "api-users"),
+			Secret:                 p.string("JWT_SECRET"),
+			AccessTokenExpireTime:  p.positiveInt("ACCESS_TOKEN_EXPIRE_TIME"),
+			RefreshTokenExpireTime: p.positiveInt("REFRESH_TOKEN_EXPIRE_TIME"),
+			Issuer:                 p.string("ISSUER"),
+			Audience:               p.string("AUDIENCE"),
 		},
 		R2: R2Environment{
-			BucketName:      os.Getenv("R2_BUCKET_NAME"),
-			URL:             os.Getenv("R2_URL"),
-			TokenValue:      os.Getenv("R2_TOKEN_VALUE"),
-			AccessKeyID:     os.Getenv("R2_ACCESS_KEY_ID"),
-			SecretAccessKey: os.Getenv("R2_SECRET_ACCESS_KEY"),
-			AccountID:       os.Getenv("R2_ACCOUNT_ID"),
+			BucketName:      p.string("R2_BUCKET_NAME"),
+			URL:             p.string("R2_URL"),
+			TokenValue:      p.string("R2_TOKEN_VALUE"),
+			AccessKeyID:     p.string("R2_ACCESS_KEY_ID"),
+			SecretAccessKey: p.string("R2_SECRET_ACCESS_KEY"),
+			AccountID:       p.string("R2_ACCOUNT_ID"),
+			Region:          p.string("R2_REGION"),
+		},
+		Storage: StorageEnvironment{
+			Backend:  p.oneOf("STORAGE_BACKEND", "local", "r2"),
+			LocalDir: p.string("STORAGE_LOCAL_DIR"),
+			LocalURL: p.absoluteURL("STORAGE_LOCAL_URL"),
+		},
+		Attachments: AttachmentsEnvironment{
+			MaxSize:        int64(p.positiveInt("ATTACHMENTS_MAX_BYTES")),
+			AllowedTypes:   p.list("ATTACHMENTS_ALLOWED_TYPES"),
+			URLTTL:         p.positiveDuration("ATTACHMENTS_URL_TTL"),
+			ThumbnailSizes: p.thumbnailSizes("THUMBNAIL_SIZES"),
+			ImageMaxPixels: p.positiveInt("IMAGE_MAX_PIXELS"),
 		},
-		Port: getEnvOrDefault("PORT", "8080"),
-	}, nil
+		ShareLinks: ShareLinksEnvironment{
+			URL:    p.absoluteURL("SHARE_LINK_URL"),
+			TTL:    p.positiveDuration("SHARE_LINK_TTL"),
+			MaxTTL: p.positiveDuration("SHARE_LINK_MAX_TTL"),
+		},
+		Telemetry: TelemetryEnvironment{
+			ServiceName: p.string("OTEL_SERVICE_NAME"),
+			Exporter:    p.oneOf("OTEL_TRACES_EXPORTER", "none", "stdout", "otlp-http", "otlp-grpc"),
+			Endpoint:    p.string("OTEL_EXPORTER_OTLP_ENDPOINT"),
+			Insecure:    p.bool("OTEL_EXPORTER_OTLP_INSECURE"),
+			SampleRatio: p.ratio("OTEL_TRACES_SAMPLER_ARG"),
+		},
+		Health: HealthEnvironment{
+			CheckTimeout:       p.positiveDuration("READINESS_CHECK_TIMEOUT"),
+			ShutdownDrainDelay: p.duration("SHUTDOWN_DRAIN_DELAY"),
+		},
+		Assets: AssetsEnvironment{
+			MigrationsDir: p.string("MIGRATIONS_DIR"),
+			DocsDir:       p.string("DOCS_DIR"),
+		},
+		Migrations: MigrationEnvironment{
+			Policy:      p.oneOf("MIGRATE_ON_STARTUP", "auto", "check-only", "off"),
+			LockTimeout: p.positiveDuration("MIGRATION_LOCK_TIMEOUT"),
+		},
+		Jobs: JobsEnvironment{
+			InServer:        p.bool("JOBS_IN_SERVER"),
+			Workers:         p.positiveInt("JOBS_WORKERS"),
+			PollInterval:    p.positiveDuration("JOBS_POLL_INTERVAL"),
+			StaleAfter:      p.positiveDuration("JOBS_STALE_AFTER"),
+			ShutdownTimeout: p.positiveDuration("JOBS_SHUTDOWN_TIMEOUT"),
+			Retention:       p.duration("JOBS_RETENTION"),
+			CleanupSpec:     p.cron("SCHEDULE_JOBS_CLEANUP"),
+		},
+		Scheduler: SchedulerEnvironment{
+			Enabled:            p.bool("SCHEDULER_ENABLED"),
+			SessionCleanupSpec: p.cron("SCHEDULE_SESSION_CLEANUP"),
+			LabubuPurgeSpec:    p.cron("SCHEDULE_LABUBU_PURGE"),
+			LabubuPublishSpec:  p.cron("SCHEDULE_LABUBU_PUBLISH"),
+			UsageReportSpec:    p.cron("SCHEDULE_USAGE_REPORT"),
+			SessionRetention:   p.duration("SESSION_RETENTION"),
+			LabubuPurgeAfter:   p.duration("LABUBU_PURGE_AFTER"),
+		},
+		Outbox: OutboxEnvironment{
+			RelayEnabled:      p.bool("OUTBOX_RELAY_ENABLED"),
+			Sinks:             p.subset("OUTBOX_SINKS", "log", "webhook", "nats", "webhooks"),
+			WebhookURL:        p.string("OUTBOX_WEBHOOK_URL"),
+			NATSURL:           p.string("OUTBOX_NATS_URL"),
+			NATSSubjectPrefix: p.string("OUTBOX_NATS_SUBJECT_PREFIX"),
+			PollInterval:      p.positiveDuration("OUTBOX_POLL_INTERVAL"),
+			BatchSize:         p.positiveInt("OUTBOX_BATCH_SIZE"),
+			Retention:         p.duration("OUTBOX_RETENTION"),
+			CleanupSpec:       p.cron("SCHEDULE_OUTBOX_CLEANUP"),
+		},
+		Webhooks: WebhooksEnvironment{
+			Timeout:      p.positiveDuration("WEBHOOKS_TIMEOUT"),
+			MaxAttempts:  p.positiveInt("WEBHOOKS_MAX_ATTEMPTS"),
+			DisableAfter: p.positiveInt("WEBHOOKS_DISABLE_AFTER"),
+			AllowPrivate: p.bool("WEBHOOKS_ALLOW_PRIVATE"),
+		},
+		Stream: StreamEnvironment{
+			HeartbeatInterval: p.positiveDuration("STREAM_HEARTBEAT_INTERVAL"),
+			BufferSize:        p.positiveInt("STREAM_BUFFER_SIZE"),
+			ReplayLimit:       p.positiveInt("STREAM_REPLAY_LIMIT"),
+			WriteTimeout:      p.positiveDuration("STREAM_WRITE_TIMEOUT"),
+		},
+		Collab: CollabEnvironment{
+			PingInterval:      p.positiveDuration("WS_PING_INTERVAL"),
+			MessagesPerSecond: p.positiveInt("WS_MESSAGES_PER_SECOND"),
+			Burst:             p.positiveInt("WS_BURST"),
+			MaxSubscriptions:  p.positiveInt("WS_MAX_SUBSCRIPTIONS"),
+			PresenceInterval:  p.positiveDuration("PRESENCE_ANNOUNCE_INTERVAL"),
+		},
+		Port: p.port("PORT"),
+		Dynamic: Dynamic{
+			LogLevel:           p.logLevel("LOG_LEVEL"),
+			CORSAllowedOrigins: p.list("CORS_ALLOWED_ORIGINS"),
+			RateLimit: RateLimitEnvironment{
+				Enabled:    p.bool("RATE_LIMIT_ENABLED"),
+				Requests:   p.positiveInt("RATE_LIMIT_REQUESTS"),
+				Window:     p.positiveDuration("RATE_LIMIT_WINDOW"),
+				Operations: p.budgets("RATE_LIMIT_OPERATIONS"),
+			},
+			Features: p.flags("FEATURE_FLAGS"),
+		},
+		resolved: l.values,
+	}
+
+	p.errs = append(p.errs, validateOutbox(config.Outbox)...)
+	p.errs = append(p.errs, validateMail(config)...)
+	p.errs = append(p.errs, validateStorage(config)...)
+	if config.ShareLinks.TTL > config.ShareLinks.MaxTTL {
+		p.errs = append(p.errs, errors.New("SHARE_LINK_TTL: must not exceed SHARE_LINK_MAX_TTL"))
+	}
+	if config.RateLimitBackend == "redis" && config.RedisURL == "" {
+		p.errs = append(p.errs, errors.New("RATE_LIMIT_BACKEND: redis requires REDIS_URL"))
+	}
+	if config.IsProduction() {
+		p.errs = append(p.errs, validateProduction(config)...)
+	}
+
+	if err := errors.Join(append(l.errs, p.errs...)...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return config, nil
 }
 
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// envName picks ENV before env files are read, since it selects which file to load
+func envName(l *layers, overrides map[string]string) string {
+	if env := overrides["ENV"]; env != "" {
+		return env
+	}
+	if env := os.Getenv("ENV"); env != "" {
+		return env
 	}
-	return defaultValue
-}
\ No newline at end of file
+	return l.values["ENV"].raw
+}
+
+// placeholderSecrets are the example values shipped with the repo
+var placeholderSecrets = map[string]bool{
+	defaultJWTSecret:       true,
+	"your-secret-key-here": true,
+}
+
+// minProductionSecretLength is the shortest JWT secret accepted in production
+const minProductionSecretLength = 32
+
+// validateProduction refuses insecure defaults
+func validateProduction(config *Environment) []error {
+	var errs []error
+	secret := config.Token.Secret
+	switch {
+	case placeholderSecrets[secret]:
+		errs = append(errs, errors.New("JWT_SECRET: the placeholder secret is not allowed in production"))
+	case len(secret) < minProductionSecretLength:
+		errs = append(errs, fmt.Errorf("JWT_SECRET: must be at least %d characters in production", minProductionSecretLength))
+	}
+	if config.DatabaseURL == "" {
+		errs = append(errs, errors.New("DATABASE_URL: required in production"))
+	}
+	if config.Webhooks.AllowPrivate {
+		errs = append(errs, errors.New("WEBHOOKS_ALLOW_PRIVATE: deliveries to internal addresses are not allowed in production"))
+	}
+	if config.Migrations.Policy == "auto" {
+		slog.Warn("MIGRATE_ON_STARTUP=auto in production; prefer running `migrate up` as a release step")
+	}
+	return errs
+}
+
+// validateMail requires the settings of the chosen mail driver
+func validateMail(config *Environment) []error {
+	var errs []error
+	mailCfg := config.Mail
+	if (mailCfg.Driver == "resend" || mailCfg.Driver == "smtp") && mailCfg.From == "" {
+		errs = append(errs, fmt.Errorf("MAIL_FROM: required when MAIL_DRIVER is %s", mailCfg.Driver))
+	}
+	switch mailCfg.Driver {
+	case "resend":
+		if config.Resend.Key == "" {
+			errs = append(errs, errors.New("RESEND_KEY: required when MAIL_DRIVER is resend"))
+		}
+	case "smtp":
+		if mailCfg.SMTP.Host == "" {
+			errs = append(errs, errors.New("SMTP_HOST: required when MAIL_DRIVER is smtp"))
+		}
+	case "mailbox":
+		if mailCfg.MailboxDir == "" {
+			errs = append(errs, errors.New("MAILBOX_DIR: required when MAIL_DRIVER is mailbox"))
+		}
+		if config.IsProduction() {
+			errs = append(errs, errors.New("MAIL_DRIVER: mailbox only keeps mail locally and is not allowed in production"))
+		}
+	}
+	return errs
+}
+
+// validateStorage requires the bucket and credentials of the r2 backend
+func validateStorage(config *Environment) []error {
+	var errs []error
+	switch config.Storage.Backend {
+	case "local":
+		if config.Storage.LocalDir == "" {
+			errs = append(errs, errors.New("STORAGE_LOCAL_DIR: required when STORAGE_BACKEND is local"))
+		}
+	case "r2":
+		r2 := config.R2
+		required := []struct{ key, value string }{
+			{"R2_BUCKET_NAME", r2.BucketName},
+			{"R2_ACCESS_KEY_ID", r2.AccessKeyID},
+			{"R2_SECRET_ACCESS_KEY", r2.SecretAccessKey},
+		}
+		for _, setting := range required {
+			if setting.value == "" {
+				errs = append(errs, fmt.Errorf("%s: required when STORAGE_BACKEND is r2", setting.key))
+			}
+		}
+		if r2.Endpoint() == "" {
+			errs = append(errs, errors.New("R2_URL: required when STORAGE_BACKEND is r2 and R2_ACCOUNT_ID is not set"))
+		}
+	}
+	return errs
+}
+
+// validateOutbox requires a destination for every enabled sink
+func validateOutbox(outbox OutboxEnvironment) []error {
+	var errs []error
+	for _, sink := range outbox.Sinks {
+		switch {
+		case sink == "webhook" && outbox.WebhookURL == "":
+			errs = append(errs, errors.New("OUTBOX_WEBHOOK_URL: required when OUTBOX_SINKS includes webhook"))
+		case sink == "nats" && outbox.NATSURL == "":
+			errs = append(errs, errors.New("OUTBOX_NATS_URL: required when OUTBOX_SINKS includes nats"))
+		}
+	}
+	return errs
+}
+
+// parser converts raw values, collecting every error instead of stopping at the first
+type parser struct {
+	values map[string]value
+	errs   []error
+}
+
+func (p *parser) errorf(key, format string, args ...any) {
+	v := p.values[key]
+	p.errs = append(p.errs, fmt.Errorf("%s=%q (from %s): %s", key, v.raw, v.source, fmt.Sprintf(format, args...)))
+}
+
+func (p *parser) string(key string) string {
+	return p.values[key].raw
+}
+
+func (p *parser) oneOf(key string, allowed ...string) string {
+	raw := p.string(key)
+	for _, a := range allowed {
+		if raw == a {
+			return raw
+		}
+	}
+	p.errorf(key, "must be one of %s", strings.Join(allowed, ", "))
+	return raw
+}
+
+func (p *parser) positiveInt(key string) int {
+	n, err := strconv.Atoi(p.string(key))
+	if err != nil {
+		p.errorf(key, "not an integer")
+		return 0
+	}
+	if n <= 0 {
+		p.errorf(key, "must be greater than 0")
+	}
+	return n
+}
+
+// absoluteURL requires an http or https URL, since it ends up in emails
+func (p *parser) absoluteURL(key string) string {
+	raw := p.string(key)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.errorf(key, "must be an absolute http or https URL")
+	}
+	return raw
+}
+
+func (p *parser) port(key string) string {
+	raw := p.string(key)
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 || n > 65535 {
+		p.errorf(key, "must be a port number between 1 and 65535")
+	}
+	return raw
+}
+
+func (p *parser) bool(key string) bool {
+	b, err := strconv.ParseBool(p.string(key))
+	if err != nil {
+		p.errorf(key, "not a boolean")
+	}
+	return b
+}
+
+func (p *parser) ratio(key string) float64 {
+	f, err := strconv.ParseFloat(p.string(key), 64)
+	if err != nil {
+		p.errorf(key, "not a number")
+		return 0
+	}
+	if f < 0 || f > 1 {
+		p.errorf(key, "must be between 0 and 1")
+	}
+	return f
+}
+
+func (p *parser) duration(key string) time.Duration {
+	d, err := time.ParseDuration(p.string(key))
+	switch {
+	case err != nil:
+		p.errorf(key, "not a duration (e.g. 500ms, 2s, 1m)")
+	case d < 0:
+		p.errorf(key, "must not be negative")
+	}
+	return d
+}
+
+func (p *parser) positiveDuration(key string) time.Duration {
+	d, err := time.ParseDuration(p.string(key))
+	switch {
+	case err != nil:
+		p.errorf(key, "not a duration (e.g. 500ms, 2s, 1m)")
+	case d <= 0:
+		p.errorf(key, "must be greater than 0")
+	}
+	return d
+}
+
+func (p *parser) cron(key string) string {
+	raw := p.string(key)
+	if _, err := cron.ParseStandard(raw); err != nil {
+		p.errorf(key, "invalid cron expression: %v", err)
+	}
+	return raw
+}
+
+func (p *parser) logLevel(key string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(p.string(key))); err != nil {
+		p.errorf(key, "must be debug, info, warn or error")
+	}
+	return level
+}
+
+// list splits a comma-separated value, dropping empty entries
+func (p *parser) list(key string) []string {
+	var out []string
+	for _, item := range strings.Split(p.string(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// subset parses a list whose entries must each be one of allowed
+func (p *parser) subset(key string, allowed ...string) []string {
+	items := p.list(key)
+	for _, item := range items {
+		if !slices.Contains(allowed, item) {
+			p.errorf(key, "%q is not one of %s", item, strings.Join(allowed, ", "))
+		}
+	}
+	return items
+}
+
+// budgets parses "login=5/1m,refreshToken=30/1m" into request budgets per name
+func (p *parser) budgets(key string) map[string]RateLimitBudget {
+	out := make(map[string]RateLimitBudget)
+	for _, item := range p.list(key) {
+		name, budget, hasBudget := strings.Cut(item, "=")
+		requests, window, hasWindow := strings.Cut(budget, "/")
+		if !hasBudget || !hasWindow {
+			p.errorf(key, "%q: expected name=requests/window", item)
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(requests))
+		if err != nil || n <= 0 {
+			p.errorf(key, "%q: requests must be a positive integer", item)
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(window))
+		if err != nil || d <= 0 {
+			p.errorf(key, "%q: window must be a positive duration", item)
+			continue
+		}
+		out[strings.TrimSpace(name)] = RateLimitBudget{Requests: n, Window: d}
+	}
+	return out
+}
+
+// thumbnailSizes parses "small=160,medium=480" into sizes sorted from the smallest
+func (p *parser) thumbnailSizes(key string) []ThumbnailSize {
+	var out []ThumbnailSize
+	for _, item := range p.list(key) {
+		name, raw, hasSize := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !hasSize || name == "" {
+			p.errorf(key, "%q: expected name=pixels", item)
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || n <= 0 {
+			p.errorf(key, "%q: pixels must be a positive integer", item)
+			continue
+		}
+		if slices.ContainsFunc(out, func(s ThumbnailSize) bool { return s.Name == name }) {
+			p.errorf(key, "%q: duplicate name", item)
+			continue
+		}
+		out = append(out, ThumbnailSize{Name: name, Max: n})
+	}
+	slices.SortStableFunc(out, func(a, b ThumbnailSize) int { return a.Max - b.Max })
+	return out
+}
+
+// flags parses "a,b=false,c=true" into a set of feature flags
+func (p *parser) flags(key string) map[string]bool {
+	out := make(map[string]bool)
+	for _, item := range p.list(key) {
+		name, raw, hasValue := strings.Cut(item, "=")
+		enabled := true
+		if hasValue {
+			var err error
+			if enabled, err = strconv.ParseBool(raw); err != nil {
+				p.errorf(key, "flag %q: not a boolean", name)
+				continue
+			}
+		}
+		out[strings.TrimSpace(name)] = enabled
+	}
+	return out
+}
@@ -0,0 +1,235 @@
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// inTempDir runs the test where no .env files exist unless it writes them
+func inTempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// source returns where key was resolved from
+func source(config *Environment, key string) string {
+	for _, v := range config.Resolved() {
+		if v.Key == key {
+			return v.Source
+		}
+	}
+	return ""
+}
+
+func TestLoadLayering(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		dotEnv     string
+		envFile    string
+		processEnv string
+		overrides  map[string]string
+		wantValue  int
+		wantSource string
+	}{
+		{
+			name:       "default",
+			wantValue:  3600,
+			wantSource: SourceDefault,
+		},
+		{
+			name:       "config file over default",
+			configFile: "access_token_expire_time: 5\n",
+			wantValue:  5,
+			wantSource: SourceFile,
+		},
+		{
+			name:       ".env over config file",
+			configFile: "access_token_expire_time: 5\n",
+			dotEnv:     "ACCESS_TOKEN_EXPIRE_TIME=6\n",
+			wantValue:  6,
+			wantSource: SourceEnvFile,
+		},
+		{
+			name:       ".env.<ENV> over .env",
+			dotEnv:     "ACCESS_TOKEN_EXPIRE_TIME=6\n",
+			envFile:    "ACCESS_TOKEN_EXPIRE_TIME=7\n",
+			wantValue:  7,
+			wantSource: SourceEnvFile,
+		},
+		{
+			name:       "empty value keeps the lower layer",
+			configFile: "access_token_expire_time: 5\n",
+			dotEnv:     "ACCESS_TOKEN_EXPIRE_TIME=\n",
+			wantValue:  5,
+			wantSource: SourceFile,
+		},
+		{
+			name:       "process environment over env files",
+			dotEnv:     "ACCESS_TOKEN_EXPIRE_TIME=6\n",
+			processEnv: "8",
+			wantValue:  8,
+			wantSource: SourceEnv,
+		},
+		{
+			name:       "overrides over everything",
+			configFile: "access_token_expire_time: 5\n",
+			dotEnv:     "ACCESS_TOKEN_EXPIRE_TIME=6\n",
+			processEnv: "8",
+			overrides:  map[string]string{"ACCESS_TOKEN_EXPIRE_TIME": "9"},
+			wantValue:  9,
+			wantSource: SourceFlag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := inTempDir(t)
+			var opts []Option
+			if tt.configFile != "" {
+				path := filepath.Join(dir, "config.yaml")
+				writeFile(t, path, tt.configFile)
+				opts = append(opts, WithConfigFile(path))
+			}
+			if tt.dotEnv != "" {
+				writeFile(t, ".env", tt.dotEnv)
+			}
+			if tt.envFile != "" {
+				writeFile(t, ".env.test", tt.envFile)
+			}
+			t.Setenv("ENV", "test")
+			if tt.processEnv != "" {
+				t.Setenv("ACCESS_TOKEN_EXPIRE_TIME", tt.processEnv)
+			}
+			if tt.overrides != nil {
+				opts = append(opts, WithOverrides(tt.overrides))
+			}
+
+			config, err := Load(opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Token.AccessTokenExpireTime != tt.wantValue {
+				t.Errorf("ACCESS_TOKEN_EXPIRE_TIME = %d, want %d", config.Token.AccessTokenExpireTime, tt.wantValue)
+			}
+			if got := source(config, "ACCESS_TOKEN_EXPIRE_TIME"); got != tt.wantSource {
+				t.Errorf("source = %q, want %q", got, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	dir := inTempDir(t)
+	path := filepath.Join(dir, "api_key")
+	writeFile(t, path, "from-a-file\n")
+	t.Setenv("API_KEY_FILE", path)
+
+	config, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.APIKey != "from-a-file" {
+		t.Errorf("API_KEY = %q, want the trimmed file content", config.APIKey)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		env        map[string]string
+		want       string
+	}{
+		{
+			name:       "unknown key in config file",
+			configFile: "no_such_setting: 1\n",
+			want:       `unknown setting "NO_SUCH_SETTING"`,
+		},
+		{
+			name: "invalid number",
+			env:  map[string]string{"ACCESS_TOKEN_EXPIRE_TIME": "many"},
+			want: "ACCESS_TOKEN_EXPIRE_TIME",
+		},
+		{
+			name: "value and file both set",
+			env:  map[string]string{"API_KEY": "a", "API_KEY_FILE": "/nonexistent"},
+			want: "API_KEY and API_KEY_FILE are both set",
+		},
+		{
+			name: "value outside the allowed set",
+			env:  map[string]string{"MIGRATE_ON_STARTUP": "sometimes"},
+			want: "must be one of auto, check-only, off",
+		},
+		{
+			name: "redis backend without url",
+			env:  map[string]string{"RATE_LIMIT_BACKEND": "redis"},
+			want: "RATE_LIMIT_BACKEND: redis requires REDIS_URL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := inTempDir(t)
+			var opts []Option
+			if tt.configFile != "" {
+				path := filepath.Join(dir, "config.yaml")
+				writeFile(t, path, tt.configFile)
+				opts = append(opts, WithConfigFile(path))
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := Load(opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateProduction(t *testing.T) {
+	strongSecret := strings.Repeat("s", minProductionSecretLength)
+
+	tests := []struct {
+		name         string
+		secret       string
+		dbURL        string
+		allowPrivate bool
+		want         []string
+	}{
+		{"valid", strongSecret, "postgres://db/app", false, nil},
+		{"placeholder secret", defaultJWTSecret, "postgres://db/app", false, []string{"placeholder secret"}},
+		{"short secret", "short", "postgres://db/app", false, []string{"at least 32 characters"}},
+		{"missing database", strongSecret, "", false, []string{"DATABASE_URL"}},
+		{"private webhooks", strongSecret, "postgres://db/app", true, []string{"WEBHOOKS_ALLOW_PRIVATE"}},
+		{"everything wrong", "short", "", true, []string{"JWT_SECRET", "DATABASE_URL", "WEBHOOKS_ALLOW_PRIVATE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Environment{DatabaseURL: tt.dbURL}
+			config.Token.Secret = tt.secret
+			config.Webhooks.AllowPrivate = tt.allowPrivate
+
+			errs := validateProduction(config)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got %d errors %v, want %d", len(errs), errs, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to mention %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
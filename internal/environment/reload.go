@@ -0,0 +1,113 @@
+package environment
+
+import (
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// Change is a setting whose value differs between two loads
+type Change struct {
+	Key        string `json:"key"`
+	Old        string `json:"old"`
+	New        string `json:"new"`
+	Reloadable bool   `json:"reloadable"`
+}
+
+// Subscriber is notified after the dynamic settings have been swapped
+type Subscriber func(old, new *Dynamic)
+
+// Reloader re-reads the configuration sources and swaps the dynamic settings.
+// Static settings keep the values they had at startup; changes to them are
+// reported but only take effect after a restart.
+type Reloader struct {
+	opts []Option
+
+	mu          sync.Mutex
+	applied     map[string]ResolvedValue
+	subscribers []Subscriber
+
+	dynamic atomic.Pointer[Dynamic]
+}
+
+// NewReloader creates a Reloader seeded with the environment loaded at startup.
+// opts must be the options that produced initial.
+func NewReloader(initial *Environment, opts ...Option) *Reloader {
+	r := &Reloader{
+		opts:    opts,
+		applied: make(map[string]ResolvedValue),
+	}
+	for _, v := range initial.Resolved() {
+		r.applied[v.Key] = v
+	}
+	dynamic := initial.Dynamic
+	r.dynamic.Store(&dynamic)
+	return r
+}
+
+// Dynamic returns the current dynamic settings
+func (r *Reloader) Dynamic() *Dynamic {
+	return r.dynamic.Load()
+}
+
+// Subscribe registers fn to run after every successful reload
+func (r *Reloader) Subscribe(fn Subscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload loads and validates every source again. On error nothing changes.
+func (r *Reloader) Reload() ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := Load(r.opts...)
+	if err != nil {
+		slog.Error("Configuration reload rejected", "error", err)
+		return nil, err
+	}
+
+	changes := r.diff(next)
+
+	dynamic := next.Dynamic
+	old := r.dynamic.Swap(&dynamic)
+	for _, fn := range r.subscribers {
+		fn(old, &dynamic)
+	}
+
+	if len(changes) == 0 {
+		slog.Info("Configuration reloaded, no changes")
+	}
+	for _, c := range changes {
+		if c.Reloadable {
+			slog.Info("Configuration changed", "key", c.Key, "old", c.Old, "new", c.New)
+		} else {
+			slog.Warn("Configuration changed but requires a restart", "key", c.Key, "old", c.Old, "new", c.New)
+		}
+	}
+	return changes, nil
+}
+
+// diff compares next against the values currently in effect and records the
+// reloadable ones as applied. Static changes keep being reported until a restart.
+func (r *Reloader) diff(next *Environment) []Change {
+	var changes []Change
+	for _, v := range next.Resolved() {
+		prev := r.applied[v.Key]
+		if prev.Value == v.Value {
+			continue
+		}
+		s, _ := lookupSetting(v.Key)
+		changes = append(changes, Change{
+			Key:        v.Key,
+			Old:        prev.Redacted(),
+			New:        v.Redacted(),
+			Reloadable: s.Reloadable,
+		})
+		if s.Reloadable {
+			r.applied[v.Key] = v
+		}
+	}
+	return changes
+}
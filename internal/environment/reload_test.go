@@ -0,0 +1,79 @@
+package environment
+
+import (
+	"testing"
+)
+
+// change returns the reported change of key
+func change(changes []Change, key string) (Change, bool) {
+	for _, c := range changes {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return Change{}, false
+}
+
+func TestReloadFeatureFlags(t *testing.T) {
+	inTempDir(t)
+	t.Setenv("FEATURE_FLAGS", "search,beta=false")
+
+	initial, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewReloader(initial)
+	if !r.Dynamic().Enabled("search") || r.Dynamic().Enabled("beta") {
+		t.Fatalf("Features = %v, want search on and beta off", r.Dynamic().Features)
+	}
+
+	var notified []*Dynamic
+	r.Subscribe(func(old, new *Dynamic) { notified = append(notified, old, new) })
+
+	t.Setenv("FEATURE_FLAGS", "search=false,beta")
+	changes, err := r.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := change(changes, "FEATURE_FLAGS"); !ok || !c.Reloadable {
+		t.Errorf("changes = %+v, want a reloadable FEATURE_FLAGS change", changes)
+	}
+	if r.Dynamic().Enabled("search") || !r.Dynamic().Enabled("beta") {
+		t.Errorf("Features = %v after reload, want search off and beta on", r.Dynamic().Features)
+	}
+	if len(notified) != 2 || !notified[0].Enabled("search") || !notified[1].Enabled("beta") {
+		t.Errorf("subscriber did not see the old and new flags")
+	}
+
+	// An invalid value is rejected and the flags in effect stay
+	t.Setenv("FEATURE_FLAGS", "search=maybe")
+	if _, err := r.Reload(); err == nil {
+		t.Fatal("Reload() accepted a flag that is not a boolean")
+	}
+	if !r.Dynamic().Enabled("beta") {
+		t.Errorf("Features = %v after a rejected reload, want them unchanged", r.Dynamic().Features)
+	}
+}
+
+func TestReloadStaticSetting(t *testing.T) {
+	inTempDir(t)
+	t.Setenv("PORT", "8080")
+
+	initial, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewReloader(initial)
+
+	t.Setenv("PORT", "9090")
+	for range 2 {
+		changes, err := r.Reload()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Reported on every reload until a restart picks it up
+		if c, ok := change(changes, "PORT"); !ok || c.Reloadable {
+			t.Errorf("changes = %+v, want PORT reported as requiring a restart", changes)
+		}
+	}
+}
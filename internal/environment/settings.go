@@ -0,0 +1,175 @@
+package environment
+
+import (
+	"net/url"
+	"sort"
+)
+
+// defaultJWTSecret is the development fallback that production refuses
+const defaultJWTSecret = "dev-secret-key-change-in-production"
+
+// setting describes a configuration key and its default value. Reloadable
+// settings are applied by Reloader without a restart.
+type setting struct {
+	Key        string
+	Default    string
+	Secret     bool
+	Reloadable bool
+}
+
+// settings lists every key Load understands
+var settings = []setting{
+	{Key: "ENV", Default: "development"},
+	{Key: "PORT", Default: "8080"},
+	{Key: "API_KEY", Secret: true},
+	{Key: "DATABASE_URL", Secret: true},
+	{Key: "REDIS_URL", Secret: true},
+	{Key: "RATE_LIMIT_BACKEND", Default: "memory"},
+	{Key: "RESEND_URL"},
+	{Key: "RESEND_KEY", Secret: true},
+	{Key: "MAIL_DRIVER", Default: "log"},
+	{Key: "MAIL_FROM"},
+	{Key: "MAIL_DEFAULT_LOCALE", Default: "en"},
+	{Key: "MAILBOX_DIR", Default: "tmp/mailbox"},
+	{Key: "SMTP_HOST"},
+	{Key: "SMTP_PORT", Default: "587"},
+	{Key: "SMTP_USERNAME"},
+	{Key: "SMTP_PASSWORD", Secret: true},
+	{Key: "SMTP_SECURITY", Default: "starttls"},
+	{Key: "JWT_SECRET", Default: defaultJWTSecret, Secret: true},
+	{Key: "ACCESS_TOKEN_EXPIRE_TIME", Default: "3600"},
+	{Key: "REFRESH_TOKEN_EXPIRE_TIME", Default: "604800"},
+	{Key: "PASSWORD_RESET_URL", Default: "http://localhost:3000/reset-password"},
+	{Key: "PASSWORD_RESET_TTL", Default: "1h"},
+	{Key: "EMAIL_VERIFY_URL", Default: "http://localhost:8080/email/verify"},
+	{Key: "EMAIL_VERIFICATION_TTL", Default: "48h"},
+	{Key: "REQUIRE_VERIFIED_EMAIL", Default: "false"},
+	{Key: "LOGIN_DELAY_AFTER", Default: "3"},
+	{Key: "LOGIN_DELAY_BASE", Default: "1s"},
+	{Key: "LOGIN_DELAY_MAX", Default: "30s"},
+	{Key: "LOGIN_LOCK_AFTER", Default: "10"},
+	{Key: "LOGIN_LOCK_DURATION", Default: "15m"},
+	{Key: "LOGIN_FAILURE_RESET", Default: "1h"},
+	{Key: "ISSUER", Default: "go-api-starter"},
+	{Key: "AUDIENCE", Default: "api-users"},
+	{Key: "R2_BUCKET_NAME"},
+	{Key: "R2_URL"},
+	{Key: "R2_TOKEN_VALUE", Secret: true},
+	{Key: "R2_ACCESS_KEY_ID", Secret: true},
+	{Key: "R2_SECRET_ACCESS_KEY", Secret: true},
+	{Key: "R2_ACCOUNT_ID"},
+	{Key: "R2_REGION", Default: "auto"},
+	{Key: "STORAGE_BACKEND", Default: "local"},
+	{Key: "STORAGE_LOCAL_DIR", Default: "tmp/storage"},
+	{Key: "STORAGE_LOCAL_URL", Default: "http://localhost:8080/storage"},
+	{Key: "ATTACHMENTS_MAX_BYTES", Default: "26214400"},
+	{Key: "ATTACHMENTS_ALLOWED_TYPES", Default: "image/jpeg,image/png,image/gif,image/webp,application/pdf,text/plain"},
+	{Key: "ATTACHMENTS_URL_TTL", Default: "15m"},
+	{Key: "THUMBNAIL_SIZES", Default: "small=160,medium=480,large=1280"},
+	{Key: "IMAGE_MAX_PIXELS", Default: "25000000"},
+	{Key: "SHARE_LINK_URL", Default: "http://localhost:8080/s"},
+	{Key: "SHARE_LINK_TTL", Default: "168h"},
+	{Key: "SHARE_LINK_MAX_TTL", Default: "2160h"},
+	{Key: "OTEL_SERVICE_NAME", Default: "go-api-starter"},
+	{Key: "OTEL_TRACES_EXPORTER", Default: "none"},
+	{Key: "OTEL_EXPORTER_OTLP_ENDPOINT"},
+	{Key: "OTEL_EXPORTER_OTLP_INSECURE", Default: "false"},
+	{Key: "OTEL_TRACES_SAMPLER_ARG", Default: "1.0"},
+	{Key: "READINESS_CHECK_TIMEOUT", Default: "2s"},
+	{Key: "SHUTDOWN_DRAIN_DELAY", Default: "0s"},
+	{Key: "MIGRATIONS_DIR"},
+	{Key: "DOCS_DIR"},
+	{Key: "MIGRATE_ON_STARTUP", Default: "check-only"},
+	{Key: "MIGRATION_LOCK_TIMEOUT", Default: "1m"},
+	{Key: "JOBS_IN_SERVER", Default: "true"},
+	{Key: "JOBS_WORKERS", Default: "4"},
+	{Key: "JOBS_POLL_INTERVAL", Default: "1s"},
+	{Key: "JOBS_STALE_AFTER", Default: "15m"},
+	{Key: "JOBS_SHUTDOWN_TIMEOUT", Default: "30s"},
+	{Key: "JOBS_RETENTION", Default: "168h"},
+	{Key: "SCHEDULE_JOBS_CLEANUP", Default: "@hourly"},
+	{Key: "SCHEDULER_ENABLED", Default: "true"},
+	{Key: "SCHEDULE_SESSION_CLEANUP", Default: "@hourly"},
+	{Key: "SCHEDULE_LABUBU_PURGE", Default: "30 3 * * *"},
+	{Key: "SCHEDULE_LABUBU_PUBLISH", Default: "* * * * *"},
+	{Key: "SCHEDULE_USAGE_REPORT", Default: "5 0 * * *"},
+	{Key: "SESSION_RETENTION", Default: "168h"},
+	{Key: "LABUBU_PURGE_AFTER", Default: "720h"},
+	{Key: "OUTBOX_RELAY_ENABLED", Default: "true"},
+	{Key: "OUTBOX_SINKS", Default: "webhooks"},
+	{Key: "OUTBOX_WEBHOOK_URL", Secret: true},
+	{Key: "OUTBOX_NATS_URL", Secret: true},
+	{Key: "OUTBOX_NATS_SUBJECT_PREFIX", Default: "events"},
+	{Key: "OUTBOX_POLL_INTERVAL", Default: "1s"},
+	{Key: "OUTBOX_BATCH_SIZE", Default: "100"},
+	{Key: "OUTBOX_RETENTION", Default: "72h"},
+	{Key: "SCHEDULE_OUTBOX_CLEANUP", Default: "@hourly"},
+	{Key: "WEBHOOKS_TIMEOUT", Default: "10s"},
+	{Key: "WEBHOOKS_MAX_ATTEMPTS", Default: "8"},
+	{Key: "WEBHOOKS_DISABLE_AFTER", Default: "20"},
+	{Key: "WEBHOOKS_ALLOW_PRIVATE", Default: "false"},
+	{Key: "STREAM_HEARTBEAT_INTERVAL", Default: "15s"},
+	{Key: "STREAM_BUFFER_SIZE", Default: "64"},
+	{Key: "STREAM_REPLAY_LIMIT", Default: "1000"},
+	{Key: "STREAM_WRITE_TIMEOUT", Default: "10s"},
+	{Key: "WS_PING_INTERVAL", Default: "30s"},
+	{Key: "WS_MESSAGES_PER_SECOND", Default: "10"},
+	{Key: "WS_BURST", Default: "20"},
+	{Key: "WS_MAX_SUBSCRIPTIONS", Default: "50"},
+	{Key: "PRESENCE_ANNOUNCE_INTERVAL", Default: "20s"},
+	{Key: "LOG_LEVEL", Default: "info", Reloadable: true},
+	{Key: "CORS_ALLOWED_ORIGINS", Reloadable: true},
+	{Key: "RATE_LIMIT_ENABLED", Default: "true", Reloadable: true},
+	{Key: "RATE_LIMIT_REQUESTS", Default: "100", Reloadable: true},
+	{Key: "RATE_LIMIT_WINDOW", Default: "1m", Reloadable: true},
+	{Key: "RATE_LIMIT_OPERATIONS", Default: "login=5/1m,refreshToken=30/1m,forgotPassword=5/15m,resendVerification=5/15m", Reloadable: true},
+	{Key: "FEATURE_FLAGS", Reloadable: true},
+}
+
+func lookupSetting(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func isSetting(key string) bool {
+	_, ok := lookupSetting(key)
+	return ok
+}
+
+// ResolvedValue is the effective value of a setting and where it came from
+type ResolvedValue struct {
+	Key    string
+	Value  string
+	Source string
+	Secret bool
+}
+
+// Redacted returns the value with secrets masked. URLs keep everything but
+// their password so that hosts and database names stay visible.
+func (v ResolvedValue) Redacted() string {
+	if !v.Secret || v.Value == "" {
+		return v.Value
+	}
+	if u, err := url.Parse(v.Value); err == nil && u.Scheme != "" && u.Host != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+		}
+		return u.String()
+	}
+	return "********"
+}
+
+// Resolved returns every setting sorted by key
+func (e *Environment) Resolved() []ResolvedValue {
+	out := make([]ResolvedValue, 0, len(e.resolved))
+	for key, v := range e.resolved {
+		s, _ := lookupSetting(key)
+		out = append(out, ResolvedValue{Key: key, Value: v.raw, Source: v.source, Secret: s.Secret})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
@@ -0,0 +1,176 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Source names, lowest precedence first
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnvFile = "env-file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// value is a resolved setting and the layer it came from
+type value struct {
+	raw    string
+	source string
+}
+
+// layers resolves keys across every configuration source
+type layers struct {
+	values map[string]value
+	errs   []error
+}
+
+func newLayers() *layers {
+	values := make(map[string]value, len(settings))
+	for _, s := range settings {
+		values[s.Key] = value{raw: s.Default, source: SourceDefault}
+	}
+	return &layers{values: values}
+}
+
+func (l *layers) errorf(format string, args ...any) {
+	l.errs = append(l.errs, fmt.Errorf(format, args...))
+}
+
+// set applies a value from a higher-precedence source. Empty values are
+// treated as unset so that `KEY=` in an env file keeps the default.
+func (l *layers) set(key, raw, source string) {
+	if raw == "" {
+		return
+	}
+	l.values[key] = value{raw: raw, source: source}
+}
+
+// applyMap applies every known key from m, reporting unknown ones when strict
+func (l *layers) applyMap(m map[string]string, source string, strict bool) {
+	for key, raw := range m {
+		key = strings.ToUpper(key)
+		if base, ok := strings.CutSuffix(key, fileSuffix); ok && isSetting(base) {
+			l.applySecretFile(base, raw, source)
+			continue
+		}
+		if !isSetting(key) {
+			if strict {
+				l.errorf("%s: unknown setting %q", source, key)
+			}
+			continue
+		}
+		l.set(key, raw, source)
+	}
+}
+
+// fileSuffix marks a setting whose value is read from a mounted file
+const fileSuffix = "_FILE"
+
+// applySecretFile reads KEY's value from path, as used with Docker and Kubernetes secrets
+func (l *layers) applySecretFile(key, path, source string) {
+	if path == "" {
+		return
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		l.errorf("%s%s: %w", key, fileSuffix, err)
+		return
+	}
+	l.set(key, strings.TrimRight(string(content), "\r\n"), source+" (file "+path+")")
+}
+
+// loadConfigFile reads a flat or nested YAML/TOML file. Nested keys are joined
+// with underscores, so `otel: {traces_exporter: stdout}` sets OTEL_TRACES_EXPORTER.
+func (l *layers) loadConfigFile(path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		l.errorf("config file: %w", err)
+		return
+	}
+
+	var doc map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &doc)
+	case ".toml":
+		err = toml.Unmarshal(content, &doc)
+	default:
+		err = fmt.Errorf("unsupported config file extension %q (want .yaml, .yml or .toml)", ext)
+	}
+	if err != nil {
+		l.errorf("config file %s: %w", path, err)
+		return
+	}
+
+	flat := make(map[string]string)
+	flatten("", doc, flat)
+	l.applyMap(flat, SourceFile, true)
+}
+
+func flatten(prefix string, doc map[string]any, out map[string]string) {
+	for k, v := range doc {
+		key := strings.ToUpper(k)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			flatten(key, v, out)
+		case []any:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(parts, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// loadEnvFiles reads .env.<env> and then .env; files are optional and the
+// more specific file wins
+func (l *layers) loadEnvFiles(env string) {
+	merged := make(map[string]string)
+	for _, name := range []string{".env", fmt.Sprintf(".env.%s", env)} {
+		values, err := godotenv.Read(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			l.errorf("%s: %w", name, err)
+			continue
+		}
+		for k, v := range values {
+			merged[k] = v
+		}
+	}
+	l.applyMap(merged, SourceEnvFile, false)
+}
+
+// loadProcessEnv applies real environment variables, including KEY_FILE variants
+func (l *layers) loadProcessEnv() {
+	for _, s := range settings {
+		path, hasFile := os.LookupEnv(s.Key + fileSuffix)
+		raw, hasValue := os.LookupEnv(s.Key)
+		switch {
+		case hasFile && hasValue:
+			l.errorf("%s and %s%s are both set; use only one", s.Key, s.Key, fileSuffix)
+		case hasFile:
+			l.applySecretFile(s.Key, path, SourceEnv)
+		case hasValue:
+			l.set(s.Key, raw, SourceEnv)
+		}
+	}
+}
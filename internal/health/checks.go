@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// PostgresCheck pings the connection pool and reports pool usage
+func PostgresCheck(pool *pgxpool.Pool) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		if err := pool.Ping(ctx); err != nil {
+			return nil, err
+		}
+		stat := pool.Stat()
+		return map[string]any{
+			"total_conns":    stat.TotalConns(),
+			"acquired_conns": stat.AcquiredConns(),
+			"idle_conns":     stat.IdleConns(),
+		}, nil
+	}
+}
+
+// MigrationCheck verifies that the schema is clean and at least at expectedVersion,
+// and reports the current version
+func MigrationCheck(pool *pgxpool.Pool, expectedVersion uint) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		var (
+			version int64
+			dirty   bool
+		)
+		err := pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return map[string]any{"expected_version": expectedVersion}, errors.New("no migrations applied")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading schema version: %w", err)
+		}
+
+		details := map[string]any{
+			"version":          version,
+			"dirty":            dirty,
+			"expected_version": expectedVersion,
+		}
+		if dirty {
+			return details, fmt.Errorf("schema version %d is dirty", version)
+		}
+		if uint(version) < expectedVersion {
+			return details, fmt.Errorf("schema version %d is behind expected version %d", version, expectedVersion)
+		}
+		return details, nil
+	}
+}
+
+// RedisCheck pings the Redis server
+func RedisCheck(client redis.UniversalClient) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		return nil, client.Ping(ctx).Err()
+	}
+}
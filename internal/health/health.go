@@ -0,0 +1,154 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status values reported by the health endpoints
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDraining = "draining"
+)
+
+// CheckFunc probes a single dependency and returns an error when it is unhealthy.
+// Details, when non-nil, are included in the readiness report.
+type CheckFunc func(ctx context.Context) (details map[string]any, err error)
+
+// Check is a named readiness probe with its own timeout
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Run     CheckFunc
+}
+
+// CheckResult is the outcome of a single check
+type CheckResult struct {
+	Status     string         `json:"status"`
+	DurationMS int64          `json:"duration_ms"`
+	Error      string         `json:"error,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// Report is the JSON body returned by the readiness endpoint
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs readiness checks and tracks whether the process is draining
+type Checker struct {
+	checks         []Check
+	defaultTimeout time.Duration
+	draining       atomic.Bool
+}
+
+// NewChecker creates a Checker; checks registered without a timeout use defaultTimeout
+func NewChecker(defaultTimeout time.Duration) *Checker {
+	return &Checker{
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+// Register adds a readiness check. It is not safe to call once the server is running.
+func (c *Checker) Register(check Check) {
+	if check.Timeout <= 0 {
+		check.Timeout = c.defaultTimeout
+	}
+	c.checks = append(c.checks, check)
+}
+
+// SetDraining marks the process as shutting down so readiness fails immediately
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Draining reports whether SetDraining has been called
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Ready runs all checks concurrently and aggregates their results.
+// Once draining, checks are skipped and the report fails straight away.
+func (c *Checker) Ready(ctx context.Context) Report {
+	if c.Draining() {
+		return Report{Status: StatusDraining, Checks: map[string]CheckResult{}}
+	}
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := check.Run(ctx)
+	result := CheckResult{
+		Status:     StatusOK,
+		DurationMS: time.Since(start).Milliseconds(),
+		Details:    details,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LivenessHandler reports that the process is up and able to serve HTTP
+func (c *Checker) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	}
+}
+
+// ReadinessHandler reports whether the process should receive traffic
+func (c *Checker) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := c.Ready(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	// StatusDead marks a job that exhausted its attempts or failed permanently
+	StatusDead Status = "dead"
+)
+
+// Job is a unit of background work stored in Postgres
+type Job struct {
+	ID          int64
+	Kind        string
+	Payload     json.RawMessage
+	Status      Status
+	UniqueKey   string
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LastError   string
+	CreatedAt   time.Time
+}
+
+// Args is the typed payload of a job. Kind must return a constant that does
+// not depend on the receiver's fields.
+type Args interface {
+	Kind() string
+}
+
+// EnqueueParams is a job ready to be inserted
+type EnqueueParams struct {
+	Kind        string
+	Payload     []byte
+	UniqueKey   string
+	MaxAttempts int
+	RunAt       time.Time
+}
+
+// Common errors
+var (
+	ErrNotFound = errors.New("job not found")
+	ErrNoKind   = errors.New("job kind is required")
+	// ErrLeaseLost is returned when a run's outcome arrives after the job was
+	// rescued from its worker
+	ErrLeaseLost = errors.New("job is no longer held by this worker")
+)
+
+// permanentError marks a failure that should not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the job goes straight to the dead state
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/jobs")
+
+// DefaultMaxAttempts is used when an enqueue does not set WithMaxAttempts
+const DefaultMaxAttempts = 5
+
+// EnqueueOption customises a single enqueue
+type EnqueueOption func(*EnqueueParams)
+
+// WithRunAt schedules the job for a specific time
+func WithRunAt(t time.Time) EnqueueOption {
+	return func(p *EnqueueParams) { p.RunAt = t }
+}
+
+// WithDelay schedules the job to run after d
+func WithDelay(d time.Duration) EnqueueOption {
+	return func(p *EnqueueParams) { p.RunAt = time.Now().Add(d) }
+}
+
+// WithUniqueKey skips the enqueue while a pending or running job has the same key
+func WithUniqueKey(key string) EnqueueOption {
+	return func(p *EnqueueParams) { p.UniqueKey = key }
+}
+
+// WithMaxAttempts sets how many times the job runs before it is dead-lettered
+func WithMaxAttempts(n int) EnqueueOption {
+	return func(p *EnqueueParams) { p.MaxAttempts = n }
+}
+
+// Queue enqueues jobs. Bind it to a transaction with WithTx so the job is
+// only visible to workers once the surrounding changes commit.
+type Queue interface {
+	WithTx(tx pgx.Tx) Queue
+	Enqueue(ctx context.Context, args Args, opts ...EnqueueOption) (*Job, error)
+}
+
+type queue struct {
+	repo Repository
+}
+
+// NewQueue creates a new job queue
+func NewQueue(repo Repository) Queue {
+	return &queue{
+		repo: repo,
+	}
+}
+
+func (q *queue) WithTx(tx pgx.Tx) Queue {
+	return &queue{
+		repo: q.repo.WithTx(tx),
+	}
+}
+
+func (q *queue) Enqueue(ctx context.Context, args Args, opts ...EnqueueOption) (_ *Job, err error) {
+	ctx, span := tracer.Start(ctx, "jobs.Queue.Enqueue")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	kind := args.Kind()
+	if kind == "" {
+		return nil, ErrNoKind
+	}
+	span.SetAttributes(attribute.String("job.kind", kind))
+
+	payload, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s payload: %w", kind, err)
+	}
+
+	params := EnqueueParams{
+		Kind:        kind,
+		Payload:     payload,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+	return q.repo.Enqueue(ctx, params)
+}
@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type noKindArgs struct{}
+
+func (noKindArgs) Kind() string { return "" }
+
+func TestQueueEnqueue(t *testing.T) {
+	runAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		args Args
+		opts []EnqueueOption
+		want EnqueueParams
+		err  error
+	}{
+		{
+			name: "defaults",
+			args: testArgs{N: 7},
+			want: EnqueueParams{Kind: "test.job", Payload: []byte(`{"n":7}`), MaxAttempts: DefaultMaxAttempts},
+		},
+		{
+			name: "options",
+			args: testArgs{N: 1},
+			opts: []EnqueueOption{WithRunAt(runAt), WithUniqueKey("once"), WithMaxAttempts(1)},
+			want: EnqueueParams{Kind: "test.job", Payload: []byte(`{"n":1}`), UniqueKey: "once", MaxAttempts: 1, RunAt: runAt},
+		},
+		{
+			name: "no kind",
+			args: noKindArgs{},
+			err:  ErrNoKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepository{}
+			before := time.Now()
+			job, err := NewQueue(repo).Enqueue(context.Background(), tt.args, tt.opts...)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Enqueue() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if job.Kind != tt.want.Kind || string(job.Payload) != string(tt.want.Payload) ||
+				job.UniqueKey != tt.want.UniqueKey || job.MaxAttempts != tt.want.MaxAttempts {
+				t.Errorf("Enqueue() = %+v, want %+v", job, tt.want)
+			}
+			if tt.want.RunAt.IsZero() {
+				if job.RunAt.Before(before) || job.RunAt.After(time.Now()) {
+					t.Errorf("run at = %v, want now", job.RunAt)
+				}
+			} else if !job.RunAt.Equal(tt.want.RunAt) {
+				t.Errorf("run at = %v, want %v", job.RunAt, tt.want.RunAt)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	var got testArgs
+	Register(registry, func(_ context.Context, _ *Job, args testArgs) error {
+		got = args
+		return nil
+	})
+
+	handler, ok := registry.handler("test.job")
+	if !ok {
+		t.Fatal("handler not registered")
+	}
+	payload, _ := json.Marshal(testArgs{N: 42})
+	if err := handler(context.Background(), &Job{Payload: payload}); err != nil || got.N != 42 {
+		t.Errorf("handler() = %v with args %+v, want the decoded payload", err, got)
+	}
+	if err := handler(context.Background(), &Job{Payload: []byte("not json")}); !IsPermanent(err) {
+		t.Errorf("handler() with a bad payload = %v, want a permanent error", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a kind twice did not panic")
+		}
+	}()
+	Register(registry, func(context.Context, *Job, testArgs) error { return nil })
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// handlerFunc runs a job with its raw payload
+type handlerFunc func(ctx context.Context, job *Job) error
+
+// Registry maps job kinds to handlers
+type Registry struct {
+	handlers map[string]handlerFunc
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: make(map[string]handlerFunc),
+	}
+}
+
+// Register adds a typed handler for the kind of T. The payload is decoded
+// into T before fn is called; undecodable payloads fail permanently.
+func Register[T Args](r *Registry, fn func(ctx context.Context, job *Job, args T) error) {
+	var zero T
+	kind := zero.Kind()
+	if _, exists := r.handlers[kind]; exists {
+		panic(fmt.Sprintf("jobs: handler for %q registered twice", kind))
+	}
+
+	r.handlers[kind] = func(ctx context.Context, job *Job) error {
+		var args T
+		if err := json.Unmarshal(job.Payload, &args); err != nil {
+			return Permanent(fmt.Errorf("decoding %s payload: %w", kind, err))
+		}
+		return fn(ctx, job, args)
+	}
+}
+
+// Kinds returns the registered kinds in sorted order
+func (r *Registry) Kinds() []string {
+	kinds := make([]string, 0, len(r.handlers))
+	for kind := range r.handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func (r *Registry) handler(kind string) (handlerFunc, bool) {
+	h, ok := r.handlers[kind]
+	return h, ok
+}
@@ -0,0 +1,173 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for job data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	Enqueue(ctx context.Context, params EnqueueParams) (*Job, error)
+	Claim(ctx context.Context, workerID string, kinds []string, limit int) ([]*Job, error)
+	// Complete, Retry and Kill record the outcome of workerID's run and
+	// return ErrLeaseLost when workerID no longer holds the job
+	Complete(ctx context.Context, id int64, workerID string) error
+	Retry(ctx context.Context, id int64, workerID string, runAt time.Time, lastError string) error
+	Kill(ctx context.Context, id int64, workerID string, lastError string) error
+	// ExtendLock renews workerID's lock on a running job, returning
+	// ErrLeaseLost when workerID no longer holds it
+	ExtendLock(ctx context.Context, id int64, workerID string) error
+	// RescueStale releases jobs locked before staleBefore, killing those
+	// without attempts left
+	RescueStale(ctx context.Context, staleBefore time.Time) (int64, error)
+	PurgeFinished(ctx context.Context, finishedBefore time.Time) (int64, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+// Enqueue inserts a job. When a live job with the same unique key already
+// exists, that job is returned instead.
+func (r *pgxRepository) Enqueue(ctx context.Context, params EnqueueParams) (*Job, error) {
+	result, err := r.q.EnqueueJob(ctx, sqlc.EnqueueJobParams{
+		Kind:        params.Kind,
+		Payload:     params.Payload,
+		UniqueKey:   pgtype.Text{String: params.UniqueKey, Valid: params.UniqueKey != ""},
+		MaxAttempts: int32(params.MaxAttempts),
+		RunAt:       pgtype.Timestamptz{Time: params.RunAt, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) && params.UniqueKey != "" {
+		existing, err := r.q.GetJobByUniqueKey(ctx, pgtype.Text{String: params.UniqueKey, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("GetJobByUniqueKey failed: %w", err)
+		}
+		return toJob(existing), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("EnqueueJob failed: %w", err)
+	}
+	return toJob(result), nil
+}
+
+func (r *pgxRepository) Claim(ctx context.Context, workerID string, kinds []string, limit int) ([]*Job, error) {
+	results, err := r.q.ClaimJobs(ctx, sqlc.ClaimJobsParams{
+		WorkerID:  workerID,
+		Kinds:     kinds,
+		BatchSize: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ClaimJobs failed: %w", err)
+	}
+
+	jobs := make([]*Job, 0, len(results))
+	for _, result := range results {
+		jobs = append(jobs, toJob(result))
+	}
+	return jobs, nil
+}
+
+func (r *pgxRepository) Complete(ctx context.Context, id int64, workerID string) error {
+	n, err := r.q.CompleteJob(ctx, sqlc.CompleteJobParams{ID: id, WorkerID: workerID})
+	if err != nil {
+		return fmt.Errorf("CompleteJob failed: %w", err)
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *pgxRepository) Retry(ctx context.Context, id int64, workerID string, runAt time.Time, lastError string) error {
+	n, err := r.q.RetryJob(ctx, sqlc.RetryJobParams{
+		ID:        id,
+		WorkerID:  workerID,
+		RunAt:     pgtype.Timestamptz{Time: runAt, Valid: true},
+		LastError: pgtype.Text{String: lastError, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("RetryJob failed: %w", err)
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *pgxRepository) Kill(ctx context.Context, id int64, workerID string, lastError string) error {
+	n, err := r.q.KillJob(ctx, sqlc.KillJobParams{
+		ID:        id,
+		WorkerID:  workerID,
+		LastError: pgtype.Text{String: lastError, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("KillJob failed: %w", err)
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *pgxRepository) ExtendLock(ctx context.Context, id int64, workerID string) error {
+	n, err := r.q.ExtendJobLock(ctx, sqlc.ExtendJobLockParams{ID: id, WorkerID: workerID})
+	if err != nil {
+		return fmt.Errorf("ExtendJobLock failed: %w", err)
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *pgxRepository) RescueStale(ctx context.Context, staleBefore time.Time) (int64, error) {
+	n, err := r.q.RescueStaleJobs(ctx, pgtype.Timestamptz{Time: staleBefore, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("RescueStaleJobs failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) PurgeFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	n, err := r.q.PurgeFinishedJobs(ctx, pgtype.Timestamptz{Time: finishedBefore, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("PurgeFinishedJobs failed: %w", err)
+	}
+	return n, nil
+}
+
+func toJob(j sqlc.Job) *Job {
+	return &Job{
+		ID:          j.ID,
+		Kind:        j.Kind,
+		Payload:     j.Payload,
+		Status:      Status(j.Status),
+		UniqueKey:   j.UniqueKey.String,
+		Attempts:    int(j.Attempts),
+		MaxAttempts: int(j.MaxAttempts),
+		RunAt:       j.RunAt.Time,
+		LastError:   j.LastError.String,
+		CreatedAt:   j.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,278 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+// WorkerConfig tunes a worker pool
+type WorkerConfig struct {
+	// ID identifies the worker in jobs.locked_by; defaults to hostname-pid
+	ID              string
+	Concurrency     int
+	PollInterval    time.Duration
+	StaleAfter      time.Duration
+	ShutdownTimeout time.Duration
+	BaseBackoff     time.Duration
+	MaxBackoff      time.Duration
+
+	// HeartbeatInterval is how often a running job's lock is renewed; defaults to StaleAfter/3
+	HeartbeatInterval time.Duration
+}
+
+func (c WorkerConfig) withDefaults() WorkerConfig {
+	if c.ID == "" {
+		host, _ := os.Hostname()
+		c.ID = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 4
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.StaleAfter <= 0 {
+		c.StaleAfter = 15 * time.Minute
+	}
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = c.StaleAfter / 3
+	}
+	if c.ShutdownTimeout <= 0 {
+		c.ShutdownTimeout = 30 * time.Second
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = 5 * time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = time.Hour
+	}
+	return c
+}
+
+// Worker claims due jobs and runs them on a bounded pool of goroutines
+type Worker struct {
+	repo     Repository
+	registry *Registry
+	config   WorkerConfig
+}
+
+// NewWorker creates a worker for the kinds in registry
+func NewWorker(repo Repository, registry *Registry, config WorkerConfig) *Worker {
+	return &Worker{
+		repo:     repo,
+		registry: registry,
+		config:   config.withDefaults(),
+	}
+}
+
+// Run processes jobs until ctx is cancelled. It then stops claiming and waits
+// up to ShutdownTimeout for running jobs before cancelling them; cancelled
+// jobs are retried later.
+func (w *Worker) Run(ctx context.Context) error {
+	kinds := w.registry.Kinds()
+	if len(kinds) == 0 {
+		slog.Info("Job worker has no handlers registered, not starting")
+		<-ctx.Done()
+		return nil
+	}
+	slog.Info("Job worker started", "worker_id", w.config.ID, "concurrency", w.config.Concurrency, "kinds", kinds)
+
+	// Jobs outlive ctx so they can finish during the drain
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, w.config.Concurrency)
+	finished := make(chan struct{}, w.config.Concurrency)
+
+	poll := time.NewTicker(w.config.PollInterval)
+	defer poll.Stop()
+	rescue := time.NewTicker(w.config.StaleAfter / 2)
+	defer rescue.Stop()
+
+	for {
+		w.claimAndRun(ctx, jobCtx, kinds, slots, finished, &wg)
+
+		select {
+		case <-ctx.Done():
+			return w.drain(&wg, cancelJobs)
+		case <-poll.C:
+		case <-finished:
+		case <-rescue.C:
+			w.rescueStale(ctx)
+		}
+	}
+}
+
+// claimAndRun claims as many jobs as there are free slots and starts them
+func (w *Worker) claimAndRun(ctx, jobCtx context.Context, kinds []string, slots, finished chan struct{}, wg *sync.WaitGroup) {
+	free := cap(slots) - len(slots)
+	if free == 0 || ctx.Err() != nil {
+		return
+	}
+
+	jobs, err := w.repo.Claim(ctx, w.config.ID, kinds, free)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Failed to claim jobs", "error", err)
+		}
+		return
+	}
+
+	for _, job := range jobs {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+				select {
+				case finished <- struct{}{}:
+				default:
+				}
+			}()
+			w.process(jobCtx, job)
+		}()
+	}
+}
+
+func (w *Worker) drain(wg *sync.WaitGroup, cancelJobs context.CancelFunc) error {
+	slog.Info("Job worker draining", "timeout", w.config.ShutdownTimeout)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("Job worker stopped")
+		return nil
+	case <-time.After(w.config.ShutdownTimeout):
+		cancelJobs()
+		<-done
+		return errors.New("job worker drain timed out; running jobs were cancelled")
+	}
+}
+
+func (w *Worker) rescueStale(ctx context.Context) {
+	n, err := w.repo.RescueStale(ctx, time.Now().Add(-w.config.StaleAfter))
+	if err != nil {
+		slog.Error("Failed to rescue stale jobs", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.Warn("Rescued stale jobs", "count", n)
+	}
+}
+
+// process runs one job and records the outcome
+func (w *Worker) process(ctx context.Context, job *Job) {
+	ctx, span := tracer.Start(ctx, "jobs.Worker.process "+job.Kind)
+	span.SetAttributes(
+		attribute.String("job.kind", job.Kind),
+		attribute.Int64("job.id", job.ID),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	stopHeartbeat := w.heartbeat(runCtx, job, cancelRun)
+	err := w.run(runCtx, job)
+	stopHeartbeat()
+	cancelRun()
+	telemetry.EndSpan(span, err)
+
+	// Record the outcome even if the job context was cancelled
+	ctx = context.WithoutCancel(ctx)
+	logger := slog.With("job_id", job.ID, "kind", job.Kind, "attempt", job.Attempts)
+
+	var recordErr error
+	switch {
+	case err == nil:
+		if recordErr = w.repo.Complete(ctx, job.ID, w.config.ID); recordErr != nil && !errors.Is(recordErr, ErrLeaseLost) {
+			logger.Error("Failed to mark job succeeded", "error", recordErr)
+		}
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		logger.Error("Job failed permanently", "error", err)
+		if recordErr = w.repo.Kill(ctx, job.ID, w.config.ID, err.Error()); recordErr != nil && !errors.Is(recordErr, ErrLeaseLost) {
+			logger.Error("Failed to mark job dead", "error", recordErr)
+		}
+	default:
+		runAt := time.Now().Add(w.backoff(job.Attempts))
+		logger.Warn("Job failed, will retry", "error", err, "run_at", runAt)
+		if recordErr = w.repo.Retry(ctx, job.ID, w.config.ID, runAt, err.Error()); recordErr != nil && !errors.Is(recordErr, ErrLeaseLost) {
+			logger.Error("Failed to reschedule job", "error", recordErr)
+		}
+	}
+	if errors.Is(recordErr, ErrLeaseLost) {
+		// The job ran past StaleAfter and was rescued; the new run owns the outcome
+		logger.Warn("Job lease lost, discarding outcome", "error", err)
+	}
+}
+
+// heartbeat renews the lock on job until the returned function is called, so
+// a long run is not rescued as stale. If the lock cannot be renewed the run is
+// cancelled, since another worker may pick the job up.
+func (w *Worker) heartbeat(ctx context.Context, job *Job, cancel context.CancelFunc) (stop func()) {
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(w.config.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := w.repo.ExtendLock(ctx, job.ID, w.config.ID); err != nil {
+				slog.Error("Failed to renew job lock, cancelling the run", "job_id", job.ID, "kind", job.Kind, "error", err)
+				cancel()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// run calls the handler, turning panics into errors
+func (w *Worker) run(ctx context.Context, job *Job) (err error) {
+	handler, ok := w.registry.handler(job.Kind)
+	if !ok {
+		return Permanent(fmt.Errorf("no handler for job kind %q", job.Kind))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Job handler panicked", "job_id", job.ID, "kind", job.Kind, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// backoff returns an exponential delay with equal jitter for the given attempt
+func (w *Worker) backoff(attempt int) time.Duration {
+	d := w.config.BaseBackoff << (attempt - 1)
+	if d <= 0 || d > w.config.MaxBackoff {
+		d = w.config.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int64N(int64(d/2)+1))
+}
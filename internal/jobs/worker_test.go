@@ -0,0 +1,395 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryRepository keeps jobs in memory the way the Postgres queries treat them
+type memoryRepository struct {
+	Repository
+
+	mu   sync.Mutex
+	jobs []*Job
+	// lockedBy is the worker holding each running job
+	lockedBy map[int64]string
+	// renewals counts the heartbeats of each job
+	renewals map[int64]int
+}
+
+func (r *memoryRepository) Enqueue(_ context.Context, params EnqueueParams) (*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := &Job{
+		ID:          int64(len(r.jobs) + 1),
+		Kind:        params.Kind,
+		Payload:     params.Payload,
+		Status:      StatusPending,
+		UniqueKey:   params.UniqueKey,
+		MaxAttempts: params.MaxAttempts,
+		RunAt:       params.RunAt,
+		CreatedAt:   time.Now(),
+	}
+	r.jobs = append(r.jobs, job)
+	return job, nil
+}
+
+func (r *memoryRepository) Claim(_ context.Context, workerID string, kinds []string, limit int) ([]*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lockedBy == nil {
+		r.lockedBy = make(map[int64]string)
+	}
+	var claimed []*Job
+	for _, job := range r.jobs {
+		if len(claimed) == limit {
+			break
+		}
+		if job.Status != StatusPending || job.RunAt.After(time.Now()) || !slices.Contains(kinds, job.Kind) {
+			continue
+		}
+		job.Status = StatusRunning
+		job.Attempts++
+		r.lockedBy[job.ID] = workerID
+		copied := *job
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (r *memoryRepository) Complete(_ context.Context, id int64, workerID string) error {
+	return r.update(id, workerID, func(job *Job) { job.Status = StatusSucceeded })
+}
+
+func (r *memoryRepository) Retry(_ context.Context, id int64, workerID string, runAt time.Time, lastError string) error {
+	return r.update(id, workerID, func(job *Job) {
+		job.Status, job.RunAt, job.LastError = StatusPending, runAt, lastError
+	})
+}
+
+func (r *memoryRepository) Kill(_ context.Context, id int64, workerID string, lastError string) error {
+	return r.update(id, workerID, func(job *Job) { job.Status, job.LastError = StatusDead, lastError })
+}
+
+func (r *memoryRepository) ExtendLock(_ context.Context, id int64, workerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job := r.jobs[id-1]; job.Status != StatusRunning || r.lockedBy[id] != workerID {
+		return ErrLeaseLost
+	}
+	if r.renewals == nil {
+		r.renewals = make(map[int64]int)
+	}
+	r.renewals[id]++
+	return nil
+}
+
+// update applies fn to a running job held by workerID
+func (r *memoryRepository) update(id int64, workerID string, fn func(job *Job)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.ID != id {
+			continue
+		}
+		if job.Status != StatusRunning || r.lockedBy[id] != workerID {
+			return ErrLeaseLost
+		}
+		fn(job)
+		delete(r.lockedBy, id)
+		return nil
+	}
+	return ErrNotFound
+}
+
+// get returns a copy of the stored job
+func (r *memoryRepository) get(id int64) Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.jobs[id-1]
+}
+
+type testArgs struct {
+	N int `json:"n"`
+}
+
+func (testArgs) Kind() string { return "test.job" }
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWorkerProcess(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		handler     func(ctx context.Context, job *Job, args testArgs) error
+		kind        string
+		payload     string
+		attempt     int
+		wantStatus  Status
+		wantError   string
+		wantRetried bool
+	}{
+		{
+			name:       "success",
+			handler:    func(context.Context, *Job, testArgs) error { return nil },
+			wantStatus: StatusSucceeded,
+		},
+		{
+			name:        "failure with attempts left is retried",
+			handler:     func(context.Context, *Job, testArgs) error { return errBoom },
+			wantStatus:  StatusPending,
+			wantError:   "boom",
+			wantRetried: true,
+		},
+		{
+			name:       "failure on the last attempt is dead",
+			handler:    func(context.Context, *Job, testArgs) error { return errBoom },
+			attempt:    3,
+			wantStatus: StatusDead,
+			wantError:  "boom",
+		},
+		{
+			name:       "permanent failure is dead at once",
+			handler:    func(context.Context, *Job, testArgs) error { return Permanent(errBoom) },
+			wantStatus: StatusDead,
+			wantError:  "boom",
+		},
+		{
+			name:        "panic is retried",
+			handler:     func(context.Context, *Job, testArgs) error { panic("kaboom") },
+			wantStatus:  StatusPending,
+			wantError:   "panic: kaboom",
+			wantRetried: true,
+		},
+		{
+			name:       "undecodable payload is dead",
+			handler:    func(context.Context, *Job, testArgs) error { return nil },
+			payload:    `{"n":"one"}`,
+			wantStatus: StatusDead,
+			wantError:  "decoding test.job payload",
+		},
+		{
+			name:       "unknown kind is dead",
+			handler:    func(context.Context, *Job, testArgs) error { return nil },
+			kind:       "test.unknown",
+			wantStatus: StatusDead,
+			wantError:  `no handler for job kind "test.unknown"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepository{}
+			registry := NewRegistry()
+			Register(registry, tt.handler)
+			w := NewWorker(repo, registry, WorkerConfig{ID: "test", BaseBackoff: time.Minute, MaxBackoff: time.Hour})
+
+			kind, payload := "test.job", `{"n":1}`
+			if tt.kind != "" {
+				kind = tt.kind
+			}
+			if tt.payload != "" {
+				payload = tt.payload
+			}
+			if _, err := repo.Enqueue(context.Background(), EnqueueParams{Kind: kind, Payload: []byte(payload), MaxAttempts: 3}); err != nil {
+				t.Fatal(err)
+			}
+			claimed, _ := repo.Claim(context.Background(), "test", []string{kind}, 1)
+			job := claimed[0]
+			job.Attempts = max(tt.attempt, 1)
+
+			w.process(context.Background(), job)
+
+			got := repo.get(job.ID)
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if tt.wantError != "" && !strings.Contains(got.LastError, tt.wantError) {
+				t.Errorf("last error = %q, want it to mention %q", got.LastError, tt.wantError)
+			}
+			if tt.wantRetried && !got.RunAt.After(time.Now().Add(29*time.Second)) {
+				t.Errorf("run at = %v, want it pushed back by the backoff", got.RunAt)
+			}
+		})
+	}
+}
+
+func TestWorkerRun(t *testing.T) {
+	repo := &memoryRepository{}
+	queue := NewQueue(repo)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	var seen []int
+	registry := NewRegistry()
+	Register(registry, func(_ context.Context, _ *Job, args testArgs) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		seen = append(seen, args.N)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+
+	for n := range 10 {
+		if _, err := queue.Enqueue(context.Background(), testArgs{N: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Not due yet, so it stays pending
+	later, err := queue.Enqueue(context.Background(), testArgs{N: 99}, WithDelay(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	w := NewWorker(repo, registry, WorkerConfig{ID: "test", Concurrency: 3, PollInterval: 5 * time.Millisecond})
+	go func() { done <- w.Run(ctx) }()
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen) == 10 && running == 0
+	})
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+
+	if peak > 3 {
+		t.Errorf("%d jobs ran at once, want at most the concurrency of 3", peak)
+	}
+	for id := int64(1); id <= 10; id++ {
+		if got := repo.get(id).Status; got != StatusSucceeded {
+			t.Errorf("job %d status = %s, want succeeded", id, got)
+		}
+	}
+	if got := repo.get(later.ID).Status; got != StatusPending {
+		t.Errorf("delayed job status = %s, want pending", got)
+	}
+}
+
+func TestWorkerDrainTimeout(t *testing.T) {
+	repo := &memoryRepository{}
+	started := make(chan struct{})
+	registry := NewRegistry()
+	Register(registry, func(ctx context.Context, _ *Job, _ testArgs) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if _, err := NewQueue(repo).Enqueue(context.Background(), testArgs{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	w := NewWorker(repo, registry, WorkerConfig{ID: "test", PollInterval: 5 * time.Millisecond, ShutdownTimeout: 20 * time.Millisecond})
+	go func() { done <- w.Run(ctx) }()
+
+	<-started
+	cancel()
+	if err := <-done; err == nil {
+		t.Fatal("Run() = nil, want the drain to time out")
+	}
+	// The cancelled job is retried later rather than lost
+	if got := repo.get(1); got.Status != StatusPending {
+		t.Errorf("status = %s, want the cancelled job pending again", got.Status)
+	}
+}
+
+func TestWorkerLeaseLost(t *testing.T) {
+	repo := &memoryRepository{}
+	registry := NewRegistry()
+	Register(registry, func(context.Context, *Job, testArgs) error { return nil })
+	w := NewWorker(repo, registry, WorkerConfig{ID: "slow"})
+
+	if _, err := NewQueue(repo).Enqueue(context.Background(), testArgs{}); err != nil {
+		t.Fatal(err)
+	}
+	claimed, _ := repo.Claim(context.Background(), "slow", []string{"test.job"}, 1)
+
+	// The run took past StaleAfter: the job was rescued and claimed again elsewhere
+	repo.update(1, "slow", func(job *Job) { job.Status = StatusPending })
+	repo.Claim(context.Background(), "other", []string{"test.job"}, 1)
+
+	w.process(context.Background(), claimed[0])
+
+	if got := repo.get(1); got.Status != StatusRunning || repo.lockedBy[1] != "other" {
+		t.Errorf("status = %s held by %q, want the new run left alone", got.Status, repo.lockedBy[1])
+	}
+}
+
+func TestWorkerHeartbeat(t *testing.T) {
+	tests := []struct {
+		name string
+		// rescue hands the job to another worker while it runs
+		rescue       bool
+		wantStatus   Status
+		wantLockedBy string
+	}{
+		{name: "long run keeps its lock", wantStatus: StatusSucceeded},
+		{name: "lost lock cancels the run", rescue: true, wantStatus: StatusRunning, wantLockedBy: "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepository{}
+			registry := NewRegistry()
+			Register(registry, func(ctx context.Context, job *Job, _ testArgs) error {
+				if tt.rescue {
+					repo.update(job.ID, "test", func(job *Job) { job.Status = StatusPending })
+					repo.Claim(context.Background(), "other", []string{"test.job"}, 1)
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(100 * time.Millisecond):
+					return nil
+				}
+			})
+			w := NewWorker(repo, registry, WorkerConfig{ID: "test", HeartbeatInterval: 10 * time.Millisecond})
+
+			if _, err := NewQueue(repo).Enqueue(context.Background(), testArgs{}); err != nil {
+				t.Fatal(err)
+			}
+			claimed, _ := repo.Claim(context.Background(), "test", []string{"test.job"}, 1)
+
+			start := time.Now()
+			w.process(context.Background(), claimed[0])
+
+			got := repo.get(1)
+			if got.Status != tt.wantStatus || repo.lockedBy[1] != tt.wantLockedBy {
+				t.Errorf("status = %s held by %q, want %s held by %q", got.Status, repo.lockedBy[1], tt.wantStatus, tt.wantLockedBy)
+			}
+			if tt.rescue {
+				if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+					t.Errorf("run took %v, want it cancelled at the first failed renewal", elapsed)
+				}
+			} else if repo.renewals[1] < 2 {
+				t.Errorf("lock renewed %d times, want a renewal every heartbeat", repo.renewals[1])
+			}
+		})
+	}
+}
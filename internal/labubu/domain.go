@@ -1,12 +1,170 @@
 package labubu
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
 // Labubu represents the domain entity
 type Labubu struct {
-	ID   int    `json:"id"`
-	Text string `json:"text"`
+	ID      int      `json:"id"`
+	Text    string   `json:"text"`
+	OwnerID int      `json:"owner_id"`
+	Version int      `json:"version"`
+	Tags    []string `json:"tags"`
+	Status  Status   `json:"status"`
+	// PublishAt is when a scheduled labubu gets published
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Status is where a labubu is in its lifecycle. Only published labubu are
+// shown to anyone but their owner.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusScheduled Status = "scheduled"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
+)
+
+// transitions lists the statuses each status can move to. Scheduling a
+// scheduled labubu again changes its publish time.
+var transitions = map[Status][]Status{
+	StatusDraft:     {StatusScheduled, StatusPublished},
+	StatusScheduled: {StatusScheduled, StatusDraft, StatusPublished},
+	StatusPublished: {StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+// Valid reports whether s is a known status
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransition reports whether a labubu in s can move to to
+func (s Status) CanTransition(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionRequest moves a labubu to Status. PublishAt is required when
+// scheduling and ignored otherwise.
+type TransitionRequest struct {
+	ID        int
+	OwnerID   int
+	Status    Status
+	PublishAt time.Time
+}
+
+// Transition is a recorded change of status
+type Transition struct {
+	ID       int64  `json:"id"`
+	LabubuID int    `json:"labubu_id"`
+	From     Status `json:"from,omitempty"`
+	To       Status `json:"to"`
+	// ActorID is zero when the publisher made the change
+	ActorID   int        `json:"actor_id,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func transitionError(from, to Status) error {
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
 }
 
 // CreateLabubuRequest represents the request to create a labubu
 type CreateLabubuRequest struct {
-	Text string `json:"text" validate:"required"`
-}
\ No newline at end of file
+	Text    string   `json:"text" validate:"required"`
+	OwnerID int      `json:"owner_id" validate:"required"`
+	Tags    []string `json:"tags"`
+}
+
+// UpdateLabubuRequest replaces the text of a labubu. Version must match the
+// stored version, otherwise ErrVersionConflict is returned.
+type UpdateLabubuRequest struct {
+	ID      int    `json:"id"`
+	OwnerID int    `json:"owner_id"`
+	Text    string `json:"text"`
+	Version int    `json:"version"`
+	// Tags replace the current tags; nil keeps them
+	Tags []string `json:"tags"`
+}
+
+// ListFilter narrows the labubu listed to an owner's
+type ListFilter struct {
+	// Tags keeps labubu carrying any of them, or all of them with MatchAll
+	Tags     []string
+	MatchAll bool
+	// Statuses keeps labubu in any of them; empty keeps all
+	Statuses []Status
+}
+
+// Tag is a name an owner puts on labubu
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Count is how many labubu, not counting deleted ones, carry the tag
+	Count int `json:"count"`
+}
+
+// RenameTagRequest renames a tag on every labubu that carries it
+type RenameTagRequest struct {
+	ID      int
+	OwnerID int
+	Name    string
+}
+
+// MergeTagsRequest moves the labubu of the source tag to the target and
+// deletes the source
+type MergeTagsRequest struct {
+	SourceID int
+	TargetID int
+	OwnerID  int
+}
+
+// Event types written to the outbox
+const (
+	AggregateType = "labubu"
+	EventCreated  = "labubu.created"
+	EventUpdated  = "labubu.updated"
+	EventDeleted  = "labubu.deleted"
+	// EventStatusChanged carries the labubu after a transition
+	EventStatusChanged = "labubu.status_changed"
+)
+
+// EventOwner returns the owner of the labubu carried in an event payload
+func EventOwner(payload []byte) (int, bool) {
+	var l struct {
+		OwnerID *int `json:"owner_id"`
+	}
+	if err := json.Unmarshal(payload, &l); err != nil || l.OwnerID == nil {
+		return 0, false
+	}
+	return *l.OwnerID, true
+}
+
+// Common errors
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrEmptyText       = errors.New("text is required")
+	ErrVersionConflict = errors.New("labubu was modified by someone else")
+	ErrInvalidTag      = errors.New("tags must be 1 to 50 characters")
+	ErrTooManyTags     = errors.New("too many tags")
+	// ErrTagExists is returned when renaming a tag to the name of another; merge them instead
+	ErrTagExists         = errors.New("a tag with this name already exists")
+	ErrSameTag           = errors.New("a tag cannot be merged into itself")
+	ErrInvalidStatus     = errors.New("status must be draft, scheduled, published or archived")
+	ErrInvalidTransition = errors.New("status cannot change")
+	ErrPublishAtRequired = errors.New("publish_at must be in the future to schedule")
+)
@@ -2,18 +2,46 @@ package labubu
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Repository defines the contract for labubu data operations
 type Repository interface {
 	WithTx(tx pgx.Tx) Repository
-	CreateLabubu(ctx context.Context, text string) (*Labubu, error)
-	GetAllLabubu(ctx context.Context) ([]*Labubu, error)
+	CreateLabubu(ctx context.Context, text string, ownerID int) (*Labubu, error)
+	// GetAllLabubu returns the owner's labubu in any of statuses, or all when empty
+	GetAllLabubu(ctx context.Context, ownerID int, statuses []Status) ([]*Labubu, error)
 	GetLabubuByID(ctx context.Context, id int) (*Labubu, error)
+	GetLabubuByIDs(ctx context.Context, ids []int) ([]*Labubu, error)
+	GetOwnedLabubu(ctx context.Context, id, ownerID int) (*Labubu, error)
+	UpdateLabubu(ctx context.Context, req UpdateLabubuRequest) (*Labubu, error)
+	SoftDeleteLabubu(ctx context.Context, id, ownerID int) (*Labubu, error)
+	PurgeDeletedLabubu(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// ListLabubuByTags returns labubu carrying at least minMatches of names
+	ListLabubuByTags(ctx context.Context, ownerID int, names []string, minMatches int, statuses []Status) ([]*Labubu, error)
+	// SetStatus bumps the version and returns ErrNotFound when the labubu is
+	// no longer in from
+	SetStatus(ctx context.Context, id int, from, to Status, publishAt *time.Time) (*Labubu, error)
+	// ListDueLabubu locks up to limit scheduled labubu due at now
+	ListDueLabubu(ctx context.Context, now time.Time, limit int) ([]*Labubu, error)
+	RecordTransition(ctx context.Context, t Transition) error
+	ListTransitions(ctx context.Context, labubuID int) ([]*Transition, error)
+	// LoadTags sets the Tags of items
+	LoadTags(ctx context.Context, items ...*Labubu) error
+	// SetTags replaces the tags of a labubu and deletes the owner's tags left unused
+	SetTags(ctx context.Context, labubuID, ownerID int, names []string) error
+	ListTags(ctx context.Context, ownerID int) ([]*Tag, error)
+	GetTag(ctx context.Context, id, ownerID int) (*Tag, error)
+	RenameTag(ctx context.Context, id, ownerID int, name string) error
+	MergeTags(ctx context.Context, sourceID, targetID, ownerID int) error
 }
 
 type pgxRepository struct {
@@ -33,44 +61,332 @@ func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
 	}
 }
 
-func (r *pgxRepository) CreateLabubu(ctx context.Context, text string) (*Labubu, error) {
-	// TODO: Use SQLC generated method
-	// result, err := r.q.CreateLabubu(ctx, text)
-	// if err != nil {
-	//     return nil, fmt.Errorf("CreateLabubu failed: %w", err)
-	// }
-	
-	// For now, return mock data
-	return &Labubu{
-		ID:   1,
-		Text: text,
-	}, nil
+func (r *pgxRepository) CreateLabubu(ctx context.Context, text string, ownerID int) (*Labubu, error) {
+	result, err := r.q.CreateLabubu(ctx, sqlc.CreateLabubuParams{
+		Text:    pgtype.Text{String: text, Valid: true},
+		OwnerID: pgtype.Int4{Int32: int32(ownerID), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateLabubu failed: %w", err)
+	}
+	return toLabubu(result), nil
 }
 
-func (r *pgxRepository) GetAllLabubu(ctx context.Context) ([]*Labubu, error) {
-	// TODO: Use SQLC generated method
-	// results, err := r.q.GetAllLabubu(ctx)
-	// if err != nil {
-	//     return nil, fmt.Errorf("GetAllLabubu failed: %w", err)
-	// }
-	
-	// For now, return mock data
-	return []*Labubu{
-		{ID: 1, Text: "sample labubu 1"},
-		{ID: 2, Text: "sample labubu 2"},
-	}, nil
+func (r *pgxRepository) GetAllLabubu(ctx context.Context, ownerID int, statuses []Status) ([]*Labubu, error) {
+	results, err := r.q.GetAllLabubu(ctx, sqlc.GetAllLabubuParams{
+		OwnerID:  pgtype.Int4{Int32: int32(ownerID), Valid: true},
+		Statuses: statusNames(statuses),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetAllLabubu failed: %w", err)
+	}
+
+	items := make([]*Labubu, 0, len(results))
+	for _, result := range results {
+		items = append(items, toLabubu(result))
+	}
+	return items, nil
 }
 
 func (r *pgxRepository) GetLabubuByID(ctx context.Context, id int) (*Labubu, error) {
-	// TODO: Use SQLC generated method
-	// result, err := r.q.GetLabubuByID(ctx, id)
-	// if err != nil {
-	//     return nil, fmt.Errorf("GetLabubuByID failed: %w", err)
-	// }
-	
-	// For now, return mock data
+	result, err := r.q.GetLabubuByID(ctx, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetLabubuByID failed: %w", err)
+	}
+	return toLabubu(result), nil
+}
+
+func (r *pgxRepository) GetLabubuByIDs(ctx context.Context, ids []int) ([]*Labubu, error) {
+	keys := make([]int32, len(ids))
+	for i, id := range ids {
+		keys[i] = int32(id)
+	}
+	results, err := r.q.GetLabubuByIDs(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("GetLabubuByIDs failed: %w", err)
+	}
+
+	items := make([]*Labubu, 0, len(results))
+	for _, result := range results {
+		items = append(items, toLabubu(result))
+	}
+	return items, nil
+}
+
+func (r *pgxRepository) GetOwnedLabubu(ctx context.Context, id, ownerID int) (*Labubu, error) {
+	result, err := r.q.GetOwnedLabubu(ctx, sqlc.GetOwnedLabubuParams{
+		ID:      int32(id),
+		OwnerID: pgtype.Int4{Int32: int32(ownerID), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetOwnedLabubu failed: %w", err)
+	}
+	return toLabubu(result), nil
+}
+
+// UpdateLabubu returns ErrNotFound when no row matches id, owner and version;
+// the service tells a missing row from a stale version
+func (r *pgxRepository) UpdateLabubu(ctx context.Context, req UpdateLabubuRequest) (*Labubu, error) {
+	result, err := r.q.UpdateLabubu(ctx, sqlc.UpdateLabubuParams{
+		ID:      int32(req.ID),
+		OwnerID: pgtype.Int4{Int32: int32(req.OwnerID), Valid: true},
+		Text:    pgtype.Text{String: req.Text, Valid: true},
+		Version: int32(req.Version),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("UpdateLabubu failed: %w", err)
+	}
+	return toLabubu(result), nil
+}
+
+func (r *pgxRepository) SoftDeleteLabubu(ctx context.Context, id, ownerID int) (*Labubu, error) {
+	result, err := r.q.SoftDeleteLabubu(ctx, sqlc.SoftDeleteLabubuParams{
+		ID:      int32(id),
+		OwnerID: pgtype.Int4{Int32: int32(ownerID), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SoftDeleteLabubu failed: %w", err)
+	}
+	return toLabubu(result), nil
+}
+
+func (r *pgxRepository) PurgeDeletedLabubu(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	n, err := r.q.PurgeDeletedLabubu(ctx, pgtype.Timestamptz{Time: deletedBefore, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("PurgeDeletedLabubu failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) ListLabubuByTags(ctx context.Context, ownerID int, names []string, minMatches int, statuses []Status) ([]*Labubu, error) {
+	results, err := r.q.ListLabubuByTags(ctx, sqlc.ListLabubuByTagsParams{
+		OwnerID:    pgtype.Int4{Int32: int32(ownerID), Valid: true},
+		Names:      names,
+		MinMatches: int32(minMatches),
+		Statuses:   statusNames(statuses),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListLabubuByTags failed: %w", err)
+	}
+
+	items := make([]*Labubu, 0, len(results))
+	for _, result := range results {
+		items = append(items, toLabubu(result))
+	}
+	return items, nil
+}
+
+func (r *pgxRepository) SetStatus(ctx context.Context, id int, from, to Status, publishAt *time.Time) (*Labubu, error) {
+	result, err := r.q.SetLabubuStatus(ctx, sqlc.SetLabubuStatusParams{
+		ID:         int32(id),
+		FromStatus: string(from),
+		ToStatus:   string(to),
+		PublishAt:  timestamptz(publishAt),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SetLabubuStatus failed: %w", err)
+	}
+	return toLabubu(result), nil
+}
+
+func (r *pgxRepository) ListDueLabubu(ctx context.Context, now time.Time, limit int) ([]*Labubu, error) {
+	results, err := r.q.ListDueLabubu(ctx, sqlc.ListDueLabubuParams{
+		DueAt:    pgtype.Timestamptz{Time: now, Valid: true},
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListDueLabubu failed: %w", err)
+	}
+
+	items := make([]*Labubu, 0, len(results))
+	for _, result := range results {
+		items = append(items, toLabubu(result))
+	}
+	return items, nil
+}
+
+func (r *pgxRepository) RecordTransition(ctx context.Context, t Transition) error {
+	err := r.q.RecordLabubuTransition(ctx, sqlc.RecordLabubuTransitionParams{
+		LabubuID:   int32(t.LabubuID),
+		FromStatus: pgtype.Text{String: string(t.From), Valid: t.From != ""},
+		ToStatus:   string(t.To),
+		ActorID:    pgtype.Int4{Int32: int32(t.ActorID), Valid: t.ActorID != 0},
+		PublishAt:  timestamptz(t.PublishAt),
+	})
+	if err != nil {
+		return fmt.Errorf("RecordLabubuTransition failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListTransitions(ctx context.Context, labubuID int) ([]*Transition, error) {
+	results, err := r.q.ListLabubuTransitions(ctx, int32(labubuID))
+	if err != nil {
+		return nil, fmt.Errorf("ListLabubuTransitions failed: %w", err)
+	}
+
+	items := make([]*Transition, 0, len(results))
+	for _, result := range results {
+		items = append(items, &Transition{
+			ID:        result.ID,
+			LabubuID:  int(result.LabubuID),
+			From:      Status(result.FromStatus.String),
+			To:        Status(result.ToStatus),
+			ActorID:   int(result.ActorID.Int32),
+			PublishAt: timePtr(result.PublishAt),
+			CreatedAt: result.CreatedAt.Time,
+		})
+	}
+	return items, nil
+}
+
+func (r *pgxRepository) LoadTags(ctx context.Context, items ...*Labubu) error {
+	ids := make([]int32, 0, len(items))
+	byID := make(map[int32]*Labubu, len(items))
+	for _, item := range items {
+		item.Tags = []string{}
+		ids = append(ids, int32(item.ID))
+		byID[int32(item.ID)] = item
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	results, err := r.q.ListLabubuTagNames(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("ListLabubuTagNames failed: %w", err)
+	}
+	for _, result := range results {
+		item := byID[result.LabubuID]
+		item.Tags = append(item.Tags, result.Name)
+	}
+	return nil
+}
+
+func (r *pgxRepository) SetTags(ctx context.Context, labubuID, ownerID int, names []string) error {
+	tagIDs := []int32{}
+	if len(names) > 0 {
+		var err error
+		tagIDs, err = r.q.EnsureTags(ctx, sqlc.EnsureTagsParams{OwnerID: int32(ownerID), Names: names})
+		if err != nil {
+			return fmt.Errorf("EnsureTags failed: %w", err)
+		}
+	}
+	if err := r.q.RemoveLabubuTagsExcept(ctx, sqlc.RemoveLabubuTagsExceptParams{LabubuID: int32(labubuID), TagIds: tagIDs}); err != nil {
+		return fmt.Errorf("RemoveLabubuTagsExcept failed: %w", err)
+	}
+	if err := r.q.AddLabubuTags(ctx, sqlc.AddLabubuTagsParams{LabubuID: int32(labubuID), TagIds: tagIDs}); err != nil {
+		return fmt.Errorf("AddLabubuTags failed: %w", err)
+	}
+	if err := r.q.DeleteUnusedTags(ctx, int32(ownerID)); err != nil {
+		return fmt.Errorf("DeleteUnusedTags failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListTags(ctx context.Context, ownerID int) ([]*Tag, error) {
+	results, err := r.q.ListTags(ctx, int32(ownerID))
+	if err != nil {
+		return nil, fmt.Errorf("ListTags failed: %w", err)
+	}
+
+	tags := make([]*Tag, 0, len(results))
+	for _, result := range results {
+		tags = append(tags, &Tag{ID: int(result.ID), Name: result.Name, Count: int(result.UsageCount)})
+	}
+	return tags, nil
+}
+
+func (r *pgxRepository) GetTag(ctx context.Context, id, ownerID int) (*Tag, error) {
+	result, err := r.q.GetTag(ctx, sqlc.GetTagParams{ID: int32(id), OwnerID: int32(ownerID)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetTag failed: %w", err)
+	}
+	return &Tag{ID: int(result.ID), Name: result.Name, Count: int(result.UsageCount)}, nil
+}
+
+func (r *pgxRepository) RenameTag(ctx context.Context, id, ownerID int, name string) error {
+	n, err := r.q.RenameTag(ctx, sqlc.RenameTagParams{ID: int32(id), OwnerID: int32(ownerID), Name: name})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrTagExists
+		}
+		return fmt.Errorf("RenameTag failed: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MergeTags expects both tags to belong to ownerID
+func (r *pgxRepository) MergeTags(ctx context.Context, sourceID, targetID, ownerID int) error {
+	if err := r.q.MoveTagLinks(ctx, sqlc.MoveTagLinksParams{SourceID: int32(sourceID), TargetID: int32(targetID)}); err != nil {
+		return fmt.Errorf("MoveTagLinks failed: %w", err)
+	}
+	n, err := r.q.DeleteTag(ctx, sqlc.DeleteTagParams{ID: int32(sourceID), OwnerID: int32(ownerID)})
+	if err != nil {
+		return fmt.Errorf("DeleteTag failed: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func toLabubu(l sqlc.Labubu) *Labubu {
 	return &Labubu{
-		ID:   id,
-		Text: "sample labubu",
-	}, nil
-}
\ No newline at end of file
+		ID:          int(l.ID),
+		Text:        l.Text.String,
+		OwnerID:     int(l.OwnerID.Int32),
+		Version:     int(l.Version),
+		Status:      Status(l.Status),
+		PublishAt:   timePtr(l.PublishAt),
+		PublishedAt: timePtr(l.PublishedAt),
+		CreatedAt:   l.CreatedAt.Time,
+		UpdatedAt:   l.UpdatedAt.Time,
+	}
+}
+
+func timePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func timestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// statusNames returns an empty, not nil, slice for no statuses so the query
+// gets an empty array rather than NULL
+func statusNames(statuses []Status) []string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return names
+}
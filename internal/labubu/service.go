@@ -2,43 +2,339 @@ package labubu
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
 )
 
-// Service defines the contract for labubu business logic
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/labubu")
+
+// Service defines the contract for labubu business logic. Mutations record
+// an outbox event and therefore must run on a service bound to a transaction.
 type Service interface {
 	WithTx(tx pgx.Tx) Service
 	CreateLabubu(ctx context.Context, req CreateLabubuRequest) (*Labubu, error)
-	GetAllLabubu(ctx context.Context) ([]*Labubu, error)
+	GetAllLabubu(ctx context.Context, ownerID int, filter ListFilter) ([]*Labubu, error)
 	GetLabubuByID(ctx context.Context, id int) (*Labubu, error)
+	// GetLabubuByIDs returns the entries that exist, in no particular order
+	GetLabubuByIDs(ctx context.Context, ids []int) ([]*Labubu, error)
+	UpdateLabubu(ctx context.Context, req UpdateLabubuRequest) (*Labubu, error)
+	// DeleteLabubu soft-deletes an entry; PurgeDeleted removes it for good later
+	DeleteLabubu(ctx context.Context, id, ownerID int) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// ListTags returns the owner's tags in use, with how many labubu carry each
+	ListTags(ctx context.Context, ownerID int) ([]*Tag, error)
+	RenameTag(ctx context.Context, req RenameTagRequest) (*Tag, error)
+	// MergeTags returns the target tag with the labubu of both
+	MergeTags(ctx context.Context, req MergeTagsRequest) (*Tag, error)
+	// Transition moves a labubu along its lifecycle and records the change
+	Transition(ctx context.Context, req TransitionRequest) (*Labubu, error)
+	// PublishDue publishes up to limit scheduled labubu due at now and
+	// returns how many it published
+	PublishDue(ctx context.Context, now time.Time, limit int) (int, error)
+	ListTransitions(ctx context.Context, id, ownerID int) ([]*Transition, error)
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	events outbox.Writer
 }
 
 // NewService creates a new labubu service
-func NewService(repo Repository) Service {
+func NewService(repo Repository, events outbox.Writer) Service {
 	return &service{
-		repo: repo,
+		repo:   repo,
+		events: events,
 	}
 }
 
 func (s *service) WithTx(tx pgx.Tx) Service {
 	return &service{
-		repo: s.repo.WithTx(tx),
+		repo:   s.repo.WithTx(tx),
+		events: s.events.WithTx(tx),
 	}
 }
 
-func (s *service) CreateLabubu(ctx context.Context, req CreateLabubuRequest) (*Labubu, error) {
-	return s.repo.CreateLabubu(ctx, req.Text)
+// publish records eventType for l in the outbox
+func (s *service) publish(ctx context.Context, eventType string, l *Labubu) error {
+	return s.events.Append(ctx, AggregateType, strconv.Itoa(l.ID), eventType, l)
+}
+
+func (s *service) CreateLabubu(ctx context.Context, req CreateLabubuRequest) (_ *Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.CreateLabubu")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateLabubu(ctx, text, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		if err := s.repo.SetTags(ctx, created.ID, req.OwnerID, tags); err != nil {
+			return nil, err
+		}
+	}
+	created.Tags = tags
+	if err := s.repo.RecordTransition(ctx, Transition{LabubuID: created.ID, To: created.Status, ActorID: req.OwnerID}); err != nil {
+		return nil, err
+	}
+	if err := s.publish(ctx, EventCreated, created); err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
-func (s *service) GetAllLabubu(ctx context.Context) ([]*Labubu, error) {
-	return s.repo.GetAllLabubu(ctx)
+func (s *service) GetAllLabubu(ctx context.Context, ownerID int, filter ListFilter) (_ []*Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.GetAllLabubu")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	for _, status := range filter.Statuses {
+		if !status.Valid() {
+			return nil, ErrInvalidStatus
+		}
+	}
+	var items []*Labubu
+	if len(filter.Tags) > 0 {
+		tags, err := normalizeTags(filter.Tags)
+		if err != nil {
+			return nil, err
+		}
+		minMatches := 1
+		if filter.MatchAll {
+			minMatches = len(tags)
+		}
+		items, err = s.repo.ListLabubuByTags(ctx, ownerID, tags, minMatches, filter.Statuses)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		items, err = s.repo.GetAllLabubu(ctx, ownerID, filter.Statuses)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.LoadTags(ctx, items...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *service) GetLabubuByID(ctx context.Context, id int) (_ *Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.GetLabubuByID")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	item, err := s.repo.GetLabubuByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTags(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *service) GetLabubuByIDs(ctx context.Context, ids []int) (_ []*Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.GetLabubuByIDs")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	items, err := s.repo.GetLabubuByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTags(ctx, items...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *service) UpdateLabubu(ctx context.Context, req UpdateLabubuRequest) (_ *Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.UpdateLabubu")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" {
+		return nil, ErrEmptyText
+	}
+	if req.Tags != nil {
+		if req.Tags, err = normalizeTags(req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.repo.UpdateLabubu(ctx, req)
+	if errors.Is(err, ErrNotFound) {
+		// Nothing matched the version; tell a stale version from a missing entry
+		if _, err := s.repo.GetOwnedLabubu(ctx, req.ID, req.OwnerID); err != nil {
+			return nil, err
+		}
+		return nil, ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.Tags != nil {
+		if err := s.repo.SetTags(ctx, updated.ID, req.OwnerID, req.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.LoadTags(ctx, updated); err != nil {
+		return nil, err
+	}
+	if err := s.publish(ctx, EventUpdated, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *service) DeleteLabubu(ctx context.Context, id, ownerID int) (err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.DeleteLabubu")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	deleted, err := s.repo.SoftDeleteLabubu(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.LoadTags(ctx, deleted); err != nil {
+		return err
+	}
+	return s.publish(ctx, EventDeleted, deleted)
 }
 
-func (s *service) GetLabubuByID(ctx context.Context, id int) (*Labubu, error) {
-	return s.repo.GetLabubuByID(ctx, id)
-}
\ No newline at end of file
+func (s *service) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.PurgeDeleted")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.PurgeDeletedLabubu(ctx, deletedBefore)
+}
+
+func (s *service) ListTags(ctx context.Context, ownerID int) (_ []*Tag, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.ListTags")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.ListTags(ctx, ownerID)
+}
+
+func (s *service) RenameTag(ctx context.Context, req RenameTagRequest) (_ *Tag, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.RenameTag")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	name, err := NormalizeTag(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RenameTag(ctx, req.ID, req.OwnerID, name); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTag(ctx, req.ID, req.OwnerID)
+}
+
+func (s *service) MergeTags(ctx context.Context, req MergeTagsRequest) (_ *Tag, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.MergeTags")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if req.SourceID == req.TargetID {
+		return nil, ErrSameTag
+	}
+	// Both have to belong to the owner before any labubu moves
+	for _, id := range []int{req.SourceID, req.TargetID} {
+		if _, err := s.repo.GetTag(ctx, id, req.OwnerID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.MergeTags(ctx, req.SourceID, req.TargetID, req.OwnerID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTag(ctx, req.TargetID, req.OwnerID)
+}
+
+func (s *service) Transition(ctx context.Context, req TransitionRequest) (_ *Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.Transition")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if !req.Status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+	current, err := s.repo.GetOwnedLabubu(ctx, req.ID, req.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if !current.Status.CanTransition(req.Status) {
+		return nil, transitionError(current.Status, req.Status)
+	}
+	var publishAt *time.Time
+	if req.Status == StatusScheduled {
+		if !req.PublishAt.After(time.Now()) {
+			return nil, ErrPublishAtRequired
+		}
+		publishAt = &req.PublishAt
+	}
+	return s.transition(ctx, current, req.Status, publishAt, req.OwnerID)
+}
+
+func (s *service) PublishDue(ctx context.Context, now time.Time, limit int) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.PublishDue")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	due, err := s.repo.ListDueLabubu(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range due {
+		// The rows are locked, so none can have moved on since they were listed
+		if _, err := s.transition(ctx, item, StatusPublished, nil, 0); err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+func (s *service) ListTransitions(ctx context.Context, id, ownerID int) (_ []*Transition, err error) {
+	ctx, span := tracer.Start(ctx, "labubu.Service.ListTransitions")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.repo.GetOwnedLabubu(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTransitions(ctx, id)
+}
+
+// transition moves current to status on behalf of actorID, zero for the
+// publisher, and records the change. ErrVersionConflict means the status
+// changed since current was read.
+func (s *service) transition(ctx context.Context, current *Labubu, status Status, publishAt *time.Time, actorID int) (*Labubu, error) {
+	updated, err := s.repo.SetStatus(ctx, current.ID, current.Status, status, publishAt)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	record := Transition{LabubuID: updated.ID, From: current.Status, To: status, ActorID: actorID, PublishAt: publishAt}
+	if status == StatusPublished && actorID == 0 {
+		// Keep the time it was scheduled for next to when it went out
+		record.PublishAt = current.PublishAt
+	}
+	if err := s.repo.RecordTransition(ctx, record); err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTags(ctx, updated); err != nil {
+		return nil, err
+	}
+	if err := s.publish(ctx, EventStatusChanged, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
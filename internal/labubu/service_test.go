@@ -0,0 +1,193 @@
+package labubu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+)
+
+const ownerID = 1
+
+// memoryRepository keeps the lifecycle of labubu in memory
+type memoryRepository struct {
+	Repository
+
+	items       map[int]*Labubu
+	transitions []Transition
+}
+
+func (r *memoryRepository) GetOwnedLabubu(_ context.Context, id, ownerID int) (*Labubu, error) {
+	l, ok := r.items[id]
+	if !ok || l.OwnerID != ownerID {
+		return nil, ErrNotFound
+	}
+	copied := *l
+	return &copied, nil
+}
+
+func (r *memoryRepository) SetStatus(_ context.Context, id int, from, to Status, publishAt *time.Time) (*Labubu, error) {
+	l, ok := r.items[id]
+	if !ok || l.Status != from {
+		return nil, ErrNotFound
+	}
+	l.Status, l.PublishAt = to, publishAt
+	if to == StatusPublished {
+		now := time.Now()
+		l.PublishedAt = &now
+	}
+	copied := *l
+	return &copied, nil
+}
+
+func (r *memoryRepository) ListDueLabubu(_ context.Context, now time.Time, limit int) ([]*Labubu, error) {
+	var due []*Labubu
+	for id := 1; id <= len(r.items) && len(due) < limit; id++ {
+		l := r.items[id]
+		if l.Status == StatusScheduled && !l.PublishAt.After(now) {
+			copied := *l
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryRepository) RecordTransition(_ context.Context, t Transition) error {
+	r.transitions = append(r.transitions, t)
+	return nil
+}
+
+func (r *memoryRepository) LoadTags(context.Context, ...*Labubu) error {
+	return nil
+}
+
+// recordingWriter records the events appended to the outbox
+type recordingWriter struct {
+	events []string
+}
+
+func (w *recordingWriter) WithTx(pgx.Tx) outbox.Writer { return w }
+
+func (w *recordingWriter) Append(_ context.Context, _, _, eventType string, _ any) error {
+	w.events = append(w.events, eventType)
+	return nil
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusDraft, StatusScheduled, true},
+		{StatusDraft, StatusPublished, true},
+		{StatusDraft, StatusArchived, false},
+		{StatusScheduled, StatusScheduled, true},
+		{StatusScheduled, StatusDraft, true},
+		{StatusScheduled, StatusPublished, true},
+		{StatusPublished, StatusArchived, true},
+		{StatusPublished, StatusDraft, false},
+		{StatusArchived, StatusDraft, true},
+		{StatusArchived, StatusPublished, false},
+		{Status("deleted"), StatusDraft, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransition(tt.to); got != tt.want {
+			t.Errorf("%s.CanTransition(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTransition(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		from      Status
+		req       TransitionRequest
+		wantErr   error
+		wantAfter Status
+	}{
+		{name: "publish a draft", from: StatusDraft, req: TransitionRequest{Status: StatusPublished}, wantAfter: StatusPublished},
+		{name: "schedule", from: StatusDraft, req: TransitionRequest{Status: StatusScheduled, PublishAt: future}, wantAfter: StatusScheduled},
+		{name: "schedule without a time", from: StatusDraft, req: TransitionRequest{Status: StatusScheduled}, wantErr: ErrPublishAtRequired, wantAfter: StatusDraft},
+		{name: "schedule in the past", from: StatusDraft, req: TransitionRequest{Status: StatusScheduled, PublishAt: time.Now().Add(-time.Minute)}, wantErr: ErrPublishAtRequired, wantAfter: StatusDraft},
+		{name: "not allowed", from: StatusPublished, req: TransitionRequest{Status: StatusDraft}, wantErr: ErrInvalidTransition, wantAfter: StatusPublished},
+		{name: "unknown status", from: StatusDraft, req: TransitionRequest{Status: "live"}, wantErr: ErrInvalidStatus, wantAfter: StatusDraft},
+		{name: "not the owner", from: StatusDraft, req: TransitionRequest{OwnerID: 2, Status: StatusPublished}, wantErr: ErrNotFound, wantAfter: StatusDraft},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepository{items: map[int]*Labubu{1: {ID: 1, OwnerID: ownerID, Status: tt.from}}}
+			events := &recordingWriter{}
+			svc := NewService(repo, events)
+
+			tt.req.ID = 1
+			if tt.req.OwnerID == 0 {
+				tt.req.OwnerID = ownerID
+			}
+			got, err := svc.Transition(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transition() = %v, want %v", err, tt.wantErr)
+			}
+			if status := repo.items[1].Status; status != tt.wantAfter {
+				t.Errorf("status = %s, want %s", status, tt.wantAfter)
+			}
+			if tt.wantErr != nil {
+				if len(repo.transitions) != 0 || len(events.events) != 0 {
+					t.Error("refused transition was recorded")
+				}
+				return
+			}
+
+			if got.Status != tt.wantAfter {
+				t.Errorf("Transition() = %+v, want status %s", got, tt.wantAfter)
+			}
+			want := Transition{LabubuID: 1, From: tt.from, To: tt.wantAfter, ActorID: ownerID}
+			if len(repo.transitions) != 1 || repo.transitions[0].From != want.From || repo.transitions[0].To != want.To || repo.transitions[0].ActorID != want.ActorID {
+				t.Errorf("transitions = %+v, want %+v", repo.transitions, want)
+			}
+			if len(events.events) != 1 || events.events[0] != EventStatusChanged {
+				t.Errorf("events = %v, want %s", events.events, EventStatusChanged)
+			}
+		})
+	}
+}
+
+func TestPublishDue(t *testing.T) {
+	now := time.Now()
+	past, later := now.Add(-time.Minute), now.Add(time.Hour)
+	repo := &memoryRepository{items: map[int]*Labubu{
+		1: {ID: 1, OwnerID: ownerID, Status: StatusScheduled, PublishAt: &past},
+		2: {ID: 2, OwnerID: ownerID, Status: StatusScheduled, PublishAt: &later},
+		3: {ID: 3, OwnerID: ownerID, Status: StatusDraft},
+		4: {ID: 4, OwnerID: ownerID, Status: StatusScheduled, PublishAt: &past},
+	}}
+	svc := NewService(repo, &recordingWriter{})
+
+	n, err := svc.PublishDue(context.Background(), now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("PublishDue() = %d, want 2", n)
+	}
+	for id, want := range map[int]Status{1: StatusPublished, 2: StatusScheduled, 3: StatusDraft, 4: StatusPublished} {
+		if got := repo.items[id].Status; got != want {
+			t.Errorf("labubu %d status = %s, want %s", id, got, want)
+		}
+	}
+	for _, tr := range repo.transitions {
+		// The publisher acts on its own and keeps the scheduled time on record
+		if tr.ActorID != 0 || tr.PublishAt == nil || !tr.PublishAt.Equal(past) {
+			t.Errorf("transition = %+v, want no actor and the scheduled time", tr)
+		}
+	}
+
+	if n, err := svc.PublishDue(context.Background(), now, 10); err != nil || n != 0 {
+		t.Errorf("second PublishDue() = %d, %v, want nothing left to publish", n, err)
+	}
+}
@@ -0,0 +1,48 @@
+package labubu
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxTags is the most tags a labubu carries
+	MaxTags = 20
+	// maxTagLength is the longest tag name, in characters
+	maxTagLength = 50
+)
+
+// NormalizeTag trims a tag name, collapses the whitespace inside it and folds
+// its case, so "  Summer   Trip" and "summer trip" are the same tag
+func NormalizeTag(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	// A Caser keeps state, so each call gets its own
+	name = norm.NFC.String(cases.Fold().String(norm.NFC.String(name)))
+	if name == "" || utf8.RuneCountInString(name) > maxTagLength || strings.ContainsFunc(name, unicode.IsControl) {
+		return "", ErrInvalidTag
+	}
+	return name, nil
+}
+
+// normalizeTags normalizes names and drops duplicates, sorted
+func normalizeTags(names []string) ([]string, error) {
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	if len(tags) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+	return tags, nil
+}
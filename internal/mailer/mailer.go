@@ -0,0 +1,51 @@
+// Package mailer sends templated mail from a job, so a failing provider is
+// retried by the worker instead of failing the request that caused the mail
+package mailer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/platform/mail"
+)
+
+// SendArgs is the job that renders one of the mail templates and sends it
+type SendArgs struct {
+	To       string `json:"to"`
+	Template string `json:"template"`
+	// Locale is a tag such as "tr-TR"; empty or unknown locales use the default
+	Locale string `json:"locale,omitempty"`
+	// Data is the template's data; times arrive as RFC 3339 strings
+	Data map[string]any `json:"data,omitempty"`
+}
+
+func (SendArgs) Kind() string { return "email.send" }
+
+// Send enqueues template for to in the locale recorded on ctx by mail.WithLocale.
+// Enqueue with a transactional queue so the mail only goes out on commit.
+func Send(ctx context.Context, queue jobs.Queue, to, template string, data map[string]any, opts ...jobs.EnqueueOption) error {
+	_, err := queue.Enqueue(ctx, SendArgs{
+		To:       to,
+		Template: template,
+		Locale:   mail.LocaleFromContext(ctx),
+		Data:     data,
+	}, opts...)
+	return err
+}
+
+// RegisterJobs adds the send handler to registry
+func RegisterJobs(registry *jobs.Registry, m mail.Mailer, templates *mail.Templates) {
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args SendArgs) error {
+		msg, err := templates.Render(args.Template, args.Locale, args.To, args.Data)
+		if err != nil {
+			// A broken template or unknown name fails the same way on every attempt
+			return jobs.Permanent(err)
+		}
+		err = m.Send(ctx, msg)
+		if errors.Is(err, mail.ErrNoRecipient) {
+			return jobs.Permanent(err)
+		}
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+)
+
+// APIKeyHeader carries the key for admin endpoints
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyAuthKey marks requests that passed APIKeyAuth
+const apiKeyAuthKey contextKey = "api_key_auth"
+
+// APIKeyAuth protects admin endpoints with the configured API_KEY. When no
+// key is configured the endpoints are disabled.
+func APIKeyAuth(apiKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey == "" {
+				http.NotFound(w, r)
+				return
+			}
+
+			key := r.Header.Get(APIKeyHeader)
+			if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+				http.Error(w, "Invalid API key", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyAuthKey, true)))
+		})
+	}
+}
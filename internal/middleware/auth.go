@@ -3,9 +3,11 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/platform/token"
 )
 
 // contextKey is used for context keys to avoid collisions
@@ -53,4 +55,40 @@ func BearerAuth(authService auth.Service) func(http.Handler) http.Handler {
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
-}
\ No newline at end of file
+}
+
+// RequireVerifiedEmail rejects access tokens issued before the user verified
+// their email; it must run after BearerAuth
+func RequireVerifiedEmail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Authorization required", http.StatusUnauthorized)
+			return
+		}
+		if verified, _ := claims.Data["email_verified"].(bool); !verified {
+			http.Error(w, "Email address not verified", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClaimsFromContext returns the token claims stored by BearerAuth
+func ClaimsFromContext(ctx context.Context) (*token.Claims, bool) {
+	claims, ok := ctx.Value(TokenClaimsKey).(*token.Claims)
+	return claims, ok && claims != nil
+}
+
+// UserIDFromContext returns the authenticated user's ID from the token subject
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"golang.org/x/text/language"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/mail"
+)
+
+// Locale picks the best of supported for the request's Accept-Language and
+// records it for mail sent while handling the request. supported[0] is the
+// default and is used when nothing matches.
+func Locale(supported []string) func(http.Handler) http.Handler {
+	tags := make([]language.Tag, len(supported))
+	for i, locale := range supported {
+		tags[i] = language.Make(locale)
+	}
+	matcher := language.NewMatcher(tags)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Accept-Language")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			_, index := language.MatchStrings(matcher, header)
+			next.ServeHTTP(w, r.WithContext(mail.WithLocale(r.Context(), supported[index])))
+		})
+	}
+}
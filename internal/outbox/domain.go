@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Event is a domain event waiting in, or delivered from, the outbox
+type Event struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ReorderWindow is how far below the last event seen readers look again when
+// resuming by id. Ids are taken when an event is written, not when its
+// transaction commits, so an event can become visible after higher ids were
+// already read.
+const ReorderWindow = 1000
+
+// ResumeFrom returns the id to list after when resuming from lastID
+func ResumeFrom(lastID int64) int64 {
+	return max(lastID-ReorderWindow, 0)
+}
+
+// Common errors
+var (
+	// ErrNoTransaction is returned when an event is appended outside a
+	// transaction, where it could be published for a change that never commits
+	ErrNoTransaction = errors.New("outbox events must be written inside a transaction")
+)
@@ -0,0 +1,163 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/database"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/outbox")
+
+// relayLockName names the advisory lock that elects the single relay, which
+// keeps delivery ordered per aggregate across replicas
+const relayLockName = "go-api-starter:outbox-relay"
+
+// RelayConfig tunes the relay loop
+type RelayConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	PublishTimeout time.Duration
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (c RelayConfig) withDefaults() RelayConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.PublishTimeout <= 0 {
+		c.PublishTimeout = 10 * time.Second
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 5 * time.Minute
+	}
+	return c
+}
+
+// Relay publishes pending outbox events to every sink, at least once and in
+// order per aggregate. Failed events are retried with backoff; later events
+// of the same aggregate wait for them.
+type Relay struct {
+	pool   *pgxpool.Pool
+	repo   Repository
+	sinks  []Sink
+	config RelayConfig
+}
+
+// NewRelay creates a relay for sinks
+func NewRelay(pool *pgxpool.Pool, repo Repository, sinks []Sink, config RelayConfig) *Relay {
+	return &Relay{
+		pool:   pool,
+		repo:   repo,
+		sinks:  sinks,
+		config: config.withDefaults(),
+	}
+}
+
+// Run relays events while this replica is the leader, until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) error {
+	if len(r.sinks) == 0 {
+		slog.Info("Outbox relay has no sinks configured, not starting")
+		<-ctx.Done()
+		return nil
+	}
+
+	database.RunAsLeader(ctx, r.pool, relayLockName, r.config.PollInterval, r.lead)
+	return nil
+}
+
+func (r *Relay) lead(ctx context.Context) {
+	for {
+		n, err := r.relayBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Outbox relay failed", "error", err)
+		}
+
+		// Keep going while batches are full, otherwise wait for new events
+		if n == r.config.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.config.PollInterval):
+		}
+	}
+}
+
+// relayBatch publishes one batch of due events and returns how many it read
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	events, err := r.repo.ListDue(ctx, r.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Aggregates with a failed event in this batch skip their later events
+	blocked := make(map[string]bool)
+	for _, event := range events {
+		key := event.AggregateType + "/" + event.AggregateID
+		if blocked[key] {
+			continue
+		}
+
+		if err := r.publish(ctx, event); err != nil {
+			if ctx.Err() != nil {
+				return len(events), ctx.Err()
+			}
+			blocked[key] = true
+			next := time.Now().Add(r.backoff(event.Attempts + 1))
+			slog.Warn("Outbox event delivery failed", "id", event.ID, "type", event.Type, "attempt", event.Attempts+1, "next_attempt_at", next, "error", err)
+			if err := r.repo.MarkFailed(ctx, event.ID, err.Error(), next); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+
+		if err := r.repo.MarkPublished(ctx, event.ID); err != nil {
+			return len(events), err
+		}
+	}
+	return len(events), nil
+}
+
+// publish sends event to every sink
+func (r *Relay) publish(ctx context.Context, event *Event) (err error) {
+	ctx, span := tracer.Start(ctx, "outbox.Relay.publish "+event.Type)
+	span.SetAttributes(attribute.Int64("outbox.event_id", event.ID))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoff returns an exponential delay with equal jitter for the given attempt
+func (r *Relay) backoff(attempt int) time.Duration {
+	d := r.config.BaseBackoff << (attempt - 1)
+	if d <= 0 || d > r.config.MaxBackoff {
+		d = r.config.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int64N(int64(d/2)+1))
+}
@@ -0,0 +1,185 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// memoryRepository records what the relay and writer do to the outbox
+type memoryRepository struct {
+	Repository
+
+	due       []*Event
+	appended  []*Event
+	published []int64
+	failed    map[int64]failure
+}
+
+type failure struct {
+	lastError     string
+	nextAttemptAt time.Time
+}
+
+func (r *memoryRepository) WithTx(pgx.Tx) Repository { return r }
+
+func (r *memoryRepository) Append(_ context.Context, aggregateType, aggregateID, eventType string, payload []byte) (*Event, error) {
+	event := &Event{ID: int64(len(r.appended) + 1), AggregateType: aggregateType, AggregateID: aggregateID, Type: eventType, Payload: payload}
+	r.appended = append(r.appended, event)
+	return event, nil
+}
+
+func (r *memoryRepository) ListDue(_ context.Context, limit int) ([]*Event, error) {
+	return r.due[:min(limit, len(r.due))], nil
+}
+
+func (r *memoryRepository) MarkPublished(_ context.Context, id int64) error {
+	r.published = append(r.published, id)
+	return nil
+}
+
+func (r *memoryRepository) MarkFailed(_ context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	if r.failed == nil {
+		r.failed = make(map[int64]failure)
+	}
+	r.failed[id] = failure{lastError: lastError, nextAttemptAt: nextAttemptAt}
+	return nil
+}
+
+// recordingSink records the events it receives and fails the ones in fail
+type recordingSink struct {
+	name     string
+	fail     map[int64]bool
+	received []int64
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Publish(_ context.Context, event *Event) error {
+	s.received = append(s.received, event.ID)
+	if s.fail[event.ID] {
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+func TestRelayBatch(t *testing.T) {
+	event := func(id int64, aggregateID string) *Event {
+		return &Event{ID: id, AggregateType: "labubu", AggregateID: aggregateID, Type: "labubu.updated"}
+	}
+
+	tests := []struct {
+		name          string
+		due           []*Event
+		fail          map[int64]bool
+		wantPublished []int64
+		wantFailed    []int64
+		// wantSkipped never reach a sink
+		wantSkipped []int64
+	}{
+		{
+			name:          "every event is published in order",
+			due:           []*Event{event(1, "1"), event(2, "2"), event(3, "1")},
+			wantPublished: []int64{1, 2, 3},
+		},
+		{
+			name:          "a failure holds back later events of the same aggregate",
+			due:           []*Event{event(1, "1"), event(2, "2"), event(3, "1"), event(4, "2")},
+			fail:          map[int64]bool{1: true},
+			wantPublished: []int64{2, 4},
+			wantFailed:    []int64{1},
+			wantSkipped:   []int64{3},
+		},
+		{
+			name: "nothing due",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepository{due: tt.due}
+			healthy := &recordingSink{name: "healthy"}
+			flaky := &recordingSink{name: "flaky", fail: tt.fail}
+			relay := NewRelay(nil, repo, []Sink{healthy, flaky}, RelayConfig{BaseBackoff: time.Minute})
+
+			n, err := relay.relayBatch(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(tt.due) {
+				t.Errorf("relayBatch() = %d, want %d", n, len(tt.due))
+			}
+			if !slices.Equal(repo.published, tt.wantPublished) {
+				t.Errorf("published = %v, want %v", repo.published, tt.wantPublished)
+			}
+			for _, id := range tt.wantFailed {
+				f, ok := repo.failed[id]
+				if !ok {
+					t.Errorf("event %d not marked failed", id)
+					continue
+				}
+				if !strings.Contains(f.lastError, "flaky: unavailable") || !f.nextAttemptAt.After(time.Now()) {
+					t.Errorf("event %d failure = %+v, want the sink error and a later attempt", id, f)
+				}
+			}
+			for _, id := range tt.wantSkipped {
+				if slices.Contains(healthy.received, id) {
+					t.Errorf("event %d reached a sink before the earlier failed event", id)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterAppend(t *testing.T) {
+	repo := &memoryRepository{}
+	w := NewWriter(repo)
+
+	if err := w.Append(context.Background(), "labubu", "1", "labubu.created", nil); !errors.Is(err, ErrNoTransaction) {
+		t.Fatalf("Append() outside a transaction = %v, want ErrNoTransaction", err)
+	}
+	if len(repo.appended) != 0 {
+		t.Fatal("event stored outside a transaction")
+	}
+
+	var tx pgx.Tx
+	if err := w.WithTx(tx).Append(context.Background(), "labubu", "1", "labubu.created", map[string]int{"id": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.appended) != 1 || string(repo.appended[0].Payload) != `{"id":1}` {
+		t.Errorf("appended = %+v, want the JSON encoded payload", repo.appended)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received Event
+	var idempotencyKey string
+	status := http.StatusAccepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, srv.Client())
+	event := &Event{ID: 42, AggregateType: "labubu", AggregateID: "7", Type: "labubu.created", Payload: json.RawMessage(`{"id":7}`)}
+	if err := sink.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if received.ID != 42 || received.Type != "labubu.created" || idempotencyKey != "42" {
+		t.Errorf("received %+v with Idempotency-Key %q, want the event keyed by its id", received, idempotencyKey)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := sink.Publish(context.Background(), event); err == nil {
+		t.Error("Publish() = nil for a 503 response, want an error")
+	}
+}
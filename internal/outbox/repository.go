@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for outbox data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	Append(ctx context.Context, aggregateType, aggregateID, eventType string, payload []byte) (*Event, error)
+	ListDue(ctx context.Context, limit int) ([]*Event, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+	GetEvents(ctx context.Context, ids []int64) ([]*Event, error)
+	// ListAfter returns events after afterID; an empty aggregateType matches every type
+	ListAfter(ctx context.Context, afterID int64, aggregateType string, limit int) ([]*Event, error)
+	// OldestID returns the id of the oldest retained event, or 0 when the outbox is empty
+	OldestID(ctx context.Context) (int64, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+func (r *pgxRepository) Append(ctx context.Context, aggregateType, aggregateID, eventType string, payload []byte) (*Event, error) {
+	result, err := r.q.AppendOutboxEvent(ctx, sqlc.AppendOutboxEventParams{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AppendOutboxEvent failed: %w", err)
+	}
+	return toEvent(result), nil
+}
+
+func (r *pgxRepository) ListDue(ctx context.Context, limit int) ([]*Event, error) {
+	results, err := r.q.ListDueOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("ListDueOutboxEvents failed: %w", err)
+	}
+	return toEvents(results), nil
+}
+
+func (r *pgxRepository) MarkPublished(ctx context.Context, id int64) error {
+	if err := r.q.MarkOutboxEventPublished(ctx, id); err != nil {
+		return fmt.Errorf("MarkOutboxEventPublished failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	err := r.q.MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		ID:            id,
+		LastError:     pgtype.Text{String: lastError, Valid: true},
+		NextAttemptAt: pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("MarkOutboxEventFailed failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.DeletePublishedOutboxEvents(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("DeletePublishedOutboxEvents failed: %w", err)
+	}
+	return n, nil
+}
+
+func (r *pgxRepository) GetEvents(ctx context.Context, ids []int64) ([]*Event, error) {
+	results, err := r.q.GetOutboxEvents(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("GetOutboxEvents failed: %w", err)
+	}
+	return toEvents(results), nil
+}
+
+func (r *pgxRepository) ListAfter(ctx context.Context, afterID int64, aggregateType string, limit int) ([]*Event, error) {
+	results, err := r.q.ListOutboxEventsAfter(ctx, sqlc.ListOutboxEventsAfterParams{
+		ID:            afterID,
+		AggregateType: aggregateType,
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListOutboxEventsAfter failed: %w", err)
+	}
+	return toEvents(results), nil
+}
+
+func (r *pgxRepository) OldestID(ctx context.Context) (int64, error) {
+	id, err := r.q.GetOldestOutboxEventID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("GetOldestOutboxEventID failed: %w", err)
+	}
+	return id, nil
+}
+
+func toEvents(results []sqlc.Outbox) []*Event {
+	events := make([]*Event, 0, len(results))
+	for _, result := range results {
+		events = append(events, toEvent(result))
+	}
+	return events
+}
+
+func toEvent(e sqlc.Outbox) *Event {
+	return &Event{
+		ID:            e.ID,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Type:          e.EventType,
+		Payload:       e.Payload,
+		Attempts:      int(e.Attempts),
+		CreatedAt:     e.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Sink delivers events to another system. Publish may be called more than
+// once for the same event, so receivers should deduplicate on Event.ID.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event *Event) error
+}
+
+// LogSink writes events to the logger; useful in development and tests
+type LogSink struct{}
+
+func (LogSink) Name() string { return "log" }
+
+func (LogSink) Publish(ctx context.Context, event *Event) error {
+	slog.InfoContext(ctx, "Outbox event",
+		"id", event.ID,
+		"type", event.Type,
+		"aggregate", event.AggregateType+"/"+event.AggregateID,
+		"payload", string(event.Payload),
+	)
+	return nil
+}
+
+// WebhookSink POSTs each event as JSON to a single URL
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink that posts to url with client
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: client,
+	}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Publish(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", strconv.FormatInt(event.ID, 10))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// NATSSink publishes events to subject "<prefix>.<event type>"
+type NATSSink struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+// NewNATSSink connects to the NATS server at url
+func NewNATSSink(url, prefix string) (*NATSSink, error) {
+	conn, err := nats.Connect(url, nats.Name("go-api-starter outbox"))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS: %w", err)
+	}
+	return &NATSSink{
+		conn:   conn,
+		prefix: prefix,
+	}, nil
+}
+
+func (s *NATSSink) Name() string { return "nats" }
+
+func (s *NATSSink) Publish(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// FlushWithContext requires a deadline
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	msg := nats.NewMsg(s.prefix + "." + event.Type)
+	msg.Data = body
+	msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(event.ID, 10))
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	// Flush so a publish only counts once the server has the message
+	return s.conn.FlushWithContext(ctx)
+}
+
+// Close drains the connection
+func (s *NATSSink) Close() error {
+	return s.conn.Drain()
+}
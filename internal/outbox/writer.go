@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Writer records domain events. It only accepts events once bound to the
+// transaction that makes the matching domain change, so an event is stored
+// if and only if the change commits.
+type Writer interface {
+	WithTx(tx pgx.Tx) Writer
+	Append(ctx context.Context, aggregateType, aggregateID, eventType string, payload any) error
+}
+
+type writer struct {
+	repo  Repository
+	bound bool
+}
+
+// NewWriter creates a new outbox writer
+func NewWriter(repo Repository) Writer {
+	return &writer{
+		repo: repo,
+	}
+}
+
+func (w *writer) WithTx(tx pgx.Tx) Writer {
+	return &writer{
+		repo:  w.repo.WithTx(tx),
+		bound: true,
+	}
+}
+
+func (w *writer) Append(ctx context.Context, aggregateType, aggregateID, eventType string, payload any) error {
+	if !w.bound {
+		return ErrNoTransaction
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", eventType, err)
+	}
+	_, err = w.repo.Append(ctx, aggregateType, aggregateID, eventType, data)
+	return err
+}
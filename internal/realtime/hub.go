@@ -0,0 +1,280 @@
+// Package realtime fans committed outbox events out to connected clients.
+// Every replica listens to Postgres notifications itself, so a client sees
+// changes made through any replica.
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+)
+
+// Channel is the notification channel written by the outbox trigger
+const Channel = "outbox_events"
+
+// catchUpBatch is how many events are read per query after a reconnect
+const catchUpBatch = 500
+
+// Config tunes the hub
+type Config struct {
+	// BufferSize is how many events may queue per subscriber before it is dropped
+	BufferSize int
+	// ReconnectDelay is the wait before listening again after the connection fails
+	ReconnectDelay time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.BufferSize <= 0 {
+		c.BufferSize = 64
+	}
+	if c.ReconnectDelay <= 0 {
+		c.ReconnectDelay = time.Second
+	}
+	return c
+}
+
+// Filter selects the events a subscriber receives
+type Filter func(event *outbox.Event) bool
+
+// Subscription receives the events that match its filter
+type Subscription struct {
+	events chan *outbox.Event
+	filter Filter
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Events delivers matching events in commit order
+func (s *Subscription) Events() <-chan *outbox.Event {
+	return s.events
+}
+
+// Done is closed when the subscriber fell too far behind and was dropped, or
+// when the hub stopped. Clients should reconnect and resume from the last
+// event they saw.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Hub listens for outbox notifications and fans events out to subscribers
+type Hub struct {
+	pool   *pgxpool.Pool
+	repo   outbox.Repository
+	config Config
+
+	// handlers receive notifications on extra channels; set before Run
+	handlers map[string]func(payload string)
+
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	stopped bool
+	// lastID is the newest event dispatched, used to catch up after a reconnect
+	lastID int64
+	// seen holds the ids dispatched within outbox.ReorderWindow of lastID, so
+	// catching up sends late commits below lastID but nothing twice
+	seen map[int64]struct{}
+}
+
+// NewHub creates a hub that reads events through repo
+func NewHub(pool *pgxpool.Pool, repo outbox.Repository, config Config) *Hub {
+	return &Hub{
+		pool:     pool,
+		repo:     repo,
+		config:   config.withDefaults(),
+		handlers: make(map[string]func(payload string)),
+		subs:     make(map[*Subscription]struct{}),
+		seen:     make(map[int64]struct{}),
+	}
+}
+
+// Handle calls fn with the payload of every notification on channel. It
+// must be called before Run; fn runs on the listener goroutine and must not block.
+func (h *Hub) Handle(channel string, fn func(payload string)) {
+	h.handlers[channel] = fn
+}
+
+// Notify sends payload to every replica listening on channel, this one included
+func (h *Hub) Notify(ctx context.Context, channel, payload string) error {
+	if _, err := h.pool.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload); err != nil {
+		return fmt.Errorf("notify %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber; call Unsubscribe when it goes away
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		events: make(chan *outbox.Event, h.config.BufferSize),
+		filter: filter,
+		done:   make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		sub.close()
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe removes sub from the hub
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs, sub)
+	sub.close()
+}
+
+// Run listens for notifications until ctx is cancelled, reconnecting after
+// failures. When it returns every subscription is closed.
+func (h *Hub) Run(ctx context.Context) error {
+	defer h.stop()
+
+	for {
+		err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		slog.Error("Realtime listener failed, reconnecting", "error", err, "delay", h.config.ReconnectDelay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(h.config.ReconnectDelay):
+		}
+	}
+}
+
+// listen holds a dedicated connection and dispatches every notified event
+func (h *Hub) listen(ctx context.Context) error {
+	conn, err := h.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	channels := []string{Channel}
+	for channel := range h.handlers {
+		channels = append(channels, channel)
+	}
+	for _, channel := range channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return fmt.Errorf("listen %s: %w", channel, err)
+		}
+	}
+	// Notifications sent while we were not listening are lost; read them from the outbox
+	if err := h.catchUp(ctx); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		if fn, ok := h.handlers[notification.Channel]; ok {
+			fn(notification.Payload)
+			continue
+		}
+
+		id, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			slog.Warn("Ignoring malformed outbox notification", "payload", notification.Payload)
+			continue
+		}
+
+		events, err := h.repo.GetEvents(ctx, []int64{id})
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			h.dispatch(event)
+		}
+	}
+}
+
+// catchUp dispatches events committed while the hub was not listening,
+// including ones below the last id seen that committed late
+func (h *Hub) catchUp(ctx context.Context) error {
+	h.mu.Lock()
+	lastID := h.lastID
+	h.mu.Unlock()
+	if lastID == 0 {
+		return nil
+	}
+	after := outbox.ResumeFrom(lastID)
+
+	for {
+		events, err := h.repo.ListAfter(ctx, after, "", catchUpBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			h.dispatch(event)
+			after = event.ID
+		}
+		if len(events) < catchUpBatch {
+			return nil
+		}
+	}
+}
+
+// dispatch queues event for every matching subscriber unless it was
+// dispatched before. Subscribers whose buffer is full are dropped rather than
+// blocking everyone else.
+func (h *Hub) dispatch(event *outbox.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.seen[event.ID]; ok {
+		return
+	}
+	h.seen[event.ID] = struct{}{}
+	if event.ID > h.lastID {
+		h.lastID = event.ID
+		if len(h.seen) > 2*outbox.ReorderWindow {
+			for id := range h.seen {
+				if id <= outbox.ResumeFrom(h.lastID) {
+					delete(h.seen, id)
+				}
+			}
+		}
+	}
+	for sub := range h.subs {
+		if !sub.filter(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("Dropping slow realtime subscriber", "buffer", cap(sub.events))
+			delete(h.subs, sub)
+			sub.close()
+		}
+	}
+}
+
+// stop closes every subscription so their handlers return
+func (h *Hub) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		sub.close()
+	}
+}
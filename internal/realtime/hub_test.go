@@ -0,0 +1,154 @@
+package realtime
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+)
+
+// eventRepository serves committed events to catchUp
+type eventRepository struct {
+	outbox.Repository
+	events []*outbox.Event
+}
+
+func (r *eventRepository) ListAfter(_ context.Context, afterID int64, _ string, limit int) ([]*outbox.Event, error) {
+	var out []*outbox.Event
+	for _, event := range r.events {
+		if event.ID > afterID && len(out) < limit {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func event(id int64, aggregateID string) *outbox.Event {
+	return &outbox.Event{ID: id, AggregateType: "labubu", AggregateID: aggregateID, Type: "labubu.updated"}
+}
+
+func aggregate(id string) Filter {
+	return func(event *outbox.Event) bool { return event.AggregateID == id }
+}
+
+// received drains what is buffered for sub
+func received(sub *Subscription) []int64 {
+	var ids []int64
+	for {
+		select {
+		case event := <-sub.Events():
+			ids = append(ids, event.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestHubDispatch(t *testing.T) {
+	h := NewHub(nil, nil, Config{})
+	one := h.Subscribe(aggregate("1"))
+	two := h.Subscribe(aggregate("2"))
+
+	for _, e := range []*outbox.Event{event(1, "1"), event(2, "2"), event(3, "1")} {
+		h.dispatch(e)
+	}
+
+	if got := received(one); !slices.Equal(got, []int64{1, 3}) {
+		t.Errorf("subscriber of 1 received %v, want [1 3]", got)
+	}
+	if got := received(two); !slices.Equal(got, []int64{2}) {
+		t.Errorf("subscriber of 2 received %v, want [2]", got)
+	}
+
+	h.Unsubscribe(two)
+	h.dispatch(event(4, "2"))
+	if got := received(two); len(got) != 0 {
+		t.Errorf("unsubscribed subscriber received %v", got)
+	}
+	select {
+	case <-two.Done():
+	default:
+		t.Error("Done() still open after Unsubscribe")
+	}
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	h := NewHub(nil, nil, Config{BufferSize: 1})
+	slow := h.Subscribe(aggregate("1"))
+	other := h.Subscribe(aggregate("2"))
+
+	h.dispatch(event(1, "1"))
+	h.dispatch(event(2, "1"))
+	h.dispatch(event(3, "2"))
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("slow subscriber was not dropped")
+	}
+	select {
+	case <-other.Done():
+		t.Fatal("subscriber with room in its buffer was dropped")
+	default:
+	}
+	if got := received(other); !slices.Equal(got, []int64{3}) {
+		t.Errorf("other subscriber received %v, want [3]", got)
+	}
+}
+
+func TestHubStop(t *testing.T) {
+	h := NewHub(nil, nil, Config{})
+	sub := h.Subscribe(aggregate("1"))
+	h.stop()
+
+	for name, s := range map[string]*Subscription{"existing": sub, "late": h.Subscribe(aggregate("1"))} {
+		select {
+		case <-s.Done():
+		default:
+			t.Errorf("%s subscription still open after the hub stopped", name)
+		}
+	}
+}
+
+func TestHubCatchUp(t *testing.T) {
+	repo := &eventRepository{}
+	for id := int64(1); id <= catchUpBatch+10; id++ {
+		repo.events = append(repo.events, event(id, "1"))
+	}
+	h := NewHub(nil, repo, Config{BufferSize: 2 * catchUpBatch})
+
+	// Nothing seen yet: live notifications cover everything from now on
+	sub := h.Subscribe(aggregate("1"))
+	if err := h.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := received(sub); len(got) != 0 {
+		t.Fatalf("catchUp() before any event sent %d events", len(got))
+	}
+
+	// Event 3 commits late, after 4 and 5 were dispatched
+	for _, e := range []*outbox.Event{repo.events[0], repo.events[1], repo.events[3], repo.events[4]} {
+		h.dispatch(e)
+	}
+	received(sub)
+	if err := h.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := received(sub)
+	want := []int64{3}
+	for id := int64(6); id <= catchUpBatch+10; id++ {
+		want = append(want, id)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("catchUp() sent %d events from %v, want the late event and every event after 5, each once", len(got), got[:min(len(got), 3)])
+	}
+
+	// Catching up again finds nothing new
+	if err := h.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := received(sub); len(got) != 0 {
+		t.Errorf("second catchUp() sent %v again", got[:min(len(got), 3)])
+	}
+}
@@ -0,0 +1,272 @@
+package realtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PresenceChannel carries presence snapshots between replicas
+const PresenceChannel = "realtime_presence"
+
+// Viewer is a user present in a room and how many connections they have open
+type Viewer struct {
+	UserID      int `json:"user_id"`
+	Connections int `json:"connections"`
+}
+
+// PresenceConfig tunes presence tracking
+type PresenceConfig struct {
+	// AnnounceInterval is how often each replica re-sends its rooms
+	AnnounceInterval time.Duration
+	// TTL is how long a replica's snapshot counts without being re-sent, so
+	// viewers on a crashed replica disappear
+	TTL time.Duration
+}
+
+func (c PresenceConfig) withDefaults() PresenceConfig {
+	if c.AnnounceInterval <= 0 {
+		c.AnnounceInterval = 20 * time.Second
+	}
+	if c.TTL <= 0 {
+		c.TTL = 3 * c.AnnounceInterval
+	}
+	return c
+}
+
+// presenceMessage is one replica's view of one room
+type presenceMessage struct {
+	Node  string      `json:"node"`
+	Room  string      `json:"room"`
+	Users map[int]int `json:"users"`
+}
+
+// snapshot is the latest presenceMessage of a replica
+type snapshot struct {
+	users map[int]int
+	seen  time.Time
+}
+
+// Presence tracks who is in which room across every replica. Each replica
+// broadcasts the users connected to it; the merged view is what clients see.
+type Presence struct {
+	hub    *Hub
+	node   string
+	config PresenceConfig
+
+	mu       sync.Mutex
+	onChange func(room string, viewers []Viewer)
+	// local counts connections per user on this replica
+	local map[string]map[int]int
+	// rooms holds the snapshot of every replica, this one included
+	rooms map[string]map[string]snapshot
+}
+
+// NewPresence creates a presence tracker on hub. It must be created before
+// the hub runs.
+func NewPresence(hub *Hub, config PresenceConfig) *Presence {
+	node := make([]byte, 8)
+	_, _ = rand.Read(node)
+
+	p := &Presence{
+		hub:    hub,
+		node:   hex.EncodeToString(node),
+		config: config.withDefaults(),
+		local:  make(map[string]map[int]int),
+		rooms:  make(map[string]map[string]snapshot),
+	}
+	hub.Handle(PresenceChannel, p.receive)
+	return p
+}
+
+// OnChange sets the function called with the new viewers whenever a room's
+// presence changes. It runs on the listener goroutine and must not block.
+func (p *Presence) OnChange(fn func(room string, viewers []Viewer)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onChange = fn
+}
+
+// Join records a connection of userID in room
+func (p *Presence) Join(ctx context.Context, room string, userID int) error {
+	p.mu.Lock()
+	if p.local[room] == nil {
+		p.local[room] = make(map[int]int)
+	}
+	p.local[room][userID]++
+	msg := p.message(room)
+	p.mu.Unlock()
+
+	return p.announce(ctx, msg)
+}
+
+// Leave removes a connection of userID from room
+func (p *Presence) Leave(ctx context.Context, room string, userID int) error {
+	p.mu.Lock()
+	if users := p.local[room]; users != nil {
+		if users[userID]--; users[userID] <= 0 {
+			delete(users, userID)
+		}
+		if len(users) == 0 {
+			delete(p.local, room)
+		}
+	}
+	msg := p.message(room)
+	p.mu.Unlock()
+
+	return p.announce(ctx, msg)
+}
+
+// Viewers returns everyone in room, ordered by user ID
+func (p *Presence) Viewers(room string) []Viewer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.viewers(room)
+}
+
+// Run re-announces this replica's rooms and expires silent replicas until
+// ctx is cancelled
+func (p *Presence) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.AnnounceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		msgs := make([]presenceMessage, 0, len(p.local))
+		for room := range p.local {
+			msgs = append(msgs, p.message(room))
+		}
+		p.mu.Unlock()
+
+		for _, msg := range msgs {
+			if err := p.announce(ctx, msg); err != nil && ctx.Err() == nil {
+				slog.Warn("Failed to announce presence", "room", msg.Room, "error", err)
+			}
+		}
+		p.expire(time.Now())
+	}
+}
+
+// message builds this replica's snapshot of room; the caller holds mu
+func (p *Presence) message(room string) presenceMessage {
+	users := make(map[int]int, len(p.local[room]))
+	for userID, n := range p.local[room] {
+		users[userID] = n
+	}
+	return presenceMessage{Node: p.node, Room: room, Users: users}
+}
+
+func (p *Presence) announce(ctx context.Context, msg presenceMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return p.hub.Notify(ctx, PresenceChannel, string(payload))
+}
+
+// receive applies a snapshot sent by any replica, including this one
+func (p *Presence) receive(payload string) {
+	var msg presenceMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil || msg.Room == "" {
+		slog.Warn("Ignoring malformed presence message", "payload", payload)
+		return
+	}
+
+	p.mu.Lock()
+	nodes := p.rooms[msg.Room]
+	if nodes == nil {
+		nodes = make(map[string]snapshot)
+		p.rooms[msg.Room] = nodes
+	}
+	before := p.viewers(msg.Room)
+	if len(msg.Users) == 0 {
+		delete(nodes, msg.Node)
+		if len(nodes) == 0 {
+			delete(p.rooms, msg.Room)
+		}
+	} else {
+		nodes[msg.Node] = snapshot{users: msg.Users, seen: time.Now()}
+	}
+	after := p.viewers(msg.Room)
+	onChange := p.onChange
+	p.mu.Unlock()
+
+	if onChange != nil && !sameViewers(before, after) {
+		onChange(msg.Room, after)
+	}
+}
+
+// expire drops snapshots that were not re-announced within the TTL
+func (p *Presence) expire(now time.Time) {
+	type change struct {
+		room    string
+		viewers []Viewer
+	}
+	var changes []change
+
+	p.mu.Lock()
+	for room, nodes := range p.rooms {
+		expired := false
+		for node, snap := range nodes {
+			if now.Sub(snap.seen) > p.config.TTL {
+				delete(nodes, node)
+				expired = true
+			}
+		}
+		if len(nodes) == 0 {
+			delete(p.rooms, room)
+		}
+		if expired {
+			changes = append(changes, change{room: room, viewers: p.viewers(room)})
+		}
+	}
+	onChange := p.onChange
+	p.mu.Unlock()
+
+	if onChange == nil {
+		return
+	}
+	for _, c := range changes {
+		onChange(c.room, c.viewers)
+	}
+}
+
+// viewers merges every replica's snapshot of room; the caller holds mu
+func (p *Presence) viewers(room string) []Viewer {
+	counts := make(map[int]int)
+	for _, snap := range p.rooms[room] {
+		for userID, n := range snap.users {
+			counts[userID] += n
+		}
+	}
+
+	viewers := make([]Viewer, 0, len(counts))
+	for userID, n := range counts {
+		viewers = append(viewers, Viewer{UserID: userID, Connections: n})
+	}
+	sort.Slice(viewers, func(i, j int) bool { return viewers[i].UserID < viewers[j].UserID })
+	return viewers
+}
+
+func sameViewers(a, b []Viewer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
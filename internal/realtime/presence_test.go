@@ -0,0 +1,109 @@
+package realtime
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// snapshotOf encodes what node sends about room
+func snapshotOf(t *testing.T, node, room string, users map[int]int) string {
+	t.Helper()
+	payload, err := json.Marshal(presenceMessage{Node: node, Room: room, Users: users})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(payload)
+}
+
+func TestPresenceReceive(t *testing.T) {
+	p := NewPresence(NewHub(nil, nil, Config{}), PresenceConfig{})
+	var changes [][]Viewer
+	p.OnChange(func(room string, viewers []Viewer) {
+		if room == "labubu:1" {
+			changes = append(changes, viewers)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		payload     string
+		want        []Viewer
+		wantChanged bool
+	}{
+		{
+			name:        "first replica",
+			payload:     snapshotOf(t, "a", "labubu:1", map[int]int{1: 1, 2: 1}),
+			want:        []Viewer{{UserID: 1, Connections: 1}, {UserID: 2, Connections: 1}},
+			wantChanged: true,
+		},
+		{
+			name:        "second replica is merged",
+			payload:     snapshotOf(t, "b", "labubu:1", map[int]int{1: 2}),
+			want:        []Viewer{{UserID: 1, Connections: 3}, {UserID: 2, Connections: 1}},
+			wantChanged: true,
+		},
+		{
+			name:    "re-announcement changes nothing",
+			payload: snapshotOf(t, "b", "labubu:1", map[int]int{1: 2}),
+			want:    []Viewer{{UserID: 1, Connections: 3}, {UserID: 2, Connections: 1}},
+		},
+		{
+			name:    "other room",
+			payload: snapshotOf(t, "a", "labubu:2", map[int]int{3: 1}),
+			want:    []Viewer{{UserID: 1, Connections: 3}, {UserID: 2, Connections: 1}},
+		},
+		{
+			name:        "replica left empty",
+			payload:     snapshotOf(t, "a", "labubu:1", nil),
+			want:        []Viewer{{UserID: 1, Connections: 2}},
+			wantChanged: true,
+		},
+		{
+			name:    "malformed",
+			payload: `{"node":"a"`,
+			want:    []Viewer{{UserID: 1, Connections: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes = nil
+			p.receive(tt.payload)
+
+			if got := p.Viewers("labubu:1"); !slices.Equal(got, tt.want) {
+				t.Errorf("Viewers() = %v, want %v", got, tt.want)
+			}
+			if changed := len(changes) == 1; changed != tt.wantChanged {
+				t.Errorf("OnChange called %d times, want a call: %v", len(changes), tt.wantChanged)
+			}
+			if tt.wantChanged && len(changes) == 1 && !slices.Equal(changes[0], tt.want) {
+				t.Errorf("OnChange got %v, want %v", changes[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestPresenceExpire(t *testing.T) {
+	p := NewPresence(NewHub(nil, nil, Config{}), PresenceConfig{TTL: time.Minute})
+	var changed []string
+	p.OnChange(func(room string, _ []Viewer) { changed = append(changed, room) })
+
+	p.receive(snapshotOf(t, "crashed", "labubu:1", map[int]int{1: 1}))
+	p.receive(snapshotOf(t, "alive", "labubu:2", map[int]int{2: 1}))
+	changed = nil
+
+	// Only the replica that went silent expires
+	p.rooms["labubu:1"]["crashed"] = snapshot{users: map[int]int{1: 1}, seen: time.Now().Add(-2 * time.Minute)}
+	p.expire(time.Now())
+
+	if got := p.Viewers("labubu:1"); len(got) != 0 {
+		t.Errorf("Viewers() = %v after the TTL, want nobody", got)
+	}
+	if got := p.Viewers("labubu:2"); len(got) != 1 {
+		t.Errorf("Viewers() = %v, want the replica within the TTL kept", got)
+	}
+	if !slices.Equal(changed, []string{"labubu:1"}) {
+		t.Errorf("OnChange called for %v, want only the expired room", changed)
+	}
+}
@@ -0,0 +1,26 @@
+package reports
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// KindDailyUsage is the report produced once per day by the scheduler
+const KindDailyUsage = "daily_usage"
+
+// Report is a stored summary for one period
+type Report struct {
+	ID          int64           `json:"id"`
+	Kind        string          `json:"kind"`
+	PeriodStart time.Time       `json:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end"`
+	Data        json.RawMessage `json:"data"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// Usage is the data of a daily usage report
+type Usage struct {
+	NewUsers    int64 `json:"new_users"`
+	NewLabubu   int64 `json:"new_labubu"`
+	ActiveUsers int64 `json:"active_users"`
+}
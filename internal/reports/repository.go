@@ -0,0 +1,75 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for report data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	GetUsage(ctx context.Context, start, end time.Time) (*Usage, error)
+	SaveReport(ctx context.Context, kind string, start, end time.Time, data []byte) (*Report, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+func (r *pgxRepository) GetUsage(ctx context.Context, start, end time.Time) (*Usage, error) {
+	result, err := r.q.GetUsageCounts(ctx, sqlc.GetUsageCountsParams{
+		PeriodStart: pgtype.Timestamptz{Time: start, Valid: true},
+		PeriodEnd:   pgtype.Timestamptz{Time: end, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetUsageCounts failed: %w", err)
+	}
+	return &Usage{
+		NewUsers:    result.NewUsers,
+		NewLabubu:   result.NewLabubu,
+		ActiveUsers: result.ActiveUsers,
+	}, nil
+}
+
+func (r *pgxRepository) SaveReport(ctx context.Context, kind string, start, end time.Time, data []byte) (*Report, error) {
+	result, err := r.q.UpsertReport(ctx, sqlc.UpsertReportParams{
+		Kind:        kind,
+		PeriodStart: pgtype.Timestamptz{Time: start, Valid: true},
+		PeriodEnd:   pgtype.Timestamptz{Time: end, Valid: true},
+		Data:        data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("UpsertReport failed: %w", err)
+	}
+	return toReport(result), nil
+}
+
+func toReport(r sqlc.Report) *Report {
+	return &Report{
+		ID:          r.ID,
+		Kind:        r.Kind,
+		PeriodStart: r.PeriodStart.Time,
+		PeriodEnd:   r.PeriodEnd.Time,
+		Data:        r.Data,
+		CreatedAt:   r.CreatedAt.Time,
+	}
+}
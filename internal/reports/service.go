@@ -0,0 +1,56 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/reports")
+
+// Service defines the contract for report generation
+type Service interface {
+	WithTx(tx pgx.Tx) Service
+	// GenerateDailyUsage builds the usage report for the UTC day containing day.
+	// Running it again for the same day replaces the stored report.
+	GenerateDailyUsage(ctx context.Context, day time.Time) (*Report, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new report service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) WithTx(tx pgx.Tx) Service {
+	return &service{
+		repo: s.repo.WithTx(tx),
+	}
+}
+
+func (s *service) GenerateDailyUsage(ctx context.Context, day time.Time) (_ *Report, err error) {
+	ctx, span := tracer.Start(ctx, "reports.Service.GenerateDailyUsage")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	start := day.UTC().Truncate(24 * time.Hour)
+	end := start.Add(24 * time.Hour)
+
+	usage, err := s.repo.GetUsage(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SaveReport(ctx, KindDailyUsage, start, end, data)
+}
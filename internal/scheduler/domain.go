@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+)
+
+// MissedPolicy decides what happens to ticks that passed while no leader was running
+type MissedPolicy string
+
+const (
+	// MissedSkip drops missed ticks and waits for the next one
+	MissedSkip MissedPolicy = "skip"
+	// MissedCatchUp enqueues one job per missed tick, oldest first, up to MaxCatchUp
+	MissedCatchUp MissedPolicy = "catch-up"
+)
+
+// MaxCatchUp bounds how many missed ticks a catch-up schedule replays at once
+const MaxCatchUp = 10
+
+// Schedule enqueues a job on a cron expression
+type Schedule struct {
+	Name string
+	// Spec is a standard five-field cron expression or a descriptor like @hourly
+	Spec   string
+	Missed MissedPolicy
+	// Args builds the job for the tick at the given time
+	Args func(at time.Time) jobs.Args
+
+	cron cron.Schedule
+}
+
+// Run is the recorded state of a schedule
+type Run struct {
+	Name      string
+	LastRunAt *time.Time
+	NextRunAt time.Time
+	LastJobID *int64
+}
+
+// Status is a schedule with its last outcome, as shown on the admin endpoint
+type Status struct {
+	Name           string       `json:"name"`
+	Spec           string       `json:"spec"`
+	Missed         MissedPolicy `json:"missed"`
+	LastRunAt      *time.Time   `json:"last_run_at"`
+	NextRunAt      *time.Time   `json:"next_run_at"`
+	LastJobID      *int64       `json:"last_job_id"`
+	LastStatus     string       `json:"last_status,omitempty"`
+	LastError      string       `json:"last_error,omitempty"`
+	LastFinishedAt *time.Time   `json:"last_finished_at"`
+}
+
+// ParseSpec validates a cron expression
+func ParseSpec(spec string) error {
+	_, err := cron.ParseStandard(spec)
+	return err
+}
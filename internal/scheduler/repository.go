@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for schedule state
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	GetRun(ctx context.Context, name string) (*Run, error)
+	SaveRun(ctx context.Context, run Run) error
+	ListStatuses(ctx context.Context) ([]Status, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+// GetRun returns nil without error for a schedule that never ran
+func (r *pgxRepository) GetRun(ctx context.Context, name string) (*Run, error) {
+	result, err := r.q.GetScheduleRun(ctx, name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetScheduleRun failed: %w", err)
+	}
+	return &Run{
+		Name:      result.Name,
+		LastRunAt: timePtr(result.LastRunAt),
+		NextRunAt: result.NextRunAt.Time,
+		LastJobID: int64Ptr(result.LastJobID),
+	}, nil
+}
+
+func (r *pgxRepository) SaveRun(ctx context.Context, run Run) error {
+	params := sqlc.UpsertScheduleRunParams{
+		Name:      run.Name,
+		NextRunAt: pgtype.Timestamptz{Time: run.NextRunAt, Valid: true},
+	}
+	if run.LastRunAt != nil {
+		params.LastRunAt = pgtype.Timestamptz{Time: *run.LastRunAt, Valid: true}
+	}
+	if run.LastJobID != nil {
+		params.LastJobID = pgtype.Int8{Int64: *run.LastJobID, Valid: true}
+	}
+	if err := r.q.UpsertScheduleRun(ctx, params); err != nil {
+		return fmt.Errorf("UpsertScheduleRun failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListStatuses(ctx context.Context) ([]Status, error) {
+	results, err := r.q.ListScheduleRuns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListScheduleRuns failed: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(results))
+	for _, result := range results {
+		statuses = append(statuses, Status{
+			Name:           result.Name,
+			LastRunAt:      timePtr(result.LastRunAt),
+			NextRunAt:      timePtr(result.NextRunAt),
+			LastJobID:      int64Ptr(result.LastJobID),
+			LastStatus:     result.LastStatus.String,
+			LastError:      result.LastError.String,
+			LastFinishedAt: timePtr(result.LastFinishedAt),
+		})
+	}
+	return statuses, nil
+}
+
+func timePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func int64Ptr(n pgtype.Int8) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int64
+}
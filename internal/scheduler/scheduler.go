@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/robfig/cron/v3"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/database"
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/scheduler")
+
+// leaderLockName names the advisory lock that elects the leader
+const leaderLockName = "go-api-starter:scheduler"
+
+// Config tunes the scheduler loop
+type Config struct {
+	// Interval is how often the leader checks for due ticks and followers retry the election
+	Interval time.Duration
+	// Grace is how late a tick may fire before it counts as missed
+	Grace time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.Interval <= 0 {
+		c.Interval = 10 * time.Second
+	}
+	if c.Grace <= 0 {
+		c.Grace = time.Minute
+	}
+	return c
+}
+
+// Scheduler enqueues jobs for cron schedules, evaluated in UTC. Every replica
+// may run one; a Postgres advisory lock makes sure only the leader fires ticks.
+type Scheduler struct {
+	pool      *pgxpool.Pool
+	repo      Repository
+	queue     jobs.Queue
+	schedules []*Schedule
+	config    Config
+}
+
+// New validates the schedules and creates a scheduler
+func New(pool *pgxpool.Pool, repo Repository, queue jobs.Queue, schedules []Schedule, config Config) (*Scheduler, error) {
+	s := &Scheduler{
+		pool:   pool,
+		repo:   repo,
+		queue:  queue,
+		config: config.withDefaults(),
+	}
+
+	seen := make(map[string]bool, len(schedules))
+	for _, sch := range schedules {
+		if seen[sch.Name] {
+			return nil, fmt.Errorf("schedule %q is defined twice", sch.Name)
+		}
+		seen[sch.Name] = true
+
+		parsed, err := cron.ParseStandard(sch.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: invalid spec %q: %w", sch.Name, sch.Spec, err)
+		}
+		if sch.Missed == "" {
+			sch.Missed = MissedSkip
+		}
+		sch.cron = parsed
+		s.schedules = append(s.schedules, &sch)
+	}
+	return s, nil
+}
+
+// Run takes part in leader election until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) error {
+	if len(s.schedules) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	database.RunAsLeader(ctx, s.pool, leaderLockName, s.config.Interval, s.lead)
+	return nil
+}
+
+// lead fires due ticks while this replica is the leader
+func (s *Scheduler) lead(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	for _, sch := range s.schedules {
+		if err := s.fire(ctx, sch, now); err != nil && ctx.Err() == nil {
+			slog.Error("Failed to fire schedule", "schedule", sch.Name, "error", err)
+		}
+	}
+}
+
+// fire enqueues the due ticks of one schedule and records its next run in
+// the same transaction, so a crash never loses or repeats a tick
+func (s *Scheduler) fire(ctx context.Context, sch *Schedule, now time.Time) (err error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	repo := s.repo.WithTx(tx)
+	run, err := repo.GetRun(ctx, sch.Name)
+	if err != nil {
+		return err
+	}
+
+	// First sight of a schedule: start from the next tick instead of firing immediately
+	if run == nil {
+		if err := repo.SaveRun(ctx, Run{Name: sch.Name, NextRunAt: sch.cron.Next(now)}); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}
+	if run.NextRunAt.After(now) {
+		return nil
+	}
+
+	ctx, span := tracer.Start(ctx, "scheduler.Scheduler.fire "+sch.Name)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	due, next := dueTicks(sch.cron, run.NextRunAt.UTC(), now)
+	fire := s.ticksToFire(sch, due, now)
+	if skipped := len(due) - len(fire); skipped > 0 {
+		slog.Warn("Schedule missed ticks", "schedule", sch.Name, "policy", sch.Missed, "skipped", skipped, "since", due[0])
+	}
+
+	queue := s.queue.WithTx(tx)
+	updated := Run{Name: sch.Name, NextRunAt: next}
+	for _, at := range fire {
+		job, err := queue.Enqueue(ctx, sch.Args(at), jobs.WithUniqueKey(fmt.Sprintf("schedule:%s:%d", sch.Name, at.Unix())))
+		if err != nil {
+			return fmt.Errorf("enqueue: %w", err)
+		}
+		updated.LastRunAt = &at
+		updated.LastJobID = &job.ID
+		slog.Info("Schedule fired", "schedule", sch.Name, "tick", at, "job_id", job.ID)
+	}
+
+	if err := repo.SaveRun(ctx, updated); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ticksToFire applies the missed-run policy to the due ticks
+func (s *Scheduler) ticksToFire(sch *Schedule, due []time.Time, now time.Time) []time.Time {
+	if sch.Missed == MissedCatchUp {
+		if len(due) > MaxCatchUp {
+			return due[len(due)-MaxCatchUp:]
+		}
+		return due
+	}
+
+	latest := due[len(due)-1]
+	if now.Sub(latest) <= s.config.Grace {
+		return due[len(due)-1:]
+	}
+	return nil
+}
+
+// dueTicks lists every tick from first up to now and returns the following tick
+func dueTicks(schedule cron.Schedule, first, now time.Time) ([]time.Time, time.Time) {
+	var due []time.Time
+	t := first
+	for !t.After(now) {
+		due = append(due, t)
+		t = schedule.Next(t)
+	}
+	return due, t
+}
+
+// Statuses returns every configured schedule with its last run and outcome
+func (s *Scheduler) Statuses(ctx context.Context) ([]Status, error) {
+	stored, err := s.repo.ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]Status, len(stored))
+	for _, st := range stored {
+		byName[st.Name] = st
+	}
+
+	statuses := make([]Status, 0, len(s.schedules))
+	for _, sch := range s.schedules {
+		st, ok := byName[sch.Name]
+		if !ok {
+			next := sch.cron.Next(time.Now().UTC())
+			st = Status{Name: sch.Name, NextRunAt: &next}
+		}
+		st.Spec = sch.Spec
+		st.Missed = sch.Missed
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+)
+
+type tickArgs struct{}
+
+func (tickArgs) Kind() string { return "test.tick" }
+
+func args(time.Time) jobs.Args { return tickArgs{} }
+
+// statusRepository returns fixed statuses
+type statusRepository struct {
+	Repository
+	statuses []Status
+}
+
+func (r *statusRepository) ListStatuses(context.Context) ([]Status, error) {
+	return r.statuses, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []Schedule
+		want      string
+	}{
+		{
+			name:      "valid",
+			schedules: []Schedule{{Name: "a", Spec: "@hourly", Args: args}, {Name: "b", Spec: "*/5 * * * *", Args: args}},
+		},
+		{
+			name:      "duplicate name",
+			schedules: []Schedule{{Name: "a", Spec: "@hourly", Args: args}, {Name: "a", Spec: "@daily", Args: args}},
+			want:      `schedule "a" is defined twice`,
+		},
+		{
+			name:      "invalid spec",
+			schedules: []Schedule{{Name: "a", Spec: "every minute", Args: args}},
+			want:      `schedule "a": invalid spec`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(nil, nil, nil, tt.schedules, Config{})
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("New() = %v", err)
+				}
+				for _, sch := range s.schedules {
+					if sch.Missed != MissedSkip {
+						t.Errorf("schedule %s missed policy = %q, want skip by default", sch.Name, sch.Missed)
+					}
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDueTicks(t *testing.T) {
+	hourly, _ := cron.ParseStandard("@hourly")
+	at := func(hour, minute int) time.Time { return time.Date(2030, 1, 1, hour, minute, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		first    time.Time
+		now      time.Time
+		wantDue  []time.Time
+		wantNext time.Time
+	}{
+		{"not due", at(3, 0), at(2, 59), nil, at(3, 0)},
+		{"exactly due", at(3, 0), at(3, 0), []time.Time{at(3, 0)}, at(4, 0)},
+		{"one due", at(3, 0), at(3, 30), []time.Time{at(3, 0)}, at(4, 0)},
+		{"several missed", at(3, 0), at(5, 10), []time.Time{at(3, 0), at(4, 0), at(5, 0)}, at(6, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, next := dueTicks(hourly, tt.first, tt.now)
+			if len(due) != len(tt.wantDue) {
+				t.Fatalf("due = %v, want %v", due, tt.wantDue)
+			}
+			for i := range due {
+				if !due[i].Equal(tt.wantDue[i]) {
+					t.Errorf("due = %v, want %v", due, tt.wantDue)
+				}
+			}
+			if !next.Equal(tt.wantNext) {
+				t.Errorf("next = %v, want %v", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestTicksToFire(t *testing.T) {
+	s := &Scheduler{config: Config{Grace: time.Minute}.withDefaults()}
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	ticks := func(n int) []time.Time {
+		out := make([]time.Time, n)
+		for i := range out {
+			out[i] = start.Add(time.Duration(i) * time.Hour)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		missed MissedPolicy
+		due    []time.Time
+		now    time.Time
+		want   []time.Time
+	}{
+		{"skip fires the latest tick within the grace", MissedSkip, ticks(3), start.Add(2*time.Hour + 30*time.Second), ticks(3)[2:]},
+		{"skip drops a tick past the grace", MissedSkip, ticks(1), start.Add(2 * time.Minute), nil},
+		{"catch-up fires every missed tick", MissedCatchUp, ticks(3), start.Add(5 * time.Hour), ticks(3)},
+		{"catch-up is bounded", MissedCatchUp, ticks(MaxCatchUp + 5), start.Add(100 * time.Hour), ticks(MaxCatchUp + 5)[5:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.ticksToFire(&Schedule{Missed: tt.missed}, tt.due, tt.now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ticksToFire() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("ticksToFire() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	lastRun := time.Date(2030, 1, 1, 3, 0, 0, 0, time.UTC)
+	repo := &statusRepository{statuses: []Status{
+		{Name: "reports", LastRunAt: &lastRun, LastStatus: "succeeded"},
+		// Left over from a schedule that was removed from the config
+		{Name: "retired"},
+	}}
+	s, err := New(nil, repo, nil, []Schedule{
+		{Name: "reports", Spec: "@daily", Missed: MissedCatchUp, Args: args},
+		{Name: "cleanup", Spec: "@hourly", Args: args},
+	}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := s.Statuses(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("Statuses() = %+v, want only the configured schedules", statuses)
+	}
+	reports, cleanup := statuses[0], statuses[1]
+	if reports.Name != "reports" || reports.Spec != "@daily" || reports.Missed != MissedCatchUp || reports.LastStatus != "succeeded" {
+		t.Errorf("reports = %+v, want the stored run with its configuration", reports)
+	}
+	if cleanup.Name != "cleanup" || cleanup.LastRunAt != nil || cleanup.NextRunAt == nil || !cleanup.NextRunAt.After(time.Now()) {
+		t.Errorf("cleanup = %+v, want a schedule that never ran with its next tick", cleanup)
+	}
+}
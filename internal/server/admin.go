@@ -0,0 +1,48 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/scheduler"
+)
+
+// reloadConfigResponse lists what a reload changed
+type reloadConfigResponse struct {
+	Changes []environment.Change `json:"changes"`
+}
+
+// reloadConfig re-reads the configuration, like sending SIGHUP
+func reloadConfig(reloader *environment.Reloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		changes, err := reloader.Reload()
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+			return
+		}
+		if changes == nil {
+			changes = []environment.Change{}
+		}
+		writeJSON(w, http.StatusOK, reloadConfigResponse{Changes: changes})
+	}
+}
+
+// listSchedules shows every recurring task with its last and next run
+func listSchedules(sched *scheduler.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statuses, err := sched.Statuses(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, statuses)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/api"
+	"github.com/abdurrahimagca/go-api-starter/internal/attachments"
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+)
+
+// ListAttachments implements the GET /labubu/{id}/attachments endpoint
+func (s *Server) ListAttachments(ctx context.Context, request api.ListAttachmentsRequestObject) (api.ListAttachmentsResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	results, err := s.attachmentService.ListAttachments(ctx, request.Id, userID)
+	if errors.Is(err, labubu.ErrNotFound) {
+		return api.ListAttachments404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(api.ListAttachments200JSONResponse, len(results))
+	for i, item := range results {
+		items[i] = attachmentResponse(item, nil)
+	}
+	return items, nil
+}
+
+// UploadAttachment implements the POST /labubu/{id}/attachments endpoint: a
+// multipart body is uploaded through the API, a JSON body creates a direct upload
+func (s *Server) UploadAttachment(ctx context.Context, request api.UploadAttachmentRequestObject) (api.UploadAttachmentResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var (
+		result *attachments.Attachment
+		upload *storage.PresignedRequest
+		err    error
+	)
+	if request.JSONBody != nil {
+		var created *attachments.Upload
+		created, err = s.attachmentService.CreateUpload(ctx, attachments.CreateUploadRequest{
+			LabubuID:    request.Id,
+			UploaderID:  userID,
+			Filename:    request.JSONBody.Filename,
+			ContentType: request.JSONBody.ContentType,
+			Size:        request.JSONBody.Size,
+		})
+		if created != nil {
+			result, upload = created.Attachment, created.Request
+		}
+	} else {
+		part, partErr := filePart(request.MultipartBody)
+		if partErr != nil {
+			return api.UploadAttachment400JSONResponse{Error: partErr.Error()}, nil
+		}
+		defer part.Close()
+		var uploaded *attachments.Attachment
+		uploaded, err = s.attachmentService.Upload(ctx, attachments.UploadRequest{
+			LabubuID:   request.Id,
+			UploaderID: userID,
+			Filename:   part.FileName(),
+			Body:       part,
+		})
+		if err == nil {
+			if result, err = s.saveAttachment(ctx, uploaded); err != nil {
+				s.attachmentService.Discard(ctx, uploaded)
+			}
+		}
+	}
+	switch {
+	case errors.Is(err, attachments.ErrInvalidFilename):
+		return api.UploadAttachment400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, labubu.ErrNotFound):
+		return api.UploadAttachment404Response{}, nil
+	case errors.Is(err, attachments.ErrTooLarge):
+		return api.UploadAttachment413Response{}, nil
+	case errors.Is(err, attachments.ErrTypeNotAllowed):
+		return api.UploadAttachment415Response{}, nil
+	case errors.Is(err, attachments.ErrRejected):
+		return api.UploadAttachment422Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return attachmentResponse(result, upload), nil
+}
+
+// errNoFile is returned when a multipart upload has no file field
+var errNoFile = errors.New("the file field is required")
+
+// filePart skips to the "file" field of a multipart body
+func filePart(body *multipart.Reader) (*multipart.Part, error) {
+	if body == nil {
+		return nil, errNoFile
+	}
+	for {
+		part, err := body.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errNoFile
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+		_ = part.Close()
+	}
+}
+
+// CompleteAttachment implements the POST /labubu/{id}/attachments/{attachmentId}/complete endpoint
+func (s *Server) CompleteAttachment(ctx context.Context, request api.CompleteAttachmentRequestObject) (api.CompleteAttachmentResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	result, err := s.attachmentService.CompleteUpload(ctx, request.Id, request.AttachmentId, userID)
+	if err == nil {
+		result, err = s.saveAttachment(ctx, result)
+	}
+	switch {
+	case errors.Is(err, labubu.ErrNotFound), errors.Is(err, attachments.ErrNotFound):
+		return api.CompleteAttachment404Response{}, nil
+	case errors.Is(err, attachments.ErrNotUploaded):
+		return api.CompleteAttachment409Response{}, nil
+	case errors.Is(err, attachments.ErrTypeNotAllowed):
+		return api.CompleteAttachment415Response{}, nil
+	case errors.Is(err, attachments.ErrRejected):
+		return api.CompleteAttachment422Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.CompleteAttachment200JSONResponse(attachmentResponse(result, nil)), nil
+}
+
+// saveAttachment records a checked attachment together with the job that
+// processes it, if it is an image
+func (s *Server) saveAttachment(ctx context.Context, a *attachments.Attachment) (*attachments.Attachment, error) {
+	var saved *attachments.Attachment
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		saved, err = svc.Attachments.Save(ctx, a)
+		return err
+	})
+	return saved, err
+}
+
+// DownloadAttachment implements the GET /labubu/{id}/attachments/{attachmentId} endpoint
+func (s *Server) DownloadAttachment(ctx context.Context, request api.DownloadAttachmentRequestObject) (api.DownloadAttachmentResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	download, err := s.attachmentService.DownloadURL(ctx, request.Id, request.AttachmentId, userID)
+	switch {
+	case errors.Is(err, labubu.ErrNotFound), errors.Is(err, attachments.ErrNotFound):
+		return api.DownloadAttachment404Response{}, nil
+	case errors.Is(err, attachments.ErrNotReady):
+		return api.DownloadAttachment409Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.DownloadAttachment302Response{Headers: api.DownloadAttachment302ResponseHeaders{
+		Location: download.URL,
+	}}, nil
+}
+
+// DeleteAttachment implements the DELETE /labubu/{id}/attachments/{attachmentId} endpoint
+func (s *Server) DeleteAttachment(ctx context.Context, request api.DeleteAttachmentRequestObject) (api.DeleteAttachmentResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		return svc.Attachments.DeleteAttachment(ctx, request.Id, request.AttachmentId, userID)
+	})
+	if errors.Is(err, labubu.ErrNotFound) || errors.Is(err, attachments.ErrNotFound) {
+		return api.DeleteAttachment404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.DeleteAttachment204Response{}, nil
+}
+
+// ListAttachmentVariants implements the GET /labubu/{id}/attachments/{attachmentId}/variants endpoint
+func (s *Server) ListAttachmentVariants(ctx context.Context, request api.ListAttachmentVariantsRequestObject) (api.ListAttachmentVariantsResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	a, variants, err := s.attachmentService.ListVariants(ctx, request.Id, request.AttachmentId, userID)
+	if errors.Is(err, labubu.ErrNotFound) || errors.Is(err, attachments.ErrNotFound) {
+		return api.ListAttachmentVariants404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := api.ListAttachmentVariants200JSONResponse{}
+	if a.ImageStatus != "" {
+		imageStatus := string(a.ImageStatus)
+		response.ImageStatus = &imageStatus
+	}
+	if a.ImageError != "" {
+		response.ImageError = &a.ImageError
+	}
+	if a.Width > 0 {
+		response.Width, response.Height = &a.Width, &a.Height
+	}
+	response.Variants = make([]struct {
+		ContentType string    `json:"content_type"`
+		ExpiresAt   time.Time `json:"expires_at"`
+		Height      int       `json:"height"`
+		Name        string    `json:"name"`
+		Size        int64     `json:"size"`
+		Url         string    `json:"url"`
+		Width       int       `json:"width"`
+	}, len(variants))
+	for i, v := range variants {
+		response.Variants[i].ContentType = v.ContentType
+		response.Variants[i].ExpiresAt = v.ExpiresAt
+		response.Variants[i].Height = v.Height
+		response.Variants[i].Name = v.Name
+		response.Variants[i].Size = v.Size
+		response.Variants[i].Url = v.URL
+		response.Variants[i].Width = v.Width
+	}
+	return response, nil
+}
+
+// attachmentResponse maps a to the API; upload is set for direct uploads
+func attachmentResponse(a *attachments.Attachment, upload *storage.PresignedRequest) api.UploadAttachment201JSONResponse {
+	response := api.UploadAttachment201JSONResponse{
+		Id:          a.ID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Status:      string(a.Status),
+		CreatedAt:   a.CreatedAt,
+	}
+	if a.SHA256 != "" {
+		response.Sha256 = &a.SHA256
+	}
+	if a.ImageStatus != "" {
+		imageStatus := string(a.ImageStatus)
+		response.ImageStatus = &imageStatus
+	}
+	if a.Width > 0 {
+		response.Width, response.Height = &a.Width, &a.Height
+	}
+	if upload != nil {
+		response.Upload = &struct {
+			ExpiresAt time.Time         `json:"expires_at"`
+			Headers   map[string]string `json:"headers"`
+			Method    string            `json:"method"`
+			Url       string            `json:"url"`
+		}{
+			ExpiresAt: upload.ExpiresAt,
+			Headers:   upload.Headers,
+			Method:    upload.Method,
+			Url:       upload.URL,
+		}
+	}
+	return response
+}
+
+// AttachmentsConfig maps the ATTACHMENTS_* and image settings to the attachment service
+func AttachmentsConfig(config *environment.Environment) attachments.Config {
+	sizes := make([]attachments.ThumbnailSize, len(config.Attachments.ThumbnailSizes))
+	for i, size := range config.Attachments.ThumbnailSizes {
+		sizes[i] = attachments.ThumbnailSize{Name: size.Name, Max: size.Max}
+	}
+	return attachments.Config{
+		MaxSize:      config.Attachments.MaxSize,
+		AllowedTypes: config.Attachments.AllowedTypes,
+		URLTTL:       config.Attachments.URLTTL,
+		Sizes:        sizes,
+		MaxPixels:    config.Attachments.ImageMaxPixels,
+	}
+}
@@ -2,13 +2,33 @@ package server
 
 import (
 	"context"
+	"errors"
+	"math"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 
 	"github.com/abdurrahimagca/go-api-starter/internal/api"
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
 )
 
 // Login implements the /login endpoint
 func (s *Server) Login(ctx context.Context, request api.LoginRequestObject) (api.LoginResponseObject, error) {
-	loginResponse, err := s.authService.Login(ctx)
+	loginResponse, err := s.authService.Login(ctx, auth.LoginRequest{
+		Email:    string(request.Body.Email),
+		Password: request.Body.Password,
+	})
+	if errors.Is(err, auth.ErrInvalidCredentials) {
+		return api.Login401Response{}, nil
+	}
+	var throttled *auth.ThrottledError
+	if errors.As(err, &throttled) {
+		return api.Login429Response{Headers: api.Login429ResponseHeaders{
+			RetryAfter: int(math.Ceil(throttled.RetryAfter.Seconds())),
+		}}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -17,4 +37,113 @@ func (s *Server) Login(ctx context.Context, request api.LoginRequestObject) (api
 		AccessToken:  loginResponse.AccessToken,
 		RefreshToken: loginResponse.RefreshToken,
 	}, nil
-}
\ No newline at end of file
+}
+
+// RefreshToken implements the /token/refresh endpoint
+func (s *Server) RefreshToken(ctx context.Context, request api.RefreshTokenRequestObject) (api.RefreshTokenResponseObject, error) {
+	loginResponse, err := s.authService.Refresh(ctx, request.Body.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken) {
+		return api.RefreshToken401Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.RefreshToken200JSONResponse{
+		AccessToken:  loginResponse.AccessToken,
+		RefreshToken: loginResponse.RefreshToken,
+	}, nil
+}
+
+// ForgotPassword implements POST /password/forgot. It answers the same way
+// whether or not the email belongs to an account.
+func (s *Server) ForgotPassword(ctx context.Context, request api.ForgotPasswordRequestObject) (api.ForgotPasswordResponseObject, error) {
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		return svc.Auth.RequestPasswordReset(ctx, string(request.Body.Email))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.ForgotPassword202Response{}, nil
+}
+
+// ResetPassword implements POST /password/reset
+func (s *Server) ResetPassword(ctx context.Context, request api.ResetPasswordRequestObject) (api.ResetPasswordResponseObject, error) {
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		_, err := svc.Auth.CompletePasswordReset(ctx, request.Body.Token, request.Body.Password)
+		return err
+	})
+	if errors.Is(err, auth.ErrInvalidToken) {
+		return api.ResetPassword400JSONResponse{Error: "reset link is invalid, expired or already used"}, nil
+	}
+	if errors.Is(err, auth.ErrWeakPassword) {
+		return api.ResetPassword400JSONResponse{Error: err.Error()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.ResetPassword204Response{}, nil
+}
+
+// VerifyEmail implements GET /email/verify
+func (s *Server) VerifyEmail(ctx context.Context, request api.VerifyEmailRequestObject) (api.VerifyEmailResponseObject, error) {
+	var user *auth.User
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		var err error
+		user, err = svc.Auth.VerifyEmail(ctx, request.Params.Token)
+		if err != nil {
+			return err
+		}
+		// Collections shared with the email before it had an account
+		_, err = svc.Collections.AcceptInvites(ctx, user.ID, user.Email)
+		return err
+	})
+	if errors.Is(err, auth.ErrInvalidToken) {
+		return api.VerifyEmail400JSONResponse{Error: "verification link is invalid, expired or already used"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.VerifyEmail200JSONResponse{
+		Email:           openapi_types.Email(user.Email),
+		EmailVerifiedAt: *user.EmailVerifiedAt,
+	}, nil
+}
+
+// ResendVerification implements POST /email/verification
+func (s *Server) ResendVerification(ctx context.Context, request api.ResendVerificationRequestObject) (api.ResendVerificationResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return api.ResendVerification401Response{}, nil
+	}
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		return svc.Auth.SendVerification(ctx, userID)
+	})
+	if errors.Is(err, auth.ErrAlreadyVerified) {
+		return api.ResendVerification409Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.ResendVerification202Response{}, nil
+}
+
+// AuthConfig maps the token, LOGIN_* and account email settings to the auth service
+func AuthConfig(config *environment.Environment) auth.Config {
+	return auth.Config{
+		AccessTokenTTL:  config.Token.AccessTokenTTL(),
+		RefreshTokenTTL: config.Token.RefreshTokenTTL(),
+		Lockout: auth.LockoutConfig{
+			DelayAfter: config.Lockout.DelayAfter,
+			BaseDelay:  config.Lockout.BaseDelay,
+			MaxDelay:   config.Lockout.MaxDelay,
+			LockAfter:  config.Lockout.LockAfter,
+			Duration:   config.Lockout.Duration,
+			ResetAfter: config.Lockout.ResetAfter,
+		},
+		PasswordResetURL: config.Account.PasswordResetURL,
+		PasswordResetTTL: config.Account.PasswordResetTTL,
+		VerifyEmailURL:   config.Account.VerifyEmailURL,
+		VerificationTTL:  config.Account.VerificationTTL,
+	}
+}
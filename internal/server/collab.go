@@ -0,0 +1,463 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"golang.org/x/time/rate"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/realtime"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+)
+
+// CollabConfig tunes the WebSocket collaboration endpoint
+type CollabConfig struct {
+	PingInterval time.Duration
+	WriteTimeout time.Duration
+	// MessagesPerSecond and Burst limit the messages each connection may send
+	MessagesPerSecond float64
+	Burst             int
+	MaxSubscriptions  int
+}
+
+// collabReadLimit caps the size of a client message
+const collabReadLimit = 64 << 10
+
+// collabSendBuffer is how many replies and presence changes may queue per connection
+const collabSendBuffer = 64
+
+// Client message types
+const (
+	collabSubscribe   = "subscribe"
+	collabUnsubscribe = "unsubscribe"
+	collabEdit        = "edit"
+	collabPing        = "ping"
+)
+
+// Error codes sent in error messages
+const (
+	codeBadRequest       = "bad_request"
+	codeNotFound         = "not_found"
+	codeEmptyText        = "empty_text"
+	codeVersionConflict  = "version_conflict"
+	codeRateLimited      = "rate_limited"
+	codeTooManySubs      = "too_many_subscriptions"
+	codeInternal         = "internal"
+	codeUnknownOperation = "unknown_type"
+)
+
+// collabRequest is a message sent by the client. Ref is echoed in the reply
+// so the client can match it to the request.
+type collabRequest struct {
+	Type    string `json:"type"`
+	Ref     string `json:"ref,omitempty"`
+	ID      int    `json:"id"`
+	Text    string `json:"text"`
+	Version int    `json:"version"`
+}
+
+// collabMessage is a message sent to the client
+type collabMessage struct {
+	Type    string            `json:"type"`
+	Ref     string            `json:"ref,omitempty"`
+	ID      int               `json:"id,omitempty"`
+	Event   string            `json:"event,omitempty"`
+	EventID int64             `json:"event_id,omitempty"`
+	Labubu  any               `json:"labubu,omitempty"`
+	Viewers []realtime.Viewer `json:"viewers,omitempty"`
+	Code    string            `json:"code,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// collab tracks which connections of this replica watch which labubu, so
+// presence changes reach them
+type collab struct {
+	hub      *realtime.Hub
+	presence *realtime.Presence
+	tm       uow.TxManager
+	svc      labubu.Service
+	reloader *environment.Reloader
+	config   CollabConfig
+
+	mu    sync.Mutex
+	rooms map[int]map[*collabConn]struct{}
+}
+
+func newCollab(hub *realtime.Hub, presence *realtime.Presence, svc labubu.Service, tm uow.TxManager, reloader *environment.Reloader, config CollabConfig) *collab {
+	c := &collab{
+		hub:      hub,
+		presence: presence,
+		tm:       tm,
+		svc:      svc,
+		reloader: reloader,
+		config:   config,
+		rooms:    make(map[int]map[*collabConn]struct{}),
+	}
+	presence.OnChange(c.presenceChanged)
+	return c
+}
+
+// collabRoom is the presence room of a labubu
+func collabRoom(id int) string {
+	return "labubu:" + strconv.Itoa(id)
+}
+
+// presenceChanged forwards a room's viewers to the connections watching it
+func (c *collab) presenceChanged(room string, viewers []realtime.Viewer) {
+	raw, ok := strings.CutPrefix(room, "labubu:")
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for conn := range c.rooms[id] {
+		conn.send(collabMessage{Type: "presence", ID: id, Viewers: viewers})
+	}
+}
+
+func (c *collab) join(conn *collabConn, id int) {
+	c.mu.Lock()
+	if c.rooms[id] == nil {
+		c.rooms[id] = make(map[*collabConn]struct{})
+	}
+	c.rooms[id][conn] = struct{}{}
+	c.mu.Unlock()
+
+	if err := c.presence.Join(context.WithoutCancel(conn.ctx), collabRoom(id), conn.userID); err != nil {
+		slog.Warn("Failed to announce presence", "labubu_id", id, "error", err)
+	}
+}
+
+func (c *collab) leave(conn *collabConn, id int) {
+	c.mu.Lock()
+	delete(c.rooms[id], conn)
+	if len(c.rooms[id]) == 0 {
+		delete(c.rooms, id)
+	}
+	c.mu.Unlock()
+
+	// The connection may already be gone, so the announcement gets its own deadline
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(conn.ctx), 5*time.Second)
+	defer cancel()
+	if err := c.presence.Leave(ctx, collabRoom(id), conn.userID); err != nil {
+		slog.Warn("Failed to announce presence", "labubu_id", id, "error", err)
+	}
+}
+
+// acceptOptions allows the origins in CORS_ALLOWED_ORIGINS, read per request
+// so a reload applies to new connections
+func (c *collab) acceptOptions() *websocket.AcceptOptions {
+	var patterns []string
+	if c.reloader != nil {
+		for _, origin := range c.reloader.Dynamic().CORSAllowedOrigins {
+			if origin == "*" {
+				patterns = append(patterns, "*")
+				continue
+			}
+			if u, err := url.Parse(origin); err == nil && u.Host != "" {
+				patterns = append(patterns, u.Host)
+			}
+		}
+	}
+	return &websocket.AcceptOptions{OriginPatterns: patterns}
+}
+
+// handle implements GET /labubu/ws. Clients subscribe to labubu they own,
+// receive their changes and the people viewing them, and send edits that
+// go through the same validation and version check as PUT /labubu/{id}.
+func (c *collab) handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := websocket.Accept(w, r, c.acceptOptions())
+	if err != nil {
+		// Accept already wrote the error response
+		return
+	}
+	ws.SetReadLimit(collabReadLimit)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	conn := &collabConn{
+		collab:  c,
+		ws:      ws,
+		ctx:     ctx,
+		userID:  userID,
+		limiter: rate.NewLimiter(rate.Limit(c.config.MessagesPerSecond), c.config.Burst),
+		rooms:   make(map[int]bool),
+		out:     make(chan collabMessage, collabSendBuffer),
+	}
+	conn.sub = c.hub.Subscribe(conn.watching)
+	defer func() {
+		c.hub.Unsubscribe(conn.sub)
+		for _, id := range conn.subscribed() {
+			c.leave(conn, id)
+		}
+	}()
+
+	go func() {
+		defer cancel()
+		conn.writeLoop()
+	}()
+
+	status, reason := conn.readLoop()
+	cancel()
+	_ = ws.Close(status, reason)
+}
+
+// collabConn is one client connection
+type collabConn struct {
+	collab  *collab
+	ws      *websocket.Conn
+	ctx     context.Context
+	userID  int
+	limiter *rate.Limiter
+	sub     *realtime.Subscription
+	out     chan collabMessage
+
+	mu    sync.Mutex
+	rooms map[int]bool
+	// slow is set once a message could not be queued; the connection is closed
+	slow bool
+}
+
+// watching selects the labubu events of the rooms this connection joined
+func (c *collabConn) watching(event *outbox.Event) bool {
+	if event.AggregateType != labubu.AggregateType {
+		return false
+	}
+	id, err := strconv.Atoi(event.AggregateID)
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rooms[id]
+}
+
+func (c *collabConn) subscribed() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]int, 0, len(c.rooms))
+	for id := range c.rooms {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// send queues msg without blocking; a client that cannot keep up is disconnected
+func (c *collabConn) send(msg collabMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.slow {
+		return
+	}
+	select {
+	case c.out <- msg:
+	default:
+		c.slow = true
+		close(c.out)
+	}
+}
+
+// readLoop handles client messages until the connection ends and returns
+// the close status to send
+func (c *collabConn) readLoop() (websocket.StatusCode, string) {
+	for {
+		typ, data, err := c.ws.Read(c.ctx)
+		if err != nil {
+			if status := websocket.CloseStatus(err); status != -1 {
+				return websocket.StatusNormalClosure, ""
+			}
+			if errors.Is(err, context.Canceled) {
+				return websocket.StatusGoingAway, "server closing connection"
+			}
+			return websocket.StatusPolicyViolation, "invalid message"
+		}
+		if typ != websocket.MessageText {
+			return websocket.StatusUnsupportedData, "text messages only"
+		}
+
+		var req collabRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			c.send(collabMessage{Type: "error", Code: codeBadRequest, Error: "invalid JSON message"})
+			continue
+		}
+		if !c.limiter.Allow() {
+			c.send(collabMessage{Type: "error", Ref: req.Ref, Code: codeRateLimited, Error: "too many messages"})
+			continue
+		}
+		c.handle(req)
+	}
+}
+
+func (c *collabConn) handle(req collabRequest) {
+	switch req.Type {
+	case collabPing:
+		c.send(collabMessage{Type: "pong", Ref: req.Ref})
+	case collabSubscribe:
+		c.subscribe(req)
+	case collabUnsubscribe:
+		c.mu.Lock()
+		joined := c.rooms[req.ID]
+		delete(c.rooms, req.ID)
+		c.mu.Unlock()
+		if joined {
+			c.collab.leave(c, req.ID)
+		}
+		c.send(collabMessage{Type: "unsubscribed", Ref: req.Ref, ID: req.ID})
+	case collabEdit:
+		c.edit(req)
+	default:
+		c.send(collabMessage{Type: "error", Ref: req.Ref, Code: codeUnknownOperation, Error: "unknown message type"})
+	}
+}
+
+// subscribe joins the room of a labubu the user owns and replies with its
+// current state and viewers
+func (c *collabConn) subscribe(req collabRequest) {
+	item, err := c.collab.svc.GetLabubuByID(c.ctx, req.ID)
+	if err == nil && item.OwnerID != c.userID {
+		err = labubu.ErrNotFound
+	}
+	if err != nil {
+		c.sendError(req, err)
+		return
+	}
+
+	c.mu.Lock()
+	joined := c.rooms[req.ID]
+	full := len(c.rooms) >= c.collab.config.MaxSubscriptions
+	if !joined && !full {
+		c.rooms[req.ID] = true
+	}
+	c.mu.Unlock()
+
+	if !joined {
+		if full {
+			c.send(collabMessage{Type: "error", Ref: req.Ref, ID: req.ID, Code: codeTooManySubs, Error: "too many subscriptions"})
+			return
+		}
+		c.collab.join(c, req.ID)
+	}
+	c.send(collabMessage{
+		Type:    "subscribed",
+		Ref:     req.Ref,
+		ID:      req.ID,
+		Labubu:  item,
+		Viewers: c.collab.presence.Viewers(collabRoom(req.ID)),
+	})
+}
+
+// edit replaces the text of a labubu. Everyone watching it, this connection
+// included, also receives the resulting update event.
+func (c *collabConn) edit(req collabRequest) {
+	var updated *labubu.Labubu
+	err := c.collab.tm.Do(c.ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		updated, err = svc.Labubu.UpdateLabubu(ctx, labubu.UpdateLabubuRequest{
+			ID:      req.ID,
+			OwnerID: c.userID,
+			Text:    req.Text,
+			Version: req.Version,
+		})
+		return err
+	})
+	if err != nil {
+		c.sendError(req, err)
+		return
+	}
+	c.send(collabMessage{Type: "ack", Ref: req.Ref, ID: req.ID, Labubu: updated})
+}
+
+func (c *collabConn) sendError(req collabRequest, err error) {
+	msg := collabMessage{Type: "error", Ref: req.Ref, ID: req.ID, Error: err.Error()}
+	switch {
+	case errors.Is(err, labubu.ErrNotFound):
+		msg.Code = codeNotFound
+	case errors.Is(err, labubu.ErrEmptyText):
+		msg.Code = codeEmptyText
+	case errors.Is(err, labubu.ErrVersionConflict):
+		msg.Code = codeVersionConflict
+		// Send the current state so the client can rebase its edit
+		if current, err := c.collab.svc.GetLabubuByID(c.ctx, req.ID); err == nil {
+			msg.Labubu = current
+		}
+	default:
+		slog.Error("Collaboration request failed", "type", req.Type, "error", err)
+		msg.Code = codeInternal
+		msg.Error = "internal error"
+	}
+	c.send(msg)
+}
+
+// writeLoop is the only writer on the connection. It sends replies, update
+// events and keepalive pings until the connection ends.
+func (c *collabConn) writeLoop() {
+	ping := time.NewTicker(c.collab.config.PingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.sub.Done():
+			// Dropped for falling behind or shutting down; the client resubscribes to resync
+			_ = c.ws.Close(websocket.StatusTryAgainLater, "too far behind")
+			return
+		case msg, ok := <-c.out:
+			if !ok {
+				_ = c.ws.Close(websocket.StatusTryAgainLater, "too far behind")
+				return
+			}
+			if err := c.write(msg); err != nil {
+				return
+			}
+		case event := <-c.sub.Events():
+			id, _ := strconv.Atoi(event.AggregateID)
+			msg := collabMessage{Type: "update", ID: id, Event: event.Type, EventID: event.ID, Labubu: json.RawMessage(event.Payload)}
+			if err := c.write(msg); err != nil {
+				return
+			}
+		case <-ping.C:
+			ctx, cancel := context.WithTimeout(c.ctx, c.collab.config.WriteTimeout)
+			err := c.ws.Ping(ctx)
+			cancel()
+			if err != nil {
+				_ = c.ws.Close(websocket.StatusPolicyViolation, "ping timeout")
+				return
+			}
+		}
+	}
+}
+
+func (c *collabConn) write(msg collabMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, c.collab.config.WriteTimeout)
+	defer cancel()
+	return c.ws.Write(ctx, websocket.MessageText, data)
+}
@@ -0,0 +1,395 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/api"
+	"github.com/abdurrahimagca/go-api-starter/internal/collection"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+)
+
+// errInvalidPage is returned for limit or offset query parameters out of range
+var errInvalidPage = errors.New("limit must be between 1 and " + strconv.Itoa(collection.MaxPageSize) + " and offset must not be negative")
+
+// collectionPage reads the limit and offset query parameters
+func collectionPage(limit, offset *int) (collection.Page, error) {
+	page := collection.Page{Limit: collection.DefaultPageSize}
+	if limit != nil {
+		if *limit < 1 || *limit > collection.MaxPageSize {
+			return page, errInvalidPage
+		}
+		page.Limit = *limit
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return page, errInvalidPage
+		}
+		page.Offset = *offset
+	}
+	return page, nil
+}
+
+// nextOffset is where the page after next starts, or nil after the last page
+func nextOffset(next *collection.Page) *int {
+	if next == nil {
+		return nil
+	}
+	return &next.Offset
+}
+
+// collectionResponse maps c to the API
+func collectionResponse(c *collection.Collection) api.GetCollection200JSONResponse {
+	return api.GetCollection200JSONResponse{
+		Id:        c.ID,
+		OwnerId:   c.OwnerID,
+		Name:      c.Name,
+		Role:      string(c.Role),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// collectionItemResponse maps item, with its labubu, to the API
+func collectionItemResponse(item *collection.Item) api.AddCollectionItem201JSONResponse {
+	response := api.AddCollectionItem201JSONResponse{
+		LabubuId: item.LabubuID,
+		Position: item.Position,
+		AddedAt:  item.AddedAt,
+	}
+	if item.AddedBy != 0 {
+		response.AddedBy = &item.AddedBy
+	}
+	if item.Labubu != nil {
+		response.Labubu = labubuResponse(item.Labubu)
+	}
+	return response
+}
+
+// ListCollections implements the GET /collections endpoint
+func (s *Server) ListCollections(ctx context.Context, request api.ListCollectionsRequestObject) (api.ListCollectionsResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+	page, err := collectionPage(request.Params.Limit, request.Params.Offset)
+	if err != nil {
+		return api.ListCollections400JSONResponse{Error: err.Error()}, nil
+	}
+
+	results, next, err := s.collectionService.ListCollections(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	response := api.ListCollections200JSONResponse{NextOffset: nextOffset(next)}
+	response.Items = make([]struct {
+		CreatedAt time.Time `json:"created_at"`
+		Id        int       `json:"id"`
+		Name      string    `json:"name"`
+		OwnerId   int       `json:"owner_id"`
+		Role      string    `json:"role"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}, len(results))
+	for i, c := range results {
+		response.Items[i] = collectionResponse(c)
+	}
+	return response, nil
+}
+
+// CreateCollection implements the POST /collections endpoint
+func (s *Server) CreateCollection(ctx context.Context, request api.CreateCollectionRequestObject) (api.CreateCollectionResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	result, err := s.collectionService.CreateCollection(ctx, collection.CreateCollectionRequest{
+		OwnerID: userID,
+		Name:    request.Body.Name,
+	})
+	if errors.Is(err, collection.ErrInvalidName) {
+		return api.CreateCollection400JSONResponse{Error: err.Error()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateCollection201JSONResponse(collectionResponse(result)), nil
+}
+
+// GetCollection implements the GET /collections/{id} endpoint
+func (s *Server) GetCollection(ctx context.Context, request api.GetCollectionRequestObject) (api.GetCollectionResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	result, err := s.collectionService.GetCollection(ctx, request.Id, userID)
+	if errors.Is(err, collection.ErrNotFound) {
+		return api.GetCollection404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return collectionResponse(result), nil
+}
+
+// RenameCollection implements the PUT /collections/{id} endpoint
+func (s *Server) RenameCollection(ctx context.Context, request api.RenameCollectionRequestObject) (api.RenameCollectionResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	result, err := s.collectionService.RenameCollection(ctx, request.Id, userID, request.Body.Name)
+	switch {
+	case errors.Is(err, collection.ErrInvalidName):
+		return api.RenameCollection400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, collection.ErrForbidden):
+		return api.RenameCollection403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound):
+		return api.RenameCollection404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.RenameCollection200JSONResponse(collectionResponse(result)), nil
+}
+
+// DeleteCollection implements the DELETE /collections/{id} endpoint
+func (s *Server) DeleteCollection(ctx context.Context, request api.DeleteCollectionRequestObject) (api.DeleteCollectionResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	err := s.collectionService.DeleteCollection(ctx, request.Id, userID)
+	switch {
+	case errors.Is(err, collection.ErrForbidden):
+		return api.DeleteCollection403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound):
+		return api.DeleteCollection404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.DeleteCollection204Response{}, nil
+}
+
+// ListCollectionItems implements the GET /collections/{id}/items endpoint
+func (s *Server) ListCollectionItems(ctx context.Context, request api.ListCollectionItemsRequestObject) (api.ListCollectionItemsResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+	page, err := collectionPage(request.Params.Limit, request.Params.Offset)
+	if err != nil {
+		return api.ListCollectionItems400JSONResponse{Error: err.Error()}, nil
+	}
+
+	results, next, err := s.collectionService.ListItems(ctx, request.Id, userID, page)
+	if errors.Is(err, collection.ErrNotFound) {
+		return api.ListCollectionItems404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := api.ListCollectionItems200JSONResponse{NextOffset: nextOffset(next)}
+	response.Items = make([]struct {
+		AddedAt time.Time `json:"added_at"`
+		AddedBy *int      `json:"added_by,omitempty"`
+		Labubu  struct {
+			Id          int        `json:"id"`
+			PublishAt   *time.Time `json:"publish_at,omitempty"`
+			PublishedAt *time.Time `json:"published_at,omitempty"`
+			Status      string     `json:"status"`
+			Tags        []string   `json:"tags"`
+			Text        string     `json:"text"`
+			Version     int        `json:"version"`
+		} `json:"labubu"`
+		LabubuId int `json:"labubu_id"`
+		Position int `json:"position"`
+	}, len(results))
+	for i, item := range results {
+		response.Items[i] = collectionItemResponse(item)
+	}
+	return response, nil
+}
+
+// AddCollectionItem implements the POST /collections/{id}/items endpoint
+func (s *Server) AddCollectionItem(ctx context.Context, request api.AddCollectionItemRequestObject) (api.AddCollectionItemResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var result *collection.Item
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Collections.AddItem(ctx, request.Id, request.Body.LabubuId, userID)
+		return err
+	})
+	switch {
+	case errors.Is(err, collection.ErrForbidden):
+		return api.AddCollectionItem403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound), errors.Is(err, labubu.ErrNotFound):
+		return api.AddCollectionItem404Response{}, nil
+	case errors.Is(err, collection.ErrAlreadyAdded):
+		return api.AddCollectionItem409Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return collectionItemResponse(result), nil
+}
+
+// RemoveCollectionItem implements the DELETE /collections/{id}/items/{labubuId} endpoint
+func (s *Server) RemoveCollectionItem(ctx context.Context, request api.RemoveCollectionItemRequestObject) (api.RemoveCollectionItemResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		return svc.Collections.RemoveItem(ctx, request.Id, request.LabubuId, userID)
+	})
+	switch {
+	case errors.Is(err, collection.ErrForbidden):
+		return api.RemoveCollectionItem403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound), errors.Is(err, collection.ErrItemNotFound):
+		return api.RemoveCollectionItem404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.RemoveCollectionItem204Response{}, nil
+}
+
+// MoveCollectionItem implements the PUT /collections/{id}/items/{labubuId}/position endpoint
+func (s *Server) MoveCollectionItem(ctx context.Context, request api.MoveCollectionItemRequestObject) (api.MoveCollectionItemResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var result *collection.Item
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Collections.MoveItem(ctx, collection.MoveItemRequest{
+			CollectionID: request.Id,
+			LabubuID:     request.LabubuId,
+			UserID:       userID,
+			Position:     request.Body.Position,
+		})
+		if err != nil {
+			return err
+		}
+		result.Labubu, err = svc.Labubu.GetLabubuByID(ctx, result.LabubuID)
+		if err == nil && result.Labubu.Status != labubu.StatusPublished && result.Labubu.OwnerID != userID {
+			// Others' labubu are only shown once published
+			result.Labubu = nil
+		}
+		return err
+	})
+	switch {
+	case errors.Is(err, collection.ErrForbidden):
+		return api.MoveCollectionItem403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound), errors.Is(err, collection.ErrItemNotFound), errors.Is(err, labubu.ErrNotFound):
+		return api.MoveCollectionItem404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.MoveCollectionItem200JSONResponse(collectionItemResponse(result)), nil
+}
+
+// ListCollectionShares implements the GET /collections/{id}/shares endpoint
+func (s *Server) ListCollectionShares(ctx context.Context, request api.ListCollectionSharesRequestObject) (api.ListCollectionSharesResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+	page, err := collectionPage(request.Params.Limit, request.Params.Offset)
+	if err != nil {
+		return api.ListCollectionShares400JSONResponse{Error: err.Error()}, nil
+	}
+
+	results, next, err := s.collectionService.ListShares(ctx, request.Id, userID, page)
+	switch {
+	case errors.Is(err, collection.ErrForbidden):
+		return api.ListCollectionShares403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound):
+		return api.ListCollectionShares404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	response := api.ListCollectionShares200JSONResponse{NextOffset: nextOffset(next)}
+	response.Items = make([]struct {
+		CreatedAt time.Time `json:"created_at"`
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		UserId    int       `json:"user_id"`
+	}, len(results))
+	for i, share := range results {
+		item := &response.Items[i]
+		item.UserId, item.Email, item.Role, item.CreatedAt = share.UserID, share.Email, string(share.Role), share.CreatedAt
+	}
+	return response, nil
+}
+
+// ShareCollection implements the PUT /collections/{id}/shares endpoint
+func (s *Server) ShareCollection(ctx context.Context, request api.ShareCollectionRequestObject) (api.ShareCollectionResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var result *collection.Invite
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Collections.Share(ctx, collection.ShareRequest{
+			CollectionID: request.Id,
+			OwnerID:      userID,
+			Email:        string(request.Body.Email),
+			Role:         collection.Role(request.Body.Role),
+		})
+		return err
+	})
+	switch {
+	case errors.Is(err, collection.ErrInvalidRole), errors.Is(err, collection.ErrShareWithSelf):
+		return api.ShareCollection400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, collection.ErrForbidden):
+		return api.ShareCollection403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound):
+		return api.ShareCollection404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.ShareCollection202JSONResponse{Email: result.Email, Role: string(result.Role)}, nil
+}
+
+// UnshareCollection implements the DELETE /collections/{id}/shares/{userId} endpoint
+func (s *Server) UnshareCollection(ctx context.Context, request api.UnshareCollectionRequestObject) (api.UnshareCollectionResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	err := s.collectionService.Unshare(ctx, request.Id, userID, request.UserId)
+	switch {
+	case errors.Is(err, collection.ErrForbidden):
+		return api.UnshareCollection403Response{}, nil
+	case errors.Is(err, collection.ErrNotFound):
+		return api.UnshareCollection404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.UnshareCollection204Response{}, nil
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/docs"
+)
+
+// DocsFS returns the embedded docs, or dir on disk when dir is set
+func DocsFS(dir string) fs.FS {
+	if dir != "" {
+		return os.DirFS(dir)
+	}
+	return docs.FS
+}
+
+// docsCacheControl is used for embedded docs, which only change on deploy.
+// Clients revalidate with the ETag after max-age.
+const docsCacheControl = "public, max-age=300, must-revalidate"
+
+// serveDocsFile serves a single file from fsys with an ETag derived from its
+// content. Files from a directory override are never cached so edits show up
+// on reload.
+func serveDocsFile(fsys fs.FS, name, contentType string, embedded bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		sum := sha256.Sum256(content)
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+		if embedded {
+			w.Header().Set("Cache-Control", docsCacheControl)
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+	}
+}
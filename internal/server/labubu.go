@@ -2,46 +2,222 @@ package server
 
 import (
 	"context"
+	"errors"
 
 	"github.com/abdurrahimagca/go-api-starter/internal/api"
 	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
 )
 
+// errNoUser is returned when a protected handler runs without an authenticated user
+var errNoUser = errors.New("no authenticated user in context")
+
 // CreateLabubu implements the POST /labubu endpoint
 func (s *Server) CreateLabubu(ctx context.Context, request api.CreateLabubuRequestObject) (api.CreateLabubuResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
 	req := labubu.CreateLabubuRequest{
-		Text: request.Body.Text,
+		Text:    request.Body.Text,
+		OwnerID: userID,
+	}
+	if request.Body.Tags != nil {
+		req.Tags = *request.Body.Tags
 	}
 
-	result, err := s.labubuService.CreateLabubu(ctx, req)
-	if err != nil {
+	var result *labubu.Labubu
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Labubu.CreateLabubu(ctx, req)
+		return err
+	})
+	if errors.Is(err, labubu.ErrEmptyText) || isTagError(err) {
 		return api.CreateLabubu400Response{}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	return api.CreateLabubu200JSONResponse{
-		Id:   result.ID,
-		Text: result.Text,
-	}, nil
+	return api.CreateLabubu200JSONResponse(labubuResponse(result)), nil
 }
 
 // GetLabubu implements the GET /labubu endpoint
 func (s *Server) GetLabubu(ctx context.Context, request api.GetLabubuRequestObject) (api.GetLabubuResponseObject, error) {
-	results, err := s.labubuService.GetAllLabubu(ctx)
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var filter labubu.ListFilter
+	if request.Params.Tag != nil {
+		filter.Tags = *request.Params.Tag
+	}
+	if request.Params.Match != nil {
+		switch *request.Params.Match {
+		case api.All:
+			filter.MatchAll = true
+		case api.Any:
+		default:
+			return api.GetLabubu400JSONResponse{Error: "match must be any or all"}, nil
+		}
+	}
+
+	if request.Params.Status != nil {
+		for _, status := range *request.Params.Status {
+			filter.Statuses = append(filter.Statuses, labubu.Status(status))
+		}
+	}
+
+	results, err := s.labubuService.GetAllLabubu(ctx, userID, filter)
+	if isTagError(err) || errors.Is(err, labubu.ErrInvalidStatus) {
+		return api.GetLabubu400JSONResponse{Error: err.Error()}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var labubuItems api.GetLabubu200JSONResponse
-	
+	labubuItems := make(api.GetLabubu200JSONResponse, 0, len(results))
+
 	for _, item := range results {
-		labubuItems = append(labubuItems, struct {
-			Id   int    `json:"id"`
-			Text string `json:"text"`
-		}{
-			Id:   item.ID,
-			Text: item.Text,
-		})
+		labubuItems = append(labubuItems, labubuResponse(item))
 	}
 
 	return labubuItems, nil
-}
\ No newline at end of file
+}
+
+// labubuResponse maps item to the API
+func labubuResponse(item *labubu.Labubu) api.CreateLabubu200JSONResponse {
+	return api.CreateLabubu200JSONResponse{
+		Id:          item.ID,
+		Text:        item.Text,
+		Version:     item.Version,
+		Tags:        item.Tags,
+		Status:      string(item.Status),
+		PublishAt:   item.PublishAt,
+		PublishedAt: item.PublishedAt,
+	}
+}
+
+// UpdateLabubu implements the PUT /labubu/{id} endpoint
+func (s *Server) UpdateLabubu(ctx context.Context, request api.UpdateLabubuRequestObject) (api.UpdateLabubuResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	req := labubu.UpdateLabubuRequest{
+		ID:      request.Id,
+		OwnerID: userID,
+		Text:    request.Body.Text,
+		Version: request.Body.Version,
+	}
+	if request.Body.Tags != nil {
+		req.Tags = *request.Body.Tags
+	}
+
+	var result *labubu.Labubu
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Labubu.UpdateLabubu(ctx, req)
+		return err
+	})
+	switch {
+	case errors.Is(err, labubu.ErrEmptyText), isTagError(err):
+		return api.UpdateLabubu400Response{}, nil
+	case errors.Is(err, labubu.ErrNotFound):
+		return api.UpdateLabubu404Response{}, nil
+	case errors.Is(err, labubu.ErrVersionConflict):
+		return api.UpdateLabubu409Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.UpdateLabubu200JSONResponse(labubuResponse(result)), nil
+}
+
+// DeleteLabubu implements the DELETE /labubu/{id} endpoint
+func (s *Server) DeleteLabubu(ctx context.Context, request api.DeleteLabubuRequestObject) (api.DeleteLabubuResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) error {
+		return svc.Labubu.DeleteLabubu(ctx, request.Id, userID)
+	})
+	if errors.Is(err, labubu.ErrNotFound) {
+		return api.DeleteLabubu404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.DeleteLabubu204Response{}, nil
+}
+
+// TransitionLabubu implements the POST /labubu/{id}/status endpoint
+func (s *Server) TransitionLabubu(ctx context.Context, request api.TransitionLabubuRequestObject) (api.TransitionLabubuResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	req := labubu.TransitionRequest{
+		ID:      request.Id,
+		OwnerID: userID,
+		Status:  labubu.Status(request.Body.Status),
+	}
+	if request.Body.PublishAt != nil {
+		req.PublishAt = *request.Body.PublishAt
+	}
+
+	var result *labubu.Labubu
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Labubu.Transition(ctx, req)
+		return err
+	})
+	switch {
+	case errors.Is(err, labubu.ErrInvalidStatus), errors.Is(err, labubu.ErrPublishAtRequired):
+		return api.TransitionLabubu400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, labubu.ErrNotFound):
+		return api.TransitionLabubu404Response{}, nil
+	case errors.Is(err, labubu.ErrInvalidTransition), errors.Is(err, labubu.ErrVersionConflict):
+		return api.TransitionLabubu409JSONResponse{Error: err.Error()}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.TransitionLabubu200JSONResponse(labubuResponse(result)), nil
+}
+
+// ListLabubuTransitions implements the GET /labubu/{id}/history endpoint
+func (s *Server) ListLabubuTransitions(ctx context.Context, request api.ListLabubuTransitionsRequestObject) (api.ListLabubuTransitionsResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	results, err := s.labubuService.ListTransitions(ctx, request.Id, userID)
+	if errors.Is(err, labubu.ErrNotFound) {
+		return api.ListLabubuTransitions404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(api.ListLabubuTransitions200JSONResponse, len(results))
+	for i, t := range results {
+		response[i].To = string(t.To)
+		response[i].PublishAt = t.PublishAt
+		response[i].CreatedAt = t.CreatedAt
+		if t.From != "" {
+			from := string(t.From)
+			response[i].From = &from
+		}
+		if t.ActorID != 0 {
+			response[i].ActorId = &t.ActorID
+		}
+	}
+	return response, nil
+}
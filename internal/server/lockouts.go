@@ -0,0 +1,47 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+)
+
+// listLockouts returns the emails that are currently locked out
+func listLockouts(svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lockouts, err := svc.ListLockouts(r.Context())
+		if err != nil {
+			slog.Error("Listing lockouts failed", "error", err)
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+			return
+		}
+		writeJSON(w, http.StatusOK, lockouts)
+	}
+}
+
+// unlockLogin clears the failed logins of an email, lifting any delay or lockout
+func unlockLogin(svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Email string `json:"email"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "email is required"})
+			return
+		}
+
+		err := svc.Unlock(r.Context(), req.Email)
+		switch {
+		case errors.Is(err, auth.ErrNotFound):
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "no failed logins recorded for this email"})
+		case err != nil:
+			slog.Error("Unlocking login failed", "error", err)
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/platform/mail"
+)
+
+// mailboxPage lists the kept messages and shows the selected one; the HTML
+// body is rendered in a sandboxed iframe so its styles and links stay inside
+var mailboxPage = template.Must(template.New("mailbox").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Mailbox</title>
+<style>
+body { font-family: -apple-system, Segoe UI, Helvetica, Arial, sans-serif; margin: 0; display: flex; height: 100vh; color: #1f2328; }
+nav { width: 340px; overflow-y: auto; border-right: 1px solid #d0d7de; }
+nav header { display: flex; justify-content: space-between; align-items: center; padding: 12px 16px; border-bottom: 1px solid #d0d7de; }
+nav a { display: block; padding: 10px 16px; border-bottom: 1px solid #eaeef2; color: inherit; text-decoration: none; }
+nav a.selected { background: #ddf4ff; }
+nav small { color: #656d76; display: block; }
+main { flex: 1; display: flex; flex-direction: column; overflow: hidden; }
+main header { padding: 12px 16px; border-bottom: 1px solid #d0d7de; }
+iframe { flex: 1; border: 0; }
+pre { flex: 1; margin: 0; padding: 16px; overflow: auto; white-space: pre-wrap; }
+.empty { padding: 16px; color: #656d76; }
+</style>
+</head>
+<body>
+<nav>
+<header><strong>Mailbox</strong>
+<form method="post" action="/dev/mailbox/clear"><button type="submit">Clear</button></form>
+</header>
+{{range .Messages}}<a href="/dev/mailbox/{{.ID}}"{{if and $.Selected (eq .ID $.Selected.ID)}} class="selected"{{end}}>
+<strong>{{.Subject}}</strong>
+<small>{{.To}} · {{.SentAt.Local.Format "Jan 2 15:04:05"}}</small>
+</a>{{else}}<p class="empty">No mail yet.</p>{{end}}
+</nav>
+<main>
+{{with .Selected}}
+<header>
+<div><strong>{{.Subject}}</strong></div>
+<small>To {{.To}} at {{.SentAt.Local.Format "2006-01-02 15:04:05"}}</small>
+</header>
+{{if .HTML}}<iframe sandbox srcdoc="{{.HTML}}"></iframe>
+<details><summary>Plain text</summary><pre>{{.Text}}</pre></details>{{else}}<pre>{{.Text}}</pre>{{end}}
+{{else}}<p class="empty">Select a message.</p>{{end}}
+</main>
+</body>
+</html>
+`))
+
+type mailboxView struct {
+	Messages []mail.Delivered
+	Selected *mail.Delivered
+}
+
+// mailboxRoutes previews mail kept by the mailbox driver; development only
+func mailboxRoutes(box *mail.Mailbox) func(chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			renderMailbox(w, r, box, "")
+		})
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			renderMailbox(w, r, box, chi.URLParam(r, "id"))
+		})
+		r.Post("/clear", func(w http.ResponseWriter, r *http.Request) {
+			if err := box.Clear(); err != nil {
+				slog.ErrorContext(r.Context(), "Clearing mailbox failed", "error", err)
+				http.Error(w, "Clearing the mailbox failed", http.StatusInternalServerError)
+				return
+			}
+			http.Redirect(w, r, "/dev/mailbox", http.StatusSeeOther)
+		})
+	}
+}
+
+func renderMailbox(w http.ResponseWriter, r *http.Request, box *mail.Mailbox, id string) {
+	messages, err := box.Messages()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	view := mailboxView{Messages: messages}
+	if id != "" {
+		selected, err := box.Get(id)
+		if errors.Is(err, mail.ErrMessageNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		view.Selected = &selected
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = mailboxPage.Execute(w, view)
+}
@@ -1,86 +1,289 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
 
 	"github.com/abdurrahimagca/go-api-starter/internal/api"
+	"github.com/abdurrahimagca/go-api-starter/internal/attachments"
 	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/collection"
 	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/health"
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
 	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
 	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/realtime"
+	"github.com/abdurrahimagca/go-api-starter/internal/reports"
+	"github.com/abdurrahimagca/go-api-starter/internal/scheduler"
+	"github.com/abdurrahimagca/go-api-starter/internal/sharelink"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+	"github.com/abdurrahimagca/go-api-starter/internal/webhooks"
+	"github.com/abdurrahimagca/go-api-starter/platform/mail"
+	"github.com/abdurrahimagca/go-api-starter/platform/ratelimit"
+	"github.com/abdurrahimagca/go-api-starter/platform/storage"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
 	"github.com/abdurrahimagca/go-api-starter/platform/token"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Server struct {
-	authService   auth.Service
-	labubuService labubu.Service
+	authService       auth.Service
+	labubuService     labubu.Service
+	attachmentService attachments.Service
+	collectionService collection.Service
+	shareLinkService  sharelink.Service
+	// tm runs handlers that write domain events, which need a transaction
+	tm uow.TxManager
 }
 
-func NewServer(authService auth.Service, labubuService labubu.Service) *Server {
+func NewServer(authService auth.Service, labubuService labubu.Service, attachmentService attachments.Service, collectionService collection.Service, shareLinkService sharelink.Service, tm uow.TxManager) *Server {
 	return &Server{
-		authService:   authService,
-		labubuService: labubuService,
+		authService:       authService,
+		labubuService:     labubuService,
+		attachmentService: attachmentService,
+		collectionService: collectionService,
+		shareLinkService:  shareLinkService,
+		tm:                tm,
 	}
 }
 
 var _ api.StrictServerInterface = (*Server)(nil)
 
-func NewUnifiedServer(pool *pgxpool.Pool, config *environment.Environment) (http.Handler, error) {
+// Options carries the long-lived components that run() shares with the HTTP layer
+type Options struct {
+	Checker  *health.Checker
+	Reloader *environment.Reloader
+	// Scheduler reports the schedules from the database; it is only run when SCHEDULER_ENABLED is true
+	Scheduler *scheduler.Scheduler
+	// Hub feeds the event streams; its Run loop is owned by the caller
+	Hub *realtime.Hub
+	// RateLimits counts requests; nil keeps them in memory
+	RateLimits ratelimit.Store
+	// Presence tracks who is viewing each labubu across replicas
+	Presence *realtime.Presence
+	// Storage keeps uploaded files
+	Storage storage.Store
+}
+
+func NewUnifiedServer(pool *pgxpool.Pool, config *environment.Environment, opts Options) (http.Handler, error) {
 	// Initialize token service
-	tokenService := token.NewJWTToken(config.Token.Secret)
+	tokenService := token.NewJWTToken(config.Token.Secret, config.Token.Issuer, config.Token.Audience)
 
 	// Initialize repositories
 	authRepo := auth.NewPgxRepository(pool)
 	labubuRepo := labubu.NewPgxRepository(pool)
+	outboxRepo := outbox.NewPgxRepository(pool)
 
 	// Initialize services
-	authService := auth.NewService(authRepo, tokenService)
-	labubuService := labubu.NewService(labubuRepo)
+	queue := jobs.NewQueue(jobs.NewPgxRepository(pool))
+	authService := auth.NewService(authRepo, tokenService, queue, AuthConfig(config))
+	labubuService := labubu.NewService(labubuRepo, outbox.NewWriter(outboxRepo))
+	attachmentService := attachments.NewService(attachments.NewPgxRepository(pool), labubuService, opts.Storage, nil, queue, AttachmentsConfig(config))
+	collectionService := collection.NewService(collection.NewPgxRepository(pool), labubuService)
+	shareLinkService := sharelink.NewService(sharelink.NewPgxRepository(pool), labubuService, ShareLinksConfig(config))
+	webhookService := webhooks.NewService(webhooks.NewPgxRepository(pool), queue, webhooks.NewHTTPClient(config.Webhooks.Timeout, config.Webhooks.AllowPrivate), webhooks.Config{
+		MaxAttempts:  config.Webhooks.MaxAttempts,
+		DisableAfter: config.Webhooks.DisableAfter,
+		AllowPrivate: config.Webhooks.AllowPrivate,
+	})
+	tm := uow.NewTxManager(pool, uow.Services{
+		Auth:        authService,
+		Labubu:      labubuService,
+		Attachments: attachmentService,
+		Jobs:        queue,
+		Reports:     reports.NewService(reports.NewPgxRepository(pool)),
+		Webhooks:    webhookService,
+		Collections: collectionService,
+	})
 
 	// Create the server that implements StrictServerInterface
-	server := NewServer(authService, labubuService)
+	server := NewServer(authService, labubuService, attachmentService, collectionService, shareLinkService, tm)
 
-	// Create strict handler
-	strictHandler := api.NewStrictHandler(server, nil)
+	limiter := newRateLimiter(opts)
+
+	// Only the locales are needed here; the worker renders the mail
+	templates, err := mail.LoadTemplates(config.Mail.DefaultLocale)
+	if err != nil {
+		return nil, fmt.Errorf("MAIL_DEFAULT_LOCALE: %w", err)
+	}
+
+	// Create strict handler; the last middleware runs first, so rejected requests are still traced
+	strictHandler := api.NewStrictHandler(server, []api.StrictMiddlewareFunc{
+		limiter.Operation,
+		telemetry.OperationMiddleware,
+	})
 	apiHandler := api.Handler(strictHandler)
 
 	// Create Chi router with middleware
 	r := chi.NewRouter()
 
 	// Add Chi middleware
+	r.Use(telemetry.Middleware(config.Telemetry.ServiceName))
 	r.Use(chimw.Logger)
 	r.Use(chimw.Recoverer)
 	r.Use(chimw.RequestID)
 	r.Use(chimw.RealIP)
-	r.Use(chimw.Timeout(60 * time.Second))
-
-	// Documentation routes
-	r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		http.ServeFile(w, r, "docs/_spotlight/index.html")
-	})
-	r.Get("/docs/openapi.json", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		http.ServeFile(w, r, "docs/openapi.json")
+	r.Use(middleware.Locale(templates.Locales()))
+	// Above every group, so preflight requests are answered before auth or timeouts
+	r.Use(middleware.CORS(func() []string { return opts.Reloader.Dynamic().CORSAllowedOrigins }))
+	collab := newCollab(opts.Hub, opts.Presence, labubuService, tm, opts.Reloader, CollabConfig{
+		PingInterval:      config.Collab.PingInterval,
+		WriteTimeout:      config.Stream.WriteTimeout,
+		MessagesPerSecond: float64(config.Collab.MessagesPerSecond),
+		Burst:             config.Collab.Burst,
+		MaxSubscriptions:  config.Collab.MaxSubscriptions,
 	})
-	r.Get("/docs/styles.css", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/css")
-		http.ServeFile(w, r, "docs/_spotlight/styles.css")
-	})
-	// Public API routes (no auth required)
-	r.Post("/login", apiHandler.ServeHTTP)
 
-	// Protected API routes (auth required)
+	// requireVerified guards routes that unverified accounts may not use
+	requireVerified := func(next http.Handler) http.Handler { return next }
+	if config.Account.RequireVerifiedEmail {
+		requireVerified = middleware.RequireVerifiedEmail
+	}
+
+	// The local store answers its own presigned URLs; uploads may take longer than the request timeout
+	if local, ok := opts.Storage.(*storage.Local); ok {
+		prefix, err := urlPath(config.Storage.LocalURL)
+		if err != nil {
+			return nil, fmt.Errorf("STORAGE_LOCAL_URL: %w", err)
+		}
+		if prefix == "" {
+			return nil, fmt.Errorf("STORAGE_LOCAL_URL: needs a path such as /storage")
+		}
+		r.Handle(prefix+"/*", http.StripPrefix(prefix, local.Handler()))
+	}
+
+	// Event streams and WebSockets stay open indefinitely, so they are registered outside the request timeout
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.BearerAuth(authService))
-		r.Post("/labubu", apiHandler.ServeHTTP)
-		r.Get("/labubu", apiHandler.ServeHTTP)
+		r.Use(requireVerified)
+		r.With(limiter.Handler("streamLabubu")).Get("/labubu/stream", streamLabubu(opts.Hub, outboxRepo, StreamConfig{
+			HeartbeatInterval: config.Stream.HeartbeatInterval,
+			ReplayLimit:       config.Stream.ReplayLimit,
+			WriteTimeout:      config.Stream.WriteTimeout,
+		}))
+		r.With(limiter.Handler("labubuWebSocket")).Get("/labubu/ws", collab.handle)
+	})
+
+	// Every other route must finish within the request timeout
+	r.Group(func(r chi.Router) {
+		r.Use(chimw.Timeout(60 * time.Second))
+
+		// Health routes
+		r.Get("/healthz", opts.Checker.LivenessHandler())
+		r.Get("/readyz", opts.Checker.ReadinessHandler())
+
+		// Admin routes (API key required)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.APIKeyAuth(config.APIKey))
+			r.Use(limiter.Handler("admin"))
+			r.Post("/config/reload", reloadConfig(opts.Reloader))
+			r.Get("/schedules", listSchedules(opts.Scheduler))
+			r.Route("/webhooks", webhookRoutes(webhookService, tm))
+			r.Get("/lockouts", listLockouts(authService))
+			r.Post("/lockouts/unlock", unlockLogin(authService))
+		})
+
+		// Preview of the mail kept by the mailbox driver
+		if config.Env == "development" && config.Mail.Driver == "mailbox" {
+			r.Route("/dev/mailbox", mailboxRoutes(mail.NewMailbox(config.Mail.MailboxDir)))
+		}
+
+		// Documentation routes
+		docsFS := DocsFS(config.Assets.DocsDir)
+		embeddedDocs := config.Assets.DocsDir == ""
+		r.Get("/docs", serveDocsFile(docsFS, "_spotlight/index.html", "text/html; charset=utf-8", embeddedDocs))
+		r.Get("/docs/openapi.json", serveDocsFile(docsFS, "openapi.json", "application/json", embeddedDocs))
+		r.Get("/docs/styles.css", serveDocsFile(docsFS, "_spotlight/styles.css", "text/css; charset=utf-8", embeddedDocs))
+		// Public API routes (no auth required)
+		r.Post("/login", apiHandler.ServeHTTP)
+		r.Post("/token/refresh", apiHandler.ServeHTTP)
+		r.Post("/password/forgot", apiHandler.ServeHTTP)
+		r.Post("/password/reset", apiHandler.ServeHTTP)
+		r.Get("/email/verify", apiHandler.ServeHTTP)
+
+		// Public share links; each attempt counts against the link's views and the rate limit
+		openLink := openShareLink(shareLinkService)
+		r.With(limiter.Handler("openShareLink")).Get("/s/{token}", openLink)
+		r.With(limiter.Handler("openShareLink")).Post("/s/{token}", openLink)
+
+		// Protected API routes (auth required)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.BearerAuth(authService))
+			// Unverified users still need to ask for a new link
+			r.Post("/email/verification", apiHandler.ServeHTTP)
+
+			r.Group(func(r chi.Router) {
+				r.Use(requireVerified)
+				r.Post("/labubu", apiHandler.ServeHTTP)
+				r.Get("/labubu", apiHandler.ServeHTTP)
+				r.Put("/labubu/{id}", apiHandler.ServeHTTP)
+				r.Delete("/labubu/{id}", apiHandler.ServeHTTP)
+				r.Post("/labubu/{id}/status", apiHandler.ServeHTTP)
+				r.Get("/labubu/{id}/history", apiHandler.ServeHTTP)
+				r.Get("/tags", apiHandler.ServeHTTP)
+				r.Put("/tags/{id}", apiHandler.ServeHTTP)
+				r.Post("/tags/{id}/merge", apiHandler.ServeHTTP)
+				r.Get("/labubu/{id}/attachments", apiHandler.ServeHTTP)
+				r.Post("/labubu/{id}/attachments", apiHandler.ServeHTTP)
+				r.Get("/labubu/{id}/attachments/{attachmentId}", apiHandler.ServeHTTP)
+				r.Delete("/labubu/{id}/attachments/{attachmentId}", apiHandler.ServeHTTP)
+				r.Post("/labubu/{id}/attachments/{attachmentId}/complete", apiHandler.ServeHTTP)
+				r.Get("/labubu/{id}/attachments/{attachmentId}/variants", apiHandler.ServeHTTP)
+				r.Post("/labubu/{id}/share", apiHandler.ServeHTTP)
+				r.Get("/labubu/{id}/share", apiHandler.ServeHTTP)
+				r.Delete("/labubu/{id}/share/{linkId}", apiHandler.ServeHTTP)
+				r.Get("/labubu/{id}/share/{linkId}/accesses", apiHandler.ServeHTTP)
+				r.Get("/collections", apiHandler.ServeHTTP)
+				r.Post("/collections", apiHandler.ServeHTTP)
+				r.Get("/collections/{id}", apiHandler.ServeHTTP)
+				r.Put("/collections/{id}", apiHandler.ServeHTTP)
+				r.Delete("/collections/{id}", apiHandler.ServeHTTP)
+				r.Get("/collections/{id}/items", apiHandler.ServeHTTP)
+				r.Post("/collections/{id}/items", apiHandler.ServeHTTP)
+				r.Delete("/collections/{id}/items/{labubuId}", apiHandler.ServeHTTP)
+				r.Put("/collections/{id}/items/{labubuId}/position", apiHandler.ServeHTTP)
+				r.Get("/collections/{id}/shares", apiHandler.ServeHTTP)
+				r.Put("/collections/{id}/shares", apiHandler.ServeHTTP)
+				r.Delete("/collections/{id}/shares/{userId}", apiHandler.ServeHTTP)
+			})
+		})
 	})
 
 	return r, nil
 }
+
+// urlPath returns the path of rawURL without a trailing slash
+func urlPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(u.Path, "/"), nil
+}
+
+// newRateLimiter applies RATE_LIMIT_* as currently loaded, so a reload
+// changes the limits of the next request
+func newRateLimiter(opts Options) *middleware.RateLimiter {
+	store := opts.RateLimits
+	if store == nil {
+		store = ratelimit.NewMemoryStore()
+	}
+	return middleware.NewRateLimiter(store, func(operation string) (ratelimit.Limit, bool) {
+		cfg := opts.Reloader.Dynamic().RateLimit
+		if !cfg.Enabled {
+			return ratelimit.Limit{}, false
+		}
+		if budget, ok := cfg.Operations[operation]; ok {
+			return ratelimit.Limit{Requests: budget.Requests, Window: budget.Window}, true
+		}
+		return ratelimit.Limit{Requests: cfg.Requests, Window: cfg.Window}, true
+	})
+}
@@ -0,0 +1,283 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/api"
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/environment"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/sharelink"
+)
+
+// Limits of GET /labubu/{id}/share/{linkId}/accesses
+const (
+	defaultAccessLimit = 50
+	maxAccessLimit     = 200
+)
+
+// sharePasswordHeader carries the password of a link for API clients
+const sharePasswordHeader = "X-Share-Password"
+
+// maxSharePasswordForm is the largest password form accepted, in bytes
+const maxSharePasswordForm = 4 << 10
+
+// shareLinkResponse maps link to the API; created links also carry their URL
+func shareLinkResponse(link *sharelink.Link) api.CreateShareLink201JSONResponse {
+	response := api.CreateShareLink201JSONResponse{
+		Id:          link.ID,
+		HasPassword: link.HasPassword(),
+		ExpiresAt:   link.ExpiresAt,
+		Views:       link.Views,
+		RevokedAt:   link.RevokedAt,
+		CreatedAt:   link.CreatedAt,
+	}
+	if link.MaxViews > 0 {
+		response.MaxViews = &link.MaxViews
+	}
+	return response
+}
+
+// CreateShareLink implements the POST /labubu/{id}/share endpoint
+func (s *Server) CreateShareLink(ctx context.Context, request api.CreateShareLinkRequestObject) (api.CreateShareLinkResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	req := sharelink.CreateLinkRequest{LabubuID: request.Id, OwnerID: userID}
+	if request.Body.Password != nil {
+		req.Password = *request.Body.Password
+	}
+	if request.Body.ExpiresAt != nil {
+		req.ExpiresAt = *request.Body.ExpiresAt
+	}
+	if request.Body.MaxViews != nil {
+		if *request.Body.MaxViews < 1 {
+			return api.CreateShareLink400JSONResponse{Error: sharelink.ErrInvalidMaxViews.Error()}, nil
+		}
+		req.MaxViews = *request.Body.MaxViews
+	}
+
+	result, err := s.shareLinkService.CreateLink(ctx, req)
+	switch {
+	case errors.Is(err, auth.ErrWeakPassword), errors.Is(err, sharelink.ErrInvalidExpiry), errors.Is(err, sharelink.ErrInvalidMaxViews):
+		return api.CreateShareLink400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, labubu.ErrNotFound):
+		return api.CreateShareLink404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	response := shareLinkResponse(result.Link)
+	response.Url, response.Token = &result.URL, &result.Token
+	return response, nil
+}
+
+// ListShareLinks implements the GET /labubu/{id}/share endpoint
+func (s *Server) ListShareLinks(ctx context.Context, request api.ListShareLinksRequestObject) (api.ListShareLinksResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	results, err := s.shareLinkService.ListLinks(ctx, request.Id, userID)
+	if errors.Is(err, labubu.ErrNotFound) {
+		return api.ListShareLinks404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(api.ListShareLinks200JSONResponse, len(results))
+	for i, link := range results {
+		links[i] = shareLinkResponse(link)
+	}
+	return links, nil
+}
+
+// RevokeShareLink implements the DELETE /labubu/{id}/share/{linkId} endpoint
+func (s *Server) RevokeShareLink(ctx context.Context, request api.RevokeShareLinkRequestObject) (api.RevokeShareLinkResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	_, err := s.shareLinkService.RevokeLink(ctx, request.LinkId, request.Id, userID)
+	if errors.Is(err, labubu.ErrNotFound) || errors.Is(err, sharelink.ErrNotFound) {
+		return api.RevokeShareLink404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.RevokeShareLink204Response{}, nil
+}
+
+// ListShareLinkAccesses implements the GET /labubu/{id}/share/{linkId}/accesses endpoint
+func (s *Server) ListShareLinkAccesses(ctx context.Context, request api.ListShareLinkAccessesRequestObject) (api.ListShareLinkAccessesResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	limit := defaultAccessLimit
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+		if limit < 1 || limit > maxAccessLimit {
+			return api.ListShareLinkAccesses400JSONResponse{Error: "limit must be between 1 and " + strconv.Itoa(maxAccessLimit)}, nil
+		}
+	}
+
+	results, err := s.shareLinkService.ListAccesses(ctx, request.LinkId, request.Id, userID, limit)
+	if errors.Is(err, labubu.ErrNotFound) || errors.Is(err, sharelink.ErrNotFound) {
+		return api.ListShareLinkAccesses404Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	accesses := make(api.ListShareLinkAccesses200JSONResponse, len(results))
+	for i, a := range results {
+		accesses[i].Id, accesses[i].Outcome, accesses[i].AccessedAt = a.ID, string(a.Outcome), a.AccessedAt
+		accesses[i].Ip, accesses[i].UserAgent = a.IP, a.UserAgent
+	}
+	return accesses, nil
+}
+
+// sharedPage shows a shared labubu, or asks for the password of the link
+var sharedPage = template.Must(template.New("shared").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{if .Labubu}}Shared labubu{{else}}{{.Title}}{{end}}</title>
+<style>
+body { font-family: -apple-system, Segoe UI, Helvetica, Arial, sans-serif; max-width: 640px; margin: 48px auto; padding: 0 16px; color: #1f2328; }
+p.text { font-size: 1.25rem; white-space: pre-wrap; }
+ul { list-style: none; padding: 0; display: flex; flex-wrap: wrap; gap: 6px; }
+li { background: #ddf4ff; border-radius: 12px; padding: 2px 10px; font-size: .875rem; }
+.error { color: #cf222e; }
+</style>
+</head>
+<body>
+{{with .Labubu}}
+<p class="text">{{.Text}}</p>
+{{if .Tags}}<ul>{{range .Tags}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{else}}
+<h1>{{.Title}}</h1>
+{{if .AskPassword}}<form method="post">
+{{if .Message}}<p class="error">{{.Message}}</p>{{end}}
+<label>Password <input type="password" name="password" autofocus required></label>
+<button type="submit">Open</button>
+</form>{{else}}<p>{{.Message}}</p>{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+type sharedView struct {
+	Labubu      *labubu.Labubu
+	Title       string
+	Message     string
+	AskPassword bool
+}
+
+// openShareLink serves GET and POST /s/{token} without authentication. API
+// clients get JSON and send the password in X-Share-Password; browsers get a
+// page whose form posts the password back.
+func openShareLink(svc sharelink.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		html := wantsHTML(r)
+		password := r.Header.Get(sharePasswordHeader)
+		if r.Method == http.MethodPost {
+			r.Body = http.MaxBytesReader(w, r.Body, maxSharePasswordForm)
+			password = r.PostFormValue("password")
+		}
+
+		item, err := svc.Open(r.Context(), sharelink.OpenRequest{
+			Token:     chi.URLParam(r, "token"),
+			Password:  password,
+			IP:        clientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+
+		// The token is in the URL, so it must not leak to other sites or caches
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		w.Header().Set("X-Robots-Tag", "noindex")
+		status, view := http.StatusOK, sharedView{Labubu: item}
+		switch {
+		case errors.Is(err, sharelink.ErrNotFound):
+			status, view.Title, view.Message = http.StatusNotFound, "Link not found", "This link does not exist."
+		case errors.Is(err, sharelink.ErrGone):
+			status, view.Title, view.Message = http.StatusGone, "Link unavailable", "This link has expired or was revoked."
+		case errors.Is(err, sharelink.ErrPasswordRequired):
+			status, view.Title, view.AskPassword = http.StatusUnauthorized, "Password required", true
+		case errors.Is(err, sharelink.ErrWrongPassword):
+			status, view.Title, view.Message, view.AskPassword = http.StatusUnauthorized, "Password required", "Wrong password.", true
+		case err != nil:
+			slog.ErrorContext(r.Context(), "Opening share link failed", "error", err)
+			status, view.Title, view.Message = http.StatusInternalServerError, "Something went wrong", "Please try again later."
+		}
+
+		if !html {
+			if status == http.StatusInternalServerError {
+				writeJSON(w, status, map[string]string{"error": "internal error"})
+				return
+			}
+			if err != nil {
+				writeJSON(w, status, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, status, map[string]any{"id": item.ID, "text": item.Text, "tags": item.Tags})
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'")
+		w.WriteHeader(status)
+		_ = sharedPage.Execute(w, view)
+	}
+}
+
+// wantsHTML reports whether the client prefers a page to JSON; ?format=json
+// or ?format=html decides when set
+func wantsHTML(r *http.Request) bool {
+	switch r.URL.Query().Get("format") {
+	case "html":
+		return true
+	case "json":
+		return false
+	}
+	accept := r.Header.Get("Accept")
+	html, json := strings.Index(accept, "text/html"), strings.Index(accept, "application/json")
+	return html >= 0 && (json < 0 || html < json)
+}
+
+// clientIP is the address RealIP resolved, without the port
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// ShareLinksConfig maps the SHARE_LINK_* settings to the share link service
+func ShareLinksConfig(config *environment.Environment) sharelink.Config {
+	return sharelink.Config{
+		URL:    config.ShareLinks.URL,
+		TTL:    config.ShareLinks.TTL,
+		MaxTTL: config.ShareLinks.MaxTTL,
+	}
+}
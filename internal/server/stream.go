@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/realtime"
+)
+
+// StreamConfig tunes the Server-Sent Events endpoints
+type StreamConfig struct {
+	HeartbeatInterval time.Duration
+	// ReplayLimit caps how many events a resuming client is sent before it is told to reset
+	ReplayLimit int
+	// WriteTimeout bounds a single write so a stalled client cannot pin the handler
+	WriteTimeout time.Duration
+}
+
+// streamRetry is the reconnect delay suggested to EventSource clients
+const streamRetry = 3 * time.Second
+
+// replayBatch is how many events are read per query while replaying
+const replayBatch = 200
+
+// eventReset tells the client it missed events that are no longer retained
+// and should refetch GET /labubu before continuing
+const eventReset = "reset"
+
+// streamLabubu implements GET /labubu/stream. It sends the caller's labubu
+// changes as they commit, resuming after Last-Event-ID when the client
+// reconnects. Events can commit out of id order, so a resumed stream also
+// repeats recent events up to Last-Event-ID; clients skip ids they have applied.
+func streamLabubu(hub *realtime.Hub, events outbox.Repository, config StreamConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, ok := middleware.UserIDFromContext(ctx)
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var lastID int64
+		if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+			id, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || id < 0 {
+				http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+				return
+			}
+			lastID = id
+		}
+
+		owned := func(event *outbox.Event) bool {
+			if event.AggregateType != labubu.AggregateType {
+				return false
+			}
+			owner, ok := labubu.EventOwner(event.Payload)
+			return ok && owner == userID
+		}
+
+		// Subscribe before replaying so nothing committed in between is missed
+		sub := hub.Subscribe(owned)
+		defer hub.Unsubscribe(sub)
+
+		s := &sseWriter{w: w, rc: http.NewResponseController(w), timeout: config.WriteTimeout}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// Stop reverse proxies such as nginx from buffering the stream
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		if err := s.retry(streamRetry); err != nil {
+			return
+		}
+
+		replayed := make(map[int64]bool)
+		if lastID > 0 {
+			if err := replay(r, s, events, lastID, owned, config.ReplayLimit, replayed); err != nil {
+				if ctx.Err() == nil {
+					slog.Warn("Event stream replay failed", "error", err)
+				}
+				return
+			}
+		}
+
+		heartbeat := time.NewTicker(config.HeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.Done():
+				// Dropped for falling behind or shutting down; the client resumes from its last event
+				return
+			case <-heartbeat.C:
+				if err := s.comment("heartbeat"); err != nil {
+					return
+				}
+			case event := <-sub.Events():
+				if replayed[event.ID] {
+					continue
+				}
+				if err := s.event(strconv.FormatInt(event.ID, 10), event.Type, event.Payload); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// replay sends the events from outbox.ResumeFrom(lastID) on that are still
+// in the outbox, so ones that committed after lastID was sent are not
+// skipped. When some were already purged, or there are more than limit, it
+// sends a reset instead of an incomplete history.
+func replay(r *http.Request, s *sseWriter, events outbox.Repository, lastID int64, match realtime.Filter, limit int, replayed map[int64]bool) error {
+	ctx := r.Context()
+	oldest, err := events.OldestID(ctx)
+	if err != nil {
+		return err
+	}
+	if oldest == 0 || lastID < oldest-1 {
+		return s.event("", eventReset, []byte("{}"))
+	}
+
+	sent := 0
+	after := outbox.ResumeFrom(lastID)
+	for {
+		batch, err := events.ListAfter(ctx, after, labubu.AggregateType, replayBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range batch {
+			after = event.ID
+			if !match(event) {
+				continue
+			}
+			if sent == limit {
+				return s.event("", eventReset, []byte("{}"))
+			}
+			if err := s.event(strconv.FormatInt(event.ID, 10), event.Type, event.Payload); err != nil {
+				return err
+			}
+			replayed[event.ID] = true
+			sent++
+		}
+		if len(batch) < replayBatch {
+			return nil
+		}
+	}
+}
+
+// sseWriter writes Server-Sent Events and flushes after each one
+type sseWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (s *sseWriter) retry(d time.Duration) error {
+	return s.write(fmt.Appendf(nil, "retry: %d\n\n", d.Milliseconds()))
+}
+
+func (s *sseWriter) comment(text string) error {
+	return s.write(fmt.Appendf(nil, ": %s\n\n", text))
+}
+
+// event writes one event; an empty id leaves the client's last event ID unchanged
+func (s *sseWriter) event(id, name string, data []byte) error {
+	var buf bytes.Buffer
+	if id != "" {
+		fmt.Fprintf(&buf, "id: %s\n", id)
+	}
+	fmt.Fprintf(&buf, "event: %s\n", name)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteByte('\n')
+	return s.write(buf.Bytes())
+}
+
+func (s *sseWriter) write(p []byte) error {
+	if s.timeout > 0 {
+		if err := s.rc.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+	}
+	if _, err := s.w.Write(p); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/api"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/middleware"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+)
+
+// isTagError reports whether err is about the tags a client sent
+func isTagError(err error) bool {
+	return errors.Is(err, labubu.ErrInvalidTag) || errors.Is(err, labubu.ErrTooManyTags)
+}
+
+// ListTags implements the GET /tags endpoint
+func (s *Server) ListTags(ctx context.Context, request api.ListTagsRequestObject) (api.ListTagsResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	results, err := s.labubuService.ListTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(api.ListTags200JSONResponse, len(results))
+	for i, tag := range results {
+		tags[i].Id, tags[i].Name, tags[i].Count = tag.ID, tag.Name, tag.Count
+	}
+	return tags, nil
+}
+
+// RenameTag implements the PUT /tags/{id} endpoint
+func (s *Server) RenameTag(ctx context.Context, request api.RenameTagRequestObject) (api.RenameTagResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var result *labubu.Tag
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Labubu.RenameTag(ctx, labubu.RenameTagRequest{
+			ID:      request.Id,
+			OwnerID: userID,
+			Name:    request.Body.Name,
+		})
+		return err
+	})
+	switch {
+	case errors.Is(err, labubu.ErrInvalidTag):
+		return api.RenameTag400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, labubu.ErrNotFound):
+		return api.RenameTag404Response{}, nil
+	case errors.Is(err, labubu.ErrTagExists):
+		return api.RenameTag409Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.RenameTag200JSONResponse{Id: result.ID, Name: result.Name, Count: result.Count}, nil
+}
+
+// MergeTag implements the POST /tags/{id}/merge endpoint
+func (s *Server) MergeTag(ctx context.Context, request api.MergeTagRequestObject) (api.MergeTagResponseObject, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errNoUser
+	}
+
+	var result *labubu.Tag
+	err := s.tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+		result, err = svc.Labubu.MergeTags(ctx, labubu.MergeTagsRequest{
+			SourceID: request.Id,
+			TargetID: request.Body.Into,
+			OwnerID:  userID,
+		})
+		return err
+	})
+	switch {
+	case errors.Is(err, labubu.ErrSameTag):
+		return api.MergeTag400JSONResponse{Error: err.Error()}, nil
+	case errors.Is(err, labubu.ErrNotFound):
+		return api.MergeTag404Response{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return api.MergeTag200JSONResponse{Id: result.ID, Name: result.Name, Count: result.Count}, nil
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+	"github.com/abdurrahimagca/go-api-starter/internal/webhooks"
+)
+
+// Delivery log page sizes
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// webhookRoutes mounts subscription CRUD and the delivery log under /admin/webhooks
+func webhookRoutes(svc webhooks.Service, tm uow.TxManager) func(chi.Router) {
+	return func(r chi.Router) {
+		r.Get("/", listWebhooks(svc))
+		r.Post("/", createWebhook(svc))
+		r.Get("/deliveries/{deliveryID}", getWebhookDelivery(svc))
+		r.Post("/deliveries/{deliveryID}/redeliver", redeliverWebhook(tm))
+		r.Get("/{id}", getWebhook(svc))
+		r.Patch("/{id}", updateWebhook(svc))
+		r.Delete("/{id}", deleteWebhook(svc))
+		r.Get("/{id}/deliveries", listWebhookDeliveries(svc))
+	}
+}
+
+// createdWebhook includes the secret, which is only shown when it is created
+type createdWebhook struct {
+	*webhooks.Subscription
+	Secret string `json:"secret"`
+}
+
+func listWebhooks(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subs, err := svc.ListSubscriptions(r.Context())
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, subs)
+	}
+}
+
+func createWebhook(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req webhooks.CreateSubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		sub, err := svc.CreateSubscription(r.Context(), req)
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, createdWebhook{Subscription: sub, Secret: sub.Secret})
+	}
+}
+
+func getWebhook(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r, "id")
+		if !ok {
+			return
+		}
+		sub, err := svc.GetSubscription(r.Context(), id)
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sub)
+	}
+}
+
+// updateWebhook changes the given fields; setting enabled to true re-enables
+// an automatically disabled subscription and clears its failure streak
+func updateWebhook(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r, "id")
+		if !ok {
+			return
+		}
+		var req webhooks.UpdateSubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		sub, err := svc.UpdateSubscription(r.Context(), id, req)
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sub)
+	}
+}
+
+func deleteWebhook(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r, "id")
+		if !ok {
+			return
+		}
+		if err := svc.DeleteSubscription(r.Context(), id); err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// listWebhookDeliveries returns the newest deliveries of a subscription,
+// optionally filtered with ?status=pending|succeeded|failed
+func listWebhookDeliveries(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r, "id")
+		if !ok {
+			return
+		}
+
+		status := webhooks.DeliveryStatus(r.URL.Query().Get("status"))
+		switch status {
+		case "", webhooks.DeliveryPending, webhooks.DeliverySucceeded, webhooks.DeliveryFailed:
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "status must be pending, succeeded or failed"})
+			return
+		}
+
+		limit := defaultDeliveryLimit
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 || n > maxDeliveryLimit {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be between 1 and " + strconv.Itoa(maxDeliveryLimit)})
+				return
+			}
+			limit = n
+		}
+
+		deliveries, err := svc.ListDeliveries(r.Context(), id, status, limit)
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, deliveries)
+	}
+}
+
+func getWebhookDelivery(svc webhooks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r, "deliveryID")
+		if !ok {
+			return
+		}
+		delivery, err := svc.GetDelivery(r.Context(), id)
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, delivery)
+	}
+}
+
+// redeliverWebhook queues a delivery to be sent again with a fresh set of attempts
+func redeliverWebhook(tm uow.TxManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r, "deliveryID")
+		if !ok {
+			return
+		}
+		var delivery *webhooks.Delivery
+		err := tm.Do(r.Context(), func(ctx context.Context, svc uow.Services) (err error) {
+			delivery, err = svc.Webhooks.Redeliver(ctx, id)
+			return err
+		})
+		if err != nil {
+			writeWebhookError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, delivery)
+	}
+}
+
+// pathID parses a numeric URL parameter, answering 404 when it is not one
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, name), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, webhooks.ErrNotFound):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	case errors.Is(err, webhooks.ErrInvalidURL), errors.Is(err, webhooks.ErrNoEvents), errors.Is(err, webhooks.ErrInvalidSecret):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, webhooks.ErrSubscriptionDisabled):
+		writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		slog.Error("Webhook admin request failed", "error", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+}
@@ -0,0 +1,103 @@
+// Package sharelink gives the public expiring, optionally password-protected
+// links to single labubu and logs who opens them
+package sharelink
+
+import (
+	"errors"
+	"time"
+)
+
+// Link opens one labubu without an account
+type Link struct {
+	ID       int64 `json:"id"`
+	LabubuID int   `json:"labubu_id"`
+	OwnerID  int   `json:"owner_id"`
+	// PasswordHash is empty for links without a password
+	PasswordHash string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	// MaxViews is 0 for links that can be viewed any number of times
+	MaxViews  int        `json:"max_views,omitempty"`
+	Views     int        `json:"views"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// HasPassword reports whether the link asks for a password
+func (l *Link) HasPassword() bool {
+	return l.PasswordHash != ""
+}
+
+// closed returns why the link can no longer be opened at now, or "" while it can
+func (l *Link) closed(now time.Time) Outcome {
+	switch {
+	case l.RevokedAt != nil:
+		return OutcomeRevoked
+	case !now.Before(l.ExpiresAt):
+		return OutcomeExpired
+	case l.MaxViews > 0 && l.Views >= l.MaxViews:
+		return OutcomeExhausted
+	}
+	return ""
+}
+
+// CreatedLink is a new link with its token, which is not stored and shown only once
+type CreatedLink struct {
+	*Link
+	Token string
+	// URL opens the link; it is SHARE_LINK_URL followed by the token
+	URL string
+}
+
+// Outcome is the result of an attempt to open a link
+type Outcome string
+
+const (
+	OutcomeViewed           Outcome = "viewed"
+	OutcomePasswordRequired Outcome = "password_required"
+	OutcomeWrongPassword    Outcome = "wrong_password"
+	OutcomeExpired          Outcome = "expired"
+	OutcomeRevoked          Outcome = "revoked"
+	// OutcomeExhausted marks a link whose views are used up
+	OutcomeExhausted Outcome = "exhausted"
+	// OutcomeUnavailable marks a link to a labubu that has been deleted
+	OutcomeUnavailable Outcome = "unavailable"
+)
+
+// Access is a logged attempt to open a link
+type Access struct {
+	ID         int64     `json:"id"`
+	LinkID     int64     `json:"link_id"`
+	Outcome    Outcome   `json:"outcome"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	AccessedAt time.Time `json:"accessed_at"`
+}
+
+// CreateLinkRequest creates a link to a labubu owned by OwnerID. A zero
+// ExpiresAt gives the configured TTL and a zero MaxViews allows any number.
+type CreateLinkRequest struct {
+	LabubuID  int
+	OwnerID   int
+	Password  string
+	ExpiresAt time.Time
+	MaxViews  int
+}
+
+// OpenRequest opens a link; IP and UserAgent go to the access log
+type OpenRequest struct {
+	Token     string
+	Password  string
+	IP        string
+	UserAgent string
+}
+
+// Common errors
+var (
+	ErrNotFound = errors.New("share link not found")
+	// ErrGone is returned for links that were revoked, have expired or have no views left
+	ErrGone             = errors.New("share link is no longer available")
+	ErrPasswordRequired = errors.New("share link requires a password")
+	ErrWrongPassword    = errors.New("wrong password")
+	ErrInvalidExpiry    = errors.New("expiry must be in the future and within the allowed maximum")
+	ErrInvalidMaxViews  = errors.New("max views must be positive")
+)
@@ -0,0 +1,164 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for share link data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	CreateLink(ctx context.Context, link *Link, tokenHash string) (*Link, error)
+	GetLinkByToken(ctx context.Context, tokenHash string) (*Link, error)
+	GetLink(ctx context.Context, id int64, labubuID int) (*Link, error)
+	ListLinks(ctx context.Context, labubuID int) ([]*Link, error)
+	RevokeLink(ctx context.Context, id int64, labubuID int) (*Link, error)
+	// ConsumeView counts a view; ErrGone when the link cannot be opened anymore
+	ConsumeView(ctx context.Context, id int64) (*Link, error)
+	RecordAccess(ctx context.Context, access *Access) error
+	ListAccesses(ctx context.Context, linkID int64, limit int) ([]*Access, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+func (r *pgxRepository) CreateLink(ctx context.Context, link *Link, tokenHash string) (*Link, error) {
+	result, err := r.q.CreateShareLink(ctx, sqlc.CreateShareLinkParams{
+		LabubuID:     int32(link.LabubuID),
+		OwnerID:      int32(link.OwnerID),
+		TokenHash:    tokenHash,
+		PasswordHash: pgtype.Text{String: link.PasswordHash, Valid: link.PasswordHash != ""},
+		ExpiresAt:    pgtype.Timestamptz{Time: link.ExpiresAt, Valid: true},
+		MaxViews:     pgtype.Int4{Int32: int32(link.MaxViews), Valid: link.MaxViews > 0},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateShareLink failed: %w", err)
+	}
+	return toLink(result), nil
+}
+
+func (r *pgxRepository) GetLinkByToken(ctx context.Context, tokenHash string) (*Link, error) {
+	result, err := r.q.GetShareLinkByToken(ctx, tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetShareLinkByToken failed: %w", err)
+	}
+	return toLink(result), nil
+}
+
+func (r *pgxRepository) GetLink(ctx context.Context, id int64, labubuID int) (*Link, error) {
+	result, err := r.q.GetShareLink(ctx, sqlc.GetShareLinkParams{ID: id, LabubuID: int32(labubuID)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetShareLink failed: %w", err)
+	}
+	return toLink(result), nil
+}
+
+func (r *pgxRepository) ListLinks(ctx context.Context, labubuID int) ([]*Link, error) {
+	results, err := r.q.ListShareLinks(ctx, int32(labubuID))
+	if err != nil {
+		return nil, fmt.Errorf("ListShareLinks failed: %w", err)
+	}
+	links := make([]*Link, 0, len(results))
+	for _, result := range results {
+		links = append(links, toLink(result))
+	}
+	return links, nil
+}
+
+func (r *pgxRepository) RevokeLink(ctx context.Context, id int64, labubuID int) (*Link, error) {
+	result, err := r.q.RevokeShareLink(ctx, sqlc.RevokeShareLinkParams{ID: id, LabubuID: int32(labubuID)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RevokeShareLink failed: %w", err)
+	}
+	return toLink(result), nil
+}
+
+func (r *pgxRepository) ConsumeView(ctx context.Context, id int64) (*Link, error) {
+	result, err := r.q.ConsumeShareLinkView(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrGone
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ConsumeShareLinkView failed: %w", err)
+	}
+	return toLink(result), nil
+}
+
+func (r *pgxRepository) RecordAccess(ctx context.Context, access *Access) error {
+	err := r.q.RecordShareLinkAccess(ctx, sqlc.RecordShareLinkAccessParams{
+		LinkID:    access.LinkID,
+		Outcome:   string(access.Outcome),
+		Ip:        access.IP,
+		UserAgent: access.UserAgent,
+	})
+	if err != nil {
+		return fmt.Errorf("RecordShareLinkAccess failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ListAccesses(ctx context.Context, linkID int64, limit int) ([]*Access, error) {
+	results, err := r.q.ListShareLinkAccesses(ctx, sqlc.ListShareLinkAccessesParams{LinkID: linkID, Limit: int32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("ListShareLinkAccesses failed: %w", err)
+	}
+	accesses := make([]*Access, 0, len(results))
+	for _, a := range results {
+		accesses = append(accesses, &Access{
+			ID:         a.ID,
+			LinkID:     a.LinkID,
+			Outcome:    Outcome(a.Outcome),
+			IP:         a.Ip,
+			UserAgent:  a.UserAgent,
+			AccessedAt: a.AccessedAt.Time,
+		})
+	}
+	return accesses, nil
+}
+
+func toLink(l sqlc.ShareLink) *Link {
+	link := &Link{
+		ID:           l.ID,
+		LabubuID:     int(l.LabubuID),
+		OwnerID:      int(l.OwnerID),
+		PasswordHash: l.PasswordHash.String,
+		ExpiresAt:    l.ExpiresAt.Time,
+		MaxViews:     int(l.MaxViews.Int32),
+		Views:        int(l.Views),
+		CreatedAt:    l.CreatedAt.Time,
+	}
+	if l.RevokedAt.Valid {
+		revokedAt := l.RevokedAt.Time
+		link.RevokedAt = &revokedAt
+	}
+	return link
+}
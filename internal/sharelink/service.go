@@ -0,0 +1,254 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+	"github.com/abdurrahimagca/go-api-starter/platform/token"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/sharelink")
+
+// tokenBytes is the entropy of a link token; it is 43 characters once encoded
+const tokenBytes = 32
+
+// maxUserAgentLength is the longest user agent kept in the access log, in bytes
+const maxUserAgentLength = 512
+
+// Config configures share links
+type Config struct {
+	// URL is where links are opened; the token is appended as a path segment
+	URL string
+	// TTL is the lifetime of links created without an expiry
+	TTL time.Duration
+	// MaxTTL is the latest expiry a link may be given
+	MaxTTL time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.TTL <= 0 {
+		c.TTL = 7 * 24 * time.Hour
+	}
+	if c.MaxTTL <= 0 {
+		c.MaxTTL = 90 * 24 * time.Hour
+	}
+	c.MaxTTL = max(c.MaxTTL, c.TTL)
+	c.URL = strings.TrimSuffix(c.URL, "/")
+	return c
+}
+
+// Service defines the contract for share link business logic. Only the
+// owner of a labubu manages its links; others get labubu.ErrNotFound.
+type Service interface {
+	WithTx(tx pgx.Tx) Service
+	CreateLink(ctx context.Context, req CreateLinkRequest) (*CreatedLink, error)
+	ListLinks(ctx context.Context, labubuID, ownerID int) ([]*Link, error)
+	// RevokeLink closes a link for good; revoking it again changes nothing
+	RevokeLink(ctx context.Context, id int64, labubuID, ownerID int) (*Link, error)
+	// ListAccesses returns the latest attempts to open a link, newest first
+	ListAccesses(ctx context.Context, id int64, labubuID, ownerID, limit int) ([]*Access, error)
+	// Open counts a view of a link and returns its labubu. Every attempt is
+	// logged, so it must not run in a transaction that is rolled back on error.
+	Open(ctx context.Context, req OpenRequest) (*labubu.Labubu, error)
+}
+
+type service struct {
+	repo   Repository
+	labubu labubu.Service
+	config Config
+}
+
+// NewService creates a new share link service
+func NewService(repo Repository, labubuService labubu.Service, config Config) Service {
+	return &service{
+		repo:   repo,
+		labubu: labubuService,
+		config: config.withDefaults(),
+	}
+}
+
+func (s *service) WithTx(tx pgx.Tx) Service {
+	return &service{
+		repo:   s.repo.WithTx(tx),
+		labubu: s.labubu.WithTx(tx),
+		config: s.config,
+	}
+}
+
+// authorize returns labubu.ErrNotFound unless userID owns the labubu
+func (s *service) authorize(ctx context.Context, labubuID, userID int) error {
+	l, err := s.labubu.GetLabubuByID(ctx, labubuID)
+	if err != nil {
+		return err
+	}
+	if l.OwnerID != userID {
+		return labubu.ErrNotFound
+	}
+	return nil
+}
+
+func (s *service) CreateLink(ctx context.Context, req CreateLinkRequest) (_ *CreatedLink, err error) {
+	ctx, span := tracer.Start(ctx, "sharelink.Service.CreateLink")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	now := time.Now()
+	if req.ExpiresAt.IsZero() {
+		req.ExpiresAt = now.Add(s.config.TTL)
+	}
+	if !req.ExpiresAt.After(now) || req.ExpiresAt.After(now.Add(s.config.MaxTTL)) {
+		return nil, ErrInvalidExpiry
+	}
+	if req.MaxViews < 0 {
+		return nil, ErrInvalidMaxViews
+	}
+	link := &Link{
+		LabubuID:  req.LabubuID,
+		OwnerID:   req.OwnerID,
+		ExpiresAt: req.ExpiresAt,
+		MaxViews:  req.MaxViews,
+	}
+	if req.Password != "" {
+		if link.PasswordHash, err = auth.HashPassword(req.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.authorize(ctx, req.LabubuID, req.OwnerID); err != nil {
+		return nil, err
+	}
+	linkToken, err := token.RandomString(tokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateLink(ctx, link, token.HashOpaque(linkToken))
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedLink{Link: created, Token: linkToken, URL: s.config.URL + "/" + linkToken}, nil
+}
+
+func (s *service) ListLinks(ctx context.Context, labubuID, ownerID int) (_ []*Link, err error) {
+	ctx, span := tracer.Start(ctx, "sharelink.Service.ListLinks")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, ownerID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListLinks(ctx, labubuID)
+}
+
+func (s *service) RevokeLink(ctx context.Context, id int64, labubuID, ownerID int) (_ *Link, err error) {
+	ctx, span := tracer.Start(ctx, "sharelink.Service.RevokeLink")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, ownerID); err != nil {
+		return nil, err
+	}
+	return s.repo.RevokeLink(ctx, id, labubuID)
+}
+
+func (s *service) ListAccesses(ctx context.Context, id int64, labubuID, ownerID, limit int) (_ []*Access, err error) {
+	ctx, span := tracer.Start(ctx, "sharelink.Service.ListAccesses")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := s.authorize(ctx, labubuID, ownerID); err != nil {
+		return nil, err
+	}
+	link, err := s.repo.GetLink(ctx, id, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListAccesses(ctx, link.ID, limit)
+}
+
+func (s *service) Open(ctx context.Context, req OpenRequest) (_ *labubu.Labubu, err error) {
+	ctx, span := tracer.Start(ctx, "sharelink.Service.Open")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	link, err := s.repo.GetLinkByToken(ctx, token.HashOpaque(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	item, outcome, err := s.open(ctx, link, req.Password)
+	if outcome == "" {
+		// The attempt failed for reasons of our own, not the visitor's
+		return nil, err
+	}
+	access := &Access{
+		LinkID:    link.ID,
+		Outcome:   outcome,
+		IP:        req.IP,
+		UserAgent: truncate(req.UserAgent, maxUserAgentLength),
+	}
+	if logErr := s.repo.RecordAccess(ctx, access); logErr != nil {
+		return nil, logErr
+	}
+	return item, err
+}
+
+// open checks the link and the password and counts the view. The outcome is
+// empty when the attempt failed with an unexpected error.
+func (s *service) open(ctx context.Context, link *Link, password string) (*labubu.Labubu, Outcome, error) {
+	if outcome := link.closed(time.Now()); outcome != "" {
+		return nil, outcome, ErrGone
+	}
+	if link.HasPassword() {
+		if password == "" {
+			return nil, OutcomePasswordRequired, ErrPasswordRequired
+		}
+		if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+			return nil, OutcomeWrongPassword, ErrWrongPassword
+		}
+	}
+
+	// The labubu is read first, so a deleted or unpublished one does not use
+	// up a view
+	item, err := s.labubu.GetLabubuByID(ctx, link.LabubuID)
+	if errors.Is(err, labubu.ErrNotFound) {
+		return nil, OutcomeUnavailable, ErrGone
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if item.Status != labubu.StatusPublished {
+		return nil, OutcomeUnavailable, ErrGone
+	}
+
+	_, err = s.repo.ConsumeView(ctx, link.ID)
+	if errors.Is(err, ErrGone) {
+		// Another view, the expiry or a revocation came first
+		current, err := s.repo.GetLink(ctx, link.ID, link.LabubuID)
+		if err != nil {
+			return nil, "", err
+		}
+		outcome := current.closed(time.Now())
+		if outcome == "" {
+			outcome = OutcomeExhausted
+		}
+		return nil, outcome, ErrGone
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return item, OutcomeViewed, nil
+}
+
+// truncate cuts s to at most n bytes without splitting a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return strings.ToValidUTF8(s, "")
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,249 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/platform/token"
+)
+
+const (
+	ownerID  = 1
+	labubuID = 7
+)
+
+// memoryRepository keeps links and their access log in memory
+type memoryRepository struct {
+	Repository
+
+	links    []*Link
+	hashes   map[int64]string
+	accesses []*Access
+}
+
+func (r *memoryRepository) CreateLink(_ context.Context, link *Link, tokenHash string) (*Link, error) {
+	if r.hashes == nil {
+		r.hashes = make(map[int64]string)
+	}
+	created := *link
+	created.ID = int64(len(r.links) + 1)
+	r.links = append(r.links, &created)
+	r.hashes[created.ID] = tokenHash
+	copied := created
+	return &copied, nil
+}
+
+func (r *memoryRepository) GetLinkByToken(_ context.Context, tokenHash string) (*Link, error) {
+	for _, link := range r.links {
+		if r.hashes[link.ID] == tokenHash {
+			copied := *link
+			return &copied, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRepository) GetLink(_ context.Context, id int64, labubuID int) (*Link, error) {
+	for _, link := range r.links {
+		if link.ID == id && link.LabubuID == labubuID {
+			copied := *link
+			return &copied, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRepository) ConsumeView(_ context.Context, id int64) (*Link, error) {
+	link := r.links[id-1]
+	if link.closed(time.Now()) != "" {
+		return nil, ErrGone
+	}
+	link.Views++
+	copied := *link
+	return &copied, nil
+}
+
+func (r *memoryRepository) RecordAccess(_ context.Context, access *Access) error {
+	r.accesses = append(r.accesses, access)
+	return nil
+}
+
+// labubuService serves the labubu in labubus
+type labubuService struct {
+	labubu.Service
+	labubus map[int]*labubu.Labubu
+}
+
+func (s *labubuService) GetLabubuByID(_ context.Context, id int) (*labubu.Labubu, error) {
+	l, ok := s.labubus[id]
+	if !ok {
+		return nil, labubu.ErrNotFound
+	}
+	return l, nil
+}
+
+func newTestService() (Service, *memoryRepository, *labubuService) {
+	repo := &memoryRepository{}
+	labubus := &labubuService{labubus: map[int]*labubu.Labubu{labubuID: {ID: labubuID, OwnerID: ownerID, Status: labubu.StatusPublished}}}
+	return NewService(repo, labubus, Config{URL: "https://example.com/s/", TTL: time.Hour, MaxTTL: 24 * time.Hour}), repo, labubus
+}
+
+func TestCreateLink(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		req     CreateLinkRequest
+		wantErr error
+	}{
+		{name: "defaults", req: CreateLinkRequest{}},
+		{name: "expiry, views and password", req: CreateLinkRequest{ExpiresAt: now.Add(2 * time.Hour), MaxViews: 3, Password: "correct horse"}},
+		{name: "expiry in the past", req: CreateLinkRequest{ExpiresAt: now.Add(-time.Minute)}, wantErr: ErrInvalidExpiry},
+		{name: "expiry past the maximum", req: CreateLinkRequest{ExpiresAt: now.Add(25 * time.Hour)}, wantErr: ErrInvalidExpiry},
+		{name: "negative max views", req: CreateLinkRequest{MaxViews: -1}, wantErr: ErrInvalidMaxViews},
+		{name: "not the owner", req: CreateLinkRequest{OwnerID: 2}, wantErr: labubu.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestService()
+			tt.req.LabubuID = labubuID
+			if tt.req.OwnerID == 0 {
+				tt.req.OwnerID = ownerID
+			}
+
+			created, err := svc.CreateLink(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateLink() = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.links) != 0 {
+					t.Error("link stored for a refused request")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if created.URL != "https://example.com/s/"+created.Token {
+				t.Errorf("URL = %q, want the configured URL followed by the token", created.URL)
+			}
+			// Only the hash of the token is stored
+			if repo.hashes[created.ID] != token.HashOpaque(created.Token) {
+				t.Error("stored token hash does not match the token")
+			}
+			if tt.req.ExpiresAt.IsZero() && created.ExpiresAt.Sub(now).Round(time.Minute) != time.Hour {
+				t.Errorf("ExpiresAt = %v, want the configured TTL from now", created.ExpiresAt)
+			}
+			if created.HasPassword() != (tt.req.Password != "") || (created.HasPassword() && created.PasswordHash == tt.req.Password) {
+				t.Errorf("PasswordHash = %q, want a hash only when a password is set", created.PasswordHash)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name         string
+		link         Link
+		password     string
+		deleteLabubu bool
+		status       labubu.Status
+		wantErr      error
+		wantOutcome  Outcome
+		wantViews    int
+	}{
+		{name: "viewed", wantOutcome: OutcomeViewed, wantViews: 1},
+		{name: "password required", link: Link{PasswordHash: hash}, wantErr: ErrPasswordRequired, wantOutcome: OutcomePasswordRequired},
+		{name: "wrong password", link: Link{PasswordHash: hash}, password: "guess", wantErr: ErrWrongPassword, wantOutcome: OutcomeWrongPassword},
+		{name: "right password", link: Link{PasswordHash: hash}, password: "correct horse", wantOutcome: OutcomeViewed, wantViews: 1},
+		{name: "expired", link: Link{ExpiresAt: time.Now().Add(-time.Second)}, wantErr: ErrGone, wantOutcome: OutcomeExpired},
+		{name: "revoked", link: Link{RevokedAt: &revokedAt}, wantErr: ErrGone, wantOutcome: OutcomeRevoked},
+		{name: "last view", link: Link{MaxViews: 2, Views: 1}, wantOutcome: OutcomeViewed, wantViews: 2},
+		{name: "views used up", link: Link{MaxViews: 2, Views: 2}, wantErr: ErrGone, wantOutcome: OutcomeExhausted, wantViews: 2},
+		// A deleted or unpublished labubu does not use up a view
+		{name: "labubu deleted", deleteLabubu: true, wantErr: ErrGone, wantOutcome: OutcomeUnavailable},
+		{name: "labubu unpublished", status: labubu.StatusDraft, wantErr: ErrGone, wantOutcome: OutcomeUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, labubus := newTestService()
+			link := tt.link
+			link.LabubuID, link.OwnerID = labubuID, ownerID
+			if link.ExpiresAt.IsZero() {
+				link.ExpiresAt = time.Now().Add(time.Hour)
+			}
+			if _, err := repo.CreateLink(context.Background(), &link, token.HashOpaque("link-token")); err != nil {
+				t.Fatal(err)
+			}
+			if tt.deleteLabubu {
+				delete(labubus.labubus, labubuID)
+			}
+			if tt.status != "" {
+				labubus.labubus[labubuID].Status = tt.status
+			}
+
+			item, err := svc.Open(context.Background(), OpenRequest{
+				Token:     "link-token",
+				Password:  tt.password,
+				IP:        "203.0.113.7",
+				UserAgent: "test",
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Open() = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (item == nil || item.ID != labubuID) {
+				t.Errorf("Open() = %+v, want the shared labubu", item)
+			}
+			if len(repo.accesses) != 1 || repo.accesses[0].Outcome != tt.wantOutcome || repo.accesses[0].IP != "203.0.113.7" {
+				t.Errorf("accesses = %+v, want one logged %s attempt", repo.accesses, tt.wantOutcome)
+			}
+			if got := repo.links[0].Views; got != tt.wantViews {
+				t.Errorf("views = %d, want %d", got, tt.wantViews)
+			}
+		})
+	}
+
+	t.Run("unknown token", func(t *testing.T) {
+		svc, repo, _ := newTestService()
+		if _, err := svc.Open(context.Background(), OpenRequest{Token: "missing"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Open() = %v, want ErrNotFound", err)
+		}
+		if len(repo.accesses) != 0 {
+			t.Error("attempt logged for a link that does not exist")
+		}
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		n    int
+		want string
+	}{
+		{"short", "curl/8.0", 16, "curl/8.0"},
+		{"cut", "Mozilla/5.0", 7, "Mozilla"},
+		{"cut before a multibyte character", "ağaç", 2, "a"},
+		{"invalid utf-8 dropped", "a\xffb", 16, "ab"},
+		{"long", strings.Repeat("x", 600), maxUserAgentLength, strings.Repeat("x", maxUserAgentLength)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.in, tt.n); got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,472 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: attachments.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const completeAttachment = `-- name: CompleteAttachment :one
+UPDATE attachments
+SET status = 'ready', content_type = $2, size = $3, sha256 = $4, image_status = $5, updated_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error
+`
+
+type CompleteAttachmentParams struct {
+	ID          int64       `json:"id"`
+	ContentType string      `json:"content_type"`
+	Size        int64       `json:"size"`
+	Sha256      pgtype.Text `json:"sha256"`
+	ImageStatus pgtype.Text `json:"image_status"`
+}
+
+func (q *Queries) CompleteAttachment(ctx context.Context, arg CompleteAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRow(ctx, completeAttachment,
+		arg.ID,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+		arg.ImageStatus,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.UploaderID,
+		&i.ObjectKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.ImageStatus,
+		&i.ImageError,
+	)
+	return i, err
+}
+
+const createAttachment = `-- name: CreateAttachment :one
+INSERT INTO attachments (labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, image_status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error
+`
+
+type CreateAttachmentParams struct {
+	LabubuID    int32       `json:"labubu_id"`
+	UploaderID  pgtype.Int4 `json:"uploader_id"`
+	ObjectKey   string      `json:"object_key"`
+	Filename    string      `json:"filename"`
+	ContentType string      `json:"content_type"`
+	Size        int64       `json:"size"`
+	Sha256      pgtype.Text `json:"sha256"`
+	Status      string      `json:"status"`
+	ImageStatus pgtype.Text `json:"image_status"`
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRow(ctx, createAttachment,
+		arg.LabubuID,
+		arg.UploaderID,
+		arg.ObjectKey,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+		arg.Status,
+		arg.ImageStatus,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.UploaderID,
+		&i.ObjectKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.ImageStatus,
+		&i.ImageError,
+	)
+	return i, err
+}
+
+const deleteAttachment = `-- name: DeleteAttachment :one
+DELETE FROM attachments WHERE id = $1 AND labubu_id = $2 RETURNING id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error
+`
+
+type DeleteAttachmentParams struct {
+	ID       int64 `json:"id"`
+	LabubuID int32 `json:"labubu_id"`
+}
+
+func (q *Queries) DeleteAttachment(ctx context.Context, arg DeleteAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRow(ctx, deleteAttachment, arg.ID, arg.LabubuID)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.UploaderID,
+		&i.ObjectKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.ImageStatus,
+		&i.ImageError,
+	)
+	return i, err
+}
+
+const deleteAttachmentsByID = `-- name: DeleteAttachmentsByID :execrows
+DELETE FROM attachments WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) DeleteAttachmentsByID(ctx context.Context, ids []int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAttachmentsByID, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const failAttachmentImage = `-- name: FailAttachmentImage :exec
+UPDATE attachments SET image_status = $2, image_error = $3, updated_at = now() WHERE id = $1
+`
+
+type FailAttachmentImageParams struct {
+	ID          int64       `json:"id"`
+	ImageStatus pgtype.Text `json:"image_status"`
+	ImageError  pgtype.Text `json:"image_error"`
+}
+
+func (q *Queries) FailAttachmentImage(ctx context.Context, arg FailAttachmentImageParams) error {
+	_, err := q.db.Exec(ctx, failAttachmentImage, arg.ID, arg.ImageStatus, arg.ImageError)
+	return err
+}
+
+const getAttachment = `-- name: GetAttachment :one
+SELECT id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error FROM attachments WHERE id = $1 AND labubu_id = $2
+`
+
+type GetAttachmentParams struct {
+	ID       int64 `json:"id"`
+	LabubuID int32 `json:"labubu_id"`
+}
+
+func (q *Queries) GetAttachment(ctx context.Context, arg GetAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRow(ctx, getAttachment, arg.ID, arg.LabubuID)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.UploaderID,
+		&i.ObjectKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.ImageStatus,
+		&i.ImageError,
+	)
+	return i, err
+}
+
+const getAttachmentByID = `-- name: GetAttachmentByID :one
+SELECT id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error FROM attachments WHERE id = $1
+`
+
+func (q *Queries) GetAttachmentByID(ctx context.Context, id int64) (Attachment, error) {
+	row := q.db.QueryRow(ctx, getAttachmentByID, id)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.UploaderID,
+		&i.ObjectKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.ImageStatus,
+		&i.ImageError,
+	)
+	return i, err
+}
+
+const listAttachmentVariants = `-- name: ListAttachmentVariants :many
+SELECT id, attachment_id, name, object_key, content_type, width, height, size, created_at FROM attachment_variants WHERE attachment_id = $1 ORDER BY width, name
+`
+
+func (q *Queries) ListAttachmentVariants(ctx context.Context, attachmentID int64) ([]AttachmentVariant, error) {
+	rows, err := q.db.Query(ctx, listAttachmentVariants, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AttachmentVariant{}
+	for rows.Next() {
+		var i AttachmentVariant
+		if err := rows.Scan(
+			&i.ID,
+			&i.AttachmentID,
+			&i.Name,
+			&i.ObjectKey,
+			&i.ContentType,
+			&i.Width,
+			&i.Height,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttachments = `-- name: ListAttachments :many
+SELECT id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error FROM attachments WHERE labubu_id = $1 ORDER BY id
+`
+
+func (q *Queries) ListAttachments(ctx context.Context, labubuID int32) ([]Attachment, error) {
+	rows, err := q.db.Query(ctx, listAttachments, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Attachment{}
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.LabubuID,
+			&i.UploaderID,
+			&i.ObjectKey,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Width,
+			&i.Height,
+			&i.ImageStatus,
+			&i.ImageError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableAttachments = `-- name: ListPurgeableAttachments :many
+SELECT a.id, a.labubu_id, a.uploader_id, a.object_key, a.filename, a.content_type, a.size, a.sha256, a.status, a.created_at, a.updated_at, a.width, a.height, a.image_status, a.image_error FROM attachments a
+JOIN labubu l ON l.id = a.labubu_id
+WHERE l.deleted_at < $1 OR (a.status = 'pending' AND a.created_at < $2)
+ORDER BY a.id
+`
+
+type ListPurgeableAttachmentsParams struct {
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Attachments of labubu deleted before $1, and direct uploads never completed before $2
+func (q *Queries) ListPurgeableAttachments(ctx context.Context, arg ListPurgeableAttachmentsParams) ([]Attachment, error) {
+	rows, err := q.db.Query(ctx, listPurgeableAttachments, arg.DeletedAt, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Attachment{}
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.LabubuID,
+			&i.UploaderID,
+			&i.ObjectKey,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Width,
+			&i.Height,
+			&i.ImageStatus,
+			&i.ImageError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVariantKeys = `-- name: ListVariantKeys :many
+SELECT object_key FROM attachment_variants WHERE attachment_id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListVariantKeys(ctx context.Context, ids []int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, listVariantKeys, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var object_key string
+		if err := rows.Scan(&object_key); err != nil {
+			return nil, err
+		}
+		items = append(items, object_key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordAttachmentImage = `-- name: RecordAttachmentImage :one
+UPDATE attachments
+SET width = $2, height = $3, size = $4, sha256 = $5, image_status = 'processed', image_error = NULL, updated_at = now()
+WHERE id = $1
+RETURNING id, labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, created_at, updated_at, width, height, image_status, image_error
+`
+
+type RecordAttachmentImageParams struct {
+	ID     int64       `json:"id"`
+	Width  pgtype.Int4 `json:"width"`
+	Height pgtype.Int4 `json:"height"`
+	Size   int64       `json:"size"`
+	Sha256 pgtype.Text `json:"sha256"`
+}
+
+// Size and digest change when metadata is stripped from the original
+func (q *Queries) RecordAttachmentImage(ctx context.Context, arg RecordAttachmentImageParams) (Attachment, error) {
+	row := q.db.QueryRow(ctx, recordAttachmentImage,
+		arg.ID,
+		arg.Width,
+		arg.Height,
+		arg.Size,
+		arg.Sha256,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.UploaderID,
+		&i.ObjectKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.ImageStatus,
+		&i.ImageError,
+	)
+	return i, err
+}
+
+const rejectAttachment = `-- name: RejectAttachment :exec
+UPDATE attachments SET status = 'rejected', updated_at = now() WHERE id = $1 AND status = 'pending'
+`
+
+func (q *Queries) RejectAttachment(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, rejectAttachment, id)
+	return err
+}
+
+const upsertAttachmentVariant = `-- name: UpsertAttachmentVariant :one
+INSERT INTO attachment_variants (attachment_id, name, object_key, content_type, width, height, size)
+SELECT a.id, $1, $2, $3, $4::int, $5::int, $6::bigint
+FROM attachments a WHERE a.id = $7
+ON CONFLICT (attachment_id, name) DO UPDATE
+SET object_key = excluded.object_key, content_type = excluded.content_type,
+    width = excluded.width, height = excluded.height, size = excluded.size
+RETURNING id, attachment_id, name, object_key, content_type, width, height, size, created_at
+`
+
+type UpsertAttachmentVariantParams struct {
+	Name         string `json:"name"`
+	ObjectKey    string `json:"object_key"`
+	ContentType  string `json:"content_type"`
+	Width        int32  `json:"width"`
+	Height       int32  `json:"height"`
+	Size         int64  `json:"size"`
+	AttachmentID int64  `json:"attachment_id"`
+}
+
+// Inserts nothing once the attachment is deleted
+func (q *Queries) UpsertAttachmentVariant(ctx context.Context, arg UpsertAttachmentVariantParams) (AttachmentVariant, error) {
+	row := q.db.QueryRow(ctx, upsertAttachmentVariant,
+		arg.Name,
+		arg.ObjectKey,
+		arg.ContentType,
+		arg.Width,
+		arg.Height,
+		arg.Size,
+		arg.AttachmentID,
+	)
+	var i AttachmentVariant
+	err := row.Scan(
+		&i.ID,
+		&i.AttachmentID,
+		&i.Name,
+		&i.ObjectKey,
+		&i.ContentType,
+		&i.Width,
+		&i.Height,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,479 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: collections.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const acceptCollectionInvites = `-- name: AcceptCollectionInvites :execrows
+WITH accepted AS (
+    DELETE FROM collection_invites WHERE email = $2 RETURNING collection_id, role
+)
+INSERT INTO collection_shares (collection_id, user_id, role)
+SELECT a.collection_id, $1, a.role FROM accepted a
+JOIN collections c ON c.id = a.collection_id
+WHERE c.owner_id <> $1
+ON CONFLICT (collection_id, user_id) DO NOTHING
+`
+
+type AcceptCollectionInvitesParams struct {
+	UserID int32  `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// Turns the invites for email into shares for user_id; an existing share
+// keeps its role
+func (q *Queries) AcceptCollectionInvites(ctx context.Context, arg AcceptCollectionInvitesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, acceptCollectionInvites, arg.UserID, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addCollectionItem = `-- name: AddCollectionItem :one
+INSERT INTO collection_items (collection_id, labubu_id, position, added_by)
+SELECT $1, $2,
+       coalesce((SELECT max(position) + 1 FROM collection_items WHERE collection_id = $1), 0),
+       $3
+ON CONFLICT (collection_id, labubu_id) DO NOTHING
+RETURNING collection_id, labubu_id, position, added_by, added_at
+`
+
+type AddCollectionItemParams struct {
+	CollectionID int32       `json:"collection_id"`
+	LabubuID     int32       `json:"labubu_id"`
+	AddedBy      pgtype.Int4 `json:"added_by"`
+}
+
+// Appends to the end; nothing is inserted when the labubu is already in the collection
+func (q *Queries) AddCollectionItem(ctx context.Context, arg AddCollectionItemParams) (CollectionItem, error) {
+	row := q.db.QueryRow(ctx, addCollectionItem, arg.CollectionID, arg.LabubuID, arg.AddedBy)
+	var i CollectionItem
+	err := row.Scan(
+		&i.CollectionID,
+		&i.LabubuID,
+		&i.Position,
+		&i.AddedBy,
+		&i.AddedAt,
+	)
+	return i, err
+}
+
+const closeCollectionGap = `-- name: CloseCollectionGap :exec
+UPDATE collection_items SET position = position - 1
+WHERE collection_id = $1 AND position > $2
+`
+
+type CloseCollectionGapParams struct {
+	CollectionID int32 `json:"collection_id"`
+	Position     int32 `json:"position"`
+}
+
+func (q *Queries) CloseCollectionGap(ctx context.Context, arg CloseCollectionGapParams) error {
+	_, err := q.db.Exec(ctx, closeCollectionGap, arg.CollectionID, arg.Position)
+	return err
+}
+
+const createCollection = `-- name: CreateCollection :one
+INSERT INTO collections (owner_id, name) VALUES ($1, $2) RETURNING id, owner_id, name, created_at, updated_at
+`
+
+type CreateCollectionParams struct {
+	OwnerID int32  `json:"owner_id"`
+	Name    string `json:"name"`
+}
+
+func (q *Queries) CreateCollection(ctx context.Context, arg CreateCollectionParams) (Collection, error) {
+	row := q.db.QueryRow(ctx, createCollection, arg.OwnerID, arg.Name)
+	var i Collection
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteCollection = `-- name: DeleteCollection :exec
+DELETE FROM collections WHERE id = $1
+`
+
+func (q *Queries) DeleteCollection(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, deleteCollection, id)
+	return err
+}
+
+const deleteCollectionShare = `-- name: DeleteCollectionShare :execrows
+DELETE FROM collection_shares WHERE collection_id = $1 AND user_id = $2
+`
+
+type DeleteCollectionShareParams struct {
+	CollectionID int32 `json:"collection_id"`
+	UserID       int32 `json:"user_id"`
+}
+
+func (q *Queries) DeleteCollectionShare(ctx context.Context, arg DeleteCollectionShareParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCollectionShare, arg.CollectionID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCollectionForUser = `-- name: GetCollectionForUser :one
+SELECT c.id, c.owner_id, c.name, c.created_at, c.updated_at, (CASE WHEN c.owner_id = $1::int THEN 'owner' ELSE s.role END)::text AS role
+FROM collections c
+LEFT JOIN collection_shares s ON s.collection_id = c.id AND s.user_id = $1::int
+WHERE c.id = $2 AND (c.owner_id = $1::int OR s.user_id IS NOT NULL)
+`
+
+type GetCollectionForUserParams struct {
+	UserID int32 `json:"user_id"`
+	ID     int32 `json:"id"`
+}
+
+type GetCollectionForUserRow struct {
+	ID        int32              `json:"id"`
+	OwnerID   int32              `json:"owner_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Role      string             `json:"role"`
+}
+
+// The collection with the role of user_id in it; no row when it is not shared with them
+func (q *Queries) GetCollectionForUser(ctx context.Context, arg GetCollectionForUserParams) (GetCollectionForUserRow, error) {
+	row := q.db.QueryRow(ctx, getCollectionForUser, arg.UserID, arg.ID)
+	var i GetCollectionForUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getCollectionItem = `-- name: GetCollectionItem :one
+SELECT collection_id, labubu_id, position, added_by, added_at FROM collection_items WHERE collection_id = $1 AND labubu_id = $2
+`
+
+type GetCollectionItemParams struct {
+	CollectionID int32 `json:"collection_id"`
+	LabubuID     int32 `json:"labubu_id"`
+}
+
+func (q *Queries) GetCollectionItem(ctx context.Context, arg GetCollectionItemParams) (CollectionItem, error) {
+	row := q.db.QueryRow(ctx, getCollectionItem, arg.CollectionID, arg.LabubuID)
+	var i CollectionItem
+	err := row.Scan(
+		&i.CollectionID,
+		&i.LabubuID,
+		&i.Position,
+		&i.AddedBy,
+		&i.AddedAt,
+	)
+	return i, err
+}
+
+const listCollectionItems = `-- name: ListCollectionItems :many
+SELECT ci.collection_id, ci.labubu_id, ci.position, ci.added_by, ci.added_at FROM collection_items ci
+JOIN labubu l ON l.id = ci.labubu_id AND l.deleted_at IS NULL
+  AND (l.status = 'published' OR l.owner_id = $1::int)
+WHERE ci.collection_id = $2
+ORDER BY ci.position
+LIMIT $4 OFFSET $3
+`
+
+type ListCollectionItemsParams struct {
+	ViewerID     int32 `json:"viewer_id"`
+	CollectionID int32 `json:"collection_id"`
+	RowOffset    int32 `json:"row_offset"`
+	RowLimit     int32 `json:"row_limit"`
+}
+
+// Deleted labubu stay in place until they are purged but are not listed, nor
+// are unpublished ones to anyone but their owner
+func (q *Queries) ListCollectionItems(ctx context.Context, arg ListCollectionItemsParams) ([]CollectionItem, error) {
+	rows, err := q.db.Query(ctx, listCollectionItems,
+		arg.ViewerID,
+		arg.CollectionID,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CollectionItem{}
+	for rows.Next() {
+		var i CollectionItem
+		if err := rows.Scan(
+			&i.CollectionID,
+			&i.LabubuID,
+			&i.Position,
+			&i.AddedBy,
+			&i.AddedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCollectionShares = `-- name: ListCollectionShares :many
+SELECT s.collection_id, s.user_id, s.role, s.created_at, u.email FROM collection_shares s
+JOIN users u ON u.id = s.user_id
+WHERE s.collection_id = $1
+ORDER BY s.created_at, s.user_id
+LIMIT $3 OFFSET $2
+`
+
+type ListCollectionSharesParams struct {
+	CollectionID int32 `json:"collection_id"`
+	RowOffset    int32 `json:"row_offset"`
+	RowLimit     int32 `json:"row_limit"`
+}
+
+type ListCollectionSharesRow struct {
+	CollectionID int32              `json:"collection_id"`
+	UserID       int32              `json:"user_id"`
+	Role         string             `json:"role"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	Email        string             `json:"email"`
+}
+
+func (q *Queries) ListCollectionShares(ctx context.Context, arg ListCollectionSharesParams) ([]ListCollectionSharesRow, error) {
+	rows, err := q.db.Query(ctx, listCollectionShares, arg.CollectionID, arg.RowOffset, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCollectionSharesRow{}
+	for rows.Next() {
+		var i ListCollectionSharesRow
+		if err := rows.Scan(
+			&i.CollectionID,
+			&i.UserID,
+			&i.Role,
+			&i.CreatedAt,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCollectionsForUser = `-- name: ListCollectionsForUser :many
+SELECT c.id, c.owner_id, c.name, c.created_at, c.updated_at, (CASE WHEN c.owner_id = $1::int THEN 'owner' ELSE s.role END)::text AS role
+FROM collections c
+LEFT JOIN collection_shares s ON s.collection_id = c.id AND s.user_id = $1::int
+WHERE c.owner_id = $1::int OR s.user_id IS NOT NULL
+ORDER BY c.id
+LIMIT $3 OFFSET $2
+`
+
+type ListCollectionsForUserParams struct {
+	UserID    int32 `json:"user_id"`
+	RowOffset int32 `json:"row_offset"`
+	RowLimit  int32 `json:"row_limit"`
+}
+
+type ListCollectionsForUserRow struct {
+	ID        int32              `json:"id"`
+	OwnerID   int32              `json:"owner_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Role      string             `json:"role"`
+}
+
+func (q *Queries) ListCollectionsForUser(ctx context.Context, arg ListCollectionsForUserParams) ([]ListCollectionsForUserRow, error) {
+	rows, err := q.db.Query(ctx, listCollectionsForUser, arg.UserID, arg.RowOffset, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCollectionsForUserRow{}
+	for rows.Next() {
+		var i ListCollectionsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCollection = `-- name: LockCollection :exec
+SELECT id FROM collections WHERE id = $1 FOR UPDATE
+`
+
+// Serializes changes to the order of the items
+func (q *Queries) LockCollection(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, lockCollection, id)
+	return err
+}
+
+const maxCollectionPosition = `-- name: MaxCollectionPosition :one
+SELECT coalesce(max(position), -1)::int FROM collection_items WHERE collection_id = $1
+`
+
+func (q *Queries) MaxCollectionPosition(ctx context.Context, collectionID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, maxCollectionPosition, collectionID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const moveCollectionItem = `-- name: MoveCollectionItem :exec
+UPDATE collection_items
+SET position = CASE
+    WHEN labubu_id = $1 THEN $2::int
+    WHEN $2::int < $3::int THEN position + 1
+    ELSE position - 1
+END
+WHERE collection_id = $4
+  AND position BETWEEN least($3::int, $2::int) AND greatest($3::int, $2::int)
+`
+
+type MoveCollectionItemParams struct {
+	LabubuID     int32 `json:"labubu_id"`
+	NewPosition  int32 `json:"new_position"`
+	OldPosition  int32 `json:"old_position"`
+	CollectionID int32 `json:"collection_id"`
+}
+
+// Moves an item from old_position to new_position and shifts the ones in between
+func (q *Queries) MoveCollectionItem(ctx context.Context, arg MoveCollectionItemParams) error {
+	_, err := q.db.Exec(ctx, moveCollectionItem,
+		arg.LabubuID,
+		arg.NewPosition,
+		arg.OldPosition,
+		arg.CollectionID,
+	)
+	return err
+}
+
+const removeCollectionItem = `-- name: RemoveCollectionItem :one
+DELETE FROM collection_items WHERE collection_id = $1 AND labubu_id = $2 RETURNING collection_id, labubu_id, position, added_by, added_at
+`
+
+type RemoveCollectionItemParams struct {
+	CollectionID int32 `json:"collection_id"`
+	LabubuID     int32 `json:"labubu_id"`
+}
+
+func (q *Queries) RemoveCollectionItem(ctx context.Context, arg RemoveCollectionItemParams) (CollectionItem, error) {
+	row := q.db.QueryRow(ctx, removeCollectionItem, arg.CollectionID, arg.LabubuID)
+	var i CollectionItem
+	err := row.Scan(
+		&i.CollectionID,
+		&i.LabubuID,
+		&i.Position,
+		&i.AddedBy,
+		&i.AddedAt,
+	)
+	return i, err
+}
+
+const renameCollection = `-- name: RenameCollection :one
+UPDATE collections SET name = $2, updated_at = now() WHERE id = $1 RETURNING id, owner_id, name, created_at, updated_at
+`
+
+type RenameCollectionParams struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) RenameCollection(ctx context.Context, arg RenameCollectionParams) (Collection, error) {
+	row := q.db.QueryRow(ctx, renameCollection, arg.ID, arg.Name)
+	var i Collection
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const touchCollection = `-- name: TouchCollection :exec
+UPDATE collections SET updated_at = now() WHERE id = $1
+`
+
+func (q *Queries) TouchCollection(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, touchCollection, id)
+	return err
+}
+
+const upsertCollectionInvite = `-- name: UpsertCollectionInvite :exec
+INSERT INTO collection_invites (collection_id, email, role) VALUES ($1, $2, $3)
+ON CONFLICT (collection_id, email) DO UPDATE SET role = excluded.role
+`
+
+type UpsertCollectionInviteParams struct {
+	CollectionID int32  `json:"collection_id"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+}
+
+func (q *Queries) UpsertCollectionInvite(ctx context.Context, arg UpsertCollectionInviteParams) error {
+	_, err := q.db.Exec(ctx, upsertCollectionInvite, arg.CollectionID, arg.Email, arg.Role)
+	return err
+}
+
+const upsertCollectionShare = `-- name: UpsertCollectionShare :one
+INSERT INTO collection_shares (collection_id, user_id, role) VALUES ($1, $2, $3)
+ON CONFLICT (collection_id, user_id) DO UPDATE SET role = excluded.role
+RETURNING collection_id, user_id, role, created_at
+`
+
+type UpsertCollectionShareParams struct {
+	CollectionID int32  `json:"collection_id"`
+	UserID       int32  `json:"user_id"`
+	Role         string `json:"role"`
+}
+
+func (q *Queries) UpsertCollectionShare(ctx context.Context, arg UpsertCollectionShareParams) (CollectionShare, error) {
+	row := q.db.QueryRow(ctx, upsertCollectionShare, arg.CollectionID, arg.UserID, arg.Role)
+	var i CollectionShare
+	err := row.Scan(
+		&i.CollectionID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
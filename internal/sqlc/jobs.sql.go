@@ -0,0 +1,252 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: jobs.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimJobs = `-- name: ClaimJobs :many
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, locked_at = now(), locked_by = $1::text, updated_at = now()
+WHERE id IN (
+    SELECT id FROM jobs
+    WHERE status = 'pending' AND run_at <= now() AND kind = ANY($2::text[])
+    ORDER BY run_at, id
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, locked_by, last_error, finished_at, created_at, updated_at
+`
+
+type ClaimJobsParams struct {
+	WorkerID  string   `json:"worker_id"`
+	Kinds     []string `json:"kinds"`
+	BatchSize int32    `json:"batch_size"`
+}
+
+func (q *Queries) ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error) {
+	rows, err := q.db.Query(ctx, claimJobs, arg.WorkerID, arg.Kinds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.UniqueKey,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LockedAt,
+			&i.LockedBy,
+			&i.LastError,
+			&i.FinishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeJob = `-- name: CompleteJob :execrows
+
+UPDATE jobs
+SET status = 'succeeded', locked_at = NULL, locked_by = NULL, finished_at = now(), updated_at = now()
+WHERE id = $1 AND status = 'running' AND locked_by = $2::text
+`
+
+type CompleteJobParams struct {
+	ID       int64  `json:"id"`
+	WorkerID string `json:"worker_id"`
+}
+
+// The outcome of a run is recorded only while the worker still holds the
+// job; after a rescue another worker may own it.
+func (q *Queries) CompleteJob(ctx context.Context, arg CompleteJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, completeJob, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enqueueJob = `-- name: EnqueueJob :one
+INSERT INTO jobs (kind, payload, unique_key, max_attempts, run_at)
+VALUES ($1, $2, $5, $3, $4)
+ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+RETURNING id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, locked_by, last_error, finished_at, created_at, updated_at
+`
+
+type EnqueueJobParams struct {
+	Kind        string             `json:"kind"`
+	Payload     []byte             `json:"payload"`
+	MaxAttempts int32              `json:"max_attempts"`
+	RunAt       pgtype.Timestamptz `json:"run_at"`
+	UniqueKey   pgtype.Text        `json:"unique_key"`
+}
+
+func (q *Queries) EnqueueJob(ctx context.Context, arg EnqueueJobParams) (Job, error) {
+	row := q.db.QueryRow(ctx, enqueueJob,
+		arg.Kind,
+		arg.Payload,
+		arg.MaxAttempts,
+		arg.RunAt,
+		arg.UniqueKey,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.UniqueKey,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LockedBy,
+		&i.LastError,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const extendJobLock = `-- name: ExtendJobLock :execrows
+UPDATE jobs
+SET locked_at = now(), updated_at = now()
+WHERE id = $1 AND status = 'running' AND locked_by = $2::text
+`
+
+type ExtendJobLockParams struct {
+	ID       int64  `json:"id"`
+	WorkerID string `json:"worker_id"`
+}
+
+// Heartbeat of a running job, so it is not rescued while its handler works
+func (q *Queries) ExtendJobLock(ctx context.Context, arg ExtendJobLockParams) (int64, error) {
+	result, err := q.db.Exec(ctx, extendJobLock, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getJobByUniqueKey = `-- name: GetJobByUniqueKey :one
+SELECT id, kind, payload, status, unique_key, attempts, max_attempts, run_at, locked_at, locked_by, last_error, finished_at, created_at, updated_at FROM jobs WHERE unique_key = $1 AND status IN ('pending', 'running')
+`
+
+func (q *Queries) GetJobByUniqueKey(ctx context.Context, uniqueKey pgtype.Text) (Job, error) {
+	row := q.db.QueryRow(ctx, getJobByUniqueKey, uniqueKey)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.UniqueKey,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LockedBy,
+		&i.LastError,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const killJob = `-- name: KillJob :execrows
+UPDATE jobs
+SET status = 'dead', last_error = $1, locked_at = NULL, locked_by = NULL, finished_at = now(), updated_at = now()
+WHERE id = $2 AND status = 'running' AND locked_by = $3::text
+`
+
+type KillJobParams struct {
+	LastError pgtype.Text `json:"last_error"`
+	ID        int64       `json:"id"`
+	WorkerID  string      `json:"worker_id"`
+}
+
+func (q *Queries) KillJob(ctx context.Context, arg KillJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, killJob, arg.LastError, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeFinishedJobs = `-- name: PurgeFinishedJobs :execrows
+DELETE FROM jobs WHERE status IN ('succeeded', 'dead') AND finished_at < $1
+`
+
+func (q *Queries) PurgeFinishedJobs(ctx context.Context, finishedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeFinishedJobs, finishedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rescueStaleJobs = `-- name: RescueStaleJobs :execrows
+UPDATE jobs
+SET status = CASE WHEN attempts >= max_attempts THEN 'dead' ELSE 'pending' END,
+    finished_at = CASE WHEN attempts >= max_attempts THEN now() END,
+    locked_at = NULL, locked_by = NULL, last_error = 'worker lock expired', updated_at = now()
+WHERE status = 'running' AND locked_at < $1
+`
+
+// Jobs whose worker died are retried, unless that run was their last attempt
+func (q *Queries) RescueStaleJobs(ctx context.Context, staleBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, rescueStaleJobs, staleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const retryJob = `-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'pending', run_at = $1, last_error = $2, locked_at = NULL, locked_by = NULL, updated_at = now()
+WHERE id = $3 AND status = 'running' AND locked_by = $4::text
+`
+
+type RetryJobParams struct {
+	RunAt     pgtype.Timestamptz `json:"run_at"`
+	LastError pgtype.Text        `json:"last_error"`
+	ID        int64              `json:"id"`
+	WorkerID  string             `json:"worker_id"`
+}
+
+func (q *Queries) RetryJob(ctx context.Context, arg RetryJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, retryJob,
+		arg.RunAt,
+		arg.LastError,
+		arg.ID,
+		arg.WorkerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -12,22 +12,104 @@ import (
 )
 
 const createLabubu = `-- name: CreateLabubu :one
-INSERT INTO labubu (text) VALUES ($1) RETURNING id, text
+INSERT INTO labubu (text, owner_id) VALUES ($1, $2) RETURNING id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at
 `
 
-func (q *Queries) CreateLabubu(ctx context.Context, text pgtype.Text) (Labubu, error) {
-	row := q.db.QueryRow(ctx, createLabubu, text)
+type CreateLabubuParams struct {
+	Text    pgtype.Text `json:"text"`
+	OwnerID pgtype.Int4 `json:"owner_id"`
+}
+
+func (q *Queries) CreateLabubu(ctx context.Context, arg CreateLabubuParams) (Labubu, error) {
+	row := q.db.QueryRow(ctx, createLabubu, arg.Text, arg.OwnerID)
 	var i Labubu
-	err := row.Scan(&i.ID, &i.Text)
+	err := row.Scan(
+		&i.ID,
+		&i.Text,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.PublishedAt,
+	)
 	return i, err
 }
 
 const getAllLabubu = `-- name: GetAllLabubu :many
-SELECT id, text FROM labubu ORDER BY id
+SELECT id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at FROM labubu
+WHERE owner_id = $1 AND deleted_at IS NULL
+  AND (cardinality($2::text[]) = 0 OR status = ANY($2::text[]))
+ORDER BY id
+`
+
+type GetAllLabubuParams struct {
+	OwnerID  pgtype.Int4 `json:"owner_id"`
+	Statuses []string    `json:"statuses"`
+}
+
+// An empty statuses matches every status
+func (q *Queries) GetAllLabubu(ctx context.Context, arg GetAllLabubuParams) ([]Labubu, error) {
+	rows, err := q.db.Query(ctx, getAllLabubu, arg.OwnerID, arg.Statuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Labubu{}
+	for rows.Next() {
+		var i Labubu
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLabubuByID = `-- name: GetLabubuByID :one
+SELECT id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at FROM labubu WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetLabubuByID(ctx context.Context, id int32) (Labubu, error) {
+	row := q.db.QueryRow(ctx, getLabubuByID, id)
+	var i Labubu
+	err := row.Scan(
+		&i.ID,
+		&i.Text,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const getLabubuByIDs = `-- name: GetLabubuByIDs :many
+SELECT id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at FROM labubu WHERE id = ANY($1::int[]) AND deleted_at IS NULL
 `
 
-func (q *Queries) GetAllLabubu(ctx context.Context) ([]Labubu, error) {
-	rows, err := q.db.Query(ctx, getAllLabubu)
+func (q *Queries) GetLabubuByIDs(ctx context.Context, ids []int32) ([]Labubu, error) {
+	rows, err := q.db.Query(ctx, getLabubuByIDs, ids)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +117,18 @@ func (q *Queries) GetAllLabubu(ctx context.Context) ([]Labubu, error) {
 	items := []Labubu{}
 	for rows.Next() {
 		var i Labubu
-		if err := rows.Scan(&i.ID, &i.Text); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.PublishedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -45,3 +138,252 @@ func (q *Queries) GetAllLabubu(ctx context.Context) ([]Labubu, error) {
 	}
 	return items, nil
 }
+
+const getOwnedLabubu = `-- name: GetOwnedLabubu :one
+SELECT id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at FROM labubu WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+`
+
+type GetOwnedLabubuParams struct {
+	ID      int32       `json:"id"`
+	OwnerID pgtype.Int4 `json:"owner_id"`
+}
+
+func (q *Queries) GetOwnedLabubu(ctx context.Context, arg GetOwnedLabubuParams) (Labubu, error) {
+	row := q.db.QueryRow(ctx, getOwnedLabubu, arg.ID, arg.OwnerID)
+	var i Labubu
+	err := row.Scan(
+		&i.ID,
+		&i.Text,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const listDueLabubu = `-- name: ListDueLabubu :many
+SELECT id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at FROM labubu
+WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+ORDER BY publish_at, id
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type ListDueLabubuParams struct {
+	DueAt    pgtype.Timestamptz `json:"due_at"`
+	RowLimit int32              `json:"row_limit"`
+}
+
+// Scheduled labubu whose time has come; rows another publisher holds are skipped
+func (q *Queries) ListDueLabubu(ctx context.Context, arg ListDueLabubuParams) ([]Labubu, error) {
+	rows, err := q.db.Query(ctx, listDueLabubu, arg.DueAt, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Labubu{}
+	for rows.Next() {
+		var i Labubu
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLabubuTransitions = `-- name: ListLabubuTransitions :many
+SELECT id, labubu_id, from_status, to_status, actor_id, publish_at, created_at FROM labubu_transitions WHERE labubu_id = $1 ORDER BY id
+`
+
+func (q *Queries) ListLabubuTransitions(ctx context.Context, labubuID int32) ([]LabubuTransition, error) {
+	rows, err := q.db.Query(ctx, listLabubuTransitions, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LabubuTransition{}
+	for rows.Next() {
+		var i LabubuTransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.LabubuID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.ActorID,
+			&i.PublishAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedLabubu = `-- name: PurgeDeletedLabubu :execrows
+DELETE FROM labubu WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedLabubu(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedLabubu, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const recordLabubuTransition = `-- name: RecordLabubuTransition :exec
+INSERT INTO labubu_transitions (labubu_id, from_status, to_status, actor_id, publish_at)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type RecordLabubuTransitionParams struct {
+	LabubuID   int32              `json:"labubu_id"`
+	FromStatus pgtype.Text        `json:"from_status"`
+	ToStatus   string             `json:"to_status"`
+	ActorID    pgtype.Int4        `json:"actor_id"`
+	PublishAt  pgtype.Timestamptz `json:"publish_at"`
+}
+
+func (q *Queries) RecordLabubuTransition(ctx context.Context, arg RecordLabubuTransitionParams) error {
+	_, err := q.db.Exec(ctx, recordLabubuTransition,
+		arg.LabubuID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.ActorID,
+		arg.PublishAt,
+	)
+	return err
+}
+
+const setLabubuStatus = `-- name: SetLabubuStatus :one
+UPDATE labubu
+SET status = $1::text,
+    publish_at = $2,
+    published_at = CASE WHEN $1::text = 'published' THEN now() ELSE published_at END,
+    version = version + 1,
+    updated_at = now()
+WHERE id = $3 AND status = $4::text AND deleted_at IS NULL
+RETURNING id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at
+`
+
+type SetLabubuStatusParams struct {
+	ToStatus   string             `json:"to_status"`
+	PublishAt  pgtype.Timestamptz `json:"publish_at"`
+	ID         int32              `json:"id"`
+	FromStatus string             `json:"from_status"`
+}
+
+// Moves a labubu from from_status only, so concurrent changes cannot both
+// apply, and bumps the version so edits based on the old status are rejected
+func (q *Queries) SetLabubuStatus(ctx context.Context, arg SetLabubuStatusParams) (Labubu, error) {
+	row := q.db.QueryRow(ctx, setLabubuStatus,
+		arg.ToStatus,
+		arg.PublishAt,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i Labubu
+	err := row.Scan(
+		&i.ID,
+		&i.Text,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const softDeleteLabubu = `-- name: SoftDeleteLabubu :one
+UPDATE labubu SET deleted_at = now(), version = version + 1, updated_at = now()
+WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+RETURNING id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at
+`
+
+type SoftDeleteLabubuParams struct {
+	ID      int32       `json:"id"`
+	OwnerID pgtype.Int4 `json:"owner_id"`
+}
+
+func (q *Queries) SoftDeleteLabubu(ctx context.Context, arg SoftDeleteLabubuParams) (Labubu, error) {
+	row := q.db.QueryRow(ctx, softDeleteLabubu, arg.ID, arg.OwnerID)
+	var i Labubu
+	err := row.Scan(
+		&i.ID,
+		&i.Text,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const updateLabubu = `-- name: UpdateLabubu :one
+UPDATE labubu
+SET text = $3, version = version + 1, updated_at = now()
+WHERE id = $1 AND owner_id = $2 AND version = $4 AND deleted_at IS NULL
+RETURNING id, text, owner_id, created_at, deleted_at, version, updated_at, status, publish_at, published_at
+`
+
+type UpdateLabubuParams struct {
+	ID      int32       `json:"id"`
+	OwnerID pgtype.Int4 `json:"owner_id"`
+	Text    pgtype.Text `json:"text"`
+	Version int32       `json:"version"`
+}
+
+func (q *Queries) UpdateLabubu(ctx context.Context, arg UpdateLabubuParams) (Labubu, error) {
+	row := q.db.QueryRow(ctx, updateLabubu,
+		arg.ID,
+		arg.OwnerID,
+		arg.Text,
+		arg.Version,
+	)
+	var i Labubu
+	err := row.Scan(
+		&i.ID,
+		&i.Text,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.PublishedAt,
+	)
+	return i, err
+}
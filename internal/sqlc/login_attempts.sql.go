@@ -0,0 +1,140 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: login_attempts.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const blockLoginAttempts = `-- name: BlockLoginAttempts :exec
+UPDATE login_attempts SET next_attempt_at = $2, locked_until = $3 WHERE email = $1
+`
+
+type BlockLoginAttemptsParams struct {
+	Email         string             `json:"email"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LockedUntil   pgtype.Timestamptz `json:"locked_until"`
+}
+
+func (q *Queries) BlockLoginAttempts(ctx context.Context, arg BlockLoginAttemptsParams) error {
+	_, err := q.db.Exec(ctx, blockLoginAttempts, arg.Email, arg.NextAttemptAt, arg.LockedUntil)
+	return err
+}
+
+const deleteLoginAttempts = `-- name: DeleteLoginAttempts :execrows
+DELETE FROM login_attempts WHERE email = $1
+`
+
+func (q *Queries) DeleteLoginAttempts(ctx context.Context, email string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteLoginAttempts, email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStaleLoginAttempts = `-- name: DeleteStaleLoginAttempts :execrows
+DELETE FROM login_attempts
+WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until < now())
+`
+
+func (q *Queries) DeleteStaleLoginAttempts(ctx context.Context, lastFailedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleLoginAttempts, lastFailedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getLoginAttempt = `-- name: GetLoginAttempt :one
+SELECT email, failures, next_attempt_at, locked_until, last_failed_at FROM login_attempts WHERE email = $1
+`
+
+func (q *Queries) GetLoginAttempt(ctx context.Context, email string) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, getLoginAttempt, email)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Email,
+		&i.Failures,
+		&i.NextAttemptAt,
+		&i.LockedUntil,
+		&i.LastFailedAt,
+	)
+	return i, err
+}
+
+const listLockedLogins = `-- name: ListLockedLogins :many
+SELECT email, failures, next_attempt_at, locked_until, last_failed_at FROM login_attempts WHERE locked_until > now() ORDER BY locked_until
+`
+
+func (q *Queries) ListLockedLogins(ctx context.Context) ([]LoginAttempt, error) {
+	rows, err := q.db.Query(ctx, listLockedLogins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LoginAttempt{}
+	for rows.Next() {
+		var i LoginAttempt
+		if err := rows.Scan(
+			&i.Email,
+			&i.Failures,
+			&i.NextAttemptAt,
+			&i.LockedUntil,
+			&i.LastFailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reserveLoginAttempt = `-- name: ReserveLoginAttempt :one
+INSERT INTO login_attempts (email, failures, last_failed_at, next_attempt_at)
+VALUES ($1, 1, now(), $2)
+ON CONFLICT (email) DO UPDATE SET
+    failures = CASE
+        WHEN login_attempts.last_failed_at < $3
+            AND (login_attempts.locked_until IS NULL OR login_attempts.locked_until < $3)
+        THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    next_attempt_at = $2,
+    locked_until = NULL,
+    last_failed_at = now()
+WHERE COALESCE(GREATEST(login_attempts.next_attempt_at, login_attempts.locked_until), '-infinity') <= now()
+RETURNING email, failures, next_attempt_at, locked_until, last_failed_at
+`
+
+type ReserveLoginAttemptParams struct {
+	Email       string             `json:"email"`
+	HoldUntil   pgtype.Timestamptz `json:"hold_until"`
+	ResetBefore pgtype.Timestamptz `json:"reset_before"`
+}
+
+// Counts an attempt as failed before its password is compared and holds the
+// email until hold_until, so parallel guesses cannot slip past a delay. No row
+// is returned while the email is delayed or locked. Counting starts over once
+// reset_before has passed since the last failure and the end of any lockout;
+// an expired lockout alone keeps the count, so the next failure locks again.
+func (q *Queries) ReserveLoginAttempt(ctx context.Context, arg ReserveLoginAttemptParams) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, reserveLoginAttempt, arg.Email, arg.HoldUntil, arg.ResetBefore)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Email,
+		&i.Failures,
+		&i.NextAttemptAt,
+		&i.LockedUntil,
+		&i.LastFailedAt,
+	)
+	return i, err
+}
@@ -8,7 +8,232 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Attachment struct {
+	ID          int64              `json:"id"`
+	LabubuID    int32              `json:"labubu_id"`
+	UploaderID  pgtype.Int4        `json:"uploader_id"`
+	ObjectKey   string             `json:"object_key"`
+	Filename    string             `json:"filename"`
+	ContentType string             `json:"content_type"`
+	Size        int64              `json:"size"`
+	Sha256      pgtype.Text        `json:"sha256"`
+	Status      string             `json:"status"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Width       pgtype.Int4        `json:"width"`
+	Height      pgtype.Int4        `json:"height"`
+	ImageStatus pgtype.Text        `json:"image_status"`
+	ImageError  pgtype.Text        `json:"image_error"`
+}
+
+type AttachmentVariant struct {
+	ID           int64              `json:"id"`
+	AttachmentID int64              `json:"attachment_id"`
+	Name         string             `json:"name"`
+	ObjectKey    string             `json:"object_key"`
+	ContentType  string             `json:"content_type"`
+	Width        int32              `json:"width"`
+	Height       int32              `json:"height"`
+	Size         int64              `json:"size"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type Collection struct {
+	ID        int32              `json:"id"`
+	OwnerID   int32              `json:"owner_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CollectionInvite struct {
+	CollectionID int32              `json:"collection_id"`
+	Email        string             `json:"email"`
+	Role         string             `json:"role"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type CollectionItem struct {
+	CollectionID int32              `json:"collection_id"`
+	LabubuID     int32              `json:"labubu_id"`
+	Position     int32              `json:"position"`
+	AddedBy      pgtype.Int4        `json:"added_by"`
+	AddedAt      pgtype.Timestamptz `json:"added_at"`
+}
+
+type CollectionShare struct {
+	CollectionID int32              `json:"collection_id"`
+	UserID       int32              `json:"user_id"`
+	Role         string             `json:"role"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type Job struct {
+	ID          int64              `json:"id"`
+	Kind        string             `json:"kind"`
+	Payload     []byte             `json:"payload"`
+	Status      string             `json:"status"`
+	UniqueKey   pgtype.Text        `json:"unique_key"`
+	Attempts    int32              `json:"attempts"`
+	MaxAttempts int32              `json:"max_attempts"`
+	RunAt       pgtype.Timestamptz `json:"run_at"`
+	LockedAt    pgtype.Timestamptz `json:"locked_at"`
+	LockedBy    pgtype.Text        `json:"locked_by"`
+	LastError   pgtype.Text        `json:"last_error"`
+	FinishedAt  pgtype.Timestamptz `json:"finished_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Labubu struct {
-	ID   int32       `json:"id"`
-	Text pgtype.Text `json:"text"`
+	ID          int32              `json:"id"`
+	Text        pgtype.Text        `json:"text"`
+	OwnerID     pgtype.Int4        `json:"owner_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	Version     int32              `json:"version"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Status      string             `json:"status"`
+	PublishAt   pgtype.Timestamptz `json:"publish_at"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+}
+
+type LabubuTag struct {
+	LabubuID int32 `json:"labubu_id"`
+	TagID    int32 `json:"tag_id"`
+}
+
+type LabubuTransition struct {
+	ID         int64              `json:"id"`
+	LabubuID   int32              `json:"labubu_id"`
+	FromStatus pgtype.Text        `json:"from_status"`
+	ToStatus   string             `json:"to_status"`
+	ActorID    pgtype.Int4        `json:"actor_id"`
+	PublishAt  pgtype.Timestamptz `json:"publish_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	Email         string             `json:"email"`
+	Failures      int32              `json:"failures"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LockedUntil   pgtype.Timestamptz `json:"locked_until"`
+	LastFailedAt  pgtype.Timestamptz `json:"last_failed_at"`
+}
+
+type Outbox struct {
+	ID            int64              `json:"id"`
+	AggregateType string             `json:"aggregate_type"`
+	AggregateID   string             `json:"aggregate_id"`
+	EventType     string             `json:"event_type"`
+	Payload       []byte             `json:"payload"`
+	Attempts      int32              `json:"attempts"`
+	LastError     pgtype.Text        `json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	PublishedAt   pgtype.Timestamptz `json:"published_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type Report struct {
+	ID          int64              `json:"id"`
+	Kind        string             `json:"kind"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+	Data        []byte             `json:"data"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type Schedule struct {
+	Name      string             `json:"name"`
+	LastRunAt pgtype.Timestamptz `json:"last_run_at"`
+	NextRunAt pgtype.Timestamptz `json:"next_run_at"`
+	LastJobID pgtype.Int8        `json:"last_job_id"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Session struct {
+	ID               int64              `json:"id"`
+	UserID           int32              `json:"user_id"`
+	RefreshTokenHash string             `json:"refresh_token_hash"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type ShareLink struct {
+	ID           int64              `json:"id"`
+	LabubuID     int32              `json:"labubu_id"`
+	OwnerID      int32              `json:"owner_id"`
+	TokenHash    string             `json:"token_hash"`
+	PasswordHash pgtype.Text        `json:"password_hash"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	MaxViews     pgtype.Int4        `json:"max_views"`
+	Views        int32              `json:"views"`
+	RevokedAt    pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type ShareLinkAccess struct {
+	ID         int64              `json:"id"`
+	LinkID     int64              `json:"link_id"`
+	Outcome    string             `json:"outcome"`
+	Ip         string             `json:"ip"`
+	UserAgent  string             `json:"user_agent"`
+	AccessedAt pgtype.Timestamptz `json:"accessed_at"`
+}
+
+type Tag struct {
+	ID        int32              `json:"id"`
+	OwnerID   int32              `json:"owner_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type User struct {
+	ID              int32              `json:"id"`
+	Email           string             `json:"email"`
+	PasswordHash    string             `json:"password_hash"`
+	DisabledAt      pgtype.Timestamptz `json:"disabled_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+}
+
+type UserToken struct {
+	ID        int64              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	Purpose   string             `json:"purpose"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64              `json:"id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	EventID        int64              `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	ResponseBody   pgtype.Text        `json:"response_body"`
+	LastError      pgtype.Text        `json:"last_error"`
+	LastAttemptAt  pgtype.Timestamptz `json:"last_attempt_at"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	ID                  int64              `json:"id"`
+	Url                 string             `json:"url"`
+	Secret              string             `json:"secret"`
+	Events              []string           `json:"events"`
+	Description         string             `json:"description"`
+	Enabled             bool               `json:"enabled"`
+	ConsecutiveFailures int32              `json:"consecutive_failures"`
+	DisabledReason      pgtype.Text        `json:"disabled_reason"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 }
@@ -0,0 +1,224 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: outbox.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const appendOutboxEvent = `-- name: AppendOutboxEvent :one
+INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, next_attempt_at, published_at, created_at
+`
+
+type AppendOutboxEventParams struct {
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	EventType     string `json:"event_type"`
+	Payload       []byte `json:"payload"`
+}
+
+func (q *Queries) AppendOutboxEvent(ctx context.Context, arg AppendOutboxEventParams) (Outbox, error) {
+	row := q.db.QueryRow(ctx, appendOutboxEvent,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.AggregateType,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.PublishedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deletePublishedOutboxEvents = `-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox WHERE published_at < $1
+`
+
+func (q *Queries) DeletePublishedOutboxEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePublishedOutboxEvents, publishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getOldestOutboxEventID = `-- name: GetOldestOutboxEventID :one
+SELECT COALESCE(MIN(id), 0)::bigint FROM outbox
+`
+
+func (q *Queries) GetOldestOutboxEventID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getOldestOutboxEventID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const getOutboxEvents = `-- name: GetOutboxEvents :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, next_attempt_at, published_at, created_at FROM outbox WHERE id = ANY($1::bigint[]) ORDER BY id
+`
+
+func (q *Queries) GetOutboxEvents(ctx context.Context, ids []int64) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, getOutboxEvents, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDueOutboxEvents = `-- name: ListDueOutboxEvents :many
+SELECT o.id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload, o.attempts, o.last_error, o.next_attempt_at, o.published_at, o.created_at FROM outbox o
+WHERE o.published_at IS NULL
+  AND o.next_attempt_at <= now()
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox p
+      WHERE p.published_at IS NULL
+        AND p.aggregate_type = o.aggregate_type
+        AND p.aggregate_id = o.aggregate_id
+        AND p.id < o.id
+        AND p.next_attempt_at > now()
+  )
+ORDER BY o.id
+LIMIT $1
+`
+
+// Events whose aggregate has an earlier event waiting for a retry are held
+// back so that each aggregate is delivered in order.
+func (q *Queries) ListDueOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, listDueOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOutboxEventsAfter = `-- name: ListOutboxEventsAfter :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, next_attempt_at, published_at, created_at FROM outbox
+WHERE id > $1 AND ($3::text = '' OR aggregate_type = $3)
+ORDER BY id
+LIMIT $2
+`
+
+type ListOutboxEventsAfterParams struct {
+	ID            int64  `json:"id"`
+	Limit         int32  `json:"limit"`
+	AggregateType string `json:"aggregate_type"`
+}
+
+// An empty aggregate type lists events of every type
+func (q *Queries) ListOutboxEventsAfter(ctx context.Context, arg ListOutboxEventsAfterParams) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, listOutboxEventsAfter, arg.ID, arg.Limit, arg.AggregateType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1
+`
+
+type MarkOutboxEventFailedParams struct {
+	ID            int64              `json:"id"`
+	LastError     pgtype.Text        `json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error {
+	_, err := q.db.Exec(ctx, markOutboxEventFailed, arg.ID, arg.LastError, arg.NextAttemptAt)
+	return err
+}
+
+const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :exec
+UPDATE outbox SET published_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markOutboxEventPublished, id)
+	return err
+}
@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: reports.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getUsageCounts = `-- name: GetUsageCounts :one
+SELECT
+    (SELECT count(*) FROM users WHERE users.created_at >= $1 AND users.created_at < $2) AS new_users,
+    (SELECT count(*) FROM labubu WHERE labubu.created_at >= $1 AND labubu.created_at < $2) AS new_labubu,
+    (SELECT count(DISTINCT user_id) FROM sessions WHERE sessions.created_at >= $1 AND sessions.created_at < $2) AS active_users
+`
+
+type GetUsageCountsParams struct {
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+}
+
+type GetUsageCountsRow struct {
+	NewUsers    int64 `json:"new_users"`
+	NewLabubu   int64 `json:"new_labubu"`
+	ActiveUsers int64 `json:"active_users"`
+}
+
+func (q *Queries) GetUsageCounts(ctx context.Context, arg GetUsageCountsParams) (GetUsageCountsRow, error) {
+	row := q.db.QueryRow(ctx, getUsageCounts, arg.PeriodStart, arg.PeriodEnd)
+	var i GetUsageCountsRow
+	err := row.Scan(&i.NewUsers, &i.NewLabubu, &i.ActiveUsers)
+	return i, err
+}
+
+const upsertReport = `-- name: UpsertReport :one
+INSERT INTO reports (kind, period_start, period_end, data)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (kind, period_start) DO UPDATE SET period_end = EXCLUDED.period_end, data = EXCLUDED.data, created_at = now()
+RETURNING id, kind, period_start, period_end, data, created_at
+`
+
+type UpsertReportParams struct {
+	Kind        string             `json:"kind"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+	Data        []byte             `json:"data"`
+}
+
+func (q *Queries) UpsertReport(ctx context.Context, arg UpsertReportParams) (Report, error) {
+	row := q.db.QueryRow(ctx, upsertReport,
+		arg.Kind,
+		arg.PeriodStart,
+		arg.PeriodEnd,
+		arg.Data,
+	)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.Data,
+		&i.CreatedAt,
+	)
+	return i, err
+}
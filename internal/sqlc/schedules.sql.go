@@ -0,0 +1,101 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: schedules.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getScheduleRun = `-- name: GetScheduleRun :one
+SELECT name, last_run_at, next_run_at, last_job_id, updated_at FROM schedules WHERE name = $1
+`
+
+func (q *Queries) GetScheduleRun(ctx context.Context, name string) (Schedule, error) {
+	row := q.db.QueryRow(ctx, getScheduleRun, name)
+	var i Schedule
+	err := row.Scan(
+		&i.Name,
+		&i.LastRunAt,
+		&i.NextRunAt,
+		&i.LastJobID,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listScheduleRuns = `-- name: ListScheduleRuns :many
+SELECT s.name, s.last_run_at, s.next_run_at, s.last_job_id, j.status AS last_status, j.last_error, j.finished_at AS last_finished_at
+FROM schedules s
+LEFT JOIN jobs j ON j.id = s.last_job_id
+ORDER BY s.name
+`
+
+type ListScheduleRunsRow struct {
+	Name           string             `json:"name"`
+	LastRunAt      pgtype.Timestamptz `json:"last_run_at"`
+	NextRunAt      pgtype.Timestamptz `json:"next_run_at"`
+	LastJobID      pgtype.Int8        `json:"last_job_id"`
+	LastStatus     pgtype.Text        `json:"last_status"`
+	LastError      pgtype.Text        `json:"last_error"`
+	LastFinishedAt pgtype.Timestamptz `json:"last_finished_at"`
+}
+
+func (q *Queries) ListScheduleRuns(ctx context.Context) ([]ListScheduleRunsRow, error) {
+	rows, err := q.db.Query(ctx, listScheduleRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListScheduleRunsRow{}
+	for rows.Next() {
+		var i ListScheduleRunsRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.LastRunAt,
+			&i.NextRunAt,
+			&i.LastJobID,
+			&i.LastStatus,
+			&i.LastError,
+			&i.LastFinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertScheduleRun = `-- name: UpsertScheduleRun :exec
+INSERT INTO schedules (name, last_run_at, next_run_at, last_job_id)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (name) DO UPDATE
+SET last_run_at = COALESCE(EXCLUDED.last_run_at, schedules.last_run_at),
+    next_run_at = EXCLUDED.next_run_at,
+    last_job_id = COALESCE(EXCLUDED.last_job_id, schedules.last_job_id),
+    updated_at = now()
+`
+
+type UpsertScheduleRunParams struct {
+	Name      string             `json:"name"`
+	LastRunAt pgtype.Timestamptz `json:"last_run_at"`
+	NextRunAt pgtype.Timestamptz `json:"next_run_at"`
+	LastJobID pgtype.Int8        `json:"last_job_id"`
+}
+
+func (q *Queries) UpsertScheduleRun(ctx context.Context, arg UpsertScheduleRunParams) error {
+	_, err := q.db.Exec(ctx, upsertScheduleRun,
+		arg.Name,
+		arg.LastRunAt,
+		arg.NextRunAt,
+		arg.LastJobID,
+	)
+	return err
+}
@@ -0,0 +1,251 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: share_links.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const consumeShareLinkView = `-- name: ConsumeShareLinkView :one
+UPDATE share_links SET views = views + 1
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND expires_at > now()
+  AND (max_views IS NULL OR views < max_views)
+RETURNING id, labubu_id, owner_id, token_hash, password_hash, expires_at, max_views, views, revoked_at, created_at
+`
+
+// Counts a view; no row when the link was revoked, has expired or has no views left
+func (q *Queries) ConsumeShareLinkView(ctx context.Context, id int64) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, consumeShareLinkView, id)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.OwnerID,
+		&i.TokenHash,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.MaxViews,
+		&i.Views,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createShareLink = `-- name: CreateShareLink :one
+INSERT INTO share_links (labubu_id, owner_id, token_hash, password_hash, expires_at, max_views)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, labubu_id, owner_id, token_hash, password_hash, expires_at, max_views, views, revoked_at, created_at
+`
+
+type CreateShareLinkParams struct {
+	LabubuID     int32              `json:"labubu_id"`
+	OwnerID      int32              `json:"owner_id"`
+	TokenHash    string             `json:"token_hash"`
+	PasswordHash pgtype.Text        `json:"password_hash"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	MaxViews     pgtype.Int4        `json:"max_views"`
+}
+
+func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, createShareLink,
+		arg.LabubuID,
+		arg.OwnerID,
+		arg.TokenHash,
+		arg.PasswordHash,
+		arg.ExpiresAt,
+		arg.MaxViews,
+	)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.OwnerID,
+		&i.TokenHash,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.MaxViews,
+		&i.Views,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getShareLink = `-- name: GetShareLink :one
+SELECT id, labubu_id, owner_id, token_hash, password_hash, expires_at, max_views, views, revoked_at, created_at FROM share_links WHERE id = $1 AND labubu_id = $2
+`
+
+type GetShareLinkParams struct {
+	ID       int64 `json:"id"`
+	LabubuID int32 `json:"labubu_id"`
+}
+
+func (q *Queries) GetShareLink(ctx context.Context, arg GetShareLinkParams) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, getShareLink, arg.ID, arg.LabubuID)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.OwnerID,
+		&i.TokenHash,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.MaxViews,
+		&i.Views,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getShareLinkByToken = `-- name: GetShareLinkByToken :one
+SELECT id, labubu_id, owner_id, token_hash, password_hash, expires_at, max_views, views, revoked_at, created_at FROM share_links WHERE token_hash = $1
+`
+
+func (q *Queries) GetShareLinkByToken(ctx context.Context, tokenHash string) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, getShareLinkByToken, tokenHash)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.OwnerID,
+		&i.TokenHash,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.MaxViews,
+		&i.Views,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listShareLinkAccesses = `-- name: ListShareLinkAccesses :many
+SELECT id, link_id, outcome, ip, user_agent, accessed_at FROM share_link_accesses WHERE link_id = $1 ORDER BY id DESC LIMIT $2
+`
+
+type ListShareLinkAccessesParams struct {
+	LinkID int64 `json:"link_id"`
+	Limit  int32 `json:"limit"`
+}
+
+func (q *Queries) ListShareLinkAccesses(ctx context.Context, arg ListShareLinkAccessesParams) ([]ShareLinkAccess, error) {
+	rows, err := q.db.Query(ctx, listShareLinkAccesses, arg.LinkID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ShareLinkAccess{}
+	for rows.Next() {
+		var i ShareLinkAccess
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkID,
+			&i.Outcome,
+			&i.Ip,
+			&i.UserAgent,
+			&i.AccessedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShareLinks = `-- name: ListShareLinks :many
+SELECT id, labubu_id, owner_id, token_hash, password_hash, expires_at, max_views, views, revoked_at, created_at FROM share_links WHERE labubu_id = $1 ORDER BY id DESC
+`
+
+func (q *Queries) ListShareLinks(ctx context.Context, labubuID int32) ([]ShareLink, error) {
+	rows, err := q.db.Query(ctx, listShareLinks, labubuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ShareLink{}
+	for rows.Next() {
+		var i ShareLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.LabubuID,
+			&i.OwnerID,
+			&i.TokenHash,
+			&i.PasswordHash,
+			&i.ExpiresAt,
+			&i.MaxViews,
+			&i.Views,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordShareLinkAccess = `-- name: RecordShareLinkAccess :exec
+INSERT INTO share_link_accesses (link_id, outcome, ip, user_agent) VALUES ($1, $2, $3, $4)
+`
+
+type RecordShareLinkAccessParams struct {
+	LinkID    int64  `json:"link_id"`
+	Outcome   string `json:"outcome"`
+	Ip        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+}
+
+func (q *Queries) RecordShareLinkAccess(ctx context.Context, arg RecordShareLinkAccessParams) error {
+	_, err := q.db.Exec(ctx, recordShareLinkAccess,
+		arg.LinkID,
+		arg.Outcome,
+		arg.Ip,
+		arg.UserAgent,
+	)
+	return err
+}
+
+const revokeShareLink = `-- name: RevokeShareLink :one
+UPDATE share_links SET revoked_at = coalesce(revoked_at, now())
+WHERE id = $1 AND labubu_id = $2
+RETURNING id, labubu_id, owner_id, token_hash, password_hash, expires_at, max_views, views, revoked_at, created_at
+`
+
+type RevokeShareLinkParams struct {
+	ID       int64 `json:"id"`
+	LabubuID int32 `json:"labubu_id"`
+}
+
+// Revoking twice keeps the time of the first
+func (q *Queries) RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (ShareLink, error) {
+	row := q.db.QueryRow(ctx, revokeShareLink, arg.ID, arg.LabubuID)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.LabubuID,
+		&i.OwnerID,
+		&i.TokenHash,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.MaxViews,
+		&i.Views,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,289 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: tags.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addLabubuTags = `-- name: AddLabubuTags :exec
+INSERT INTO labubu_tags (labubu_id, tag_id)
+SELECT $1, unnest($2::int[])
+ON CONFLICT DO NOTHING
+`
+
+type AddLabubuTagsParams struct {
+	LabubuID int32   `json:"labubu_id"`
+	TagIds   []int32 `json:"tag_ids"`
+}
+
+func (q *Queries) AddLabubuTags(ctx context.Context, arg AddLabubuTagsParams) error {
+	_, err := q.db.Exec(ctx, addLabubuTags, arg.LabubuID, arg.TagIds)
+	return err
+}
+
+const deleteTag = `-- name: DeleteTag :execrows
+DELETE FROM tags WHERE id = $1 AND owner_id = $2
+`
+
+type DeleteTagParams struct {
+	ID      int32 `json:"id"`
+	OwnerID int32 `json:"owner_id"`
+}
+
+func (q *Queries) DeleteTag(ctx context.Context, arg DeleteTagParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTag, arg.ID, arg.OwnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUnusedTags = `-- name: DeleteUnusedTags :exec
+DELETE FROM tags t
+WHERE t.owner_id = $1 AND NOT EXISTS (SELECT 1 FROM labubu_tags lt WHERE lt.tag_id = t.id)
+`
+
+func (q *Queries) DeleteUnusedTags(ctx context.Context, ownerID int32) error {
+	_, err := q.db.Exec(ctx, deleteUnusedTags, ownerID)
+	return err
+}
+
+const ensureTags = `-- name: EnsureTags :many
+INSERT INTO tags (owner_id, name)
+SELECT $1, unnest($2::text[])
+ON CONFLICT (owner_id, name) DO UPDATE SET name = excluded.name
+RETURNING id
+`
+
+type EnsureTagsParams struct {
+	OwnerID int32    `json:"owner_id"`
+	Names   []string `json:"names"`
+}
+
+// The no-op update makes existing tags return their id too
+func (q *Queries) EnsureTags(ctx context.Context, arg EnsureTagsParams) ([]int32, error) {
+	rows, err := q.db.Query(ctx, ensureTags, arg.OwnerID, arg.Names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTag = `-- name: GetTag :one
+SELECT t.id, t.name, count(l.id) AS usage_count FROM tags t
+LEFT JOIN labubu_tags lt ON lt.tag_id = t.id
+LEFT JOIN labubu l ON l.id = lt.labubu_id AND l.deleted_at IS NULL
+WHERE t.id = $1 AND t.owner_id = $2
+GROUP BY t.id
+`
+
+type GetTagParams struct {
+	ID      int32 `json:"id"`
+	OwnerID int32 `json:"owner_id"`
+}
+
+type GetTagRow struct {
+	ID         int32  `json:"id"`
+	Name       string `json:"name"`
+	UsageCount int64  `json:"usage_count"`
+}
+
+func (q *Queries) GetTag(ctx context.Context, arg GetTagParams) (GetTagRow, error) {
+	row := q.db.QueryRow(ctx, getTag, arg.ID, arg.OwnerID)
+	var i GetTagRow
+	err := row.Scan(&i.ID, &i.Name, &i.UsageCount)
+	return i, err
+}
+
+const listLabubuByTags = `-- name: ListLabubuByTags :many
+SELECT l.id, l.text, l.owner_id, l.created_at, l.deleted_at, l.version, l.updated_at, l.status, l.publish_at, l.published_at FROM labubu l
+WHERE l.owner_id = $1 AND l.deleted_at IS NULL
+  AND (cardinality($2::text[]) = 0 OR l.status = ANY($2::text[]))
+  AND l.id IN (
+    SELECT lt.labubu_id FROM labubu_tags lt
+    JOIN tags t ON t.id = lt.tag_id
+    WHERE t.owner_id = $1 AND t.name = ANY($3::text[])
+    GROUP BY lt.labubu_id
+    HAVING count(*) >= $4::int
+  )
+ORDER BY l.id
+`
+
+type ListLabubuByTagsParams struct {
+	OwnerID    pgtype.Int4 `json:"owner_id"`
+	Statuses   []string    `json:"statuses"`
+	Names      []string    `json:"names"`
+	MinMatches int32       `json:"min_matches"`
+}
+
+// Labubu carrying at least min_matches of the given tags: 1 for any, all of them otherwise
+func (q *Queries) ListLabubuByTags(ctx context.Context, arg ListLabubuByTagsParams) ([]Labubu, error) {
+	rows, err := q.db.Query(ctx, listLabubuByTags,
+		arg.OwnerID,
+		arg.Statuses,
+		arg.Names,
+		arg.MinMatches,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Labubu{}
+	for rows.Next() {
+		var i Labubu
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLabubuTagNames = `-- name: ListLabubuTagNames :many
+SELECT lt.labubu_id, t.name FROM labubu_tags lt
+JOIN tags t ON t.id = lt.tag_id
+WHERE lt.labubu_id = ANY($1::int[])
+ORDER BY lt.labubu_id, t.name COLLATE "C"
+`
+
+type ListLabubuTagNamesRow struct {
+	LabubuID int32  `json:"labubu_id"`
+	Name     string `json:"name"`
+}
+
+func (q *Queries) ListLabubuTagNames(ctx context.Context, labubuIds []int32) ([]ListLabubuTagNamesRow, error) {
+	rows, err := q.db.Query(ctx, listLabubuTagNames, labubuIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLabubuTagNamesRow{}
+	for rows.Next() {
+		var i ListLabubuTagNamesRow
+		if err := rows.Scan(&i.LabubuID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTags = `-- name: ListTags :many
+SELECT t.id, t.name, count(*) AS usage_count FROM tags t
+JOIN labubu_tags lt ON lt.tag_id = t.id
+JOIN labubu l ON l.id = lt.labubu_id AND l.deleted_at IS NULL
+WHERE t.owner_id = $1
+GROUP BY t.id
+ORDER BY t.name
+`
+
+type ListTagsRow struct {
+	ID         int32  `json:"id"`
+	Name       string `json:"name"`
+	UsageCount int64  `json:"usage_count"`
+}
+
+// Counts only labubu that are not deleted; tags used by none are left out
+func (q *Queries) ListTags(ctx context.Context, ownerID int32) ([]ListTagsRow, error) {
+	rows, err := q.db.Query(ctx, listTags, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTagsRow{}
+	for rows.Next() {
+		var i ListTagsRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.UsageCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveTagLinks = `-- name: MoveTagLinks :exec
+INSERT INTO labubu_tags (labubu_id, tag_id)
+SELECT lt.labubu_id, $1::int FROM labubu_tags lt WHERE lt.tag_id = $2::int
+ON CONFLICT DO NOTHING
+`
+
+type MoveTagLinksParams struct {
+	TargetID int32 `json:"target_id"`
+	SourceID int32 `json:"source_id"`
+}
+
+// Labubu that already carry the target keep a single link
+func (q *Queries) MoveTagLinks(ctx context.Context, arg MoveTagLinksParams) error {
+	_, err := q.db.Exec(ctx, moveTagLinks, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const removeLabubuTagsExcept = `-- name: RemoveLabubuTagsExcept :exec
+DELETE FROM labubu_tags WHERE labubu_id = $1 AND tag_id <> ALL($2::int[])
+`
+
+type RemoveLabubuTagsExceptParams struct {
+	LabubuID int32   `json:"labubu_id"`
+	TagIds   []int32 `json:"tag_ids"`
+}
+
+func (q *Queries) RemoveLabubuTagsExcept(ctx context.Context, arg RemoveLabubuTagsExceptParams) error {
+	_, err := q.db.Exec(ctx, removeLabubuTagsExcept, arg.LabubuID, arg.TagIds)
+	return err
+}
+
+const renameTag = `-- name: RenameTag :execrows
+UPDATE tags SET name = $3 WHERE id = $1 AND owner_id = $2
+`
+
+type RenameTagParams struct {
+	ID      int32  `json:"id"`
+	OwnerID int32  `json:"owner_id"`
+	Name    string `json:"name"`
+}
+
+func (q *Queries) RenameTag(ctx context.Context, arg RenameTagParams) (int64, error) {
+	result, err := q.db.Exec(ctx, renameTag, arg.ID, arg.OwnerID, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,225 @@
+// Package tasks defines the built-in background jobs and the schedules that
+// enqueue them
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/internal/reports"
+	"github.com/abdurrahimagca/go-api-starter/internal/scheduler"
+	"github.com/abdurrahimagca/go-api-starter/internal/uow"
+)
+
+// Config holds retention periods and cron specs for the built-in tasks
+type Config struct {
+	SessionRetention   time.Duration
+	LabubuPurgeAfter   time.Duration
+	SessionCleanupSpec string
+	LabubuPurgeSpec    string
+	LabubuPublishSpec  string
+	UsageReportSpec    string
+	OutboxRetention    time.Duration
+	OutboxCleanupSpec  string
+	JobsRetention      time.Duration
+	JobsCleanupSpec    string
+}
+
+// PurgeSessions deletes sessions that expired or were revoked before the retention window
+type PurgeSessions struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (PurgeSessions) Kind() string { return "sessions.purge" }
+
+// PurgeDeletedLabubu removes soft-deleted labubu past the retention window
+type PurgeDeletedLabubu struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (PurgeDeletedLabubu) Kind() string { return "labubu.purge_deleted" }
+
+// PublishScheduledLabubu publishes the scheduled labubu that are due
+type PublishScheduledLabubu struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (PublishScheduledLabubu) Kind() string { return "labubu.publish_scheduled" }
+
+// publishBatchSize is how many labubu are published per transaction
+const publishBatchSize = 100
+
+// DailyUsageReport builds the usage report for the day before ScheduledAt
+type DailyUsageReport struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (DailyUsageReport) Kind() string { return "reports.daily_usage" }
+
+// PurgeOutbox deletes published outbox events past the retention window
+type PurgeOutbox struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (PurgeOutbox) Kind() string { return "outbox.purge_published" }
+
+// PurgeJobs deletes succeeded and dead jobs past the retention window
+type PurgeJobs struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+func (PurgeJobs) Kind() string { return "jobs.purge_finished" }
+
+// Register adds the task handlers to registry. Handlers that change labubu
+// run through tm, since those changes write outbox events.
+func Register(registry *jobs.Registry, svc uow.Services, tm uow.TxManager, events outbox.Repository, queue jobs.Repository, config Config) {
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args PurgeSessions) error {
+		n, err := svc.Auth.PurgeSessions(ctx, args.ScheduledAt.Add(-config.SessionRetention))
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged stale sessions", "count", n)
+
+		// Failed logins that are no longer delaying anyone go with them
+		n, err = svc.Auth.PurgeLoginAttempts(ctx, args.ScheduledAt.Add(-config.SessionRetention))
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged stale login attempts", "count", n)
+
+		// As are reset and verification tokens that were used or expired
+		n, err = svc.Auth.PurgeUserTokens(ctx, args.ScheduledAt.Add(-config.SessionRetention))
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged stale user tokens", "count", n)
+		return nil
+	})
+
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args PurgeDeletedLabubu) error {
+		deletedBefore := args.ScheduledAt.Add(-config.LabubuPurgeAfter)
+
+		// Attachments go first: their stored files are not removed with the rows
+		n, err := svc.Attachments.PurgeDeleted(ctx, deletedBefore)
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged attachments", "count", n)
+
+		n, err = svc.Labubu.PurgeDeleted(ctx, deletedBefore)
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged deleted labubu", "count", n)
+		return nil
+	})
+
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args PublishScheduledLabubu) error {
+		// Everything due by now is published, including what a skipped run left
+		now := time.Now()
+		total := 0
+		for {
+			var n int
+			err := tm.Do(ctx, func(ctx context.Context, svc uow.Services) (err error) {
+				n, err = svc.Labubu.PublishDue(ctx, now, publishBatchSize)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			total += n
+			if n < publishBatchSize {
+				break
+			}
+		}
+		slog.InfoContext(ctx, "Published scheduled labubu", "count", total)
+		return nil
+	})
+
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args DailyUsageReport) error {
+		report, err := svc.Reports.GenerateDailyUsage(ctx, args.ScheduledAt.Add(-24*time.Hour))
+		if err != nil {
+			return err
+		}
+		// The report itself is stored; the log only notes that it was made
+		var usage reports.Usage
+		if err := json.Unmarshal(report.Data, &usage); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Generated usage report",
+			"report_id", report.ID,
+			"period_start", report.PeriodStart,
+			"period_end", report.PeriodEnd,
+			"new_users", usage.NewUsers,
+			"new_labubu", usage.NewLabubu,
+			"active_users", usage.ActiveUsers,
+		)
+		return nil
+	})
+
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args PurgeOutbox) error {
+		n, err := events.DeletePublished(ctx, args.ScheduledAt.Add(-config.OutboxRetention))
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged published outbox events", "count", n)
+		return nil
+	})
+
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args PurgeJobs) error {
+		n, err := queue.PurgeFinished(ctx, args.ScheduledAt.Add(-config.JobsRetention))
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Purged finished jobs", "count", n)
+		return nil
+	})
+}
+
+// Schedules returns the recurring schedules for the built-in tasks
+func Schedules(config Config) []scheduler.Schedule {
+	return []scheduler.Schedule{
+		{
+			Name:   "session-cleanup",
+			Spec:   config.SessionCleanupSpec,
+			Missed: scheduler.MissedSkip,
+			Args:   func(at time.Time) jobs.Args { return PurgeSessions{ScheduledAt: at} },
+		},
+		{
+			Name:   "labubu-purge",
+			Spec:   config.LabubuPurgeSpec,
+			Missed: scheduler.MissedSkip,
+			Args:   func(at time.Time) jobs.Args { return PurgeDeletedLabubu{ScheduledAt: at} },
+		},
+		{
+			// Each run publishes everything due, so missed runs need no replay
+			Name:   "labubu-publish",
+			Spec:   config.LabubuPublishSpec,
+			Missed: scheduler.MissedSkip,
+			Args:   func(at time.Time) jobs.Args { return PublishScheduledLabubu{ScheduledAt: at} },
+		},
+		{
+			// Every day deserves a report, so missed days are replayed
+			Name:   "usage-report",
+			Spec:   config.UsageReportSpec,
+			Missed: scheduler.MissedCatchUp,
+			Args:   func(at time.Time) jobs.Args { return DailyUsageReport{ScheduledAt: at} },
+		},
+		{
+			Name:   "outbox-cleanup",
+			Spec:   config.OutboxCleanupSpec,
+			Missed: scheduler.MissedSkip,
+			Args:   func(at time.Time) jobs.Args { return PurgeOutbox{ScheduledAt: at} },
+		},
+		{
+			Name:   "jobs-cleanup",
+			Spec:   config.JobsCleanupSpec,
+			Missed: scheduler.MissedSkip,
+			Args:   func(at time.Time) jobs.Args { return PurgeJobs{ScheduledAt: at} },
+		},
+	}
+}
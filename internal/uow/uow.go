@@ -0,0 +1,218 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/attachments"
+	"github.com/abdurrahimagca/go-api-starter/internal/auth"
+	"github.com/abdurrahimagca/go-api-starter/internal/collection"
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/labubu"
+	"github.com/abdurrahimagca/go-api-starter/internal/reports"
+	"github.com/abdurrahimagca/go-api-starter/internal/webhooks"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/uow")
+
+// SQLSTATE codes that are safe to retry by re-running the whole transaction
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// Services is the set of services bound to the current transaction
+type Services struct {
+	Auth        auth.Service
+	Labubu      labubu.Service
+	Attachments attachments.Service
+	Jobs        jobs.Queue
+	Reports     reports.Service
+	Webhooks    webhooks.Service
+	Collections collection.Service
+}
+
+// withTx binds every service to tx
+func (s Services) withTx(tx pgx.Tx) Services {
+	return Services{
+		Auth:        s.Auth.WithTx(tx),
+		Labubu:      s.Labubu.WithTx(tx),
+		Attachments: s.Attachments.WithTx(tx),
+		Jobs:        s.Jobs.WithTx(tx),
+		Reports:     s.Reports.WithTx(tx),
+		Webhooks:    s.Webhooks.WithTx(tx),
+		Collections: s.Collections.WithTx(tx),
+	}
+}
+
+// Func is the body of a unit of work. It may be run more than once when the
+// transaction is retried, so it must not have side effects outside the database.
+type Func func(ctx context.Context, svc Services) error
+
+// TxManager runs functions inside a database transaction
+type TxManager interface {
+	Do(ctx context.Context, fn Func, opts ...Option) error
+}
+
+// Options configure a transaction
+type Options struct {
+	IsoLevel    pgx.TxIsoLevel
+	ReadOnly    bool
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Option mutates Options
+type Option func(*Options)
+
+// WithIsolation sets the isolation level of the outermost transaction
+func WithIsolation(level pgx.TxIsoLevel) Option {
+	return func(o *Options) { o.IsoLevel = level }
+}
+
+// ReadOnly starts the outermost transaction in read-only mode
+func ReadOnly() Option {
+	return func(o *Options) { o.ReadOnly = true }
+}
+
+// WithMaxAttempts limits how many times a retryable transaction is attempted
+func WithMaxAttempts(n int) Option {
+	return func(o *Options) { o.MaxAttempts = n }
+}
+
+// WithBackoff sets the base and maximum delay between retries
+func WithBackoff(base, max time.Duration) Option {
+	return func(o *Options) {
+		o.BaseBackoff = base
+		o.MaxBackoff = max
+	}
+}
+
+func defaultOptions() Options {
+	return Options{
+		IsoLevel:    pgx.ReadCommitted,
+		MaxAttempts: 3,
+		BaseBackoff: 20 * time.Millisecond,
+		MaxBackoff:  500 * time.Millisecond,
+	}
+}
+
+// txKey is the context key for the active transaction
+type txKey struct{}
+
+// TxFromContext returns the transaction started by an enclosing Do call
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+type txManager struct {
+	pool     *pgxpool.Pool
+	services Services
+}
+
+// NewTxManager creates a TxManager that binds services to each transaction
+func NewTxManager(pool *pgxpool.Pool, services Services) TxManager {
+	return &txManager{
+		pool:     pool,
+		services: services,
+	}
+}
+
+// Do runs fn in a transaction and commits when it returns nil.
+// Calls nested inside another Do use a savepoint instead of a new
+// transaction; options other than the retry policy only apply to the
+// outermost call, and retries happen there as well.
+func (m *txManager) Do(ctx context.Context, fn Func, opts ...Option) error {
+	if parent, ok := TxFromContext(ctx); ok {
+		return m.savepoint(ctx, parent, fn)
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = m.run(ctx, o, fn)
+		if err == nil || !IsRetryable(err) || attempt >= o.MaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff(o, attempt)):
+		}
+	}
+}
+
+func (m *txManager) run(ctx context.Context, o Options, fn Func) (err error) {
+	ctx, span := tracer.Start(ctx, "uow.TxManager.Do")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	accessMode := pgx.ReadWrite
+	if o.ReadOnly {
+		accessMode = pgx.ReadOnly
+	}
+
+	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: o.IsoLevel, AccessMode: accessMode})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// Rollback is a no-op after a successful commit
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx), m.services.withTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (m *txManager) savepoint(ctx context.Context, parent pgx.Tx, fn Func) error {
+	// Begin on a pgx.Tx creates a savepoint; Commit releases it and Rollback rolls back to it
+	sp, err := parent.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+	defer func() { _ = sp.Rollback(context.WithoutCancel(ctx)) }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, sp), m.services.withTx(sp)); err != nil {
+		return err
+	}
+	if err := sp.Commit(ctx); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
+
+// IsRetryable reports whether err is a serialization failure or deadlock
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt
+func backoff(o Options, attempt int) time.Duration {
+	d := o.BaseBackoff << (attempt - 1)
+	if d <= 0 || d > o.MaxBackoff {
+		d = o.MaxBackoff
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
@@ -0,0 +1,64 @@
+package uow
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"no rows", pgx.ErrNoRows, false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"wrapped serialization failure", fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"lock timeout", &pgconn.PgError{Code: "55P03"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	o := Options{BaseBackoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}
+
+	tests := []struct {
+		name    string
+		options Options
+		attempt int
+		// ceiling is the longest delay full jitter may pick
+		ceiling time.Duration
+	}{
+		{"first retry", o, 1, 10 * time.Millisecond},
+		{"second retry doubles", o, 2, 20 * time.Millisecond},
+		{"fourth retry", o, 4, 80 * time.Millisecond},
+		{"capped", o, 5, 100 * time.Millisecond},
+		{"shift overflow is capped", o, 80, 100 * time.Millisecond},
+		{"no backoff", Options{}, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				d := backoff(tt.options, tt.attempt)
+				if d < 0 || d > tt.ceiling {
+					t.Fatalf("backoff(attempt %d) = %v, want within [0, %v]", tt.attempt, d, tt.ceiling)
+				}
+			}
+		})
+	}
+}
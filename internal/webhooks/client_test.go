@@ -0,0 +1,54 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url          string
+		allowPrivate bool
+		want         error
+	}{
+		{"https://example.com/hooks", false, nil},
+		{"http://93.184.216.34:8080/hooks", false, nil},
+		{"ftp://example.com/hooks", false, ErrInvalidURL},
+		{"/hooks", false, ErrInvalidURL},
+		{"https://", false, ErrInvalidURL},
+		{"http://localhost:8080/hooks", false, ErrInvalidURL},
+		{"http://api.localhost/hooks", false, ErrInvalidURL},
+		{"http://127.0.0.1/hooks", false, ErrInvalidURL},
+		{"http://[::1]/hooks", false, ErrInvalidURL},
+		{"http://10.1.2.3/hooks", false, ErrInvalidURL},
+		{"http://192.168.0.10/hooks", false, ErrInvalidURL},
+		{"http://169.254.169.254/latest/meta-data", false, ErrInvalidURL},
+		{"http://100.64.0.1/hooks", false, ErrInvalidURL},
+		{"http://0.0.0.0/hooks", false, ErrInvalidURL},
+		{"http://[::ffff:127.0.0.1]/hooks", false, ErrInvalidURL},
+		{"http://localhost:9090/hooks", true, nil},
+		{"http://10.1.2.3/hooks", true, nil},
+		{"ftp://localhost/hooks", true, ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if err := validateURL(tt.url, tt.allowPrivate); !errors.Is(err, tt.want) {
+				t.Errorf("validateURL(%q, %v) = %v, want %v", tt.url, tt.allowPrivate, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPClientRefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	resp, err := NewHTTPClient(time.Second, false).Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("request to a loopback address succeeded")
+	}
+}
@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Subscription is an endpoint that receives matching events
+type Subscription struct {
+	ID          int64    `json:"id"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"-"`
+	Events      []string `json:"events"`
+	Description string   `json:"description"`
+	Enabled     bool     `json:"enabled"`
+	// ConsecutiveFailures counts failed attempts since the last success
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	DisabledReason      string    `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// Matches reports whether eventType passes the subscription's filter.
+// A filter entry is an exact type, "*" or a prefix such as "labubu.*".
+func (s *Subscription) Matches(eventType string) bool {
+	for _, pattern := range s.Events {
+		if pattern == "*" || pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus is the lifecycle state of a delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed marks a delivery that exhausted its retries
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery is one event sent to one subscription, with the outcome of the
+// latest attempt
+type Delivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// Attempt is the outcome of sending a delivery once
+type Attempt struct {
+	Status         DeliveryStatus
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+}
+
+// CreateSubscriptionRequest registers a new endpoint. A secret is generated
+// when none is given.
+type CreateSubscriptionRequest struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	Events      []string `json:"events"`
+	Description string   `json:"description"`
+}
+
+// UpdateSubscriptionRequest changes the fields that are set
+type UpdateSubscriptionRequest struct {
+	URL         *string   `json:"url"`
+	Secret      *string   `json:"secret"`
+	Events      *[]string `json:"events"`
+	Description *string   `json:"description"`
+	Enabled     *bool     `json:"enabled"`
+}
+
+// Common errors
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrInvalidURL    = errors.New("url must be an absolute http or https URL on a public host")
+	ErrNoEvents      = errors.New("at least one event type is required")
+	ErrInvalidSecret = errors.New("secret must be at least 16 characters")
+	// ErrSubscriptionDisabled is returned when redelivering to a disabled subscription
+	ErrSubscriptionDisabled = errors.New("subscription is disabled")
+)
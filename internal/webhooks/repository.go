@@ -0,0 +1,277 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository defines the contract for webhook data operations
+type Repository interface {
+	WithTx(tx pgx.Tx) Repository
+	CreateSubscription(ctx context.Context, req CreateSubscriptionRequest) (*Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	ListEnabledSubscriptions(ctx context.Context) ([]*Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, sub *Subscription) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ResetFailures(ctx context.Context, id int64) error
+	IncrementFailures(ctx context.Context, id int64) (int, error)
+	DisableSubscription(ctx context.Context, id int64, reason string) error
+	// CreateDelivery returns nil when the event was already fanned out to the subscription
+	CreateDelivery(ctx context.Context, subscriptionID, eventID int64, eventType string, payload []byte) (*Delivery, error)
+	GetDelivery(ctx context.Context, id int64) (*Delivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID int64, status DeliveryStatus, limit int) ([]*Delivery, error)
+	RecordAttempt(ctx context.Context, id int64, attempt Attempt) (*Delivery, error)
+	FailDelivery(ctx context.Context, id int64, reason string) error
+	ResetDelivery(ctx context.Context, id int64) (*Delivery, error)
+}
+
+type pgxRepository struct {
+	q *sqlc.Queries
+}
+
+// NewPgxRepository creates a new PostgreSQL repository
+func NewPgxRepository(pool *pgxpool.Pool) Repository {
+	return &pgxRepository{
+		q: sqlc.New(pool),
+	}
+}
+
+func (r *pgxRepository) WithTx(tx pgx.Tx) Repository {
+	return &pgxRepository{
+		q: r.q.WithTx(tx),
+	}
+}
+
+func (r *pgxRepository) CreateSubscription(ctx context.Context, req CreateSubscriptionRequest) (*Subscription, error) {
+	result, err := r.q.CreateWebhookSubscription(ctx, sqlc.CreateWebhookSubscriptionParams{
+		Url:         req.URL,
+		Secret:      req.Secret,
+		Events:      req.Events,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateWebhookSubscription failed: %w", err)
+	}
+	return toSubscription(result), nil
+}
+
+func (r *pgxRepository) ListSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	results, err := r.q.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListWebhookSubscriptions failed: %w", err)
+	}
+	return toSubscriptions(results), nil
+}
+
+func (r *pgxRepository) ListEnabledSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	results, err := r.q.ListEnabledWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListEnabledWebhookSubscriptions failed: %w", err)
+	}
+	return toSubscriptions(results), nil
+}
+
+func (r *pgxRepository) GetSubscription(ctx context.Context, id int64) (*Subscription, error) {
+	result, err := r.q.GetWebhookSubscription(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetWebhookSubscription failed: %w", err)
+	}
+	return toSubscription(result), nil
+}
+
+func (r *pgxRepository) UpdateSubscription(ctx context.Context, sub *Subscription) (*Subscription, error) {
+	result, err := r.q.UpdateWebhookSubscription(ctx, sqlc.UpdateWebhookSubscriptionParams{
+		ID:          sub.ID,
+		Url:         sub.URL,
+		Secret:      sub.Secret,
+		Events:      sub.Events,
+		Description: sub.Description,
+		Enabled:     sub.Enabled,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("UpdateWebhookSubscription failed: %w", err)
+	}
+	return toSubscription(result), nil
+}
+
+func (r *pgxRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	n, err := r.q.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteWebhookSubscription failed: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *pgxRepository) ResetFailures(ctx context.Context, id int64) error {
+	if err := r.q.ResetWebhookFailures(ctx, id); err != nil {
+		return fmt.Errorf("ResetWebhookFailures failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) IncrementFailures(ctx context.Context, id int64) (int, error) {
+	n, err := r.q.IncrementWebhookFailures(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("IncrementWebhookFailures failed: %w", err)
+	}
+	return int(n), nil
+}
+
+func (r *pgxRepository) DisableSubscription(ctx context.Context, id int64, reason string) error {
+	err := r.q.DisableWebhookSubscription(ctx, sqlc.DisableWebhookSubscriptionParams{
+		ID:             id,
+		DisabledReason: pgtype.Text{String: reason, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("DisableWebhookSubscription failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) CreateDelivery(ctx context.Context, subscriptionID, eventID int64, eventType string, payload []byte) (*Delivery, error) {
+	result, err := r.q.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CreateWebhookDelivery failed: %w", err)
+	}
+	return toDelivery(result), nil
+}
+
+func (r *pgxRepository) GetDelivery(ctx context.Context, id int64) (*Delivery, error) {
+	result, err := r.q.GetWebhookDelivery(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetWebhookDelivery failed: %w", err)
+	}
+	return toDelivery(result), nil
+}
+
+func (r *pgxRepository) ListDeliveries(ctx context.Context, subscriptionID int64, status DeliveryStatus, limit int) ([]*Delivery, error) {
+	results, err := r.q.ListWebhookDeliveries(ctx, sqlc.ListWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		Limit:          int32(limit),
+		Status:         pgtype.Text{String: string(status), Valid: status != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListWebhookDeliveries failed: %w", err)
+	}
+
+	deliveries := make([]*Delivery, 0, len(results))
+	for _, result := range results {
+		deliveries = append(deliveries, toDelivery(result))
+	}
+	return deliveries, nil
+}
+
+func (r *pgxRepository) RecordAttempt(ctx context.Context, id int64, attempt Attempt) (*Delivery, error) {
+	result, err := r.q.RecordWebhookAttempt(ctx, sqlc.RecordWebhookAttemptParams{
+		ID:             id,
+		Status:         string(attempt.Status),
+		ResponseStatus: pgtype.Int4{Int32: int32(attempt.ResponseStatus), Valid: attempt.ResponseStatus != 0},
+		ResponseBody:   pgtype.Text{String: attempt.ResponseBody, Valid: attempt.ResponseBody != ""},
+		LastError:      pgtype.Text{String: attempt.Error, Valid: attempt.Error != ""},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RecordWebhookAttempt failed: %w", err)
+	}
+	return toDelivery(result), nil
+}
+
+func (r *pgxRepository) FailDelivery(ctx context.Context, id int64, reason string) error {
+	err := r.q.FailWebhookDelivery(ctx, sqlc.FailWebhookDeliveryParams{
+		ID:        id,
+		LastError: pgtype.Text{String: reason, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("FailWebhookDelivery failed: %w", err)
+	}
+	return nil
+}
+
+func (r *pgxRepository) ResetDelivery(ctx context.Context, id int64) (*Delivery, error) {
+	result, err := r.q.ResetWebhookDelivery(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ResetWebhookDelivery failed: %w", err)
+	}
+	return toDelivery(result), nil
+}
+
+func toSubscription(s sqlc.WebhookSubscription) *Subscription {
+	return &Subscription{
+		ID:                  s.ID,
+		URL:                 s.Url,
+		Secret:              s.Secret,
+		Events:              s.Events,
+		Description:         s.Description,
+		Enabled:             s.Enabled,
+		ConsecutiveFailures: int(s.ConsecutiveFailures),
+		DisabledReason:      s.DisabledReason.String,
+		CreatedAt:           s.CreatedAt.Time,
+		UpdatedAt:           s.UpdatedAt.Time,
+	}
+}
+
+func toSubscriptions(results []sqlc.WebhookSubscription) []*Subscription {
+	subs := make([]*Subscription, 0, len(results))
+	for _, result := range results {
+		subs = append(subs, toSubscription(result))
+	}
+	return subs
+}
+
+func toDelivery(d sqlc.WebhookDelivery) *Delivery {
+	delivery := &Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         DeliveryStatus(d.Status),
+		Attempts:       int(d.Attempts),
+		ResponseStatus: int(d.ResponseStatus.Int32),
+		ResponseBody:   d.ResponseBody.String,
+		LastError:      d.LastError.String,
+		CreatedAt:      d.CreatedAt.Time,
+	}
+	if d.LastAttemptAt.Valid {
+		delivery.LastAttemptAt = &d.LastAttemptAt.Time
+	}
+	if d.DeliveredAt.Valid {
+		delivery.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return delivery
+}
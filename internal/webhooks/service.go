@@ -0,0 +1,406 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+	"github.com/abdurrahimagca/go-api-starter/platform/telemetry"
+)
+
+var tracer = telemetry.Tracer("github.com/abdurrahimagca/go-api-starter/internal/webhooks")
+
+// Config tunes delivery
+type Config struct {
+	// MaxAttempts is how often a delivery is tried before it is marked failed
+	MaxAttempts int
+	// DisableAfter disables a subscription after this many consecutive failed attempts
+	DisableAfter int
+	// AllowPrivate accepts subscription URLs on loopback and private
+	// addresses; pair it with an HTTP client that allows them too
+	AllowPrivate bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.DisableAfter <= 0 {
+		c.DisableAfter = 20
+	}
+	return c
+}
+
+// maxResponseBody is how much of an endpoint's response is kept in the delivery log
+const maxResponseBody = 1024
+
+// Service defines the contract for webhook business logic
+type Service interface {
+	WithTx(tx pgx.Tx) Service
+	CreateSubscription(ctx context.Context, req CreateSubscriptionRequest) (*Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, id int64, req UpdateSubscriptionRequest) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	// Dispatch records a delivery for every enabled subscription matching event
+	// and enqueues a job to send it; run it in a transaction
+	Dispatch(ctx context.Context, event *outbox.Event) (int, error)
+	// Deliver sends a delivery once; final marks the last attempt
+	Deliver(ctx context.Context, deliveryID int64, final bool) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, status DeliveryStatus, limit int) ([]*Delivery, error)
+	GetDelivery(ctx context.Context, id int64) (*Delivery, error)
+	Redeliver(ctx context.Context, id int64) (*Delivery, error)
+}
+
+type service struct {
+	repo   Repository
+	queue  jobs.Queue
+	client *http.Client
+	config Config
+}
+
+// NewService creates a new webhook service that sends deliveries with client
+func NewService(repo Repository, queue jobs.Queue, client *http.Client, config Config) Service {
+	return &service{
+		repo:   repo,
+		queue:  queue,
+		client: client,
+		config: config.withDefaults(),
+	}
+}
+
+func (s *service) WithTx(tx pgx.Tx) Service {
+	return &service{
+		repo:   s.repo.WithTx(tx),
+		queue:  s.queue.WithTx(tx),
+		client: s.client,
+		config: s.config,
+	}
+}
+
+func (s *service) CreateSubscription(ctx context.Context, req CreateSubscriptionRequest) (_ *Subscription, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.CreateSubscription")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := validateURL(req.URL, s.config.AllowPrivate); err != nil {
+		return nil, err
+	}
+	if req.Events, err = normalizeEvents(req.Events); err != nil {
+		return nil, err
+	}
+	if req.Secret == "" {
+		if req.Secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	} else if err := validateSecret(req.Secret); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateSubscription(ctx, req)
+}
+
+func (s *service) ListSubscriptions(ctx context.Context) (_ []*Subscription, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.ListSubscriptions")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.ListSubscriptions(ctx)
+}
+
+func (s *service) GetSubscription(ctx context.Context, id int64) (_ *Subscription, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.GetSubscription")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.GetSubscription(ctx, id)
+}
+
+func (s *service) UpdateSubscription(ctx context.Context, id int64, req UpdateSubscriptionRequest) (_ *Subscription, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.UpdateSubscription")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	sub, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL != nil {
+		if err := validateURL(*req.URL, s.config.AllowPrivate); err != nil {
+			return nil, err
+		}
+		sub.URL = *req.URL
+	}
+	if req.Secret != nil {
+		if err := validateSecret(*req.Secret); err != nil {
+			return nil, err
+		}
+		sub.Secret = *req.Secret
+	}
+	if req.Events != nil {
+		if sub.Events, err = normalizeEvents(*req.Events); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		sub.Description = *req.Description
+	}
+	if req.Enabled != nil {
+		sub.Enabled = *req.Enabled
+	}
+	return s.repo.UpdateSubscription(ctx, sub)
+}
+
+func (s *service) DeleteSubscription(ctx context.Context, id int64) (err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.DeleteSubscription")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+// envelope is the JSON body of a delivery
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (s *service) Dispatch(ctx context.Context, event *outbox.Event) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.Dispatch")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	subs, err := s.repo.ListEnabledSubscriptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(envelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encoding %s delivery: %w", event.Type, err)
+	}
+
+	n := 0
+	for _, sub := range subs {
+		if !sub.Matches(event.Type) {
+			continue
+		}
+		delivery, err := s.repo.CreateDelivery(ctx, sub.ID, event.ID, event.Type, body)
+		if err != nil {
+			return n, err
+		}
+		// Already fanned out by an earlier attempt of the relay
+		if delivery == nil {
+			continue
+		}
+		if err := s.enqueue(ctx, delivery.ID); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// enqueue schedules a job that sends the delivery
+func (s *service) enqueue(ctx context.Context, deliveryID int64) error {
+	_, err := s.queue.Enqueue(ctx, DeliverArgs{DeliveryID: deliveryID},
+		jobs.WithUniqueKey("webhook-delivery:"+strconv.FormatInt(deliveryID, 10)),
+		jobs.WithMaxAttempts(s.config.MaxAttempts),
+	)
+	return err
+}
+
+func (s *service) Deliver(ctx context.Context, deliveryID int64, final bool) (err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.Deliver")
+	span.SetAttributes(attribute.Int64("webhook.delivery_id", deliveryID))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if errors.Is(err, ErrNotFound) {
+		// The subscription was deleted along with its deliveries
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if delivery.Status == DeliverySucceeded {
+		return nil
+	}
+
+	sub, err := s.repo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	if !sub.Enabled {
+		return s.repo.FailDelivery(ctx, delivery.ID, "subscription disabled")
+	}
+
+	attempt := s.send(ctx, sub, delivery)
+	logger := slog.With("delivery_id", delivery.ID, "subscription_id", sub.ID, "event_type", delivery.EventType)
+
+	if attempt.Status == DeliverySucceeded {
+		if _, err := s.repo.RecordAttempt(ctx, delivery.ID, attempt); err != nil {
+			return err
+		}
+		return s.repo.ResetFailures(ctx, sub.ID)
+	}
+
+	// 410 Gone means the receiver asked us to stop
+	gone := attempt.ResponseStatus == http.StatusGone
+	if final || gone {
+		attempt.Status = DeliveryFailed
+	}
+	if _, err := s.repo.RecordAttempt(ctx, delivery.ID, attempt); err != nil {
+		return err
+	}
+
+	failures, err := s.repo.IncrementFailures(ctx, sub.ID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case gone:
+		logger.Warn("Webhook endpoint is gone, disabling subscription")
+		err = s.repo.DisableSubscription(ctx, sub.ID, "endpoint responded 410 Gone")
+	case failures >= s.config.DisableAfter:
+		logger.Warn("Webhook endpoint keeps failing, disabling subscription", "consecutive_failures", failures)
+		err = s.repo.DisableSubscription(ctx, sub.ID, fmt.Sprintf("disabled after %d consecutive failed attempts", failures))
+	}
+	if err != nil {
+		return err
+	}
+
+	deliveryErr := errors.New(attempt.Error)
+	if gone {
+		return jobs.Permanent(deliveryErr)
+	}
+	return deliveryErr
+}
+
+// send POSTs the delivery to the subscription and reports the outcome
+func (s *service) send(ctx context.Context, sub *Subscription, delivery *Delivery) Attempt {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return Attempt{Status: DeliveryPending, Error: err.Error()}
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-api-starter-webhooks/1")
+	req.Header.Set(HeaderID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, now, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Attempt{Status: DeliveryPending, Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	attempt := Attempt{ResponseStatus: resp.StatusCode, ResponseBody: strings.ToValidUTF8(string(body), "")}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Status = DeliveryPending
+		attempt.Error = "endpoint responded " + resp.Status
+		return attempt
+	}
+	attempt.Status = DeliverySucceeded
+	return attempt
+}
+
+func (s *service) ListDeliveries(ctx context.Context, subscriptionID int64, status DeliveryStatus, limit int) (_ []*Delivery, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.ListDeliveries")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if _, err := s.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, subscriptionID, status, limit)
+}
+
+func (s *service) GetDelivery(ctx context.Context, id int64) (_ *Delivery, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.GetDelivery")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.repo.GetDelivery(ctx, id)
+}
+
+func (s *service) Redeliver(ctx context.Context, id int64) (_ *Delivery, err error) {
+	ctx, span := tracer.Start(ctx, "webhooks.Service.Redeliver")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := s.repo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.Enabled {
+		return nil, ErrSubscriptionDisabled
+	}
+
+	delivery, err = s.repo.ResetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enqueue(ctx, delivery.ID); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func validateSecret(secret string) error {
+	if len(secret) < minSecretLength {
+		return ErrInvalidSecret
+	}
+	return nil
+}
+
+// normalizeEvents trims the filter and drops empty and duplicate entries
+func normalizeEvents(events []string) ([]string, error) {
+	seen := make(map[string]bool, len(events))
+	out := make([]string, 0, len(events))
+	for _, e := range events {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	if len(out) == 0 {
+		return nil, ErrNoEvents
+	}
+	return out, nil
+}
+
+// minSecretLength is the shortest secret accepted from callers
+const minSecretLength = 16
+
+// generateSecret returns a random signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating secret: %w", err)
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,141 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+)
+
+// memoryRepository keeps subscriptions and deliveries in memory
+type memoryRepository struct {
+	Repository
+
+	subs       []*Subscription
+	deliveries []*Delivery
+}
+
+func (r *memoryRepository) CreateSubscription(_ context.Context, req CreateSubscriptionRequest) (*Subscription, error) {
+	sub := &Subscription{ID: int64(len(r.subs) + 1), URL: req.URL, Secret: req.Secret, Events: req.Events, Enabled: true}
+	r.subs = append(r.subs, sub)
+	return sub, nil
+}
+
+func (r *memoryRepository) ListEnabledSubscriptions(context.Context) ([]*Subscription, error) {
+	return r.subs, nil
+}
+
+func (r *memoryRepository) GetSubscription(_ context.Context, id int64) (*Subscription, error) {
+	if id < 1 || int(id) > len(r.subs) {
+		return nil, ErrNotFound
+	}
+	return r.subs[id-1], nil
+}
+
+func (r *memoryRepository) CreateDelivery(_ context.Context, subscriptionID, eventID int64, eventType string, payload []byte) (*Delivery, error) {
+	d := &Delivery{ID: int64(len(r.deliveries) + 1), SubscriptionID: subscriptionID, EventID: eventID, EventType: eventType, Payload: payload, Status: DeliveryPending}
+	r.deliveries = append(r.deliveries, d)
+	return d, nil
+}
+
+func (r *memoryRepository) GetDelivery(_ context.Context, id int64) (*Delivery, error) {
+	if id < 1 || int(id) > len(r.deliveries) {
+		return nil, ErrNotFound
+	}
+	return r.deliveries[id-1], nil
+}
+
+func (r *memoryRepository) RecordAttempt(_ context.Context, id int64, attempt Attempt) (*Delivery, error) {
+	d := r.deliveries[id-1]
+	d.Attempts++
+	d.Status, d.ResponseStatus, d.LastError = attempt.Status, attempt.ResponseStatus, attempt.Error
+	return d, nil
+}
+
+func (r *memoryRepository) ResetFailures(context.Context, int64) error {
+	return nil
+}
+
+func (r *memoryRepository) IncrementFailures(context.Context, int64) (int, error) {
+	return 1, nil
+}
+
+// recordingQueue records enqueued jobs instead of running them
+type recordingQueue struct {
+	jobs.Queue
+	enqueued []jobs.Args
+}
+
+func (q *recordingQueue) Enqueue(_ context.Context, args jobs.Args, _ ...jobs.EnqueueOption) (*jobs.Job, error) {
+	q.enqueued = append(q.enqueued, args)
+	return &jobs.Job{}, nil
+}
+
+func TestDeliverToLocalReceiver(t *testing.T) {
+	const secret = "whsec_local-receiver-secret-for-tests"
+
+	var mu sync.Mutex
+	var received []envelope
+	srv := httptest.NewServer(Receiver(secret, DefaultTolerance, func(_ http.Header, body []byte) {
+		var e envelope
+		_ = json.Unmarshal(body, &e)
+		mu.Lock()
+		received = append(received, e)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name         string
+		allowPrivate bool
+		wantErr      error
+	}{
+		{name: "refused by default", wantErr: ErrInvalidURL},
+		{name: "delivered with the opt-in", allowPrivate: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			repo := &memoryRepository{}
+			queue := &recordingQueue{}
+			svc := NewService(repo, queue, NewHTTPClient(time.Second, tt.allowPrivate), Config{AllowPrivate: tt.allowPrivate})
+			ctx := context.Background()
+
+			_, err := svc.CreateSubscription(ctx, CreateSubscriptionRequest{URL: srv.URL + "/hooks", Secret: secret, Events: []string{"labubu.*"}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateSubscription() = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			event := &outbox.Event{ID: 42, Type: "labubu.created", Payload: json.RawMessage(`{"id":7}`)}
+			if n, err := svc.Dispatch(ctx, event); err != nil || n != 1 {
+				t.Fatalf("Dispatch() = %d, %v, want one delivery", n, err)
+			}
+			args, ok := queue.enqueued[0].(DeliverArgs)
+			if !ok {
+				t.Fatalf("enqueued %+v, want a delivery job", queue.enqueued[0])
+			}
+			if err := svc.Deliver(ctx, args.DeliveryID, false); err != nil {
+				t.Fatalf("Deliver() = %v", err)
+			}
+
+			if d := repo.deliveries[0]; d.Status != DeliverySucceeded || d.ResponseStatus != http.StatusNoContent {
+				t.Errorf("delivery = %+v, want it succeeded", d)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(received) != 1 || received[0].ID != 42 || received[0].Type != "labubu.created" {
+				t.Errorf("receiver got %+v, want the signed event", received)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package webhooks
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec-current-0123456789"
+	const oldSecret = "whsec-previous-0123456789"
+	body := []byte(`{"type":"labubu.created"}`)
+	sentAt := time.Unix(1_700_000_000, 0)
+
+	headers := func(timestamp time.Time, signature string) http.Header {
+		h := http.Header{}
+		h.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+		h.Set(HeaderSignature, signature)
+		return h
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   []byte
+		now    time.Time
+		want   error
+	}{
+		{
+			name:   "valid",
+			header: headers(sentAt, Sign(secret, sentAt, body)),
+			body:   body,
+			now:    sentAt,
+		},
+		{
+			name:   "at the edge of the tolerance",
+			header: headers(sentAt, Sign(secret, sentAt, body)),
+			body:   body,
+			now:    sentAt.Add(DefaultTolerance),
+		},
+		{
+			name:   "too old",
+			header: headers(sentAt, Sign(secret, sentAt, body)),
+			body:   body,
+			now:    sentAt.Add(DefaultTolerance + time.Second),
+			want:   ErrStaleTimestamp,
+		},
+		{
+			name:   "too far in the future",
+			header: headers(sentAt, Sign(secret, sentAt, body)),
+			body:   body,
+			now:    sentAt.Add(-DefaultTolerance - time.Second),
+			want:   ErrStaleTimestamp,
+		},
+		{
+			name:   "tampered body",
+			header: headers(sentAt, Sign(secret, sentAt, body)),
+			body:   []byte(`{"type":"labubu.deleted"}`),
+			now:    sentAt,
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "timestamp replaced",
+			header: headers(sentAt.Add(time.Minute), Sign(secret, sentAt, body)),
+			body:   body,
+			now:    sentAt,
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "wrong secret",
+			header: headers(sentAt, Sign(oldSecret, sentAt, body)),
+			body:   body,
+			now:    sentAt,
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "rotation sends both signatures",
+			header: headers(sentAt, Sign(oldSecret, sentAt, body)+", "+Sign(secret, sentAt, body)),
+			body:   body,
+			now:    sentAt,
+		},
+		{
+			name:   "missing signature",
+			header: headers(sentAt, ""),
+			body:   body,
+			now:    sentAt,
+			want:   ErrMissingSignature,
+		},
+		{
+			name:   "malformed timestamp",
+			header: http.Header{HeaderTimestamp: {"yesterday"}, HeaderSignature: {Sign(secret, sentAt, body)}},
+			body:   body,
+			now:    sentAt,
+			want:   ErrMissingSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(secret, tt.header, tt.body, DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	const secret = "whsec-current-0123456789"
+	body := []byte(`{"id":1}`)
+
+	var received []byte
+	srv := httptest.NewServer(Receiver(secret, DefaultTolerance, func(_ http.Header, b []byte) {
+		received = b
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		secret   string
+		want     int
+		delivers bool
+	}{
+		{"signed with the secret", secret, http.StatusNoContent, true},
+		{"signed with another secret", "whsec-someone-else-000000", http.StatusUnauthorized, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			now := time.Now()
+			req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+			req.Header.Set(HeaderSignature, Sign(tt.secret, now, body))
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if got := received != nil; got != tt.delivers {
+				t.Errorf("delivered = %v, want %v", got, tt.delivers)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/abdurrahimagca/go-api-starter/internal/jobs"
+	"github.com/abdurrahimagca/go-api-starter/internal/outbox"
+)
+
+// DeliverArgs is the job that sends one delivery
+type DeliverArgs struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (DeliverArgs) Kind() string { return "webhooks.deliver" }
+
+// RegisterJobs adds the delivery handler to registry. Failed attempts return
+// an error so the job queue retries them with backoff.
+func RegisterJobs(registry *jobs.Registry, svc Service) {
+	jobs.Register(registry, func(ctx context.Context, job *jobs.Job, args DeliverArgs) error {
+		return svc.Deliver(ctx, args.DeliveryID, job.Attempts >= job.MaxAttempts)
+	})
+}
+
+// Sink is an outbox sink that fans events out to the matching subscriptions.
+// Each delivery is then sent by its own job, so a slow or failing endpoint
+// does not hold back the relay or other subscribers.
+type Sink struct {
+	pool *pgxpool.Pool
+	svc  Service
+}
+
+// NewSink creates an outbox sink for svc
+func NewSink(pool *pgxpool.Pool, svc Service) *Sink {
+	return &Sink{
+		pool: pool,
+		svc:  svc,
+	}
+}
+
+func (s *Sink) Name() string { return "webhooks" }
+
+// Publish records the deliveries and their jobs in one transaction
+func (s *Sink) Publish(ctx context.Context, event *outbox.Event) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	if _, err := s.svc.WithTx(tx).Dispatch(ctx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
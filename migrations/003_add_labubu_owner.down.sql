@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS labubu_owner_id_idx;
+
+ALTER TABLE labubu
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,5 @@
+ALTER TABLE labubu
+    ADD COLUMN owner_id INTEGER REFERENCES users (id) ON DELETE CASCADE,
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX labubu_owner_id_idx ON labubu (owner_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS schedules;
+DROP INDEX IF EXISTS labubu_deleted_at_idx;
+ALTER TABLE labubu DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,21 @@
+ALTER TABLE labubu ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX labubu_deleted_at_idx ON labubu (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE schedules (
+    name TEXT PRIMARY KEY,
+    last_run_at TIMESTAMPTZ,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_job_id BIGINT REFERENCES jobs (id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE reports (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (kind, period_start)
+);
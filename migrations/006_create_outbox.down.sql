@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS outbox;
+ALTER TABLE labubu
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS version;
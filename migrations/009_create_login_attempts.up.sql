@@ -0,0 +1,12 @@
+-- Failed logins per email rather than per user, so unknown addresses are
+-- throttled exactly like real accounts and responses reveal nothing
+CREATE TABLE login_attempts (
+    email TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    -- Attempts before this time are refused without checking the password
+    next_attempt_at TIMESTAMPTZ,
+    locked_until TIMESTAMPTZ,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX login_attempts_locked_until_idx ON login_attempts (locked_until) WHERE locked_until IS NOT NULL;
@@ -0,0 +1,20 @@
+-- Files attached to a labubu. The content lives in object storage under
+-- object_key; rows are removed with their labubu and the objects by the purge task.
+CREATE TABLE attachments (
+    id BIGSERIAL PRIMARY KEY,
+    labubu_id INTEGER NOT NULL REFERENCES labubu (id) ON DELETE CASCADE,
+    uploader_id INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    object_key TEXT NOT NULL UNIQUE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    -- Hex SHA-256 of the content, known once the upload is complete
+    sha256 TEXT,
+    -- pending until a direct upload is completed, rejected when checks or the scanner refuse it
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'rejected')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX attachments_labubu_id_idx ON attachments (labubu_id);
+CREATE INDEX attachments_pending_idx ON attachments (created_at) WHERE status = 'pending';
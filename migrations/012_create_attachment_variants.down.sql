@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS attachment_variants;
+ALTER TABLE attachments
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS image_status,
+    DROP COLUMN IF EXISTS image_error;
@@ -0,0 +1,22 @@
+-- Image attachments are processed in the background: metadata stripped,
+-- dimensions read and thumbnails written next to the original
+ALTER TABLE attachments
+    ADD COLUMN width INTEGER,
+    ADD COLUMN height INTEGER,
+    -- NULL for files that are not images
+    ADD COLUMN image_status TEXT CHECK (image_status IN ('pending', 'processed', 'unsupported', 'failed')),
+    ADD COLUMN image_error TEXT;
+
+CREATE TABLE attachment_variants (
+    id BIGSERIAL PRIMARY KEY,
+    attachment_id BIGINT NOT NULL REFERENCES attachments (id) ON DELETE CASCADE,
+    -- The configured size, e.g. small
+    name TEXT NOT NULL,
+    object_key TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (attachment_id, name)
+);
@@ -0,0 +1,18 @@
+-- Tags belong to the owner of the labubu they are put on. Names are stored
+-- normalized (trimmed, case folded), so the unique index also finds them.
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (owner_id, name)
+);
+
+CREATE TABLE labubu_tags (
+    labubu_id INTEGER NOT NULL REFERENCES labubu (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (labubu_id, tag_id)
+);
+
+-- Filtering and counting go from a tag to its labubu
+CREATE INDEX labubu_tags_tag_id_idx ON labubu_tags (tag_id, labubu_id);
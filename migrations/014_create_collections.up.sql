@@ -0,0 +1,34 @@
+-- Named groups of labubu. The owner shares a collection with other users as
+-- viewer or editor; items keep the order they are given.
+CREATE TABLE collections (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX collections_owner_id_idx ON collections (owner_id, id);
+
+CREATE TABLE collection_items (
+    collection_id INTEGER NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    labubu_id INTEGER NOT NULL REFERENCES labubu (id) ON DELETE CASCADE,
+    -- Order within the collection; moves shift the items in between
+    position INTEGER NOT NULL,
+    added_by INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, labubu_id)
+);
+
+CREATE INDEX collection_items_position_idx ON collection_items (collection_id, position);
+CREATE INDEX collection_items_labubu_id_idx ON collection_items (labubu_id);
+
+CREATE TABLE collection_shares (
+    collection_id INTEGER NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'editor')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, user_id)
+);
+
+CREATE INDEX collection_shares_user_id_idx ON collection_shares (user_id);
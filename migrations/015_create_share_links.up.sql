@@ -0,0 +1,31 @@
+-- Public links to a single labubu. Only the SHA-256 of the token is kept; the
+-- token itself is shown once, when the link is created.
+CREATE TABLE share_links (
+    id BIGSERIAL PRIMARY KEY,
+    labubu_id INTEGER NOT NULL REFERENCES labubu (id) ON DELETE CASCADE,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    -- bcrypt hash of the optional password
+    password_hash TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- NULL allows any number of views
+    max_views INTEGER CHECK (max_views > 0),
+    views INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX share_links_labubu_id_idx ON share_links (labubu_id, id);
+
+-- Every attempt to open a link, for its owner to review
+CREATE TABLE share_link_accesses (
+    id BIGSERIAL PRIMARY KEY,
+    link_id BIGINT NOT NULL REFERENCES share_links (id) ON DELETE CASCADE,
+    -- viewed, password_required, wrong_password, expired, revoked, exhausted or unavailable
+    outcome TEXT NOT NULL,
+    ip TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX share_link_accesses_link_id_idx ON share_link_accesses (link_id, id);
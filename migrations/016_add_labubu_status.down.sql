@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS labubu_transitions;
+DROP INDEX IF EXISTS labubu_scheduled_idx;
+ALTER TABLE labubu
+    DROP CONSTRAINT IF EXISTS labubu_publish_at_check,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS published_at;
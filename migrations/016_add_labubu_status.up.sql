@@ -0,0 +1,31 @@
+-- Lifecycle of a labubu: draft -> scheduled -> published -> archived. Only
+-- published labubu are shown to anyone but their owner. Entries that existed
+-- before count as published; new ones start as drafts.
+ALTER TABLE labubu
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    -- When a scheduled labubu is published
+    ADD COLUMN publish_at TIMESTAMPTZ,
+    ADD COLUMN published_at TIMESTAMPTZ,
+    ADD CONSTRAINT labubu_publish_at_check CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+UPDATE labubu SET published_at = created_at;
+
+ALTER TABLE labubu ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX labubu_scheduled_idx ON labubu (publish_at) WHERE status = 'scheduled' AND deleted_at IS NULL;
+
+-- Every change of status, for the owner to review
+CREATE TABLE labubu_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    labubu_id INTEGER NOT NULL REFERENCES labubu (id) ON DELETE CASCADE,
+    -- NULL when the labubu was created
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    -- NULL when the publisher made the change, or once the account is deleted
+    actor_id INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    publish_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX labubu_transitions_labubu_id_idx ON labubu_transitions (labubu_id, id);
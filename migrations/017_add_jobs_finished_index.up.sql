@@ -0,0 +1,2 @@
+-- Finished jobs are purged once past the retention window
+CREATE INDEX jobs_finished_at_idx ON jobs (finished_at) WHERE status IN ('succeeded', 'dead');
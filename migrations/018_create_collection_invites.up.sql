@@ -0,0 +1,11 @@
+-- Shares with an email that has no account yet. They turn into shares once
+-- someone verifies that email, so sharing does not reveal who is registered.
+CREATE TABLE collection_invites (
+    collection_id INTEGER NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'editor')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, email)
+);
+
+CREATE INDEX collection_invites_email_idx ON collection_invites (email);
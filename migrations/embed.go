@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migration files into the binary.
+package migrations
+
+import "embed"
+
+// FS contains every *.up.sql and *.down.sql migration
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,101 @@
+// Package imaging inspects, resizes and cleans images in pure Go
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+
+	// Decoders for the supported formats
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	// ErrUnsupported is returned for content that is not a JPEG, PNG, GIF or WebP image
+	ErrUnsupported = errors.New("imaging: unsupported image format")
+	// ErrTooLarge is returned for images with more pixels than allowed, before they are decoded
+	ErrTooLarge = errors.New("imaging: image has too many pixels")
+)
+
+// jpegQuality is used for every JPEG written
+const jpegQuality = 85
+
+// Info describes an image as it is displayed, i.e. after its EXIF orientation
+type Info struct {
+	// Format is jpeg, png, gif or webp
+	Format      string
+	Width       int
+	Height      int
+	Orientation int
+}
+
+// Inspect reads the format, size and orientation of data without decoding the pixels
+func Inspect(data []byte) (Info, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return Info{}, ErrUnsupported
+	}
+	if err != nil {
+		return Info{}, fmt.Errorf("imaging: %w", err)
+	}
+
+	info := Info{Format: format, Width: config.Width, Height: config.Height, Orientation: orientation(format, data)}
+	if info.Orientation >= 5 {
+		// Orientations 5 to 8 turn the image by 90 degrees
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+// Decode decodes data after checking it has at most maxPixels pixels; a
+// maxPixels of 0 does not limit the size
+func Decode(data []byte, maxPixels int) (image.Image, Info, error) {
+	info, err := Inspect(data)
+	if err != nil {
+		return nil, Info{}, err
+	}
+	if maxPixels > 0 && info.Width*info.Height > maxPixels {
+		return nil, Info{}, ErrTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, Info{}, fmt.Errorf("imaging: %w", err)
+	}
+	return img, info, nil
+}
+
+// Thumbnail scales img to fit in a size by size square, keeping its aspect
+// ratio, and turns it upright by orientation. Images that already fit are not
+// enlarged.
+func Thumbnail(img image.Image, orientation, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return orient(dst, orientation)
+}
+
+// Encode writes img as a JPEG, or as a PNG when it has transparent pixels,
+// and returns the content type written
+func Encode(w io.Writer, img image.Image) (string, error) {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		return "image/png", png.Encode(w, img)
+	}
+	return "image/jpeg", jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
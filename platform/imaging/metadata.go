@@ -0,0 +1,313 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// errMalformed is returned when the container of an image cannot be walked
+var errMalformed = errors.New("imaging: malformed image")
+
+// exifHeader starts the EXIF data in a JPEG APP1 segment and sometimes in WebP
+var exifHeader = []byte("Exif\x00\x00")
+
+// orientationTag is the EXIF tag of the orientation
+const orientationTag = 0x0112
+
+// StripMetadata removes EXIF, XMP and text metadata, such as GPS position,
+// camera and comments, from a JPEG, PNG or WebP image without re-encoding
+// it. The orientation is the only EXIF value kept, so the image still shows
+// upright. Other formats are returned unchanged.
+func StripMetadata(format string, data []byte) ([]byte, error) {
+	switch format {
+	case "jpeg":
+		return stripJPEG(data)
+	case "png":
+		return stripPNG(data)
+	case "webp":
+		return stripWebP(data)
+	default:
+		return data, nil
+	}
+}
+
+// orientation returns the EXIF orientation of data, or 1 when it has none
+func orientation(format string, data []byte) int {
+	var exif []byte
+	switch format {
+	case "jpeg":
+		_, _ = walkJPEG(data, func(marker byte, segment []byte) bool {
+			if marker == 0xE1 && bytes.HasPrefix(segment[4:], exifHeader) {
+				exif = segment[4+len(exifHeader):]
+				return false
+			}
+			return true
+		})
+	case "png":
+		_ = walkPNG(data, func(kind string, chunk []byte) bool {
+			if kind == "eXIf" {
+				exif = chunk[8 : len(chunk)-4]
+				return false
+			}
+			return true
+		})
+	case "webp":
+		_ = walkWebP(data, func(kind string, chunk []byte) bool {
+			if kind == "EXIF" {
+				exif = bytes.TrimPrefix(chunk[8:], exifHeader)
+				return false
+			}
+			return true
+		})
+	}
+	if o := tiffOrientation(exif); o >= 1 && o <= 8 {
+		return o
+	}
+	return 1
+}
+
+// tiffOrientation reads the orientation from the first IFD of TIFF-encoded
+// EXIF data, or returns 0
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// orientationEXIF is TIFF-encoded EXIF data that holds only the orientation
+func orientationEXIF(o int) []byte {
+	return []byte{
+		'M', 'M', 0, 42, // big endian TIFF
+		0, 0, 0, 8, // first IFD follows the header
+		0, 1, // one entry
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, byte(o), 0, 0, // orientation, one SHORT
+		0, 0, 0, 0, // no further IFD
+	}
+}
+
+// walkJPEG calls fn with each marker segment before the image data, including
+// the marker and length; fn returns false to stop. It returns the offset of
+// the start of scan marker, which the image data follows.
+func walkJPEG(data []byte, fn func(marker byte, segment []byte) bool) (int, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, errMalformed
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 0, errMalformed
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			// Fill byte
+			i++
+			continue
+		}
+		if marker == 0xDA {
+			return i, nil
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) || end < i+4 {
+			return 0, errMalformed
+		}
+		if !fn(marker, data[i:end]) {
+			return 0, nil
+		}
+		i = end
+	}
+	return 0, errMalformed
+}
+
+// stripJPEG drops APP1 (EXIF, XMP), APP13 (IPTC) and comment segments. The
+// color profile in APP2 is kept.
+func stripJPEG(data []byte) ([]byte, error) {
+	o := orientation("jpeg", data)
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	// The orientation goes right after the JFIF header, which has to come first
+	addOrientation := func() {
+		if o > 1 {
+			segment := append(append([]byte{}, exifHeader...), orientationEXIF(o)...)
+			out = append(out, 0xFF, 0xE1)
+			out = binary.BigEndian.AppendUint16(out, uint16(len(segment)+2))
+			out = append(out, segment...)
+			o = 0
+		}
+	}
+
+	scan, err := walkJPEG(data, func(marker byte, segment []byte) bool {
+		if marker != 0xE0 {
+			addOrientation()
+		}
+		switch marker {
+		case 0xE1, 0xED, 0xFE:
+		default:
+			out = append(out, segment...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	addOrientation()
+	return append(out, data[scan:]...), nil
+}
+
+// pngSignature starts every PNG file
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// walkPNG calls fn with each chunk, including its length, type and CRC; fn
+// returns false to stop
+func walkPNG(data []byte, fn func(kind string, chunk []byte) bool) error {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return errMalformed
+	}
+	for i := len(pngSignature); i < len(data); {
+		if i+12 > len(data) {
+			return errMalformed
+		}
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i+12 {
+			return errMalformed
+		}
+		if !fn(string(data[i+4:i+8]), data[i:end]) {
+			return nil
+		}
+		i = end
+	}
+	return nil
+}
+
+// stripPNG drops the eXIf, text and time chunks
+func stripPNG(data []byte) ([]byte, error) {
+	o := orientation("png", data)
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	err := walkPNG(data, func(kind string, chunk []byte) bool {
+		switch kind {
+		case "eXIf", "tEXt", "iTXt", "zTXt", "tIME":
+			return true
+		case "IDAT":
+			if o > 1 {
+				// eXIf has to come before the image data
+				out = appendPNGChunk(out, "eXIf", orientationEXIF(o))
+				o = 0
+			}
+		}
+		out = append(out, chunk...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func appendPNGChunk(out []byte, kind string, data []byte) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(data)))
+	start := len(out)
+	out = append(out, kind...)
+	out = append(out, data...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out[start:]))
+}
+
+// walkWebP calls fn with each RIFF chunk, including its header and padding;
+// fn returns false to stop
+func walkWebP(data []byte, fn func(kind string, chunk []byte) bool) error {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return errMalformed
+	}
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return errMalformed
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if end == len(data)+1 && size%2 == 1 {
+			// Some writers leave out the padding of the last chunk
+			end--
+		}
+		if end > len(data) || end < i+8 {
+			return errMalformed
+		}
+		if !fn(string(data[i:i+4]), data[i:end]) {
+			return nil
+		}
+		i = end
+	}
+	return nil
+}
+
+// VP8X flags that announce metadata chunks
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebP drops the EXIF and XMP chunks and their flags
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 {
+		return nil, errMalformed
+	}
+	o := orientation("webp", data)
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	extended := false
+	err := walkWebP(data, func(kind string, chunk []byte) bool {
+		switch kind {
+		case "EXIF", "XMP ":
+			return true
+		case "VP8X":
+			if len(chunk) <= 8 {
+				break
+			}
+			extended = true
+			start := len(out)
+			out = append(out, chunk...)
+			out[start+8] &^= webpFlagXMP
+			if o <= 1 {
+				out[start+8] &^= webpFlagEXIF
+			}
+			return true
+		}
+		out = append(out, chunk...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if extended && o > 1 {
+		// Only the extended format carries metadata; it goes after the image data
+		exif := orientationEXIF(o)
+		out = append(out, "EXIF"...)
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(exif)))
+		out = append(out, exif...)
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
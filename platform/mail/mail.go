@@ -0,0 +1,51 @@
+// Package mail sends transactional email
+package mail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Message is one email
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// Mailer sends messages
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// localeKey is the context key of the reader's locale
+type localeKey struct{}
+
+// WithLocale records the locale mail triggered from ctx is written in, e.g.
+// from the request's Accept-Language
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set by WithLocale, or "" for the default
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// ErrNoRecipient is returned for a message without a To address
+var ErrNoRecipient = errors.New("mail: message has no recipient")
+
+// LogMailer writes messages to the log instead of sending them; it is used
+// when no provider is configured
+type LogMailer struct{}
+
+func (LogMailer) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return ErrNoRecipient
+	}
+	slog.InfoContext(ctx, "Mail not sent, no provider configured", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
@@ -0,0 +1,226 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mailboxLimit is how many messages a Mailbox keeps; older ones are dropped
+const mailboxLimit = 200
+
+// ErrMessageNotFound is returned by Mailbox.Get for an unknown ID
+var ErrMessageNotFound = errors.New("mail: message not found")
+
+// mailboxID matches the IDs a Mailbox hands out, so they are safe as file names
+var mailboxID = regexp.MustCompile(`^[0-9]{20}-[0-9a-f]{8}$`)
+
+// Delivered is a message kept by a Mailbox
+type Delivered struct {
+	ID     string    `json:"id"`
+	SentAt time.Time `json:"sent_at"`
+	Message
+}
+
+// Mailbox keeps messages instead of sending them, for tests and local
+// development. Without a directory it holds them in memory; with one it
+// writes each message as a JSON file there, so separate processes such as
+// the API and the worker see the same mail.
+type Mailbox struct {
+	dir string
+
+	mu       sync.Mutex
+	messages []Delivered
+}
+
+// NewMailbox creates a mailbox in dir, or in memory when dir is empty
+func NewMailbox(dir string) *Mailbox {
+	return &Mailbox{dir: dir}
+}
+
+func (m *Mailbox) Send(_ context.Context, msg Message) error {
+	if msg.To == "" {
+		return ErrNoRecipient
+	}
+
+	now := time.Now().UTC()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	// Zero-padded nanoseconds sort IDs by time
+	d := Delivered{
+		ID:      fmt.Sprintf("%020d-%s", now.UnixNano(), hex.EncodeToString(suffix)),
+		SentAt:  now,
+		Message: msg,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dir == "" {
+		m.messages = append(m.messages, d)
+		if len(m.messages) > mailboxLimit {
+			m.messages = slices.Delete(m.messages, 0, len(m.messages)-mailboxLimit)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("mailbox: %w", err)
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.path(d.ID), data, 0o644); err != nil {
+		return fmt.Errorf("mailbox: %w", err)
+	}
+	return m.trim()
+}
+
+// Messages returns the kept messages, newest first
+func (m *Mailbox) Messages() ([]Delivered, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dir == "" {
+		messages := slices.Clone(m.messages)
+		slices.Reverse(messages)
+		return messages, nil
+	}
+
+	ids, err := m.ids()
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]Delivered, 0, len(ids))
+	for _, id := range slices.Backward(ids) {
+		d, err := m.read(id)
+		if errors.Is(err, ErrMessageNotFound) {
+			// Removed by another process since the listing
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, d)
+	}
+	return messages, nil
+}
+
+// Get returns the message with id
+func (m *Mailbox) Get(id string) (Delivered, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dir == "" {
+		for _, d := range m.messages {
+			if d.ID == id {
+				return d, nil
+			}
+		}
+		return Delivered{}, ErrMessageNotFound
+	}
+	if !mailboxID.MatchString(id) {
+		return Delivered{}, ErrMessageNotFound
+	}
+	return m.read(id)
+}
+
+// Last returns the newest message to the given address; tests use it to pick
+// up links such as a password reset
+func (m *Mailbox) Last(to string) (Delivered, error) {
+	messages, err := m.Messages()
+	if err != nil {
+		return Delivered{}, err
+	}
+	for _, d := range messages {
+		if strings.EqualFold(d.To, to) {
+			return d, nil
+		}
+	}
+	return Delivered{}, ErrMessageNotFound
+}
+
+// Clear removes every message
+func (m *Mailbox) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dir == "" {
+		m.messages = nil
+		return nil
+	}
+	ids, err := m.ids()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := os.Remove(m.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("mailbox: %w", err)
+		}
+	}
+	return nil
+}
+
+// ids lists the stored message IDs, oldest first; the caller holds mu
+func (m *Mailbox) ids() ([]string, error) {
+	entries, err := os.ReadDir(m.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mailbox: %w", err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if ok && mailboxID.MatchString(id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// trim drops the oldest files beyond mailboxLimit; the caller holds mu
+func (m *Mailbox) trim() error {
+	ids, err := m.ids()
+	if err != nil || len(ids) <= mailboxLimit {
+		return err
+	}
+	for _, id := range ids[:len(ids)-mailboxLimit] {
+		if err := os.Remove(m.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("mailbox: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m *Mailbox) read(id string) (Delivered, error) {
+	data, err := os.ReadFile(m.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return Delivered{}, ErrMessageNotFound
+	}
+	if err != nil {
+		return Delivered{}, fmt.Errorf("mailbox: %w", err)
+	}
+	var d Delivered
+	if err := json.Unmarshal(data, &d); err != nil {
+		return Delivered{}, fmt.Errorf("mailbox: reading %s: %w", id, err)
+	}
+	return d, nil
+}
+
+func (m *Mailbox) path(id string) string {
+	return filepath.Join(m.dir, id+".json")
+}
@@ -0,0 +1,79 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultResendURL is the Resend API used when no URL is configured
+const DefaultResendURL = "https://api.resend.com"
+
+// Resend sends messages through the Resend HTTP API
+type Resend struct {
+	url    string
+	key    string
+	from   string
+	client *http.Client
+}
+
+// NewResend creates a Resend mailer that sends as from
+func NewResend(url, key, from string, client *http.Client) *Resend {
+	if url == "" {
+		url = DefaultResendURL
+	}
+	return &Resend{
+		url:    strings.TrimSuffix(url, "/"),
+		key:    key,
+		from:   from,
+		client: client,
+	}
+}
+
+type resendEmail struct {
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Text    string   `json:"text,omitempty"`
+	HTML    string   `json:"html,omitempty"`
+}
+
+func (r *Resend) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return ErrNoRecipient
+	}
+
+	body, err := json.Marshal(resendEmail{
+		From:    r.from,
+		To:      []string{msg.To},
+		Subject: msg.Subject,
+		Text:    msg.Text,
+		HTML:    msg.HTML,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+"/emails", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.key)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("resend: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("resend: status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// dateLayouts is how each locale writes a point in time; others use defaultDateLayout
+var dateLayouts = map[string]string{
+	"en": "15:04 MST on Jan 2, 2006",
+	"tr": "02.01.2006 15:04 MST",
+}
+
+const defaultDateLayout = "2006-01-02 15:04 MST"
+
+// Templates renders the embedded messages in every locale under templates/.
+// A message <name> in locale <l> is made of <l>/<name>.txt, which defines
+// the "<name>.subject" and "<name>.txt" templates, and optionally
+// <l>/<name>.html, which defines the "content" of layout.html.
+type Templates struct {
+	fallback string
+	locales  map[string]*localeTemplates
+}
+
+type localeTemplates struct {
+	text *texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// LoadTemplates parses the embedded templates. Messages in a locale that
+// lacks them, and messages without a known locale, use fallback.
+func LoadTemplates(fallback string) (*Templates, error) {
+	dirs, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{fallback: fallback, locales: make(map[string]*localeTemplates)}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		locale := dir.Name()
+		lt, err := loadLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		t.locales[locale] = lt
+	}
+	if _, ok := t.locales[fallback]; !ok {
+		return nil, fmt.Errorf("no templates for the fallback locale %q", fallback)
+	}
+	return t, nil
+}
+
+// MustLoadTemplates is LoadTemplates for callers that treat a broken
+// embedded template as a programming error
+func MustLoadTemplates(fallback string) *Templates {
+	t, err := LoadTemplates(fallback)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func loadLocale(locale string) (*localeTemplates, error) {
+	funcs := localeFuncs(locale)
+
+	text, err := texttemplate.New(locale).Funcs(funcs).ParseFS(templateFS, "templates/"+locale+"/*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s text templates: %w", locale, err)
+	}
+
+	layout, err := htmltemplate.New("layout.html").Funcs(funcs).ParseFS(templateFS, "templates/layout.html")
+	if err != nil {
+		return nil, fmt.Errorf("parsing layout: %w", err)
+	}
+	files, err := fs.Glob(templateFS, "templates/"+locale+"/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	html := make(map[string]*htmltemplate.Template)
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".html")
+		t, err := htmltemplate.Must(layout.Clone()).ParseFS(templateFS, file)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		html[name] = t
+	}
+
+	return &localeTemplates{text: text, html: html}, nil
+}
+
+// localeFuncs are the template functions, formatted for locale
+func localeFuncs(locale string) map[string]any {
+	layout, ok := dateLayouts[locale]
+	if !ok {
+		layout = defaultDateLayout
+	}
+	return map[string]any{
+		"locale": func() string { return locale },
+		// datetime also takes RFC 3339 strings, which is what times become in job arguments
+		"datetime": func(v any) string {
+			switch v := v.(type) {
+			case time.Time:
+				return v.UTC().Format(layout)
+			case *time.Time:
+				if v == nil {
+					return ""
+				}
+				return v.UTC().Format(layout)
+			case string:
+				parsed, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					return v
+				}
+				return parsed.UTC().Format(layout)
+			default:
+				return fmt.Sprint(v)
+			}
+		},
+	}
+}
+
+// Locales lists the available locales, the fallback first
+func (t *Templates) Locales() []string {
+	locales := []string{t.fallback}
+	for locale := range t.locales {
+		if locale != t.fallback {
+			locales = append(locales, locale)
+		}
+	}
+	slices.Sort(locales[1:])
+	return locales
+}
+
+// Match returns the available locale for a tag such as "tr-TR": the tag
+// itself, else its language, else the fallback
+func (t *Templates) Match(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if _, ok := t.locales[locale]; ok {
+		return locale
+	}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		if _, ok := t.locales[lang]; ok {
+			return lang
+		}
+	}
+	return t.fallback
+}
+
+// Render builds the message name in locale for to from data
+func (t *Templates) Render(name, locale, to string, data any) (Message, error) {
+	lt := t.locales[t.Match(locale)]
+	if lt.text.Lookup(name+".txt") == nil {
+		// Not translated yet
+		lt = t.locales[t.fallback]
+	}
+
+	subject, err := executeText(lt.text, name+".subject", data)
+	if err != nil {
+		return Message{}, err
+	}
+	text, err := executeText(lt.text, name+".txt", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	msg := Message{To: to, Subject: strings.TrimSpace(subject), Text: text}
+	if html, ok := lt.html[name]; ok {
+		var buf bytes.Buffer
+		if err := html.ExecuteTemplate(&buf, "layout", data); err != nil {
+			return Message{}, fmt.Errorf("rendering %s.html: %w", name, err)
+		}
+		msg.HTML = buf.String()
+	}
+	return msg, nil
+}
+
+func executeText(t *texttemplate.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("rendering %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
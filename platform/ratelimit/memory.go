@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired keys are removed from a MemoryStore
+const sweepInterval = time.Minute
+
+// MemoryStore keeps buckets in process memory. Each replica counts on its
+// own, so it suits single instances, development and tests.
+type MemoryStore struct {
+	// Now returns the current time; tests may replace it
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Now:     time.Now,
+		buckets: make(map[string]time.Time),
+	}
+}
+
+func (s *MemoryStore) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Now()
+	s.sweep(now)
+
+	tat, ok := s.buckets[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	interval := limit.interval()
+	allowAt := tat.Add(interval - limit.Window)
+	if now.Before(allowAt) {
+		return result(limit, false, tat.Sub(now), allowAt.Sub(now)), nil
+	}
+
+	tat = tat.Add(interval)
+	s.buckets[key] = tat
+	return result(limit, true, tat.Sub(now), 0), nil
+}
+
+// sweep drops buckets that have refilled completely; the caller holds mu
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, tat := range s.buckets {
+		if !tat.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreAllow(t *testing.T) {
+	limit := Limit{Requests: 3, Window: 3 * time.Second}
+
+	type step struct {
+		// advance moves the clock before the request
+		advance       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst up to the limit",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2},
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRemaining: 0, wantRetry: time.Second},
+			},
+		},
+		{
+			name: "one request is earned back per interval",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2},
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{advance: 500 * time.Millisecond, wantAllowed: false, wantRetry: 500 * time.Millisecond},
+				{advance: 500 * time.Millisecond, wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRetry: time.Second},
+			},
+		},
+		{
+			name: "a full window refills the bucket",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2},
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{advance: 10 * time.Second, wantAllowed: true, wantRemaining: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1_700_000_000, 0)
+			store := NewMemoryStore()
+			store.Now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				got, err := store.Allow(context.Background(), "key", limit)
+				if err != nil {
+					t.Fatalf("step %d: %v", i, err)
+				}
+				if got.Allowed != s.wantAllowed || got.Remaining != s.wantRemaining || got.RetryAfter != s.wantRetry {
+					t.Errorf("step %d: got allowed=%v remaining=%d retry=%v, want allowed=%v remaining=%d retry=%v",
+						i, got.Allowed, got.Remaining, got.RetryAfter, s.wantAllowed, s.wantRemaining, s.wantRetry)
+				}
+				if got.Limit != limit.Requests {
+					t.Errorf("step %d: limit = %d, want %d", i, got.Limit, limit.Requests)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Window: time.Minute}
+
+	for _, key := range []string{"a", "b"} {
+		got, err := store.Allow(context.Background(), key, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Allowed {
+			t.Errorf("first request for %q was refused", key)
+		}
+	}
+	if got, _ := store.Allow(context.Background(), "a", limit); got.Allowed {
+		t.Error("second request for a was allowed")
+	}
+}
+
+func TestMemoryStoreInvalidLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit Limit
+	}{
+		{"no requests", Limit{Requests: 0, Window: time.Second}},
+		{"no window", Limit{Requests: 1}},
+		{"negative", Limit{Requests: -1, Window: -time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMemoryStore().Allow(context.Background(), "key", tt.limit)
+			if !errors.Is(err, ErrInvalidLimit) {
+				t.Errorf("err = %v, want ErrInvalidLimit", err)
+			}
+		})
+	}
+}
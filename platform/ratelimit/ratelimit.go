@@ -0,0 +1,61 @@
+// Package ratelimit throttles requests with GCRA, a token bucket that only
+// stores the theoretical arrival time of the next request per key
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Limit allows Requests per Window, all of which may be spent at once
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// interval is how long it takes to earn back one request
+func (l Limit) interval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// Result is the outcome of one request against a limit
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the full budget is available again
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed; zero when Allowed
+	RetryAfter time.Duration
+}
+
+// Store counts requests per key
+type Store interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// ErrInvalidLimit is returned for a limit without requests or window
+var ErrInvalidLimit = errors.New("limit needs positive requests and window")
+
+func (l Limit) validate() error {
+	if l.Requests <= 0 || l.Window <= 0 {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// result builds a Result from how far the bucket is from full after the request
+func result(limit Limit, allowed bool, untilFull, retryAfter time.Duration) Result {
+	remaining := int((limit.Window - untilFull) / limit.interval())
+	if remaining < 0 || !allowed {
+		remaining = 0
+	}
+	return Result{
+		Allowed:    allowed,
+		Limit:      limit.Requests,
+		Remaining:  remaining,
+		Reset:      untilFull,
+		RetryAfter: retryAfter,
+	}
+}
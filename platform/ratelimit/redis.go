@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// gcraScript applies one request atomically using the Redis clock, so every
+// replica agrees on time. It returns {allowed, ms until full, ms until allowed}.
+var gcraScript = redis.NewScript(`
+local now = redis.call('TIME')
+now = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+local interval = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local allow_at = tat + interval - window
+if now < allow_at then
+	return {0, tat - now, allow_at - now}
+end
+
+tat = tat + interval
+redis.call('SET', KEYS[1], tat, 'PX', tat - now)
+return {1, tat - now, 0}
+`)
+
+// RedisStore keeps buckets in Redis so every replica shares them
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a store whose keys are prefixed with prefix
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+
+	// Millisecond precision; an interval below 1ms would never refill
+	interval := max(limit.interval().Milliseconds(), 1)
+	values, err := gcraScript.Run(ctx, s.client, []string{s.prefix + key}, interval, limit.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit %s: %w", key, err)
+	}
+	if len(values) != 3 {
+		return Result{}, fmt.Errorf("rate limit %s: unexpected reply %v", key, values)
+	}
+
+	return result(limit, values[0] == 1, time.Duration(values[1])*time.Millisecond, time.Duration(values[2])*time.Millisecond), nil
+}
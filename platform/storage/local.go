@@ -0,0 +1,391 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalConfig configures a Local store
+type LocalConfig struct {
+	// Dir holds the objects and their metadata
+	Dir string
+	// URL is where Handler is mounted, e.g. http://localhost:8080/storage
+	URL string
+	// Secret signs presigned URLs
+	Secret []byte
+}
+
+// Local keeps objects as files under a directory, for development and
+// tests. Presigned URLs point at Handler, which must be mounted at URL.
+type Local struct {
+	config LocalConfig
+}
+
+// localMeta is what a file cannot tell about itself
+type localMeta struct {
+	ContentType string `json:"content_type"`
+	SHA256      string `json:"sha256"`
+}
+
+// NewLocal creates a store in config.Dir
+func NewLocal(config LocalConfig) *Local {
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Local{config: config}
+}
+
+func (l *Local) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (*Object, error) {
+	body, contentType, err := prepare(key, body, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write next to the objects and rename, so readers never see a partial file
+	tmpDir := filepath.Join(l.config.Dir, "tmp")
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	tmp, err := os.CreateTemp(tmpDir, "upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	d := newDigest(body)
+	_, err = io.Copy(tmp, contextReader{ctx: ctx, r: d})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: writing %s: %w", key, err)
+	}
+
+	meta := localMeta{ContentType: contentType, SHA256: d.sum()}
+	if err := l.writeMeta(key, meta); err != nil {
+		return nil, err
+	}
+	path := l.objectPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	obj, err := l.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return obj, verify(ctx, l, obj, opts.SHA256)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	obj, err := l.Stat(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(l.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("storage: %w", err)
+	}
+	return f, obj, nil
+}
+
+func (l *Local) Stat(_ context.Context, key string) (*Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(l.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	obj := l.object(key, info)
+	meta, err := l.readMeta(key)
+	if err != nil {
+		return nil, err
+	}
+	obj.ContentType = meta.ContentType
+	obj.SHA256 = meta.SHA256
+	return &obj, nil
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	for _, path := range []string{l.objectPath(key), l.metaPath(key)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("storage: %w", err)
+		}
+	}
+	return nil
+}
+
+func (l *Local) List(ctx context.Context, prefix string) ([]Object, error) {
+	root := filepath.Join(l.config.Dir, "objects")
+	var objects []Object
+	// WalkDir visits files in lexical order, which is key order
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if entry.IsDir() {
+			// Skip directories that cannot contain a match
+			if path != root && !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, l.object(key, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: listing %s: %w", prefix, err)
+	}
+	return objects, nil
+}
+
+func (l *Local) PresignGet(_ context.Context, key string, opts PresignOptions) (*PresignedRequest, error) {
+	return l.presign(http.MethodGet, key, opts)
+}
+
+func (l *Local) PresignPut(_ context.Context, key string, opts PresignOptions) (*PresignedRequest, error) {
+	req, err := l.presign(http.MethodPut, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ContentType != "" {
+		req.Headers["Content-Type"] = opts.ContentType
+	}
+	return req, nil
+}
+
+func (l *Local) presign(method, key string, opts PresignOptions) (*PresignedRequest, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(opts.TTL).Truncate(time.Second)
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	if method == http.MethodPut {
+		if opts.ContentType != "" {
+			query.Set("content_type", opts.ContentType)
+		}
+		if opts.Size > 0 {
+			query.Set("size", strconv.FormatInt(opts.Size, 10))
+		}
+	} else if opts.Filename != "" {
+		query.Set("filename", opts.Filename)
+	}
+	query.Set("signature", hex.EncodeToString(l.sign(method, key, query)))
+
+	return &PresignedRequest{
+		Method:    method,
+		URL:       l.config.URL + "/" + escapeKey(key) + "?" + query.Encode(),
+		Headers:   map[string]string{},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// sign covers the method, key and every query parameter but the signature
+func (l *Local) sign(method, key string, query url.Values) []byte {
+	mac := hmac.New(sha256.New, l.config.Secret)
+	fmt.Fprintf(mac, "%s\n%s\n", method, key)
+	for _, name := range []string{"expires", "content_type", "size", "filename"} {
+		fmt.Fprintf(mac, "%s=%s\n", name, query.Get(name))
+	}
+	return mac.Sum(nil)
+}
+
+// Handler serves the presigned URLs; mount it at LocalConfig.URL with the
+// path prefix stripped
+func (l *Local) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		query := r.URL.Query()
+		if err := l.checkSignature(r.Method, key, query); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			l.serveGet(w, r, key, query.Get("filename"))
+		case http.MethodPut:
+			l.servePut(w, r, key, query)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (l *Local) checkSignature(method, key string, query url.Values) error {
+	expected, err := hex.DecodeString(query.Get("signature"))
+	if err != nil || !hmac.Equal(expected, l.sign(method, key, query)) {
+		return ErrInvalidSignature
+	}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (l *Local) serveGet(w http.ResponseWriter, r *http.Request, key, filename string) {
+	body, obj, err := l.Get(r.Context(), key)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidKey) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	if obj.ContentType != "" {
+		w.Header().Set("Content-Type", obj.ContentType)
+	}
+	if disposition := contentDisposition(filename); disposition != "" {
+		w.Header().Set("Content-Disposition", disposition)
+	}
+	w.Header().Set("ETag", `"`+obj.ETag+`"`)
+	http.ServeContent(w, r, "", obj.LastModified, body.(io.ReadSeeker))
+}
+
+func (l *Local) servePut(w http.ResponseWriter, r *http.Request, key string, query url.Values) {
+	contentType := query.Get("content_type")
+	if contentType != "" && r.Header.Get("Content-Type") != contentType {
+		http.Error(w, "Content-Type does not match the signed type", http.StatusForbidden)
+		return
+	}
+	body := io.Reader(r.Body)
+	if size := query.Get("size"); size != "" {
+		if strconv.FormatInt(r.ContentLength, 10) != size {
+			http.Error(w, "Content-Length does not match the signed size", http.StatusForbidden)
+			return
+		}
+		body = io.LimitReader(r.Body, r.ContentLength)
+	}
+
+	if _, err := l.Put(r.Context(), key, body, PutOptions{ContentType: contentType}); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidKey) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (l *Local) object(key string, info fs.FileInfo) Object {
+	return Object{
+		Key:          key,
+		Size:         info.Size(),
+		ETag:         strconv.FormatInt(info.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(info.Size(), 16),
+		LastModified: info.ModTime().UTC(),
+	}
+}
+
+func (l *Local) readMeta(key string) (localMeta, error) {
+	var meta localMeta
+	data, err := os.ReadFile(l.metaPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		// Copied in by hand rather than through Put
+		return meta, nil
+	}
+	if err != nil {
+		return meta, fmt.Errorf("storage: %w", err)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("storage: reading metadata of %s: %w", key, err)
+	}
+	return meta, nil
+}
+
+func (l *Local) writeMeta(key string, meta localMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	path := l.metaPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	return nil
+}
+
+func (l *Local) objectPath(key string) string {
+	return filepath.Join(l.config.Dir, "objects", filepath.FromSlash(key))
+}
+
+func (l *Local) metaPath(key string) string {
+	return filepath.Join(l.config.Dir, "meta", filepath.FromSlash(key)+".json")
+}
+
+// escapeKey escapes each segment of key for use in a URL path
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// contextReader stops reading once ctx is done, so a canceled upload ends
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestLocal serves a Local store the way the server mounts it
+func newTestLocal(t *testing.T) *Local {
+	t.Helper()
+	var store *Local
+	srv := httptest.NewServer(http.StripPrefix("/storage", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store.Handler().ServeHTTP(w, r)
+	})))
+	t.Cleanup(srv.Close)
+	store = NewLocal(LocalConfig{Dir: t.TempDir(), URL: srv.URL + "/storage/", Secret: []byte("test-secret")})
+	return store
+}
+
+func do(t *testing.T, req *PresignedRequest, body string) (*http.Response, string) {
+	t.Helper()
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	httpReq, err := http.NewRequest(req.Method, req.URL, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(data)
+}
+
+func TestLocalPutGet(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocal(t)
+	const content = "hello, labubu"
+	sum := sha256.Sum256([]byte(content))
+
+	obj, err := store.Put(ctx, "notes/hello.txt", strings.NewReader(content), PutOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Size != int64(len(content)) || obj.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("Put() = %+v, want size %d and the content digest", obj, len(content))
+	}
+	if !strings.HasPrefix(obj.ContentType, "text/plain") {
+		t.Errorf("ContentType = %q, want it sniffed as text/plain", obj.ContentType)
+	}
+
+	body, got, err := store.Get(ctx, "notes/hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content || got.SHA256 != obj.SHA256 {
+		t.Errorf("Get() = %q, %+v; want the stored content", data, got)
+	}
+
+	if _, err := store.Put(ctx, "notes/bad.txt", strings.NewReader(content), PutOptions{SHA256: strings.Repeat("0", 64)}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Put() with a wrong digest = %v, want ErrChecksumMismatch", err)
+	}
+	if _, err := store.Stat(ctx, "notes/bad.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat() after a checksum mismatch = %v, want ErrNotFound", err)
+	}
+
+	if err := store.Delete(ctx, "notes/hello.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Get(ctx, "notes/hello.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalPresignRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocal(t)
+	const content = `{"name":"labubu"}`
+	const key = "exports/my report.json"
+
+	put, err := store.PresignPut(ctx, key, PresignOptions{TTL: time.Minute, ContentType: "application/json", Size: int64(len(content))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, body := do(t, put, content); resp.StatusCode != http.StatusOK {
+		t.Fatalf("presigned PUT = %d %s", resp.StatusCode, body)
+	}
+
+	get, err := store.PresignGet(ctx, key, PresignOptions{TTL: time.Minute, Filename: "report.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, body := do(t, get, "")
+	if resp.StatusCode != http.StatusOK || body != content {
+		t.Fatalf("presigned GET = %d %q, want %q", resp.StatusCode, body, content)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want the signed type", got)
+	}
+	if got := resp.Header.Get("Content-Disposition"); !strings.Contains(got, "report.json") {
+		t.Errorf("Content-Disposition = %q, want the filename", got)
+	}
+}
+
+func TestLocalPresignRejects(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocal(t)
+	if _, err := store.Put(ctx, "a.txt", strings.NewReader("a"), PutOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	signed := func(method string, opts PresignOptions) *PresignedRequest {
+		t.Helper()
+		var req *PresignedRequest
+		var err error
+		if method == http.MethodPut {
+			req, err = store.PresignPut(ctx, "a.txt", opts)
+		} else {
+			req, err = store.PresignGet(ctx, "a.txt", opts)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+
+	tests := []struct {
+		name   string
+		req    func() *PresignedRequest
+		body   string
+		status int
+	}{
+		{
+			name: "expired",
+			req: func() *PresignedRequest {
+				return signed(http.MethodGet, PresignOptions{TTL: -time.Minute})
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name: "tampered key",
+			req: func() *PresignedRequest {
+				req := signed(http.MethodGet, PresignOptions{TTL: time.Minute})
+				req.URL = strings.Replace(req.URL, "/a.txt?", "/b.txt?", 1)
+				return req
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name: "GET URL used for PUT",
+			req: func() *PresignedRequest {
+				req := signed(http.MethodGet, PresignOptions{TTL: time.Minute})
+				req.Method = http.MethodPut
+				return req
+			},
+			body:   "b",
+			status: http.StatusForbidden,
+		},
+		{
+			name: "wrong content type",
+			req: func() *PresignedRequest {
+				req := signed(http.MethodPut, PresignOptions{TTL: time.Minute, ContentType: "image/png"})
+				req.Headers["Content-Type"] = "text/html"
+				return req
+			},
+			body:   "b",
+			status: http.StatusForbidden,
+		},
+		{
+			name: "wrong size",
+			req: func() *PresignedRequest {
+				return signed(http.MethodPut, PresignOptions{TTL: time.Minute, Size: 10})
+			},
+			body:   "b",
+			status: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if resp, body := do(t, tt.req(), tt.body); resp.StatusCode != tt.status {
+				t.Errorf("status = %d %s, want %d", resp.StatusCode, body, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// partSize is the size of each part of a multipart upload; S3 requires at
+// least 5 MiB for every part but the last. Bodies that fit in one part are
+// sent in a single request.
+const partSize = 8 << 20
+
+// sha256Metadata is the user metadata key that keeps the digest computed by Put
+const sha256Metadata = "sha256"
+
+// S3Config configures an S3 store
+type S3Config struct {
+	// Endpoint is the S3 API, e.g. https://<account>.r2.cloudflarestorage.com
+	// or http://localhost:9000 for MinIO
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// HTTPClient defaults to the SDK's client
+	HTTPClient *http.Client
+}
+
+// S3 keeps objects in an S3-compatible bucket such as Cloudflare R2 or MinIO
+type S3 struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
+}
+
+// NewS3 creates a store for config.Bucket. It addresses the bucket by path,
+// which R2 and MinIO both accept.
+func NewS3(config S3Config) *S3 {
+	options := s3.Options{
+		Region:       config.Region,
+		BaseEndpoint: aws.String(config.Endpoint),
+		Credentials:  credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""),
+		UsePathStyle: true,
+		// Checksums are added where the API requires them only, since not every
+		// S3-compatible store understands the newer checksum headers
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	}
+	if config.HTTPClient != nil {
+		options.HTTPClient = config.HTTPClient
+	}
+	client := s3.New(options)
+	return &S3{
+		client:  client,
+		presign: s3.NewPresignClient(client),
+		bucket:  config.Bucket,
+	}
+}
+
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (*Object, error) {
+	body, contentType, err := prepare(key, body, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDigest(body)
+	first := make([]byte, partSize)
+	n, err := io.ReadFull(d, first)
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		err = s.putSingle(ctx, key, first[:n], contentType)
+	case err == nil:
+		err = s.putMultipart(ctx, key, d, first, contentType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: writing %s: %w", key, err)
+	}
+
+	obj, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	obj.SHA256 = d.sum()
+	return obj, verify(ctx, s, obj, opts.SHA256)
+}
+
+// putSingle sends a body that fits in one part; the store checks the digest
+func (s *S3) putSingle(ctx context.Context, key string, body []byte, contentType string) error {
+	sum := sha256.Sum256(body)
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:         aws.String(s.bucket),
+		Key:            aws.String(key),
+		Body:           bytes.NewReader(body),
+		ContentLength:  aws.Int64(int64(len(body))),
+		ContentType:    aws.String(contentType),
+		ChecksumSHA256: aws.String(base64.StdEncoding.EncodeToString(sum[:])),
+		Metadata:       map[string]string{sha256Metadata: hex.EncodeToString(sum[:])},
+	})
+	return err
+}
+
+// putMultipart streams a body larger than one part, holding one part in
+// memory at a time. The digest of the whole body is only known at the end,
+// so it is not kept as metadata.
+func (s *S3) putMultipart(ctx context.Context, key string, body io.Reader, first []byte, contentType string) (err error) {
+	upload, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			// Abandoned parts are billed until aborted
+			_, _ = s.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(s.bucket),
+				Key:      aws.String(key),
+				UploadId: upload.UploadId,
+			})
+		}
+	}()
+
+	var parts []types.CompletedPart
+	part := first
+	for number := int32(1); ; number++ {
+		out, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(s.bucket),
+			Key:           aws.String(key),
+			UploadId:      upload.UploadId,
+			PartNumber:    aws.Int32(number),
+			Body:          bytes.NewReader(part),
+			ContentLength: aws.Int64(int64(len(part))),
+		})
+		if err != nil {
+			return err
+		}
+		parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(number)})
+
+		part = first[:partSize]
+		n, err := io.ReadFull(body, part)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		part = part[:n]
+	}
+
+	_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	return err
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, nil, err
+	}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, nil, s.error(key, err)
+	}
+	return out.Body, &Object{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		SHA256:       out.Metadata[sha256Metadata],
+		ETag:         strings.Trim(aws.ToString(out.ETag), `"`),
+		LastModified: aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (*Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, s.error(key, err)
+	}
+	return &Object{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		SHA256:       out.Metadata[sha256Metadata],
+		ETag:         strings.Trim(aws.ToString(out.ETag), `"`),
+		LastModified: aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err = s.error(key, err); errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("storage: listing %s: %w", prefix, err)
+		}
+		for _, item := range page.Contents {
+			objects = append(objects, Object{
+				Key:          aws.ToString(item.Key),
+				Size:         aws.ToInt64(item.Size),
+				ETag:         strings.Trim(aws.ToString(item.ETag), `"`),
+				LastModified: aws.ToTime(item.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
+func (s *S3) PresignGet(ctx context.Context, key string, opts PresignOptions) (*PresignedRequest, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if disposition := contentDisposition(opts.Filename); disposition != "" {
+		input.ResponseContentDisposition = aws.String(disposition)
+	}
+	req, err := s.presign.PresignGetObject(ctx, input, s3.WithPresignExpires(opts.TTL))
+	if err != nil {
+		return nil, fmt.Errorf("storage: presigning %s: %w", key, err)
+	}
+	return presigned(req.Method, req.URL, req.SignedHeader, opts.TTL), nil
+}
+
+func (s *S3) PresignPut(ctx context.Context, key string, opts PresignOptions) (*PresignedRequest, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.Size > 0 {
+		input.ContentLength = aws.Int64(opts.Size)
+	}
+	req, err := s.presign.PresignPutObject(ctx, input, s3.WithPresignExpires(opts.TTL))
+	if err != nil {
+		return nil, fmt.Errorf("storage: presigning %s: %w", key, err)
+	}
+	return presigned(req.Method, req.URL, req.SignedHeader, opts.TTL), nil
+}
+
+// presigned keeps the signed headers a client has to send; clients set Host
+// from the URL themselves
+func presigned(method, url string, signed http.Header, ttl time.Duration) *PresignedRequest {
+	headers := make(map[string]string)
+	for name, values := range signed {
+		if !strings.EqualFold(name, "Host") && len(values) > 0 {
+			headers[name] = values[0]
+		}
+	}
+	return &PresignedRequest{
+		Method:    method,
+		URL:       url,
+		Headers:   headers,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// error maps a missing object to ErrNotFound
+func (s *S3) error(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NoSuchKey", "NotFound":
+			return ErrNotFound
+		}
+	}
+	return fmt.Errorf("storage: %s: %w", key, err)
+}
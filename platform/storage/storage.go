@@ -0,0 +1,179 @@
+// Package storage keeps files in an object store: an S3-compatible bucket
+// such as Cloudflare R2 or MinIO, or a local directory for development
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxKeyLength is the longest key S3 accepts
+const maxKeyLength = 1024
+
+// sniffLength is how much of a body http.DetectContentType looks at
+const sniffLength = 512
+
+var (
+	ErrNotFound         = errors.New("storage: object not found")
+	ErrInvalidKey       = errors.New("storage: invalid object key")
+	ErrChecksumMismatch = errors.New("storage: checksum mismatch")
+	// ErrInvalidSignature is returned by the local presigned URL handler
+	ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+)
+
+// Object describes a stored object
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	// SHA256 is the hex digest of the content. Put always sets it; Get and
+	// Stat only when the object was written by Put, and List never does.
+	SHA256       string
+	ETag         string
+	LastModified time.Time
+}
+
+// PutOptions describe the body passed to Put
+type PutOptions struct {
+	// ContentType is sniffed from the start of the body when empty
+	ContentType string
+	// SHA256 is the expected hex digest, if known. When the content does not
+	// match, the object is removed and Put returns ErrChecksumMismatch.
+	SHA256 string
+}
+
+// PresignOptions configure a presigned request
+type PresignOptions struct {
+	// TTL is how long the URL works
+	TTL time.Duration
+	// ContentType and Size bind an upload to this type and exact length; a
+	// Size of 0 leaves the length open
+	ContentType string
+	Size        int64
+	// Filename makes a download save as this name
+	Filename string
+}
+
+// PresignedRequest is a request a client can make without credentials
+type PresignedRequest struct {
+	Method string
+	URL    string
+	// Headers must be sent as they are, since they are part of the signature
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// Store puts, gets and lists objects by key. Keys are slash-separated paths
+// without leading slash or "." and ".." segments.
+type Store interface {
+	// Put streams body to key, replacing any object there
+	Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (*Object, error)
+	// Get opens the object; the caller closes the body
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	Stat(ctx context.Context, key string) (*Object, error)
+	// Delete removes the object; a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// List returns the objects whose key starts with prefix, in key order
+	List(ctx context.Context, prefix string) ([]Object, error)
+	PresignGet(ctx context.Context, key string, opts PresignOptions) (*PresignedRequest, error)
+	PresignPut(ctx context.Context, key string, opts PresignOptions) (*PresignedRequest, error)
+}
+
+// ValidateKey reports ErrInvalidKey for keys that are not safe on every backend
+func ValidateKey(key string) error {
+	if key == "" || len(key) > maxKeyLength || !utf8.ValidString(key) {
+		return ErrInvalidKey
+	}
+	if strings.HasPrefix(key, "/") || strings.ContainsRune(key, '\\') {
+		return ErrInvalidKey
+	}
+	for _, r := range key {
+		if unicode.IsControl(r) {
+			return ErrInvalidKey
+		}
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// Sniff detects the content type from the start of body and returns a
+// reader that still yields all of body
+func Sniff(body io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), body), nil
+}
+
+// digest hashes and counts what is read through it
+type digest struct {
+	r    io.Reader
+	hash hash.Hash
+	size int64
+}
+
+func newDigest(r io.Reader) *digest {
+	return &digest{r: r, hash: sha256.New()}
+}
+
+func (d *digest) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	d.hash.Write(p[:n])
+	d.size += int64(n)
+	return n, err
+}
+
+func (d *digest) sum() string {
+	return hex.EncodeToString(d.hash.Sum(nil))
+}
+
+// prepare validates key and sniffs the content type when opts has none
+func prepare(key string, body io.Reader, opts PutOptions) (io.Reader, string, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, "", err
+	}
+	if opts.ContentType != "" {
+		return body, opts.ContentType, nil
+	}
+	contentType, body, err := Sniff(body)
+	return body, contentType, err
+}
+
+// verify compares the written content with the expected digest and removes
+// the object on a mismatch
+func verify(ctx context.Context, store Store, obj *Object, expected string) error {
+	if expected == "" || strings.EqualFold(expected, obj.SHA256) {
+		return nil
+	}
+	// The upload is known to be bad; remove it even if ctx was canceled
+	if err := store.Delete(context.WithoutCancel(ctx), obj.Key); err != nil {
+		return errors.Join(ErrChecksumMismatch, err)
+	}
+	return ErrChecksumMismatch
+}
+
+// contentDisposition makes a download save as filename
+func contentDisposition(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+}
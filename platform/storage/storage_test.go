@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want error
+	}{
+		{"simple", "avatars/1.png", nil},
+		{"nested", "users/42/uploads/2024/report.pdf", nil},
+		{"unicode", "labubu/ラブブ.jpg", nil},
+		{"spaces", "exports/my file.csv", nil},
+		{"longest", strings.Repeat("a", maxKeyLength), nil},
+		{"empty", "", ErrInvalidKey},
+		{"too long", strings.Repeat("a", maxKeyLength+1), ErrInvalidKey},
+		{"leading slash", "/avatars/1.png", ErrInvalidKey},
+		{"trailing slash", "avatars/", ErrInvalidKey},
+		{"empty segment", "avatars//1.png", ErrInvalidKey},
+		{"dot segment", "avatars/./1.png", ErrInvalidKey},
+		{"parent segment", "avatars/../secrets", ErrInvalidKey},
+		{"only parent", "..", ErrInvalidKey},
+		{"backslash", `avatars\1.png`, ErrInvalidKey},
+		{"control character", "avatars/1\n.png", ErrInvalidKey},
+		{"invalid utf-8", "avatars/\xff.png", ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateKey(tt.key); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateKey(%q) = %v, want %v", tt.key, err, tt.want)
+			}
+		})
+	}
+}
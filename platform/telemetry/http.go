@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanNamedKey marks requests whose span OperationMiddleware has named
+type spanNamedKey struct{}
+
+// Middleware starts a server span for every incoming request and extracts
+// the W3C traceparent header sent by the caller. Spans are named after the
+// method and the chi route pattern, never the raw path, so IDs and tokens
+// stay out of span names.
+func Middleware(serverName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			operation := new(bool)
+			r = r.WithContext(context.WithValue(r.Context(), spanNamedKey{}, operation))
+			next.ServeHTTP(w, r)
+
+			// The pattern is only known once routing is done
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil || rctx.RoutePattern() == "" {
+				return
+			}
+			span := trace.SpanFromContext(r.Context())
+			span.SetAttributes(attribute.String("http.route", rctx.RoutePattern()))
+			if !*operation {
+				span.SetName(r.Method + " " + rctx.RoutePattern())
+			}
+		})
+		return otelhttp.NewHandler(named, serverName,
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method
+			}),
+		)
+	}
+}
+
+// OperationMiddleware renames the current server span after the OpenAPI
+// operation ID so traces group by operation instead of raw path
+func OperationMiddleware(f strictnethttp.StrictHTTPHandlerFunc, operationID string) strictnethttp.StrictHTTPHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		span := trace.SpanFromContext(ctx)
+		span.SetName(operationID)
+		span.SetAttributes(attribute.String("api.operation_id", operationID))
+		if named, ok := ctx.Value(spanNamedKey{}).(*bool); ok {
+			*named = true
+		}
+
+		response, err := f(ctx, w, r, request)
+		if err != nil {
+			span.RecordError(err)
+		}
+		return response, err
+	}
+}
+
+// NewTransport wraps base so outgoing requests carry the current trace context
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return otelhttp.NewTransport(base)
+}
+
+// NewHTTPClient returns an http.Client that propagates trace context
+func NewHTTPClient() *http.Client {
+	return &http.Client{Transport: NewTransport(nil)}
+}
@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const pgxTracerName = "github.com/abdurrahimagca/go-api-starter/platform/telemetry/pgx"
+
+// QueryTracer implements pgx.QueryTracer and emits a client span per query
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// NewQueryTracer creates a pgx tracer backed by the global tracer provider
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{
+		tracer: Tracer(pgxTracerName),
+	}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		// Skip queries that are not part of a request or job, such as pool health checks
+		return ctx
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemPostgreSQL,
+		semconv.DBQueryText(data.SQL),
+	}
+	if conn != nil {
+		cfg := conn.Config()
+		attrs = append(attrs,
+			semconv.DBNamespace(cfg.Database),
+			semconv.ServerAddress(cfg.Host),
+			semconv.ServerPort(int(cfg.Port)),
+		)
+	}
+
+	ctx, _ = t.tracer.Start(ctx, querySpanName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// querySpanName uses the sqlc "-- name: X" annotation when present, falling
+// back to the SQL verb so span names stay low-cardinality
+func querySpanName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if strings.HasPrefix(sql, "-- name: ") {
+		line, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), "\n")
+		if name, _, ok := strings.Cut(line, " "); ok {
+			return "db." + name
+		}
+	}
+	verb, _, _ := strings.Cut(sql, " ")
+	return "db." + strings.ToUpper(verb)
+}
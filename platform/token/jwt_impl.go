@@ -2,14 +2,23 @@ package token
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 )
 
 // Claims represents basic token claims
 type Claims struct {
+	Subject   string                 `json:"sub"`
+	Issuer    string                 `json:"iss"`
+	Audience  string                 `json:"aud"`
+	ID        string                 `json:"jti"`
 	ExpiresAt time.Time              `json:"exp"`
 	IssuedAt  time.Time              `json:"iat"`
 	Data      map[string]interface{} `json:"data,omitempty"`
@@ -19,7 +28,7 @@ type Claims struct {
 type IToken interface {
 	Verify(ctx context.Context, token string) (*Claims, error)
 	IsValid(ctx context.Context, token string) bool
-	Generate() (string, error)
+	Generate(subject string, ttl time.Duration, data map[string]interface{}) (string, error)
 }
 
 // Common errors
@@ -29,24 +38,80 @@ var (
 	ErrMalformedToken = errors.New("malformed token")
 )
 
+// jwtHeader is the fixed HS256 header
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// jwtPayload is the wire format of Claims using NumericDate timestamps
+type jwtPayload struct {
+	Subject   string                 `json:"sub,omitempty"`
+	Issuer    string                 `json:"iss,omitempty"`
+	Audience  string                 `json:"aud,omitempty"`
+	ID        string                 `json:"jti,omitempty"`
+	ExpiresAt int64                  `json:"exp"`
+	IssuedAt  int64                  `json:"iat"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
 // JWTToken implements IToken interface
 type JWTToken struct {
-	secret []byte
+	secret   []byte
+	issuer   string
+	audience string
+	now      func() time.Time
 }
 
-// NewJWTToken creates a new JWT token implementation
-func NewJWTToken(secret string) IToken {
+// NewJWTToken creates a new HS256 JWT token implementation
+func NewJWTToken(secret, issuer, audience string) IToken {
 	return &JWTToken{
-		secret: []byte(secret),
+		secret:   []byte(secret),
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
 	}
 }
 
 func (j *JWTToken) Verify(ctx context.Context, token string) (*Claims, error) {
-	// TODO: Implement proper JWT verification
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+	if parts[0] != jwtHeader {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(signature, j.sign(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var payload jwtPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if payload.Issuer != j.issuer || payload.Audience != j.audience {
+		return nil, ErrInvalidToken
+	}
+	expiresAt := time.Unix(payload.ExpiresAt, 0)
+	if !j.now().Before(expiresAt) {
+		return nil, ErrExpiredToken
+	}
+
 	return &Claims{
-		ExpiresAt: time.Now().Add(24 * time.Hour),
-		IssuedAt:  time.Now(),
-		Data:      map[string]interface{}{},
+		Subject:   payload.Subject,
+		Issuer:    payload.Issuer,
+		Audience:  payload.Audience,
+		ID:        payload.ID,
+		ExpiresAt: expiresAt,
+		IssuedAt:  time.Unix(payload.IssuedAt, 0),
+		Data:      payload.Data,
 	}, nil
 }
 
@@ -55,11 +120,47 @@ func (j *JWTToken) IsValid(ctx context.Context, token string) bool {
 	return err == nil
 }
 
-func (j *JWTToken) Generate() (string, error) {
-	// TODO: Implement proper JWT generation
-	bytes := make([]byte, 32)
+func (j *JWTToken) Generate(subject string, ttl time.Duration, data map[string]interface{}) (string, error) {
+	id, err := RandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := j.now()
+	raw, err := json.Marshal(jwtPayload{
+		Subject:   subject,
+		Issuer:    j.issuer,
+		Audience:  j.audience,
+		ID:        id,
+		ExpiresAt: now.Add(ttl).Unix(),
+		IssuedAt:  now.Unix(),
+		Data:      data,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(raw)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(j.sign(unsigned)), nil
+}
+
+func (j *JWTToken) sign(unsigned string) []byte {
+	mac := hmac.New(sha256.New, j.secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// RandomString returns a URL-safe random string built from n random bytes
+func RandomString(n int) (string, error) {
+	bytes := make([]byte, n)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", err
 	}
-	return base64.URLEncoding.EncodeToString(bytes), nil
-}
\ No newline at end of file
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashOpaque returns the hex SHA-256 of an opaque token for storage
+func HashOpaque(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,61 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (labubu_id, uploader_id, object_key, filename, content_type, size, sha256, status, image_status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetAttachment :one
+SELECT * FROM attachments WHERE id = $1 AND labubu_id = $2;
+
+-- name: GetAttachmentByID :one
+SELECT * FROM attachments WHERE id = $1;
+
+-- name: ListAttachments :many
+SELECT * FROM attachments WHERE labubu_id = $1 ORDER BY id;
+
+-- name: CompleteAttachment :one
+UPDATE attachments
+SET status = 'ready', content_type = $2, size = $3, sha256 = $4, image_status = $5, updated_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: RejectAttachment :exec
+UPDATE attachments SET status = 'rejected', updated_at = now() WHERE id = $1 AND status = 'pending';
+
+-- name: DeleteAttachment :one
+DELETE FROM attachments WHERE id = $1 AND labubu_id = $2 RETURNING *;
+
+-- name: DeleteAttachmentsByID :execrows
+DELETE FROM attachments WHERE id = ANY(@ids::bigint[]);
+
+-- name: ListPurgeableAttachments :many
+-- Attachments of labubu deleted before $1, and direct uploads never completed before $2
+SELECT a.* FROM attachments a
+JOIN labubu l ON l.id = a.labubu_id
+WHERE l.deleted_at < $1 OR (a.status = 'pending' AND a.created_at < $2)
+ORDER BY a.id;
+
+-- name: RecordAttachmentImage :one
+-- Size and digest change when metadata is stripped from the original
+UPDATE attachments
+SET width = $2, height = $3, size = $4, sha256 = $5, image_status = 'processed', image_error = NULL, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: FailAttachmentImage :exec
+UPDATE attachments SET image_status = $2, image_error = $3, updated_at = now() WHERE id = $1;
+
+-- name: UpsertAttachmentVariant :one
+-- Inserts nothing once the attachment is deleted
+INSERT INTO attachment_variants (attachment_id, name, object_key, content_type, width, height, size)
+SELECT a.id, @name, @object_key, @content_type, @width::int, @height::int, @size::bigint
+FROM attachments a WHERE a.id = @attachment_id
+ON CONFLICT (attachment_id, name) DO UPDATE
+SET object_key = excluded.object_key, content_type = excluded.content_type,
+    width = excluded.width, height = excluded.height, size = excluded.size
+RETURNING *;
+
+-- name: ListAttachmentVariants :many
+SELECT * FROM attachment_variants WHERE attachment_id = $1 ORDER BY width, name;
+
+-- name: ListVariantKeys :many
+SELECT object_key FROM attachment_variants WHERE attachment_id = ANY(@ids::bigint[]);
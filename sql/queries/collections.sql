@@ -0,0 +1,104 @@
+-- name: CreateCollection :one
+INSERT INTO collections (owner_id, name) VALUES ($1, $2) RETURNING *;
+
+-- name: GetCollectionForUser :one
+-- The collection with the role of user_id in it; no row when it is not shared with them
+SELECT c.*, (CASE WHEN c.owner_id = @user_id::int THEN 'owner' ELSE s.role END)::text AS role
+FROM collections c
+LEFT JOIN collection_shares s ON s.collection_id = c.id AND s.user_id = @user_id::int
+WHERE c.id = @id AND (c.owner_id = @user_id::int OR s.user_id IS NOT NULL);
+
+-- name: LockCollection :exec
+-- Serializes changes to the order of the items
+SELECT id FROM collections WHERE id = $1 FOR UPDATE;
+
+-- name: ListCollectionsForUser :many
+SELECT c.*, (CASE WHEN c.owner_id = @user_id::int THEN 'owner' ELSE s.role END)::text AS role
+FROM collections c
+LEFT JOIN collection_shares s ON s.collection_id = c.id AND s.user_id = @user_id::int
+WHERE c.owner_id = @user_id::int OR s.user_id IS NOT NULL
+ORDER BY c.id
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: RenameCollection :one
+UPDATE collections SET name = $2, updated_at = now() WHERE id = $1 RETURNING *;
+
+-- name: TouchCollection :exec
+UPDATE collections SET updated_at = now() WHERE id = $1;
+
+-- name: DeleteCollection :exec
+DELETE FROM collections WHERE id = $1;
+
+-- name: ListCollectionItems :many
+-- Deleted labubu stay in place until they are purged but are not listed, nor
+-- are unpublished ones to anyone but their owner
+SELECT ci.* FROM collection_items ci
+JOIN labubu l ON l.id = ci.labubu_id AND l.deleted_at IS NULL
+  AND (l.status = 'published' OR l.owner_id = @viewer_id::int)
+WHERE ci.collection_id = @collection_id
+ORDER BY ci.position
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: GetCollectionItem :one
+SELECT * FROM collection_items WHERE collection_id = $1 AND labubu_id = $2;
+
+-- name: AddCollectionItem :one
+-- Appends to the end; nothing is inserted when the labubu is already in the collection
+INSERT INTO collection_items (collection_id, labubu_id, position, added_by)
+SELECT @collection_id, @labubu_id,
+       coalesce((SELECT max(position) + 1 FROM collection_items WHERE collection_id = @collection_id), 0),
+       @added_by
+ON CONFLICT (collection_id, labubu_id) DO NOTHING
+RETURNING *;
+
+-- name: RemoveCollectionItem :one
+DELETE FROM collection_items WHERE collection_id = $1 AND labubu_id = $2 RETURNING *;
+
+-- name: CloseCollectionGap :exec
+UPDATE collection_items SET position = position - 1
+WHERE collection_id = $1 AND position > $2;
+
+-- name: MaxCollectionPosition :one
+SELECT coalesce(max(position), -1)::int FROM collection_items WHERE collection_id = $1;
+
+-- name: MoveCollectionItem :exec
+-- Moves an item from old_position to new_position and shifts the ones in between
+UPDATE collection_items
+SET position = CASE
+    WHEN labubu_id = @labubu_id THEN @new_position::int
+    WHEN @new_position::int < @old_position::int THEN position + 1
+    ELSE position - 1
+END
+WHERE collection_id = @collection_id
+  AND position BETWEEN least(@old_position::int, @new_position::int) AND greatest(@old_position::int, @new_position::int);
+
+-- name: UpsertCollectionShare :one
+INSERT INTO collection_shares (collection_id, user_id, role) VALUES ($1, $2, $3)
+ON CONFLICT (collection_id, user_id) DO UPDATE SET role = excluded.role
+RETURNING *;
+
+-- name: ListCollectionShares :many
+SELECT s.*, u.email FROM collection_shares s
+JOIN users u ON u.id = s.user_id
+WHERE s.collection_id = $1
+ORDER BY s.created_at, s.user_id
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: DeleteCollectionShare :execrows
+DELETE FROM collection_shares WHERE collection_id = $1 AND user_id = $2;
+
+-- name: UpsertCollectionInvite :exec
+INSERT INTO collection_invites (collection_id, email, role) VALUES ($1, $2, $3)
+ON CONFLICT (collection_id, email) DO UPDATE SET role = excluded.role;
+
+-- name: AcceptCollectionInvites :execrows
+-- Turns the invites for email into shares for user_id; an existing share
+-- keeps its role
+WITH accepted AS (
+    DELETE FROM collection_invites WHERE email = @email RETURNING collection_id, role
+)
+INSERT INTO collection_shares (collection_id, user_id, role)
+SELECT a.collection_id, @user_id, a.role FROM accepted a
+JOIN collections c ON c.id = a.collection_id
+WHERE c.owner_id <> @user_id
+ON CONFLICT (collection_id, user_id) DO NOTHING;
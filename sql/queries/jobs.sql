@@ -0,0 +1,55 @@
+-- name: EnqueueJob :one
+INSERT INTO jobs (kind, payload, unique_key, max_attempts, run_at)
+VALUES ($1, $2, sqlc.narg('unique_key'), $3, $4)
+ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+RETURNING *;
+
+-- name: GetJobByUniqueKey :one
+SELECT * FROM jobs WHERE unique_key = $1 AND status IN ('pending', 'running');
+
+-- name: ClaimJobs :many
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, locked_at = now(), locked_by = @worker_id::text, updated_at = now()
+WHERE id IN (
+    SELECT id FROM jobs
+    WHERE status = 'pending' AND run_at <= now() AND kind = ANY(@kinds::text[])
+    ORDER BY run_at, id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- The outcome of a run is recorded only while the worker still holds the
+-- job; after a rescue another worker may own it.
+
+-- name: CompleteJob :execrows
+UPDATE jobs
+SET status = 'succeeded', locked_at = NULL, locked_by = NULL, finished_at = now(), updated_at = now()
+WHERE id = @id AND status = 'running' AND locked_by = @worker_id::text;
+
+-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'pending', run_at = @run_at, last_error = @last_error, locked_at = NULL, locked_by = NULL, updated_at = now()
+WHERE id = @id AND status = 'running' AND locked_by = @worker_id::text;
+
+-- name: KillJob :execrows
+UPDATE jobs
+SET status = 'dead', last_error = @last_error, locked_at = NULL, locked_by = NULL, finished_at = now(), updated_at = now()
+WHERE id = @id AND status = 'running' AND locked_by = @worker_id::text;
+
+-- name: ExtendJobLock :execrows
+-- Heartbeat of a running job, so it is not rescued while its handler works
+UPDATE jobs
+SET locked_at = now(), updated_at = now()
+WHERE id = @id AND status = 'running' AND locked_by = @worker_id::text;
+
+-- name: RescueStaleJobs :execrows
+-- Jobs whose worker died are retried, unless that run was their last attempt
+UPDATE jobs
+SET status = CASE WHEN attempts >= max_attempts THEN 'dead' ELSE 'pending' END,
+    finished_at = CASE WHEN attempts >= max_attempts THEN now() END,
+    locked_at = NULL, locked_by = NULL, last_error = 'worker lock expired', updated_at = now()
+WHERE status = 'running' AND locked_at < @stale_before;
+
+-- name: PurgeFinishedJobs :execrows
+DELETE FROM jobs WHERE status IN ('succeeded', 'dead') AND finished_at < @finished_before;
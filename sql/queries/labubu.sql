@@ -1,5 +1,59 @@
 -- name: GetAllLabubu :many
-SELECT * FROM labubu ORDER BY id;
+-- An empty statuses matches every status
+SELECT * FROM labubu
+WHERE owner_id = @owner_id AND deleted_at IS NULL
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+ORDER BY id;
+
+-- name: GetLabubuByID :one
+SELECT * FROM labubu WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: CreateLabubu :one
-INSERT INTO labubu (text) VALUES ($1) RETURNING *;
\ No newline at end of file
+INSERT INTO labubu (text, owner_id) VALUES ($1, $2) RETURNING *;
+
+-- name: SoftDeleteLabubu :one
+UPDATE labubu SET deleted_at = now(), version = version + 1, updated_at = now()
+WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: PurgeDeletedLabubu :execrows
+DELETE FROM labubu WHERE deleted_at < $1;
+
+-- name: UpdateLabubu :one
+UPDATE labubu
+SET text = $3, version = version + 1, updated_at = now()
+WHERE id = $1 AND owner_id = $2 AND version = $4 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetOwnedLabubu :one
+SELECT * FROM labubu WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL;
+
+-- name: GetLabubuByIDs :many
+SELECT * FROM labubu WHERE id = ANY(@ids::int[]) AND deleted_at IS NULL;
+
+-- name: SetLabubuStatus :one
+-- Moves a labubu from from_status only, so concurrent changes cannot both
+-- apply, and bumps the version so edits based on the old status are rejected
+UPDATE labubu
+SET status = @to_status::text,
+    publish_at = sqlc.narg(publish_at),
+    published_at = CASE WHEN @to_status::text = 'published' THEN now() ELSE published_at END,
+    version = version + 1,
+    updated_at = now()
+WHERE id = @id AND status = @from_status::text AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ListDueLabubu :many
+-- Scheduled labubu whose time has come; rows another publisher holds are skipped
+SELECT * FROM labubu
+WHERE status = 'scheduled' AND publish_at <= @due_at AND deleted_at IS NULL
+ORDER BY publish_at, id
+LIMIT @row_limit
+FOR UPDATE SKIP LOCKED;
+
+-- name: RecordLabubuTransition :exec
+INSERT INTO labubu_transitions (labubu_id, from_status, to_status, actor_id, publish_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListLabubuTransitions :many
+SELECT * FROM labubu_transitions WHERE labubu_id = $1 ORDER BY id;
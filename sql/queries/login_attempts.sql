@@ -0,0 +1,36 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts WHERE email = $1;
+
+-- name: ReserveLoginAttempt :one
+-- Counts an attempt as failed before its password is compared and holds the
+-- email until hold_until, so parallel guesses cannot slip past a delay. No row
+-- is returned while the email is delayed or locked. Counting starts over once
+-- reset_before has passed since the last failure and the end of any lockout;
+-- an expired lockout alone keeps the count, so the next failure locks again.
+INSERT INTO login_attempts (email, failures, last_failed_at, next_attempt_at)
+VALUES ($1, 1, now(), sqlc.arg(hold_until))
+ON CONFLICT (email) DO UPDATE SET
+    failures = CASE
+        WHEN login_attempts.last_failed_at < sqlc.arg(reset_before)
+            AND (login_attempts.locked_until IS NULL OR login_attempts.locked_until < sqlc.arg(reset_before))
+        THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    next_attempt_at = sqlc.arg(hold_until),
+    locked_until = NULL,
+    last_failed_at = now()
+WHERE COALESCE(GREATEST(login_attempts.next_attempt_at, login_attempts.locked_until), '-infinity') <= now()
+RETURNING *;
+
+-- name: BlockLoginAttempts :exec
+UPDATE login_attempts SET next_attempt_at = $2, locked_until = $3 WHERE email = $1;
+
+-- name: DeleteLoginAttempts :execrows
+DELETE FROM login_attempts WHERE email = $1;
+
+-- name: ListLockedLogins :many
+SELECT * FROM login_attempts WHERE locked_until > now() ORDER BY locked_until;
+
+-- name: DeleteStaleLoginAttempts :execrows
+DELETE FROM login_attempts
+WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until < now());
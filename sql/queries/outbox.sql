@@ -0,0 +1,43 @@
+-- name: AppendOutboxEvent :one
+INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListDueOutboxEvents :many
+-- Events whose aggregate has an earlier event waiting for a retry are held
+-- back so that each aggregate is delivered in order.
+SELECT o.* FROM outbox o
+WHERE o.published_at IS NULL
+  AND o.next_attempt_at <= now()
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox p
+      WHERE p.published_at IS NULL
+        AND p.aggregate_type = o.aggregate_type
+        AND p.aggregate_id = o.aggregate_id
+        AND p.id < o.id
+        AND p.next_attempt_at > now()
+  )
+ORDER BY o.id
+LIMIT $1;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox SET published_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox WHERE published_at < $1;
+
+-- name: GetOutboxEvents :many
+SELECT * FROM outbox WHERE id = ANY(sqlc.arg(ids)::bigint[]) ORDER BY id;
+
+-- name: ListOutboxEventsAfter :many
+-- An empty aggregate type lists events of every type
+SELECT * FROM outbox
+WHERE id > $1 AND (sqlc.arg(aggregate_type)::text = '' OR aggregate_type = sqlc.arg(aggregate_type))
+ORDER BY id
+LIMIT $2;
+
+-- name: GetOldestOutboxEventID :one
+SELECT COALESCE(MIN(id), 0)::bigint FROM outbox;
@@ -0,0 +1,11 @@
+-- name: GetUsageCounts :one
+SELECT
+    (SELECT count(*) FROM users WHERE users.created_at >= @period_start AND users.created_at < @period_end) AS new_users,
+    (SELECT count(*) FROM labubu WHERE labubu.created_at >= @period_start AND labubu.created_at < @period_end) AS new_labubu,
+    (SELECT count(DISTINCT user_id) FROM sessions WHERE sessions.created_at >= @period_start AND sessions.created_at < @period_end) AS active_users;
+
+-- name: UpsertReport :one
+INSERT INTO reports (kind, period_start, period_end, data)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (kind, period_start) DO UPDATE SET period_end = EXCLUDED.period_end, data = EXCLUDED.data, created_at = now()
+RETURNING *;
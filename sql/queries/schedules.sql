@@ -0,0 +1,17 @@
+-- name: ListScheduleRuns :many
+SELECT s.name, s.last_run_at, s.next_run_at, s.last_job_id, j.status AS last_status, j.last_error, j.finished_at AS last_finished_at
+FROM schedules s
+LEFT JOIN jobs j ON j.id = s.last_job_id
+ORDER BY s.name;
+
+-- name: GetScheduleRun :one
+SELECT * FROM schedules WHERE name = $1;
+
+-- name: UpsertScheduleRun :exec
+INSERT INTO schedules (name, last_run_at, next_run_at, last_job_id)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (name) DO UPDATE
+SET last_run_at = COALESCE(EXCLUDED.last_run_at, schedules.last_run_at),
+    next_run_at = EXCLUDED.next_run_at,
+    last_job_id = COALESCE(EXCLUDED.last_job_id, schedules.last_job_id),
+    updated_at = now();
@@ -0,0 +1,34 @@
+-- name: CreateShareLink :one
+INSERT INTO share_links (labubu_id, owner_id, token_hash, password_hash, expires_at, max_views)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetShareLinkByToken :one
+SELECT * FROM share_links WHERE token_hash = $1;
+
+-- name: GetShareLink :one
+SELECT * FROM share_links WHERE id = $1 AND labubu_id = $2;
+
+-- name: ListShareLinks :many
+SELECT * FROM share_links WHERE labubu_id = $1 ORDER BY id DESC;
+
+-- name: RevokeShareLink :one
+-- Revoking twice keeps the time of the first
+UPDATE share_links SET revoked_at = coalesce(revoked_at, now())
+WHERE id = $1 AND labubu_id = $2
+RETURNING *;
+
+-- name: ConsumeShareLinkView :one
+-- Counts a view; no row when the link was revoked, has expired or has no views left
+UPDATE share_links SET views = views + 1
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND expires_at > now()
+  AND (max_views IS NULL OR views < max_views)
+RETURNING *;
+
+-- name: RecordShareLinkAccess :exec
+INSERT INTO share_link_accesses (link_id, outcome, ip, user_agent) VALUES ($1, $2, $3, $4);
+
+-- name: ListShareLinkAccesses :many
+SELECT * FROM share_link_accesses WHERE link_id = $1 ORDER BY id DESC LIMIT $2;
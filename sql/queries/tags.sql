@@ -0,0 +1,66 @@
+-- name: ListLabubuByTags :many
+-- Labubu carrying at least min_matches of the given tags: 1 for any, all of them otherwise
+SELECT l.* FROM labubu l
+WHERE l.owner_id = @owner_id AND l.deleted_at IS NULL
+  AND (cardinality(@statuses::text[]) = 0 OR l.status = ANY(@statuses::text[]))
+  AND l.id IN (
+    SELECT lt.labubu_id FROM labubu_tags lt
+    JOIN tags t ON t.id = lt.tag_id
+    WHERE t.owner_id = @owner_id AND t.name = ANY(@names::text[])
+    GROUP BY lt.labubu_id
+    HAVING count(*) >= @min_matches::int
+  )
+ORDER BY l.id;
+
+-- name: ListLabubuTagNames :many
+SELECT lt.labubu_id, t.name FROM labubu_tags lt
+JOIN tags t ON t.id = lt.tag_id
+WHERE lt.labubu_id = ANY(@labubu_ids::int[])
+ORDER BY lt.labubu_id, t.name COLLATE "C";
+
+-- name: EnsureTags :many
+-- The no-op update makes existing tags return their id too
+INSERT INTO tags (owner_id, name)
+SELECT @owner_id, unnest(@names::text[])
+ON CONFLICT (owner_id, name) DO UPDATE SET name = excluded.name
+RETURNING id;
+
+-- name: AddLabubuTags :exec
+INSERT INTO labubu_tags (labubu_id, tag_id)
+SELECT @labubu_id, unnest(@tag_ids::int[])
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveLabubuTagsExcept :exec
+DELETE FROM labubu_tags WHERE labubu_id = @labubu_id AND tag_id <> ALL(@tag_ids::int[]);
+
+-- name: DeleteUnusedTags :exec
+DELETE FROM tags t
+WHERE t.owner_id = $1 AND NOT EXISTS (SELECT 1 FROM labubu_tags lt WHERE lt.tag_id = t.id);
+
+-- name: ListTags :many
+-- Counts only labubu that are not deleted; tags used by none are left out
+SELECT t.id, t.name, count(*) AS usage_count FROM tags t
+JOIN labubu_tags lt ON lt.tag_id = t.id
+JOIN labubu l ON l.id = lt.labubu_id AND l.deleted_at IS NULL
+WHERE t.owner_id = $1
+GROUP BY t.id
+ORDER BY t.name;
+
+-- name: GetTag :one
+SELECT t.id, t.name, count(l.id) AS usage_count FROM tags t
+LEFT JOIN labubu_tags lt ON lt.tag_id = t.id
+LEFT JOIN labubu l ON l.id = lt.labubu_id AND l.deleted_at IS NULL
+WHERE t.id = $1 AND t.owner_id = $2
+GROUP BY t.id;
+
+-- name: RenameTag :execrows
+UPDATE tags SET name = $3 WHERE id = $1 AND owner_id = $2;
+
+-- name: MoveTagLinks :exec
+-- Labubu that already carry the target keep a single link
+INSERT INTO labubu_tags (labubu_id, tag_id)
+SELECT lt.labubu_id, @target_id::int FROM labubu_tags lt WHERE lt.tag_id = @source_id::int
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteTag :execrows
+DELETE FROM tags WHERE id = $1 AND owner_id = $2;
@@ -0,0 +1,14 @@
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (user_id, purpose, token_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: ConsumeUserToken :one
+-- Marks the token used in the same statement that checks it, so it works only once
+UPDATE user_tokens SET used_at = now()
+WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > now()
+RETURNING *;
+
+-- name: InvalidateUserTokens :exec
+UPDATE user_tokens SET used_at = now() WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL;
+
+-- name: DeleteStaleUserTokens :execrows
+DELETE FROM user_tokens WHERE expires_at < $1 OR used_at < $1;
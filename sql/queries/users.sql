@@ -0,0 +1,17 @@
+-- name: CreateUser :one
+INSERT INTO users (email, password_hash, email_verified_at) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetUserByID :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1;
+
+-- name: DisableUser :one
+UPDATE users SET disabled_at = now(), updated_at = now() WHERE id = $1 RETURNING *;
+
+-- name: UpdateUserPassword :one
+UPDATE users SET password_hash = $2, updated_at = now() WHERE id = $1 RETURNING *;
+
+-- name: MarkEmailVerified :one
+UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()), updated_at = now() WHERE id = $1 RETURNING *;
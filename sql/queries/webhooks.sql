@@ -0,0 +1,79 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, events, description)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions ORDER BY id;
+
+-- name: ListEnabledWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions WHERE enabled ORDER BY id;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions WHERE id = $1;
+
+-- name: UpdateWebhookSubscription :one
+-- Re-enabling a subscription clears its failure streak
+UPDATE webhook_subscriptions
+SET url = $2,
+    secret = $3,
+    events = $4,
+    description = $5,
+    enabled = $6,
+    consecutive_failures = CASE WHEN $6 AND NOT enabled THEN 0 ELSE consecutive_failures END,
+    disabled_reason = CASE WHEN $6 THEN NULL ELSE disabled_reason END,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: ResetWebhookFailures :exec
+UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures > 0;
+
+-- name: IncrementWebhookFailures :one
+UPDATE webhook_subscriptions
+SET consecutive_failures = consecutive_failures + 1
+WHERE id = $1
+RETURNING consecutive_failures;
+
+-- name: DisableWebhookSubscription :exec
+UPDATE webhook_subscriptions
+SET enabled = false, disabled_reason = $2, updated_at = now()
+WHERE id = $1 AND enabled;
+
+-- name: CreateWebhookDelivery :one
+-- Returns no row when the event was already fanned out to this subscription
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (subscription_id, event_id) DO NOTHING
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = $1
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: RecordWebhookAttempt :one
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    response_status = $3,
+    response_body = $4,
+    last_error = $5,
+    last_attempt_at = now(),
+    delivered_at = CASE WHEN $2 = 'succeeded' THEN now() ELSE delivered_at END
+WHERE id = $1
+RETURNING *;
+
+-- name: FailWebhookDelivery :exec
+UPDATE webhook_deliveries SET status = 'failed', last_error = $2 WHERE id = $1;
+
+-- name: ResetWebhookDelivery :one
+UPDATE webhook_deliveries SET status = 'pending' WHERE id = $1 RETURNING *;